@@ -2,6 +2,7 @@ package agents
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/Ranganaths/minion/llm"
@@ -36,6 +37,23 @@ func (m *mockLLMProvider) Name() string {
 	return "mock"
 }
 
+// mockStreamingLLMProvider streams each response split on spaces
+type mockStreamingLLMProvider struct {
+	mockLLMProvider
+}
+
+func (m *mockStreamingLLMProvider) StreamChat(ctx context.Context, req *llm.ChatRequest) (<-chan llm.ChatChunk, error) {
+	resp, _ := m.GenerateCompletion(ctx, &llm.CompletionRequest{})
+	words := strings.SplitAfter(resp.Text, " ")
+	ch := make(chan llm.ChatChunk, len(words)+1)
+	for _, w := range words {
+		ch <- llm.ChatChunk{Content: w}
+	}
+	ch <- llm.ChatChunk{Done: true, FinishReason: "stop"}
+	close(ch)
+	return ch, nil
+}
+
 func TestCalculatorTool(t *testing.T) {
 	ctx := context.Background()
 	calc := NewCalculatorTool()
@@ -347,6 +365,55 @@ func TestAgentExecutor(t *testing.T) {
 			t.Errorf("unexpected final answer: %s", finishEvent.FinalAnswer)
 		}
 	})
+
+	t.Run("stream tokens", func(t *testing.T) {
+		llm := &mockStreamingLLMProvider{mockLLMProvider{
+			responses: []string{
+				"Thought: Need math.\nAction: Calculator\nAction Input: 2 + 3",
+				"Final Answer: It is 5",
+			},
+		}}
+
+		agent, _ := NewReActAgent(ReActAgentConfig{
+			LLM:   llm,
+			Tools: []Tool{NewCalculatorTool()},
+		})
+
+		executor, _ := NewAgentExecutor(AgentExecutorConfig{
+			Agent: agent,
+			Tools: []Tool{NewCalculatorTool()},
+		})
+
+		ch, err := executor.Stream(ctx, "What is 2 + 3?")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var tokens strings.Builder
+		var observation, finalAnswer string
+		for event := range ch {
+			switch event.Type {
+			case AgentEventToken:
+				tokens.WriteString(event.Token)
+			case AgentEventObservation:
+				observation = event.Step.Observation
+			case AgentEventFinish:
+				finalAnswer = event.FinalAnswer
+			case AgentEventError:
+				t.Fatalf("unexpected error: %v", event.Error)
+			}
+		}
+
+		if !strings.Contains(tokens.String(), "Action: Calculator") || !strings.Contains(tokens.String(), "Final Answer: It is 5") {
+			t.Errorf("expected streamed tokens for both steps, got %q", tokens.String())
+		}
+		if observation != "5" {
+			t.Errorf("unexpected observation: %s", observation)
+		}
+		if finalAnswer != "It is 5" {
+			t.Errorf("unexpected final answer: %s", finalAnswer)
+		}
+	})
 }
 
 func TestConversationalReActAgent(t *testing.T) {
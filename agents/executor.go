@@ -3,14 +3,13 @@ package agents
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 )
 
 // DefaultAgentExecutor executes agents with tools
 type DefaultAgentExecutor struct {
 	agent         Agent
-	tools         map[string]Tool
+	tools         ToolSource
 	maxIterations int
 	callbacks     []AgentCallback
 	verbose       bool
@@ -22,9 +21,13 @@ type AgentExecutorConfig struct {
 	// Agent is the agent to execute (required)
 	Agent Agent
 
-	// Tools are the available tools (required)
+	// Tools are the available tools (required unless ToolSource is set)
 	Tools []Tool
 
+	// ToolSource supplies the tools instead of Tools, looked up on every
+	// call, so tools added or removed later are seen (optional)
+	ToolSource ToolSource
+
 	// MaxIterations is the maximum iterations (default: 15)
 	MaxIterations int
 
@@ -49,14 +52,14 @@ func NewAgentExecutor(cfg AgentExecutorConfig) (*DefaultAgentExecutor, error) {
 		maxIter = 15
 	}
 
-	toolMap := make(map[string]Tool)
-	for _, tool := range cfg.Tools {
-		toolMap[strings.ToLower(tool.Name())] = tool
+	tools, err := toolSource(cfg.Tools, cfg.ToolSource)
+	if err != nil {
+		return nil, err
 	}
 
 	return &DefaultAgentExecutor{
 		agent:                   cfg.Agent,
-		tools:                   toolMap,
+		tools:                   tools,
 		maxIterations:           maxIter,
 		callbacks:               cfg.Callbacks,
 		verbose:                 cfg.Verbose,
@@ -125,7 +128,9 @@ func (e *DefaultAgentExecutor) RunWithHistory(ctx context.Context, input string,
 	return "", fmt.Errorf("agent exceeded maximum iterations (%d)", e.maxIterations)
 }
 
-// Stream executes and streams intermediate steps
+// Stream executes and streams intermediate steps.
+// Agents implementing StreamingAgent also emit AgentEventToken events as the
+// LLM generates each planning step.
 func (e *DefaultAgentExecutor) Stream(ctx context.Context, input string) (<-chan AgentStreamEvent, error) {
 	ch := make(chan AgentStreamEvent)
 
@@ -151,7 +156,7 @@ func (e *DefaultAgentExecutor) Stream(ctx context.Context, input string) (<-chan
 				IntermediateSteps: steps,
 			}
 
-			action, err := e.agent.Plan(ctx, agentInput)
+			action, err := e.planStream(ctx, agentInput, ch)
 			if err != nil {
 				ch <- AgentStreamEvent{
 					Type:  AgentEventError,
@@ -209,10 +214,28 @@ func (e *DefaultAgentExecutor) Stream(ctx context.Context, input string) (<-chan
 	return ch, nil
 }
 
+// planStream plans the next action, forwarding LLM tokens to ch as
+// AgentEventToken events when the agent implements StreamingAgent.
+func (e *DefaultAgentExecutor) planStream(ctx context.Context, input AgentInput, ch chan<- AgentStreamEvent) (AgentAction, error) {
+	sa, ok := e.agent.(StreamingAgent)
+	if !ok {
+		return e.agent.Plan(ctx, input)
+	}
+
+	return sa.PlanStream(ctx, input, func(token string) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case ch <- AgentStreamEvent{Type: AgentEventToken, Token: token}:
+			return true
+		}
+	})
+}
+
 // executeTool runs a tool and returns the observation
 func (e *DefaultAgentExecutor) executeTool(ctx context.Context, toolName, toolInput string) (string, error) {
 	// Find tool (case-insensitive)
-	tool, ok := e.tools[strings.ToLower(toolName)]
+	tool, ok := e.tools.Tool(toolName)
 	if !ok {
 		return "", fmt.Errorf("unknown tool: %s", toolName)
 	}
@@ -57,6 +57,16 @@ type AgentAction struct {
 	FinalAnswer string
 }
 
+// StreamingAgent is implemented by agents that can stream LLM tokens while planning.
+// DefaultAgentExecutor.Stream uses it to forward AgentEventToken events.
+type StreamingAgent interface {
+	Agent
+
+	// PlanStream behaves like Plan but passes each generated token to onToken.
+	// Returning false from onToken aborts planning.
+	PlanStream(ctx context.Context, input AgentInput, onToken func(token string) bool) (AgentAction, error)
+}
+
 // Tool is the interface for tools that agents can use
 type Tool interface {
 	// Name returns the tool name (used by agent to invoke it)
@@ -69,6 +79,17 @@ type Tool interface {
 	Call(ctx context.Context, input string) (string, error)
 }
 
+// ToolSource supplies tools that can change while an agent is in use, such
+// as the tools of a tools.Registry. Agents and executors configured with a
+// ToolSource look tools up on every plan and call.
+type ToolSource interface {
+	// Tools returns the tools currently available
+	Tools() []Tool
+
+	// Tool returns an available tool by name, ignoring case
+	Tool(name string) (Tool, bool)
+}
+
 // ToolConfig provides additional tool configuration
 type ToolConfig struct {
 	// ReturnDirect if true, returns tool output directly as final answer
@@ -98,6 +119,12 @@ type AgentStreamEvent struct {
 	// Step contains the current step (for step events)
 	Step *AgentStep
 
+	// Token contains a generated token (for token events)
+	Token string
+
+	// Plan contains the steps still to be executed (for plan events)
+	Plan []string
+
 	// FinalAnswer contains the final answer (for finish events)
 	FinalAnswer string
 
@@ -109,6 +136,9 @@ type AgentStreamEvent struct {
 type AgentStreamEventType string
 
 const (
+	// AgentEventToken indicates the agent's LLM generated a token
+	AgentEventToken AgentStreamEventType = "token"
+
 	// AgentEventThought indicates agent is thinking
 	AgentEventThought AgentStreamEventType = "thought"
 
@@ -118,6 +148,9 @@ const (
 	// AgentEventObservation indicates tool returned observation
 	AgentEventObservation AgentStreamEventType = "observation"
 
+	// AgentEventPlan indicates a plan was made or revised
+	AgentEventPlan AgentStreamEventType = "plan"
+
 	// AgentEventFinish indicates agent finished with final answer
 	AgentEventFinish AgentStreamEventType = "finish"
 
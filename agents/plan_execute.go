@@ -0,0 +1,516 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Ranganaths/minion/llm"
+)
+
+// PlanStepTool is the tool name under which PlanAndExecuteAgent reports the
+// execution of plan steps to callbacks and in stream events
+const PlanStepTool = "plan_step"
+
+var (
+	planItemRegex    = regexp.MustCompile(`^\s*(?:\d+[.)]|[-*•])\s+(.+?)\s*$`)
+	planFinishRegex  = regexp.MustCompile(`(?ims)^\s*Final\s*Answer\s*:\s*(.*)`)
+	planHeadingRegex = regexp.MustCompile(`(?i)^(?:remaining\s+)?(?:plan|steps)\s*:?$`)
+)
+
+// PlanAndExecuteAgent plans before it acts. A planner LLM breaks the
+// objective into a list of steps, each step is executed by a ReAct agent
+// with the tools, and after every step a replanner revises the remaining
+// steps in light of the result, or gives the final answer.
+//
+// Callbacks receive each step as an action of PlanStepTool: OnAgentAction
+// and OnToolStart when it begins and OnToolEnd or OnToolError when it ends.
+// The tool calls made within a step are reported as usual.
+type PlanAndExecuteAgent struct {
+	planner           llm.Provider
+	executor          *DefaultAgentExecutor
+	tools             ToolSource
+	maxSteps          int
+	disableReplanning bool
+	callbacks         []AgentCallback
+	verbose           bool
+}
+
+var _ AgentExecutor = (*PlanAndExecuteAgent)(nil)
+
+// PlanAndExecuteAgentConfig configures the plan-and-execute agent
+type PlanAndExecuteAgentConfig struct {
+	// Planner is the LLM that writes and revises the plan (required)
+	Planner llm.Provider
+
+	// LLM is the LLM that executes the steps (default: Planner)
+	LLM llm.Provider
+
+	// Tools are the tools available to the steps (required unless
+	// ToolSource is set)
+	Tools []Tool
+
+	// ToolSource supplies the tools instead of Tools, looked up on every
+	// plan and step, so tools added or removed later are seen (optional)
+	ToolSource ToolSource
+
+	// MaxSteps is the maximum number of steps executed (default: 10)
+	MaxSteps int
+
+	// MaxIterations is the maximum iterations of each step (default: 15)
+	MaxIterations int
+
+	// DisableReplanning executes the initial plan as written. The result of
+	// the last step is the final answer.
+	DisableReplanning bool
+
+	// Callbacks are optional callbacks for events
+	Callbacks []AgentCallback
+
+	// Verbose enables verbose output
+	Verbose bool
+}
+
+// NewPlanAndExecuteAgent creates a new plan-and-execute agent
+func NewPlanAndExecuteAgent(cfg PlanAndExecuteAgentConfig) (*PlanAndExecuteAgent, error) {
+	if cfg.Planner == nil {
+		return nil, fmt.Errorf("planner is required")
+	}
+
+	stepLLM := cfg.LLM
+	if stepLLM == nil {
+		stepLLM = cfg.Planner
+	}
+
+	stepAgent, err := NewReActAgent(ReActAgentConfig{
+		LLM:        stepLLM,
+		Tools:      cfg.Tools,
+		ToolSource: cfg.ToolSource,
+		Verbose:    cfg.Verbose,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	executor, err := NewAgentExecutor(AgentExecutorConfig{
+		Agent:         stepAgent,
+		ToolSource:    stepAgent.tools,
+		MaxIterations: cfg.MaxIterations,
+		Callbacks:     []AgentCallback{stepCallback{callbacks: cfg.Callbacks}},
+		Verbose:       cfg.Verbose,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	maxSteps := cfg.MaxSteps
+	if maxSteps <= 0 {
+		maxSteps = 10
+	}
+
+	return &PlanAndExecuteAgent{
+		planner:           cfg.Planner,
+		executor:          executor,
+		tools:             stepAgent.tools,
+		maxSteps:          maxSteps,
+		disableReplanning: cfg.DisableReplanning,
+		callbacks:         cfg.Callbacks,
+		verbose:           cfg.Verbose,
+	}, nil
+}
+
+// Run plans and executes until a final answer is reached
+func (a *PlanAndExecuteAgent) Run(ctx context.Context, input string) (string, error) {
+	return a.RunWithHistory(ctx, input, "")
+}
+
+// RunWithHistory plans with conversation history, then executes
+func (a *PlanAndExecuteAgent) RunWithHistory(ctx context.Context, input string, history string) (string, error) {
+	return a.run(ctx, input, history, nil)
+}
+
+// Stream plans and executes, streaming plans, planner tokens and steps.
+// Each plan and revised plan is sent as an AgentEventPlan event. Each step
+// is sent as AgentEventAction and AgentEventObservation events of
+// PlanStepTool, between which the events of the step's ReAct agent appear.
+func (a *PlanAndExecuteAgent) Stream(ctx context.Context, input string) (<-chan AgentStreamEvent, error) {
+	ch := make(chan AgentStreamEvent)
+
+	go func() {
+		defer close(ch)
+
+		emit := func(event AgentStreamEvent) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case ch <- event:
+				return true
+			}
+		}
+
+		answer, err := a.run(ctx, input, "", emit)
+		if err != nil {
+			// The error event is delivered even if the consumer has gone
+			select {
+			case ch <- AgentStreamEvent{Type: AgentEventError, Error: err}:
+			case <-ctx.Done():
+			}
+			return
+		}
+		emit(AgentStreamEvent{Type: AgentEventFinish, FinalAnswer: answer})
+	}()
+
+	return ch, nil
+}
+
+// run executes the plan-and-execute loop. Events are passed to emit when it
+// is set; emit returns false when the stream was cancelled.
+func (a *PlanAndExecuteAgent) run(ctx context.Context, input, history string, emit func(AgentStreamEvent) bool) (string, error) {
+	plan, err := a.makePlan(ctx, input, history, emit)
+	if err != nil {
+		return "", err
+	}
+	if len(plan) == 0 {
+		return "", fmt.Errorf("planner returned an empty plan")
+	}
+	if !a.emitPlan(ctx, plan, emit) {
+		return "", ctx.Err()
+	}
+
+	var completed []AgentStep
+	for len(plan) > 0 {
+		if len(completed) >= a.maxSteps {
+			return "", fmt.Errorf("agent exceeded maximum steps (%d)", a.maxSteps)
+		}
+
+		step, err := a.executeStep(ctx, input, plan, completed, emit)
+		if err != nil {
+			return "", err
+		}
+		completed = append(completed, step)
+
+		if a.disableReplanning {
+			plan = plan[1:]
+			continue
+		}
+
+		remaining, answer, finished, err := a.replan(ctx, input, plan, completed, emit)
+		if err != nil {
+			return "", err
+		}
+		if finished {
+			a.notifyFinish(ctx, answer)
+			return answer, nil
+		}
+		plan = remaining
+		if !a.emitPlan(ctx, plan, emit) {
+			return "", ctx.Err()
+		}
+	}
+
+	// The plan ran out without a final answer, so the last result is the answer
+	answer := completed[len(completed)-1].Observation
+	a.notifyFinish(ctx, answer)
+	return answer, nil
+}
+
+// makePlan asks the planner for the initial plan
+func (a *PlanAndExecuteAgent) makePlan(ctx context.Context, input, history string, emit func(AgentStreamEvent) bool) ([]string, error) {
+	historySection := ""
+	if history != "" {
+		historySection = fmt.Sprintf("Previous conversation:\n%s\n\n", history)
+	}
+
+	prompt := fmt.Sprintf(`For the given objective, come up with a simple step by step plan. The plan should be a list of individual tasks that, if executed correctly, will yield the correct answer. Do not add any superfluous steps. The result of the final step should be the final answer.
+
+The steps can use the following tools:
+
+%s
+
+%sObjective: %s
+
+Respond with the steps as a numbered list, one step per line, and nothing else.`, a.toolDescriptions(), historySection, input)
+
+	text, err := a.complete(ctx, prompt, emit)
+	if err != nil {
+		return nil, fmt.Errorf("planner error: %w", err)
+	}
+	return parsePlan(text), nil
+}
+
+// replan asks the planner to revise the remaining steps after a step, or
+// to give the final answer
+func (a *PlanAndExecuteAgent) replan(ctx context.Context, input string, plan []string, completed []AgentStep, emit func(AgentStreamEvent) bool) ([]string, string, bool, error) {
+	prompt := fmt.Sprintf(`For the given objective, update the step by step plan.
+
+Objective: %s
+
+The current plan was:
+%s
+
+These steps have been completed:
+%s
+
+If no more steps are needed and you can answer the objective, respond with "Final Answer: " followed by the answer. Otherwise respond with only the steps that still need to be done, as a numbered list, one step per line. Do not repeat completed steps.`, input, formatPlan(plan), formatCompleted(completed))
+
+	text, err := a.complete(ctx, prompt, emit)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("replanner error: %w", err)
+	}
+
+	if answer, ok := parseFinalAnswer(text); ok {
+		return nil, answer, true, nil
+	}
+
+	remaining := parsePlan(text)
+	if len(remaining) == 0 {
+		// Nothing left to do, and no answer given: the last result is the answer
+		return nil, completed[len(completed)-1].Observation, true, nil
+	}
+	return remaining, "", false, nil
+}
+
+// executeStep runs the first step of plan with the ReAct executor. A failed
+// step is recorded with its error as the observation, so the replanner can
+// work around it.
+func (a *PlanAndExecuteAgent) executeStep(ctx context.Context, input string, plan []string, completed []AgentStep, emit func(AgentStreamEvent) bool) (AgentStep, error) {
+	number := len(completed) + 1
+	action := AgentAction{
+		Tool:      PlanStepTool,
+		ToolInput: plan[0],
+		Log:       fmt.Sprintf("Step %d: %s", number, plan[0]),
+	}
+
+	a.notifyAction(ctx, action)
+	a.notifyToolStart(ctx, action.ToolInput)
+	if emit != nil && !emit(AgentStreamEvent{Type: AgentEventAction, Step: &AgentStep{Action: action}}) {
+		return AgentStep{}, ctx.Err()
+	}
+
+	var completedSection string
+	if len(completed) > 0 {
+		completedSection = fmt.Sprintf("\n\nCompleted steps:\n%s", formatCompleted(completed))
+	}
+	stepInput := fmt.Sprintf("Objective: %s\n\nPlan:\n%s%s\n\nYour task is to complete this step: %s",
+		input, formatPlan(plan), completedSection, plan[0])
+
+	var result string
+	var err error
+	if emit != nil {
+		result, err = a.streamStep(ctx, stepInput, emit)
+	} else {
+		result, err = a.executor.Run(ctx, stepInput)
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return AgentStep{}, ctxErr
+	}
+
+	if err != nil {
+		a.notifyToolError(ctx, err)
+		result = fmt.Sprintf("Error: %s", err.Error())
+	} else {
+		a.notifyToolEnd(ctx, result)
+	}
+
+	step := AgentStep{Action: action, Observation: result}
+	if emit != nil && !emit(AgentStreamEvent{Type: AgentEventObservation, Step: &step}) {
+		return AgentStep{}, ctx.Err()
+	}
+
+	if a.verbose {
+		fmt.Printf("%s\nResult: %s\n\n", action.Log, result)
+	}
+
+	return step, nil
+}
+
+// streamStep runs a step with the executor's Stream, forwarding its events.
+// The step's finish event becomes its result.
+func (a *PlanAndExecuteAgent) streamStep(ctx context.Context, stepInput string, emit func(AgentStreamEvent) bool) (string, error) {
+	events, err := a.executor.Stream(ctx, stepInput)
+	if err != nil {
+		return "", err
+	}
+
+	var result string
+	var stepErr error
+	forwarding := true
+	for event := range events {
+		switch event.Type {
+		case AgentEventFinish:
+			result = event.FinalAnswer
+		case AgentEventError:
+			stepErr = event.Error
+		default:
+			// Keep draining after a cancelled send so the executor can finish
+			forwarding = forwarding && emit(event)
+		}
+	}
+	return result, stepErr
+}
+
+// complete sends a prompt to the planner. Tokens are streamed as
+// AgentEventToken events when emit is set and the planner supports streaming.
+func (a *PlanAndExecuteAgent) complete(ctx context.Context, prompt string, emit func(AgentStreamEvent) bool) (string, error) {
+	req := &llm.CompletionRequest{
+		UserPrompt:  prompt,
+		Temperature: 0.0,
+		MaxTokens:   1000,
+	}
+
+	sp, ok := a.planner.(llm.StreamingProvider)
+	if !ok || emit == nil {
+		resp, err := a.planner.GenerateCompletion(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		return resp.Text, nil
+	}
+
+	chunks, err := sp.StreamChat(ctx, req.ToChatRequest())
+	if err != nil {
+		return "", err
+	}
+	resp, err := llm.CollectStream(ctx, chunks, func(chunk llm.ChatChunk) bool {
+		return emit(AgentStreamEvent{Type: AgentEventToken, Token: chunk.Content})
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Message.Content, nil
+}
+
+// emitPlan sends a plan event, returning false if the stream was cancelled
+func (a *PlanAndExecuteAgent) emitPlan(ctx context.Context, plan []string, emit func(AgentStreamEvent) bool) bool {
+	if a.verbose {
+		fmt.Printf("Plan:\n%s\n\n", formatPlan(plan))
+	}
+	if emit == nil {
+		return true
+	}
+	return emit(AgentStreamEvent{Type: AgentEventPlan, Plan: append([]string(nil), plan...)})
+}
+
+// toolDescriptions lists the tools for the planner
+func (a *PlanAndExecuteAgent) toolDescriptions() string {
+	return buildToolDescriptions(a.tools.Tools())
+}
+
+// parseFinalAnswer returns the answer from a "Final Answer:" line. The
+// line only ends the run when no plan items come before it, so a remaining
+// step that mentions a final answer is still executed.
+func parseFinalAnswer(text string) (string, bool) {
+	loc := planFinishRegex.FindStringSubmatchIndex(text)
+	if loc == nil {
+		return "", false
+	}
+	for _, line := range strings.Split(text[:loc[0]], "\n") {
+		if planItemRegex.MatchString(line) {
+			return "", false
+		}
+	}
+	return strings.TrimSpace(text[loc[2]:loc[3]]), true
+}
+
+// parsePlan extracts the steps of a plan from LLM output. Numbered or
+// bulleted lines are steps; without any, every non-empty line is a step.
+func parsePlan(text string) []string {
+	var items, lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if matches := planItemRegex.FindStringSubmatch(line); len(matches) > 1 {
+			items = append(items, matches[1])
+			continue
+		}
+		if line = strings.TrimSpace(line); line != "" && !planHeadingRegex.MatchString(line) {
+			lines = append(lines, line)
+		}
+	}
+	if len(items) > 0 {
+		return items
+	}
+	return lines
+}
+
+// formatPlan numbers the steps of a plan, one per line
+func formatPlan(plan []string) string {
+	lines := make([]string, len(plan))
+	for i, step := range plan {
+		lines[i] = fmt.Sprintf("%d. %s", i+1, step)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatCompleted lists completed steps with their results
+func formatCompleted(completed []AgentStep) string {
+	parts := make([]string, len(completed))
+	for i, step := range completed {
+		parts[i] = fmt.Sprintf("%d. %s\nResult: %s", i+1, step.Action.ToolInput, step.Observation)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// Callback notification helpers
+
+func (a *PlanAndExecuteAgent) notifyAction(ctx context.Context, action AgentAction) {
+	for _, cb := range a.callbacks {
+		cb.OnAgentAction(ctx, action)
+	}
+}
+
+func (a *PlanAndExecuteAgent) notifyFinish(ctx context.Context, output string) {
+	for _, cb := range a.callbacks {
+		cb.OnAgentFinish(ctx, output)
+	}
+}
+
+func (a *PlanAndExecuteAgent) notifyToolStart(ctx context.Context, step string) {
+	for _, cb := range a.callbacks {
+		cb.OnToolStart(ctx, PlanStepTool, step)
+	}
+}
+
+func (a *PlanAndExecuteAgent) notifyToolEnd(ctx context.Context, result string) {
+	for _, cb := range a.callbacks {
+		cb.OnToolEnd(ctx, PlanStepTool, result)
+	}
+}
+
+func (a *PlanAndExecuteAgent) notifyToolError(ctx context.Context, err error) {
+	for _, cb := range a.callbacks {
+		cb.OnToolError(ctx, PlanStepTool, err)
+	}
+}
+
+// stepCallback forwards the events of a step's ReAct agent to the
+// plan-and-execute agent's callbacks. A step finishing is not the agent
+// finishing, so OnAgentFinish is not forwarded.
+type stepCallback struct {
+	callbacks []AgentCallback
+}
+
+func (c stepCallback) OnAgentAction(ctx context.Context, action AgentAction) {
+	for _, cb := range c.callbacks {
+		cb.OnAgentAction(ctx, action)
+	}
+}
+
+func (c stepCallback) OnAgentFinish(ctx context.Context, output string) {}
+
+func (c stepCallback) OnToolStart(ctx context.Context, tool string, input string) {
+	for _, cb := range c.callbacks {
+		cb.OnToolStart(ctx, tool, input)
+	}
+}
+
+func (c stepCallback) OnToolEnd(ctx context.Context, tool string, output string) {
+	for _, cb := range c.callbacks {
+		cb.OnToolEnd(ctx, tool, output)
+	}
+}
+
+func (c stepCallback) OnToolError(ctx context.Context, tool string, err error) {
+	for _, cb := range c.callbacks {
+		cb.OnToolError(ctx, tool, err)
+	}
+}
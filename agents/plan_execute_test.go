@@ -0,0 +1,222 @@
+package agents
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// recordingCallback records agent events as strings
+type recordingCallback struct {
+	NoopAgentCallback
+	events []string
+}
+
+func (c *recordingCallback) OnAgentAction(ctx context.Context, action AgentAction) {
+	c.events = append(c.events, "action:"+action.Tool)
+}
+
+func (c *recordingCallback) OnAgentFinish(ctx context.Context, output string) {
+	c.events = append(c.events, "finish:"+output)
+}
+
+func (c *recordingCallback) OnToolStart(ctx context.Context, tool string, input string) {
+	c.events = append(c.events, "start:"+tool)
+}
+
+func (c *recordingCallback) OnToolEnd(ctx context.Context, tool string, output string) {
+	c.events = append(c.events, "end:"+tool+"="+output)
+}
+
+// newTestPlanAndExecuteAgent plans "2 + 3, then times 4", revises the plan
+// after the first step and answers after the second
+func newTestPlanAndExecuteAgent(t *testing.T, callbacks ...AgentCallback) *PlanAndExecuteAgent {
+	t.Helper()
+
+	planner := &mockLLMProvider{responses: []string{
+		"Plan:\n1. Add 2 and 3\n2. Multiply the result by 4",
+		"1. Multiply 5 by 4",
+		"Final Answer: 20",
+	}}
+	stepLLM := &mockLLMProvider{responses: []string{
+		"Thought: Add.\nAction: Calculator\nAction Input: 2 + 3",
+		"Final Answer: 5",
+		"Thought: Multiply.\nAction: Calculator\nAction Input: 5 * 4",
+		"Final Answer: 20",
+	}}
+
+	agent, err := NewPlanAndExecuteAgent(PlanAndExecuteAgentConfig{
+		Planner:   planner,
+		LLM:       stepLLM,
+		Tools:     []Tool{NewCalculatorTool()},
+		Callbacks: callbacks,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return agent
+}
+
+func TestPlanAndExecuteAgent(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("requires planner and tools", func(t *testing.T) {
+		if _, err := NewPlanAndExecuteAgent(PlanAndExecuteAgentConfig{Tools: []Tool{NewCalculatorTool()}}); err == nil {
+			t.Error("expected error for missing planner")
+		}
+		if _, err := NewPlanAndExecuteAgent(PlanAndExecuteAgentConfig{Planner: &mockLLMProvider{}}); err == nil {
+			t.Error("expected error for missing tools")
+		}
+	})
+
+	t.Run("run with replanning", func(t *testing.T) {
+		callback := &recordingCallback{}
+		agent := newTestPlanAndExecuteAgent(t, callback)
+
+		result, err := agent.Run(ctx, "What is (2 + 3) * 4?")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != "20" {
+			t.Errorf("unexpected result: %s", result)
+		}
+
+		want := []string{
+			"action:plan_step", "start:plan_step",
+			"action:Calculator", "start:Calculator", "end:Calculator=5", "action:",
+			"end:plan_step=5",
+			"action:plan_step", "start:plan_step",
+			"action:Calculator", "start:Calculator", "end:Calculator=20", "action:",
+			"end:plan_step=20",
+			"finish:20",
+		}
+		if !reflect.DeepEqual(callback.events, want) {
+			t.Errorf("unexpected callback events:\n got %v\nwant %v", callback.events, want)
+		}
+	})
+
+	t.Run("stream plans and steps", func(t *testing.T) {
+		agent := newTestPlanAndExecuteAgent(t)
+
+		ch, err := agent.Stream(ctx, "What is (2 + 3) * 4?")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var plans [][]string
+		var steps []string
+		var toolObservations int
+		var finalAnswer string
+		for event := range ch {
+			switch event.Type {
+			case AgentEventPlan:
+				plans = append(plans, event.Plan)
+			case AgentEventObservation:
+				if event.Step.Action.Tool == PlanStepTool {
+					steps = append(steps, event.Step.Action.ToolInput+"="+event.Step.Observation)
+				} else {
+					toolObservations++
+				}
+			case AgentEventFinish:
+				finalAnswer = event.FinalAnswer
+			case AgentEventError:
+				t.Fatalf("unexpected error: %v", event.Error)
+			}
+		}
+
+		wantPlans := [][]string{{"Add 2 and 3", "Multiply the result by 4"}, {"Multiply 5 by 4"}}
+		if !reflect.DeepEqual(plans, wantPlans) {
+			t.Errorf("unexpected plans: %v", plans)
+		}
+		if want := []string{"Add 2 and 3=5", "Multiply 5 by 4=20"}; !reflect.DeepEqual(steps, want) {
+			t.Errorf("unexpected steps: %v", steps)
+		}
+		if toolObservations != 2 {
+			t.Errorf("expected the calculator observations of both steps, got %d", toolObservations)
+		}
+		if finalAnswer != "20" {
+			t.Errorf("unexpected final answer: %s", finalAnswer)
+		}
+	})
+
+	t.Run("without replanning", func(t *testing.T) {
+		agent, _ := NewPlanAndExecuteAgent(PlanAndExecuteAgentConfig{
+			Planner:           &mockLLMProvider{responses: []string{"- Add 1 and 1\n- Report the sum"}},
+			LLM:               &mockLLMProvider{responses: []string{"Final Answer: 2", "Final Answer: The sum is 2"}},
+			Tools:             []Tool{NewCalculatorTool()},
+			DisableReplanning: true,
+		})
+
+		result, err := agent.Run(ctx, "Add 1 and 1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != "The sum is 2" {
+			t.Errorf("unexpected result: %s", result)
+		}
+	})
+
+	t.Run("max steps", func(t *testing.T) {
+		agent, _ := NewPlanAndExecuteAgent(PlanAndExecuteAgentConfig{
+			Planner: &mockLLMProvider{responses: []string{
+				"1. Step one\n2. Step two",
+				"1. Step two\n2. Step three",
+				"1. Step three\n2. Step four",
+			}},
+			LLM:      &mockLLMProvider{},
+			Tools:    []Tool{NewCalculatorTool()},
+			MaxSteps: 2,
+		})
+
+		_, err := agent.Run(ctx, "Loop")
+		if err == nil || !strings.Contains(err.Error(), "maximum steps") {
+			t.Errorf("expected max steps error, got %v", err)
+		}
+	})
+}
+
+func TestParsePlan(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "numbered", text: "Here is the plan:\n1. Fetch data\n2) Summarize it", want: []string{"Fetch data", "Summarize it"}},
+		{name: "bulleted", text: "- Fetch data\n* Summarize it", want: []string{"Fetch data", "Summarize it"}},
+		{name: "lines", text: "Steps:\nFetch data\n\nSummarize it", want: []string{"Fetch data", "Summarize it"}},
+		{name: "empty", text: "  \n", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parsePlan(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePlan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFinalAnswer(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		want   string
+		wantOK bool
+	}{
+		{name: "answer", text: "Final Answer: 42", want: "42", wantOK: true},
+		{name: "multiline answer", text: "All steps are done.\nFinal answer:\nThe sum is 2\nand the product is 1", want: "The sum is 2\nand the product is 1", wantOK: true},
+		{name: "step mentions final answer", text: "2. Write the final answer: combine the results", wantOK: false},
+		{name: "answer after plan items", text: "1. Search the docs\nFinal Answer: unknown", wantOK: false},
+		{name: "no answer", text: "1. Fetch data\n2. Summarize it", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseFinalAnswer(tt.text)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("parseFinalAnswer() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
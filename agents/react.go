@@ -12,11 +12,10 @@ import (
 // ReActAgent implements the ReAct (Reasoning and Acting) framework.
 // It alternates between thinking (reasoning) and acting (using tools).
 type ReActAgent struct {
-	llm         llm.Provider
-	tools       []Tool
-	toolsByName map[string]Tool
-	maxIter     int
-	verbose     bool
+	llm     llm.Provider
+	tools   ToolSource
+	maxIter int
+	verbose bool
 }
 
 // ReActAgentConfig configures the ReAct agent
@@ -24,9 +23,14 @@ type ReActAgentConfig struct {
 	// LLM is the language model provider (required)
 	LLM llm.Provider
 
-	// Tools are the tools available to the agent (required)
+	// Tools are the tools available to the agent (required unless
+	// ToolSource is set)
 	Tools []Tool
 
+	// ToolSource supplies the tools instead of Tools, looked up on every
+	// plan, so tools added or removed later are seen (optional)
+	ToolSource ToolSource
+
 	// MaxIterations is the maximum number of reasoning steps (default: 10)
 	MaxIterations int
 
@@ -39,43 +43,68 @@ func NewReActAgent(cfg ReActAgentConfig) (*ReActAgent, error) {
 	if cfg.LLM == nil {
 		return nil, fmt.Errorf("LLM is required")
 	}
-	if len(cfg.Tools) == 0 {
+	if len(cfg.Tools) == 0 && cfg.ToolSource == nil {
 		return nil, fmt.Errorf("at least one tool is required")
 	}
+	tools, err := toolSource(cfg.Tools, cfg.ToolSource)
+	if err != nil {
+		return nil, err
+	}
 
 	maxIter := cfg.MaxIterations
 	if maxIter <= 0 {
 		maxIter = 10
 	}
 
-	toolsByName := make(map[string]Tool)
-	for _, tool := range cfg.Tools {
-		toolsByName[tool.Name()] = tool
-	}
-
 	return &ReActAgent{
-		llm:         cfg.LLM,
-		tools:       cfg.Tools,
-		toolsByName: toolsByName,
-		maxIter:     maxIter,
-		verbose:     cfg.Verbose,
+		llm:     cfg.LLM,
+		tools:   tools,
+		maxIter: maxIter,
+		verbose: cfg.Verbose,
 	}, nil
 }
 
 // Plan decides what action to take
 func (a *ReActAgent) Plan(ctx context.Context, input AgentInput) (AgentAction, error) {
-	prompt := a.buildPrompt(input)
+	return a.plan(ctx, a.buildPrompt(input), nil)
+}
 
-	resp, err := a.llm.GenerateCompletion(ctx, &llm.CompletionRequest{
+// PlanStream decides what action to take, streaming LLM tokens to onToken
+func (a *ReActAgent) PlanStream(ctx context.Context, input AgentInput, onToken func(token string) bool) (AgentAction, error) {
+	return a.plan(ctx, a.buildPrompt(input), onToken)
+}
+
+// plan sends the prompt to the LLM and parses the resulting action.
+// Tokens are streamed to onToken when it is set and the LLM supports streaming.
+func (a *ReActAgent) plan(ctx context.Context, prompt string, onToken func(token string) bool) (AgentAction, error) {
+	req := &llm.CompletionRequest{
 		UserPrompt:  prompt,
 		Temperature: 0.0, // Use low temperature for reasoning
 		MaxTokens:   1000,
+	}
+
+	sp, ok := a.llm.(llm.StreamingProvider)
+	if !ok || onToken == nil {
+		resp, err := a.llm.GenerateCompletion(ctx, req)
+		if err != nil {
+			return AgentAction{}, fmt.Errorf("llm error: %w", err)
+		}
+		return a.parseOutput(resp.Text)
+	}
+
+	chunks, err := sp.StreamChat(ctx, req.ToChatRequest())
+	if err != nil {
+		return AgentAction{}, fmt.Errorf("llm error: %w", err)
+	}
+
+	resp, err := llm.CollectStream(ctx, chunks, func(chunk llm.ChatChunk) bool {
+		return onToken(chunk.Content)
 	})
 	if err != nil {
 		return AgentAction{}, fmt.Errorf("llm error: %w", err)
 	}
 
-	return a.parseOutput(resp.Text)
+	return a.parseOutput(resp.Message.Content)
 }
 
 // InputKeys returns the expected input keys
@@ -90,8 +119,9 @@ func (a *ReActAgent) OutputKeys() []string {
 
 // buildPrompt constructs the ReAct prompt
 func (a *ReActAgent) buildPrompt(input AgentInput) string {
-	toolDescriptions := a.buildToolDescriptions()
-	toolNames := a.buildToolNames()
+	tools := a.tools.Tools()
+	toolDescriptions := buildToolDescriptions(tools)
+	toolNames := buildToolNames(tools)
 
 	var scratchpad string
 	for _, step := range input.IntermediateSteps {
@@ -127,18 +157,18 @@ Question: %s
 }
 
 // buildToolDescriptions builds the tool descriptions section
-func (a *ReActAgent) buildToolDescriptions() string {
+func buildToolDescriptions(tools []Tool) string {
 	var parts []string
-	for _, tool := range a.tools {
+	for _, tool := range tools {
 		parts = append(parts, fmt.Sprintf("%s: %s", tool.Name(), tool.Description()))
 	}
 	return strings.Join(parts, "\n")
 }
 
 // buildToolNames builds the comma-separated tool names
-func (a *ReActAgent) buildToolNames() string {
+func buildToolNames(tools []Tool) string {
 	var names []string
-	for _, tool := range a.tools {
+	for _, tool := range tools {
 		names = append(names, tool.Name())
 	}
 	return strings.Join(names, ", ")
@@ -189,12 +219,7 @@ func (a *ReActAgent) parseOutput(text string) (AgentAction, error) {
 // GetTool returns a tool by name
 func (a *ReActAgent) GetTool(name string) (Tool, bool) {
 	// Case-insensitive lookup
-	for toolName, tool := range a.toolsByName {
-		if strings.EqualFold(toolName, name) {
-			return tool, true
-		}
-	}
-	return nil, false
+	return a.tools.Tool(name)
 }
 
 // ConversationalReActAgent extends ReActAgent with conversation history support
@@ -229,24 +254,19 @@ func NewConversationalReActAgent(cfg ConversationalReActAgentConfig) (*Conversat
 
 // Plan decides what action to take with conversation history
 func (a *ConversationalReActAgent) Plan(ctx context.Context, input AgentInput) (AgentAction, error) {
-	prompt := a.buildConversationalPrompt(input)
-
-	resp, err := a.llm.GenerateCompletion(ctx, &llm.CompletionRequest{
-		UserPrompt:  prompt,
-		Temperature: 0.0,
-		MaxTokens:   1000,
-	})
-	if err != nil {
-		return AgentAction{}, fmt.Errorf("llm error: %w", err)
-	}
+	return a.plan(ctx, a.buildConversationalPrompt(input), nil)
+}
 
-	return a.parseOutput(resp.Text)
+// PlanStream decides what action to take with conversation history, streaming LLM tokens
+func (a *ConversationalReActAgent) PlanStream(ctx context.Context, input AgentInput, onToken func(token string) bool) (AgentAction, error) {
+	return a.plan(ctx, a.buildConversationalPrompt(input), onToken)
 }
 
 // buildConversationalPrompt builds prompt with conversation history
 func (a *ConversationalReActAgent) buildConversationalPrompt(input AgentInput) string {
-	toolDescriptions := a.buildToolDescriptions()
-	toolNames := a.buildToolNames()
+	tools := a.tools.Tools()
+	toolDescriptions := buildToolDescriptions(tools)
+	toolNames := buildToolNames(tools)
 
 	var scratchpad string
 	for _, step := range input.IntermediateSteps {
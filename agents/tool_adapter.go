@@ -0,0 +1,290 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Ranganaths/minion/models"
+	"github.com/Ranganaths/minion/tools"
+)
+
+// ErrToolNotPermitted is returned when an agent lacks the capabilities a
+// tool requires
+var ErrToolNotPermitted = errors.New("tool not permitted for agent")
+
+// DomainToolAdapter adapts a tools.Tool, such as a domain tool or an MCP
+// tool from the bridge package, to the string-based Tool used by agents.
+//
+// Input that is a JSON object becomes ToolInput.Params. Other input becomes
+// ToolInput.Data, and also the only param of tools whose schema has a single
+// property. The tool's CanExecute is checked against the agent before every
+// call.
+type DomainToolAdapter struct {
+	tool     tools.Tool
+	agent    *models.Agent
+	registry tools.Registry
+}
+
+var _ Tool = (*DomainToolAdapter)(nil)
+
+// NewDomainToolAdapter adapts tool for use by agent
+func NewDomainToolAdapter(tool tools.Tool, agent *models.Agent) (*DomainToolAdapter, error) {
+	if tool == nil {
+		return nil, fmt.Errorf("tool is required")
+	}
+	if agent == nil {
+		return nil, fmt.Errorf("agent is required")
+	}
+	return &DomainToolAdapter{tool: tool, agent: agent}, nil
+}
+
+// ToolsFromRegistry adapts the tools of registry that agent can execute now.
+// Calls go through the registry, so params are validated against each
+// tool's schema. The list does not change when tools are registered or
+// unregistered later; use NewRegistryToolSource for a live view.
+func ToolsFromRegistry(registry tools.Registry, agent *models.Agent) ([]Tool, error) {
+	source, err := NewRegistryToolSource(registry, agent)
+	if err != nil {
+		return nil, err
+	}
+	return source.Tools(), nil
+}
+
+// RegistryToolSource is a live view of the tools of a tools.Registry that an
+// agent can execute. The registry is read on every lookup, so tools
+// registered or unregistered after the agent is created, such as MCP tools
+// refreshed by the bridge, are seen on its next plan or call.
+type RegistryToolSource struct {
+	registry tools.Registry
+	agent    *models.Agent
+}
+
+var _ ToolSource = (*RegistryToolSource)(nil)
+
+// NewRegistryToolSource creates a live view of the tools of registry that
+// agent can execute
+func NewRegistryToolSource(registry tools.Registry, agent *models.Agent) (*RegistryToolSource, error) {
+	if registry == nil {
+		return nil, fmt.Errorf("registry is required")
+	}
+	if agent == nil {
+		return nil, fmt.Errorf("agent is required")
+	}
+	return &RegistryToolSource{registry: registry, agent: agent}, nil
+}
+
+// Tools adapts the tools currently registered that the agent can execute
+func (s *RegistryToolSource) Tools() []Tool {
+	available := s.registry.GetToolsForAgent(s.agent)
+	result := make([]Tool, 0, len(available))
+	for _, tool := range available {
+		result = append(result, s.adapt(tool))
+	}
+	return result
+}
+
+// Tool adapts a registered tool that the agent can execute, by name
+// ignoring case
+func (s *RegistryToolSource) Tool(name string) (Tool, bool) {
+	if tool, err := s.registry.Get(name); err == nil {
+		if !tool.CanExecute(s.agent) {
+			return nil, false
+		}
+		return s.adapt(tool), true
+	}
+
+	for _, tool := range s.registry.GetToolsForAgent(s.agent) {
+		if strings.EqualFold(tool.Name(), name) {
+			return s.adapt(tool), true
+		}
+	}
+	return nil, false
+}
+
+// adapt wraps a registry tool for the agent
+func (s *RegistryToolSource) adapt(tool tools.Tool) Tool {
+	return &DomainToolAdapter{tool: tool, agent: s.agent, registry: s.registry}
+}
+
+// Name returns the name of the adapted tool
+func (t *DomainToolAdapter) Name() string {
+	return t.tool.Name()
+}
+
+// Description returns the tool description, followed by the input schema
+// for tools that have one
+func (t *DomainToolAdapter) Description() string {
+	schema := tools.InputSchema(t.tool)
+	if schema == nil {
+		return t.tool.Description()
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return t.tool.Description()
+	}
+	return fmt.Sprintf("%s Input should be a single-line JSON object matching this schema: %s", t.tool.Description(), data)
+}
+
+// Call executes the adapted tool and returns its result as text
+func (t *DomainToolAdapter) Call(ctx context.Context, input string) (string, error) {
+	if !t.tool.CanExecute(t.agent) {
+		return "", fmt.Errorf("%w: %s cannot use %s", ErrToolNotPermitted, t.agent.ID, t.tool.Name())
+	}
+
+	toolInput, err := t.toolInput(input)
+	if err != nil {
+		return "", err
+	}
+
+	var output *models.ToolOutput
+	if t.registry != nil {
+		output, err = t.registry.Execute(ctx, t.tool.Name(), toolInput)
+	} else {
+		output, err = t.tool.Execute(ctx, toolInput)
+	}
+	if err != nil {
+		return "", err
+	}
+	if output == nil {
+		return "", nil
+	}
+	if !output.Success {
+		if output.Error == "" {
+			return "", fmt.Errorf("%s failed", t.tool.Name())
+		}
+		return "", errors.New(output.Error)
+	}
+
+	switch result := output.Result.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return result, nil
+	default:
+		data, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Sprintf("%v", result), nil
+		}
+		return string(data), nil
+	}
+}
+
+// toolInput converts the agent's text input to a ToolInput
+func (t *DomainToolAdapter) toolInput(input string) (*models.ToolInput, error) {
+	input = strings.TrimSpace(input)
+
+	if strings.HasPrefix(input, "{") {
+		var params map[string]interface{}
+		if err := json.Unmarshal([]byte(input), &params); err != nil {
+			return nil, fmt.Errorf("invalid JSON input for %s: %w", t.tool.Name(), err)
+		}
+		return &models.ToolInput{Params: params}, nil
+	}
+
+	params := make(map[string]interface{})
+	if properties, ok := tools.InputSchema(t.tool)["properties"].(map[string]interface{}); ok && len(properties) == 1 {
+		for name := range properties {
+			params[name] = input
+		}
+	}
+	return &models.ToolInput{Data: input, Params: params}, nil
+}
+
+// AgentToolAdapter adapts a string-based agent Tool to tools.Tool, so it
+// can be registered alongside domain and MCP tools. The text input is read
+// from ToolInput.Data, or from the "input" param.
+type AgentToolAdapter struct {
+	tool                 Tool
+	requiredCapabilities []string
+}
+
+var (
+	_ tools.Tool           = (*AgentToolAdapter)(nil)
+	_ tools.SchemaProvider = (*AgentToolAdapter)(nil)
+)
+
+// NewAgentToolAdapter adapts tool. Agents need every one of
+// requiredCapabilities to execute it.
+func NewAgentToolAdapter(tool Tool, requiredCapabilities ...string) *AgentToolAdapter {
+	return &AgentToolAdapter{tool: tool, requiredCapabilities: requiredCapabilities}
+}
+
+// Name returns the name of the adapted tool
+func (t *AgentToolAdapter) Name() string {
+	return t.tool.Name()
+}
+
+// Description returns the description of the adapted tool
+func (t *AgentToolAdapter) Description() string {
+	return t.tool.Description()
+}
+
+// InputSchema describes the optional "input" param
+func (t *AgentToolAdapter) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"input": tools.StringProperty("Text input for the tool"),
+	})
+}
+
+// Execute calls the adapted tool. Errors are reported in the output.
+func (t *AgentToolAdapter) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
+	output := &models.ToolOutput{ToolName: t.tool.Name()}
+
+	result, err := t.tool.Call(ctx, agentToolInput(input))
+	if err != nil {
+		output.Success = false
+		output.Error = err.Error()
+		return output, nil
+	}
+
+	output.Success = true
+	output.Result = result
+	return output, nil
+}
+
+// CanExecute checks that agent has the required capabilities
+func (t *AgentToolAdapter) CanExecute(agent *models.Agent) bool {
+	if len(t.requiredCapabilities) == 0 {
+		return true
+	}
+	if agent == nil {
+		return false
+	}
+
+	capabilities := make(map[string]bool, len(agent.Capabilities))
+	for _, capability := range agent.Capabilities {
+		capabilities[capability] = true
+	}
+	for _, required := range t.requiredCapabilities {
+		if !capabilities[required] {
+			return false
+		}
+	}
+	return true
+}
+
+// agentToolInput converts a ToolInput to text: Data if it is a string, the
+// "input" param, or the params as JSON
+func agentToolInput(input *models.ToolInput) string {
+	if input == nil {
+		return ""
+	}
+	if data, ok := input.Data.(string); ok {
+		return data
+	}
+	if text, ok := input.Params["input"].(string); ok {
+		return text
+	}
+	if len(input.Params) > 0 {
+		if data, err := json.Marshal(input.Params); err == nil {
+			return string(data)
+		}
+	}
+	if input.Data != nil {
+		return fmt.Sprintf("%v", input.Data)
+	}
+	return ""
+}
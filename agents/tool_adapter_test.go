@@ -0,0 +1,239 @@
+package agents
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Ranganaths/minion/models"
+	"github.com/Ranganaths/minion/tools"
+)
+
+// ticketTool is a domain tool that requires the "support" capability and
+// records the input it was executed with
+type ticketTool struct {
+	inputs []*models.ToolInput
+}
+
+func (t *ticketTool) Name() string        { return "create_ticket" }
+func (t *ticketTool) Description() string { return "Creates a support ticket." }
+
+func (t *ticketTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"title":    tools.StringProperty("Ticket title"),
+		"priority": tools.EnumProperty("Ticket priority", "low", "high"),
+	}, "title")
+}
+
+func (t *ticketTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
+	t.inputs = append(t.inputs, input)
+	return &models.ToolOutput{
+		ToolName: t.Name(),
+		Success:  true,
+		Result:   map[string]interface{}{"id": "T-1", "title": input.Params["title"]},
+	}, nil
+}
+
+func (t *ticketTool) CanExecute(agent *models.Agent) bool {
+	for _, capability := range agent.Capabilities {
+		if capability == "support" {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDomainToolAdapter(t *testing.T) {
+	ctx := context.Background()
+	support := &models.Agent{ID: "agent-1", Capabilities: []string{"support"}}
+
+	t.Run("maps JSON input to params", func(t *testing.T) {
+		tool := &ticketTool{}
+		adapter, err := NewDomainToolAdapter(tool, support)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !strings.Contains(adapter.Description(), `"required":["title"]`) {
+			t.Errorf("expected the schema in the description, got %q", adapter.Description())
+		}
+
+		result, err := adapter.Call(ctx, `{"title": "Printer on fire", "priority": "high"}`)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != `{"id":"T-1","title":"Printer on fire"}` {
+			t.Errorf("unexpected result: %s", result)
+		}
+		if params := tool.inputs[0].Params; params["title"] != "Printer on fire" || params["priority"] != "high" {
+			t.Errorf("unexpected params: %v", params)
+		}
+
+		if _, err := adapter.Call(ctx, `{"title": `); err == nil {
+			t.Error("expected an error for invalid JSON")
+		}
+	})
+
+	t.Run("checks capabilities on every call", func(t *testing.T) {
+		tool := &ticketTool{}
+		adapter, _ := NewDomainToolAdapter(tool, &models.Agent{ID: "agent-2", Capabilities: []string{"sales"}})
+
+		_, err := adapter.Call(ctx, `{"title": "x"}`)
+		if !errors.Is(err, ErrToolNotPermitted) {
+			t.Errorf("expected ErrToolNotPermitted, got %v", err)
+		}
+		if len(tool.inputs) != 0 {
+			t.Error("tool should not have been executed")
+		}
+	})
+
+	t.Run("registry view filters and validates", func(t *testing.T) {
+		tool := &ticketTool{}
+		registry := tools.NewRegistry()
+		registry.Register(tool)
+		registry.Register(NewAgentToolAdapter(NewCalculatorTool()))
+
+		agentTools, err := ToolsFromRegistry(registry, support)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(agentTools) != 2 {
+			t.Fatalf("expected 2 tools, got %d", len(agentTools))
+		}
+		if denied, _ := ToolsFromRegistry(registry, &models.Agent{ID: "agent-2"}); len(denied) != 1 {
+			t.Errorf("expected only the calculator without the support capability, got %d tools", len(denied))
+		}
+
+		var ticket, calculator Tool
+		for _, tool := range agentTools {
+			switch tool.Name() {
+			case "create_ticket":
+				ticket = tool
+			case "Calculator":
+				calculator = tool
+			}
+		}
+
+		if _, err := ticket.Call(ctx, `{"priority": "low"}`); !errors.Is(err, tools.ErrInvalidParams) {
+			t.Errorf("expected ErrInvalidParams, got %v", err)
+		}
+
+		// Plain text input reaches string-based tools unchanged
+		if result, err := calculator.Call(ctx, "2 + 3"); err != nil || result != "5" {
+			t.Errorf("unexpected calculator result: %q, %v", result, err)
+		}
+	})
+}
+
+func TestAgentToolAdapter(t *testing.T) {
+	ctx := context.Background()
+	adapter := NewAgentToolAdapter(NewCalculatorTool(), "math")
+
+	tests := []struct {
+		name  string
+		input *models.ToolInput
+		want  string
+	}{
+		{name: "data", input: &models.ToolInput{Data: "6 * 7"}, want: "42"},
+		{name: "input param", input: &models.ToolInput{Params: map[string]interface{}{"input": "1 + 1"}}, want: "2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := adapter.Execute(ctx, tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !output.Success || output.Result != tt.want {
+				t.Errorf("unexpected output: %+v", output)
+			}
+		})
+	}
+
+	output, _ := adapter.Execute(ctx, &models.ToolInput{Data: "1 / 0"})
+	if output.Success || !strings.Contains(output.Error, "division by zero") {
+		t.Errorf("expected the tool error in the output, got %+v", output)
+	}
+
+	if adapter.CanExecute(&models.Agent{}) || !adapter.CanExecute(&models.Agent{Capabilities: []string{"math"}}) {
+		t.Error("expected the math capability to be required")
+	}
+}
+
+func TestAgentExecutor_DomainTools(t *testing.T) {
+	tool := &ticketTool{}
+	registry := tools.NewRegistry()
+	registry.Register(tool)
+
+	agentTools, err := ToolsFromRegistry(registry, &models.Agent{ID: "agent-1", Capabilities: []string{"support"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	llm := &mockLLMProvider{responses: []string{
+		"Thought: I should open a ticket.\nAction: create_ticket\nAction Input: {\"title\": \"VPN down\"}",
+		"Final Answer: Opened T-1",
+	}}
+	agent, _ := NewReActAgent(ReActAgentConfig{LLM: llm, Tools: agentTools})
+	executor, _ := NewAgentExecutor(AgentExecutorConfig{Agent: agent, Tools: agentTools})
+
+	result, err := executor.Run(context.Background(), "The VPN is down")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != "Opened T-1" {
+		t.Errorf("unexpected result: %s", result)
+	}
+	if len(tool.inputs) != 1 || tool.inputs[0].Params["title"] != "VPN down" {
+		t.Errorf("expected the tool to receive the JSON params, got %+v", tool.inputs)
+	}
+}
+
+func TestAgentExecutor_RegistryToolSource(t *testing.T) {
+	registry := tools.NewRegistry()
+	registry.Register(NewAgentToolAdapter(NewCalculatorTool()))
+
+	source, err := NewRegistryToolSource(registry, &models.Agent{ID: "agent-1", Capabilities: []string{"support"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	llm := &mockLLMProvider{responses: []string{
+		"Thought: I should open a ticket.\nAction: CREATE_TICKET\nAction Input: {\"title\": \"VPN down\"}",
+		"Final Answer: Opened T-1",
+	}}
+	agent, err := NewReActAgent(ReActAgentConfig{LLM: llm, ToolSource: source})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	executor, _ := NewAgentExecutor(AgentExecutorConfig{Agent: agent, ToolSource: source})
+
+	// Registered after the agent was created
+	tool := &ticketTool{}
+	registry.Register(tool)
+
+	if !strings.Contains(agent.buildPrompt(AgentInput{Input: "hi"}), "create_ticket") {
+		t.Error("expected the prompt to list the new tool")
+	}
+
+	result, err := executor.Run(context.Background(), "The VPN is down")
+	if err != nil || result != "Opened T-1" {
+		t.Fatalf("unexpected result: %q, %v", result, err)
+	}
+	if len(tool.inputs) != 1 {
+		t.Errorf("expected the new tool to be called once, got %d calls", len(tool.inputs))
+	}
+
+	registry.Unregister("create_ticket")
+	if _, ok := agent.GetTool("create_ticket"); ok {
+		t.Error("expected the unregistered tool to be gone")
+	}
+	if _, ok := source.Tool("Calculator"); !ok {
+		t.Error("expected the calculator to remain")
+	}
+
+	if _, err := NewReActAgent(ReActAgentConfig{LLM: llm, Tools: source.Tools(), ToolSource: source}); err == nil {
+		t.Error("expected an error when both Tools and ToolSource are set")
+	}
+}
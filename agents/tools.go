@@ -170,6 +170,35 @@ func (t *SearchTool) Call(ctx context.Context, input string) (string, error) {
 	return t.searchFunc(ctx, input)
 }
 
+// staticTools is a fixed list of tools
+type staticTools []Tool
+
+// Tools returns the tools
+func (t staticTools) Tools() []Tool {
+	return t
+}
+
+// Tool returns a tool by name, ignoring case
+func (t staticTools) Tool(name string) (Tool, bool) {
+	for _, tool := range t {
+		if strings.EqualFold(tool.Name(), name) {
+			return tool, true
+		}
+	}
+	return nil, false
+}
+
+// toolSource returns source, or a fixed source of tools when source is nil
+func toolSource(tools []Tool, source ToolSource) (ToolSource, error) {
+	if source == nil {
+		return staticTools(tools), nil
+	}
+	if len(tools) > 0 {
+		return nil, fmt.Errorf("set either Tools or ToolSource, not both")
+	}
+	return source, nil
+}
+
 // ToolRegistry manages available tools
 type ToolRegistry struct {
 	tools map[string]Tool
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"github.com/Ranganaths/minion/llm"
 )
 
 // BaseChain provides common functionality for all chains
@@ -152,6 +154,39 @@ func MakeStreamEvent(eventType StreamEventType, content string, data map[string]
 	}
 }
 
+// TokenFunc receives streamed tokens. Returning false aborts generation.
+type TokenFunc func(token string) bool
+
+// generateCompletion calls the provider, streaming tokens to onToken when the
+// provider implements llm.StreamingProvider. With a nil onToken, or a provider
+// that cannot stream, it falls back to a blocking GenerateCompletion call.
+func generateCompletion(ctx context.Context, provider llm.Provider, req *llm.CompletionRequest, onToken TokenFunc) (*llm.CompletionResponse, error) {
+	sp, ok := provider.(llm.StreamingProvider)
+	if !ok || onToken == nil {
+		return provider.GenerateCompletion(ctx, req)
+	}
+
+	chunks, err := sp.StreamChat(ctx, req.ToChatRequest())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := llm.CollectStream(ctx, chunks, func(chunk llm.ChatChunk) bool {
+		return onToken(chunk.Content)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &llm.CompletionResponse{
+		Text:         resp.Message.Content,
+		TokensUsed:   resp.TokensUsed,
+		Usage:        resp.Usage,
+		FinishReason: resp.FinishReason,
+		Model:        resp.Model,
+	}, nil
+}
+
 // CopyInputs creates a shallow copy of inputs map
 func CopyInputs(inputs map[string]any) map[string]any {
 	result := make(map[string]any, len(inputs))
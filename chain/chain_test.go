@@ -3,8 +3,13 @@ package chain
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/Ranganaths/minion/llm"
+	"github.com/Ranganaths/minion/memory"
+	"github.com/Ranganaths/minion/vectorstore"
 )
 
 // MockChain is a simple mock chain for testing
@@ -681,3 +686,183 @@ func (t *testCallback) OnChainEnd(ctx context.Context, chainName string, outputs
 		t.onEnd()
 	}
 }
+
+// mockStreamingLLM implements llm.StreamingProvider, streaming a fixed token list
+type mockStreamingLLM struct {
+	tokens []string
+}
+
+func (m *mockStreamingLLM) GenerateCompletion(ctx context.Context, req *llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	return &llm.CompletionResponse{Text: strings.Join(m.tokens, ""), TokensUsed: len(m.tokens)}, nil
+}
+
+func (m *mockStreamingLLM) GenerateChat(ctx context.Context, req *llm.ChatRequest) (*llm.ChatResponse, error) {
+	return &llm.ChatResponse{Message: llm.Message{Role: "assistant", Content: strings.Join(m.tokens, "")}}, nil
+}
+
+func (m *mockStreamingLLM) StreamChat(ctx context.Context, req *llm.ChatRequest) (<-chan llm.ChatChunk, error) {
+	ch := make(chan llm.ChatChunk, len(m.tokens)+1)
+	for _, token := range m.tokens {
+		ch <- llm.ChatChunk{Content: token}
+	}
+	ch <- llm.ChatChunk{Done: true, FinishReason: "stop", TokensUsed: len(m.tokens)}
+	close(ch)
+	return ch, nil
+}
+
+func (m *mockStreamingLLM) Name() string { return "mock-streaming" }
+
+type staticRetriever struct {
+	docs []vectorstore.Document
+}
+
+func (r *staticRetriever) GetRelevantDocuments(ctx context.Context, query string) ([]vectorstore.Document, error) {
+	return r.docs, nil
+}
+
+// collectStreamEvents drains a stream and returns token contents and the final event
+func collectStreamEvents(t *testing.T, ch <-chan StreamEvent) ([]string, StreamEvent) {
+	t.Helper()
+	var tokens []string
+	var last StreamEvent
+	for event := range ch {
+		switch event.Type {
+		case StreamEventToken:
+			tokens = append(tokens, event.Content)
+		case StreamEventError:
+			t.Fatalf("unexpected error event: %v", event.Error)
+		}
+		last = event
+	}
+	return tokens, last
+}
+
+func TestLLMChainStreamTokens(t *testing.T) {
+	provider := &mockStreamingLLM{tokens: []string{"Hel", "lo", "!"}}
+	c, err := NewLLMChain(LLMChainConfig{
+		LLM:        provider,
+		PromptFunc: SimplePrompt("Say: "),
+	})
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+
+	ch, err := c.Stream(context.Background(), map[string]any{"input": "hello"})
+	if err != nil {
+		t.Fatalf("stream failed: %v", err)
+	}
+
+	tokens, last := collectStreamEvents(t, ch)
+	if strings.Join(tokens, "|") != "Hel|lo|!" {
+		t.Errorf("unexpected tokens: %v", tokens)
+	}
+	if last.Type != StreamEventComplete {
+		t.Fatalf("expected complete event last, got %s", last.Type)
+	}
+	if last.Data["text"] != "Hello!" {
+		t.Errorf("expected assembled text 'Hello!', got %v", last.Data["text"])
+	}
+}
+
+func TestRAGChainStreamTokens(t *testing.T) {
+	provider := &mockStreamingLLM{tokens: []string{"Paris", "."}}
+	c, err := NewRAGChain(RAGChainConfig{
+		Retriever: &staticRetriever{docs: []vectorstore.Document{
+			vectorstore.NewDocument("Paris is the capital of France."),
+		}},
+		LLM: provider,
+	})
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+
+	ch, err := c.Stream(context.Background(), map[string]any{"question": "Capital of France?"})
+	if err != nil {
+		t.Fatalf("stream failed: %v", err)
+	}
+
+	sawRetrieval := false
+	var tokens []string
+	var last StreamEvent
+	for event := range ch {
+		switch event.Type {
+		case StreamEventRetrieval:
+			sawRetrieval = true
+		case StreamEventToken:
+			if !sawRetrieval {
+				t.Error("token emitted before retrieval")
+			}
+			tokens = append(tokens, event.Content)
+		case StreamEventError:
+			t.Fatalf("unexpected error: %v", event.Error)
+		}
+		last = event
+	}
+
+	if len(tokens) != 2 {
+		t.Errorf("expected 2 tokens, got %v", tokens)
+	}
+	if last.Type != StreamEventComplete || last.Data["answer"] != "Paris." {
+		t.Errorf("unexpected final event: %+v", last)
+	}
+}
+
+// recordingRetriever records the queries it receives
+type recordingRetriever struct {
+	staticRetriever
+	queries []string
+}
+
+func (r *recordingRetriever) GetRelevantDocuments(ctx context.Context, query string) ([]vectorstore.Document, error) {
+	r.queries = append(r.queries, query)
+	return r.docs, nil
+}
+
+func TestConversationalRAGChainMemory(t *testing.T) {
+	ctx := context.Background()
+	factory := memory.NewFileChatMessageHistoryFactory(t.TempDir())
+
+	// newChain simulates a process resuming the session
+	newChain := func(retriever *recordingRetriever) *ConversationalRAGChain {
+		history, err := factory(ctx, "session-1")
+		if err != nil {
+			t.Fatalf("factory failed: %v", err)
+		}
+		c, err := NewConversationalRAGChain(ConversationalRAGChainConfig{
+			RAGChainConfig: RAGChainConfig{
+				Retriever: retriever,
+				LLM:       &mockStreamingLLM{tokens: []string{"Paris"}},
+			},
+			Memory: memory.NewConversationBufferMemory(memory.ConversationBufferMemoryConfig{ChatHistory: history}),
+		})
+		if err != nil {
+			t.Fatalf("failed to create chain: %v", err)
+		}
+		return c
+	}
+
+	first := &recordingRetriever{}
+	inputs := map[string]any{"question": "Capital of France?"}
+	if _, err := newChain(first).Call(ctx, inputs); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if first.queries[0] != "Capital of France?" {
+		t.Errorf("expected the plain question without history, got %q", first.queries[0])
+	}
+	if inputs["question"] != "Capital of France?" {
+		t.Errorf("inputs were modified: %v", inputs)
+	}
+
+	second := &recordingRetriever{}
+	resumed := newChain(second)
+	if keys := resumed.InputKeys(); len(keys) != 1 || keys[0] != "question" {
+		t.Errorf("expected history to be optional with memory, got %v", keys)
+	}
+	if _, err := resumed.Call(ctx, map[string]any{"question": "And its population?"}); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	want := "Human: Capital of France?\nAI: Paris\n\nBased on the above conversation, the current question is: And its population?"
+	if second.queries[0] != want {
+		t.Errorf("unexpected condensed question:\n%s", second.queries[0])
+	}
+}
@@ -91,6 +91,11 @@ func (c *LLMChain) OutputKeys() []string {
 
 // Call executes the LLM chain
 func (c *LLMChain) Call(ctx context.Context, inputs map[string]any) (map[string]any, error) {
+	return c.run(ctx, inputs, nil)
+}
+
+// run executes the chain, forwarding generated tokens to onToken if non-nil
+func (c *LLMChain) run(ctx context.Context, inputs map[string]any, onToken TokenFunc) (map[string]any, error) {
 	c.NotifyStart(ctx, inputs)
 
 	// Apply timeout
@@ -128,7 +133,7 @@ func (c *LLMChain) Call(ctx context.Context, inputs map[string]any) (map[string]
 	}
 
 	// Call LLM
-	resp, err := c.llmProvider.GenerateCompletion(ctx, req)
+	resp, err := generateCompletion(ctx, c.llmProvider, req, onToken)
 	if err != nil {
 		c.NotifyError(ctx, fmt.Errorf("LLM error: %w", err))
 		return nil, fmt.Errorf("LLM error: %w", err)
@@ -156,6 +161,8 @@ func (c *LLMChain) Call(ctx context.Context, inputs map[string]any) (map[string]
 }
 
 // Stream executes the chain and streams results.
+// If the LLM provider implements llm.StreamingProvider, a StreamEventToken is
+// emitted for each generated token before the final StreamEventComplete.
 // The returned channel is closed when streaming completes or context is cancelled.
 func (c *LLMChain) Stream(ctx context.Context, inputs map[string]any) (<-chan StreamEvent, error) {
 	ch := make(chan StreamEvent, 10)
@@ -177,7 +184,9 @@ func (c *LLMChain) Stream(ctx context.Context, inputs map[string]any) (<-chan St
 			return
 		}
 
-		result, err := c.Call(ctx, inputs)
+		result, err := c.run(ctx, inputs, func(token string) bool {
+			return send(MakeStreamEvent(StreamEventToken, token, nil, nil))
+		})
 		if err != nil {
 			// Check if it's a context error
 			if ctx.Err() != nil {
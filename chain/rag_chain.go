@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/Ranganaths/minion/llm"
+	"github.com/Ranganaths/minion/memory"
 	"github.com/Ranganaths/minion/retriever"
 	"github.com/Ranganaths/minion/vectorstore"
 )
@@ -205,6 +206,8 @@ func (c *RAGChain) Call(ctx context.Context, inputs map[string]any) (map[string]
 }
 
 // Stream executes the RAG chain with streaming.
+// Retrieved documents are emitted as a StreamEventRetrieval, followed by one
+// StreamEventToken per generated token when the LLM provider supports streaming.
 // The returned channel is closed when streaming completes or context is cancelled.
 func (c *RAGChain) Stream(ctx context.Context, inputs map[string]any) (<-chan StreamEvent, error) {
 	ch := make(chan StreamEvent, 10)
@@ -275,9 +278,23 @@ func (c *RAGChain) Stream(ctx context.Context, inputs map[string]any) (<-chan St
 			UserPrompt: prompt,
 		}
 
-		// Call LLM
-		resp, err := c.llmProvider.GenerateCompletion(ctx, req)
+		// Apply option overrides
+		if c.Options().Temperature != nil {
+			req.Temperature = *c.Options().Temperature
+		}
+		if c.Options().MaxTokens != nil {
+			req.MaxTokens = *c.Options().MaxTokens
+		}
+
+		// Call LLM, forwarding tokens as they arrive
+		resp, err := generateCompletion(ctx, c.llmProvider, req, func(token string) bool {
+			return send(MakeStreamEvent(StreamEventToken, token, nil, nil))
+		})
 		if err != nil {
+			if ctx.Err() != nil {
+				send(MakeStreamEvent(StreamEventError, "", nil, ctx.Err()))
+				return
+			}
 			send(MakeStreamEvent(StreamEventError, "", nil, fmt.Errorf("LLM error: %w", err)))
 			return
 		}
@@ -378,6 +395,7 @@ func WithSourcesCombineFunc(docs []vectorstore.Document) string {
 type ConversationalRAGChain struct {
 	*RAGChain
 	historyKey string
+	memory     memory.Memory
 }
 
 // ConversationalRAGChainConfig configures the conversational RAG chain
@@ -386,6 +404,12 @@ type ConversationalRAGChainConfig struct {
 
 	// HistoryKey is the key for conversation history (default: "chat_history")
 	HistoryKey string
+
+	// Memory loads the conversation history and saves each question and
+	// answer (optional). Its first memory variable is used as the history
+	// unless the inputs contain HistoryKey. Back it with a durable
+	// ChatMessageHistory to resume conversations across processes.
+	Memory memory.Memory
 }
 
 // NewConversationalRAGChain creates a new conversational RAG chain
@@ -408,11 +432,15 @@ func NewConversationalRAGChain(cfg ConversationalRAGChainConfig) (*Conversationa
 	return &ConversationalRAGChain{
 		RAGChain:   ragChain,
 		historyKey: historyKey,
+		memory:     cfg.Memory,
 	}, nil
 }
 
 // InputKeys returns the required input keys
 func (c *ConversationalRAGChain) InputKeys() []string {
+	if c.memory != nil {
+		return []string{c.inputKey}
+	}
 	return []string{c.inputKey, c.historyKey}
 }
 
@@ -420,15 +448,61 @@ func (c *ConversationalRAGChain) InputKeys() []string {
 func (c *ConversationalRAGChain) Call(ctx context.Context, inputs map[string]any) (map[string]any, error) {
 	// Rewrite question based on history for better retrieval
 	query, _ := c.GetString(inputs, c.inputKey)
-	history, _ := inputs[c.historyKey].(string)
+	history, err := c.history(ctx, inputs)
+	if err != nil {
+		return nil, err
+	}
 
+	chainInputs := make(map[string]any, len(inputs)+1)
+	for k, v := range inputs {
+		chainInputs[k] = v
+	}
 	if history != "" {
 		// Create a condensed question
 		condensedQuery := fmt.Sprintf("%s\n\nBased on the above conversation, the current question is: %s", history, query)
-		inputs[c.inputKey] = condensedQuery
+		chainInputs[c.inputKey] = condensedQuery
 	}
 
-	return c.RAGChain.Call(ctx, inputs)
+	outputs, err := c.RAGChain.Call(ctx, chainInputs)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.memory != nil {
+		// Memories read the default "input" and "output" keys unless
+		// configured with the chain's own keys
+		saveInputs := map[string]any{"input": query}
+		for k, v := range inputs {
+			saveInputs[k] = v
+		}
+		saveOutputs := map[string]any{"output": outputs[c.outputKey]}
+		for k, v := range outputs {
+			saveOutputs[k] = v
+		}
+		if err := c.memory.SaveContext(ctx, saveInputs, saveOutputs); err != nil {
+			return nil, fmt.Errorf("failed to save conversation: %w", err)
+		}
+	}
+	return outputs, nil
+}
+
+// history returns the conversation history from the inputs, or from memory
+// when the inputs have none
+func (c *ConversationalRAGChain) history(ctx context.Context, inputs map[string]any) (string, error) {
+	if history, ok := inputs[c.historyKey].(string); ok || c.memory == nil {
+		return history, nil
+	}
+
+	vars, err := c.memory.LoadMemoryVariables(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to load conversation: %w", err)
+	}
+	keys := c.memory.MemoryVariables()
+	if len(keys) == 0 {
+		return "", nil
+	}
+	history, _ := vars[keys[0]].(string)
+	return history, nil
 }
 
 // ConversationalRAGPromptFunc formats prompt with conversation history
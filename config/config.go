@@ -54,6 +54,26 @@ type LLMConfig struct {
 	Default   DefaultLLMConfig `mapstructure:"default"`
 }
 
+// DefaultAPIKey returns the API key of the default provider, for building
+// an llm.ProviderConfig:
+//
+//	llm.NewProviderFactory(&llm.ProviderConfig{
+//		Type:   llm.ProviderType(cfg.LLM.Default.Provider),
+//		APIKey: cfg.LLM.DefaultAPIKey(),
+//	})
+func (c LLMConfig) DefaultAPIKey() string {
+	switch c.Default.Provider {
+	case "openai":
+		return c.OpenAI.APIKey
+	case "anthropic":
+		return c.Anthropic.APIKey
+	case "gemini":
+		return c.Gemini.APIKey
+	default:
+		return ""
+	}
+}
+
 type OpenAIConfig struct {
 	APIKey string `mapstructure:"api_key"`
 	OrgID  string `mapstructure:"org_id"`
@@ -489,6 +509,9 @@ func validate(cfg *Config) error {
 	if cfg.LLM.Default.Provider == "openai" && cfg.LLM.OpenAI.APIKey == "" {
 		return fmt.Errorf("llm.openai.api_key is required when provider is openai")
 	}
+	if cfg.LLM.Default.Provider == "gemini" && cfg.LLM.Gemini.APIKey == "" {
+		return fmt.Errorf("llm.gemini.api_key is required when provider is gemini")
+	}
 
 	// Validate observability
 	if cfg.Observability.Tracing.SamplingRatio < 0 || cfg.Observability.Tracing.SamplingRatio > 1.0 {
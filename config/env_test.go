@@ -234,3 +234,29 @@ func TestDefaultEnv(t *testing.T) {
 		t.Errorf("expected 'minion_value', got '%s'", result)
 	}
 }
+
+func TestLLMConfig_DefaultAPIKey(t *testing.T) {
+	cfg := LLMConfig{
+		OpenAI: OpenAIConfig{APIKey: "openai-key"},
+		Gemini: GeminiConfig{APIKey: "gemini-key"},
+	}
+
+	tests := []struct {
+		provider string
+		want     string
+	}{
+		{provider: "gemini", want: "gemini-key"},
+		{provider: "openai", want: "openai-key"},
+		{provider: "anthropic", want: ""},
+		{provider: "ollama", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			cfg.Default.Provider = tt.provider
+			if got := cfg.DefaultAPIKey(); got != tt.want {
+				t.Errorf("DefaultAPIKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -17,13 +18,27 @@ import (
 // FrameworkImpl is the main implementation of the agent framework
 type FrameworkImpl struct {
 	store            storage.Store
-	llmProvider      llm.Provider
+	llmProvider      llm.Provider // default for agents that don't name a provider
+	llmProviders     *llm.Registry
 	behaviorRegistry BehaviorRegistry
 	toolRegistry     tools.Registry
+	sessionManager   SessionManager
 
 	// MCP (Model Context Protocol) components
 	mcpClientManager *client.MCPClientManager
 	mcpBridge        *bridge.BridgeRegistry
+
+	// Set by options and applied by NewFramework. Option errors are
+	// returned when LLM providers are first used.
+	namedLLMProviders []namedLLMProvider
+	optionErrs        []error
+	optionErr         error
+}
+
+// namedLLMProvider is a provider passed to WithNamedLLMProvider
+type namedLLMProvider struct {
+	name     string
+	provider llm.Provider
 }
 
 // Option is a functional option for configuring the framework
@@ -36,13 +51,39 @@ func WithStorage(store storage.Store) Option {
 	}
 }
 
-// WithLLMProvider sets the LLM provider
+// WithLLMProvider sets the default LLM provider, used for agents that don't
+// set AgentConfig.LLMProvider. Unless another provider is registered under
+// the same name, it is also registered under its Name().
 func WithLLMProvider(provider llm.Provider) Option {
 	return func(f *FrameworkImpl) {
 		f.llmProvider = provider
 	}
 }
 
+// WithNamedLLMProvider registers an LLM provider under the given name.
+// Agents select it by setting AgentConfig.LLMProvider to that name.
+// Providers are registered after all options are applied, so they are
+// added to the registry set by WithLLMProviderRegistry regardless of the
+// order of the options. An empty or duplicate name is an error, returned
+// by Execute and RegisterLLMProvider.
+func WithNamedLLMProvider(name string, provider llm.Provider) Option {
+	return func(f *FrameworkImpl) {
+		f.namedLLMProviders = append(f.namedLLMProviders, namedLLMProvider{name: name, provider: provider})
+	}
+}
+
+// WithLLMProviderRegistry sets the registry used to resolve
+// AgentConfig.LLMProvider to a provider
+func WithLLMProviderRegistry(registry *llm.Registry) Option {
+	return func(f *FrameworkImpl) {
+		if registry == nil {
+			f.optionErrs = append(f.optionErrs, fmt.Errorf("LLM provider registry is nil"))
+			return
+		}
+		f.llmProviders = registry
+	}
+}
+
 // WithBehaviorRegistry sets the behavior registry
 func WithBehaviorRegistry(registry BehaviorRegistry) Option {
 	return func(f *FrameworkImpl) {
@@ -57,6 +98,14 @@ func WithToolRegistry(registry tools.Registry) Option {
 	}
 }
 
+// WithSessionManager sets the session manager used to load and persist
+// conversation history for tool-enabled executions
+func WithSessionManager(manager SessionManager) Option {
+	return func(f *FrameworkImpl) {
+		f.sessionManager = manager
+	}
+}
+
 // NewFramework creates a new agent framework with the given options
 func NewFramework(opts ...Option) *FrameworkImpl {
 	// Initialize MCP client manager
@@ -65,6 +114,7 @@ func NewFramework(opts ...Option) *FrameworkImpl {
 	f := &FrameworkImpl{
 		behaviorRegistry: NewBehaviorRegistry(),
 		toolRegistry:     tools.NewRegistry(),
+		llmProviders:     llm.NewRegistry(),
 		mcpClientManager: mcpManager,
 	}
 
@@ -75,6 +125,29 @@ func NewFramework(opts ...Option) *FrameworkImpl {
 		opt(f)
 	}
 
+	for _, named := range f.namedLLMProviders {
+		if _, err := f.llmProviders.Get(named.name); err == nil {
+			f.optionErrs = append(f.optionErrs, fmt.Errorf("LLM provider %q is already registered", named.name))
+			continue
+		}
+		if err := f.llmProviders.Register(named.name, named.provider); err != nil {
+			f.optionErrs = append(f.optionErrs, fmt.Errorf("failed to register LLM provider %q: %w", named.name, err))
+		}
+	}
+	f.namedLLMProviders = nil
+
+	// Make the default provider selectable by name as well
+	if f.llmProvider != nil && f.llmProvider.Name() != "" {
+		if _, err := f.llmProviders.Get(f.llmProvider.Name()); err != nil {
+			f.llmProviders.Register(f.llmProvider.Name(), f.llmProvider)
+		}
+	}
+
+	if err := errors.Join(f.optionErrs...); err != nil {
+		f.optionErr = fmt.Errorf("invalid framework option: %w", err)
+	}
+	f.optionErrs = nil
+
 	return f
 }
 
@@ -246,6 +319,11 @@ func (f *FrameworkImpl) RegisterTool(tool interface{}) error {
 	return f.toolRegistry.Register(t)
 }
 
+// UnregisterTool removes a tool by name
+func (f *FrameworkImpl) UnregisterTool(name string) error {
+	return f.toolRegistry.Unregister(name)
+}
+
 // GetToolsForAgent returns tools available for an agent
 func (f *FrameworkImpl) GetToolsForAgent(agent *models.Agent) []interface{} {
 	availableTools := f.toolRegistry.GetToolsForAgent(agent)
@@ -283,9 +361,6 @@ func (f *FrameworkImpl) Execute(ctx context.Context, agentID string, input *mode
 	if f.store == nil {
 		return nil, fmt.Errorf("storage not configured")
 	}
-	if f.llmProvider == nil {
-		return nil, fmt.Errorf("LLM provider not configured")
-	}
 
 	startTime := time.Now()
 
@@ -300,7 +375,12 @@ func (f *FrameworkImpl) Execute(ctx context.Context, agentID string, input *mode
 		return nil, fmt.Errorf("agent is not active (status: %s)", agent.Status)
 	}
 
-	// 2. Get behavior
+	// 2. Resolve the agent's LLM provider and behavior
+	provider, err := f.providerForAgent(agent)
+	if err != nil {
+		return nil, err
+	}
+
 	behavior, err := f.behaviorRegistry.Get(agent.BehaviorType)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get behavior: %w", err)
@@ -321,37 +401,52 @@ func (f *FrameworkImpl) Execute(ctx context.Context, agentID string, input *mode
 		userPrompt = fmt.Sprintf("%s\n\nInstructions: %s", userPrompt, processedInput.Instructions)
 	}
 
-	// 6. Call LLM
-	llmModel := agent.Config.LLMModel
-	if llmModel == "" {
-		llmModel = "gpt-4" // Default model
+	// 6. Call LLM, letting it use the agent's tools if enabled
+	var output *models.Output
+	if agent.Config.EnableTools {
+		output, err = f.executeWithTools(ctx, provider, agent, input, systemPrompt, userPrompt)
+	} else {
+		output, err = f.executeCompletion(ctx, provider, agent, systemPrompt, userPrompt)
 	}
-
-	temperature := agent.Config.Temperature
-	if temperature == 0 {
-		temperature = 0.7 // Default temperature
+	if err != nil {
+		// Record failed execution
+		f.recordActivity(ctx, agent.ID, input, nil, "failed", time.Since(startTime), err.Error())
+		return nil, err
 	}
 
-	maxTokens := agent.Config.MaxTokens
-	if maxTokens == 0 {
-		maxTokens = 1000 // Default max tokens
+	// 7. Process output
+	processedOutput, err := behavior.ProcessOutput(ctx, agent, output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process output: %w", err)
 	}
 
-	completion, err := f.llmProvider.GenerateCompletion(ctx, &llm.CompletionRequest{
+	// 8. Record successful execution
+	duration := time.Since(startTime)
+	toolsUsed, _ := output.Metadata["tools_used"].([]string)
+	f.recordActivity(ctx, agent.ID, input, processedOutput.Original, "success", duration, "", toolsUsed...)
+
+	// 9. Update metrics
+	f.updateMetrics(ctx, agent.ID, true, duration)
+
+	return processedOutput.Original, nil
+}
+
+// executeCompletion makes a single completion call and returns its output
+func (f *FrameworkImpl) executeCompletion(ctx context.Context, provider llm.Provider, agent *models.Agent, systemPrompt, userPrompt string) (*models.Output, error) {
+	model, temperature, maxTokens := llmSettings(agent, provider)
+
+	completion, err := provider.GenerateCompletion(ctx, &llm.CompletionRequest{
 		SystemPrompt: systemPrompt,
 		UserPrompt:   userPrompt,
 		Temperature:  temperature,
 		MaxTokens:    maxTokens,
-		Model:        llmModel,
+		Model:        model,
 	})
 	if err != nil {
-		// Record failed execution
-		f.recordActivity(ctx, agent.ID, input, nil, "failed", time.Since(startTime), err.Error())
 		return nil, fmt.Errorf("LLM completion failed: %w", err)
 	}
 
-	// 7. Create output
-	output := &models.Output{
+	return &models.Output{
 		Result: completion.Text,
 		Type:   "text",
 		Metadata: map[string]interface{}{
@@ -359,22 +454,66 @@ func (f *FrameworkImpl) Execute(ctx context.Context, agentID string, input *mode
 			"model":         completion.Model,
 			"finish_reason": completion.FinishReason,
 		},
+	}, nil
+}
+
+// RegisterLLMProvider registers an LLM provider under the given name,
+// replacing any provider previously registered under that name. It returns
+// the error of an invalid LLM provider option passed to NewFramework.
+func (f *FrameworkImpl) RegisterLLMProvider(name string, provider llm.Provider) error {
+	if f.optionErr != nil {
+		return f.optionErr
 	}
+	return f.llmProviders.Register(name, provider)
+}
 
-	// 8. Process output
-	processedOutput, err := behavior.ProcessOutput(ctx, agent, output)
+// ListLLMProviders returns the names of all registered LLM providers
+func (f *FrameworkImpl) ListLLMProviders() []string {
+	return f.llmProviders.List()
+}
+
+// providerForAgent returns the provider named by the agent's config,
+// or the default provider if the agent doesn't name one
+func (f *FrameworkImpl) providerForAgent(agent *models.Agent) (llm.Provider, error) {
+	if f.optionErr != nil {
+		return nil, f.optionErr
+	}
+
+	name := agent.Config.LLMProvider
+	if name == "" {
+		if f.llmProvider == nil {
+			return nil, fmt.Errorf("LLM provider not configured")
+		}
+		return f.llmProvider, nil
+	}
+
+	provider, err := f.llmProviders.Get(name)
 	if err != nil {
-		return nil, fmt.Errorf("failed to process output: %w", err)
+		return nil, fmt.Errorf("failed to get LLM provider for agent: %w", err)
 	}
+	return provider, nil
+}
 
-	// 9. Record successful execution
-	duration := time.Since(startTime)
-	f.recordActivity(ctx, agent.ID, input, processedOutput.Original, "success", duration, "")
+// llmSettings returns the model, temperature and max tokens for an agent,
+// applying defaults for unset values. The default model depends on the
+// provider; custom providers receive an empty model and apply their own.
+func llmSettings(agent *models.Agent, provider llm.Provider) (string, float64, int) {
+	model := agent.Config.LLMModel
+	if model == "" {
+		model = llm.DefaultModel(provider.Name())
+	}
 
-	// 10. Update metrics
-	f.updateMetrics(ctx, agent.ID, true, duration)
+	temperature := agent.Config.Temperature
+	if temperature == 0 {
+		temperature = 0.7 // Default temperature
+	}
 
-	return processedOutput.Original, nil
+	maxTokens := agent.Config.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 1000 // Default max tokens
+	}
+
+	return model, temperature, maxTokens
 }
 
 // GetMetrics retrieves metrics for an agent
@@ -396,7 +535,7 @@ func (f *FrameworkImpl) GetActivities(ctx context.Context, agentID string, limit
 }
 
 // recordActivity records an activity in storage
-func (f *FrameworkImpl) recordActivity(ctx context.Context, agentID string, input *models.Input, output *models.Output, status string, duration time.Duration, errorMsg string) {
+func (f *FrameworkImpl) recordActivity(ctx context.Context, agentID string, input *models.Input, output *models.Output, status string, duration time.Duration, errorMsg string, toolsUsed ...string) {
 	activity := &models.Activity{
 		ID:        uuid.New().String(),
 		AgentID:   agentID,
@@ -405,6 +544,7 @@ func (f *FrameworkImpl) recordActivity(ctx context.Context, agentID string, inpu
 		Output:    output,
 		Status:    status,
 		Duration:  duration.Milliseconds(),
+		ToolsUsed: toolsUsed,
 		Error:     errorMsg,
 		CreatedAt: time.Now(),
 	}
@@ -497,16 +637,11 @@ func (f *FrameworkImpl) GetMCPServerStatus() map[string]interface{} {
 	return result
 }
 
-// RefreshMCPTools refreshes tools from an MCP server
+// RefreshMCPTools re-fetches the tool list from an MCP server and
+// re-registers its tools. Servers that send tools/list_changed notifications
+// are refreshed automatically.
 func (f *FrameworkImpl) RefreshMCPTools(ctx context.Context, serverName string) error {
-	// Unregister existing tools
-	if err := f.mcpBridge.UnregisterServerTools(serverName); err != nil {
-		// If no tools found, that's fine - continue with re-registration
-		_ = err
-	}
-
-	// Re-register tools
-	if err := f.mcpBridge.RegisterServerTools(ctx, serverName); err != nil {
+	if err := f.mcpBridge.RefreshServerTools(ctx, serverName); err != nil {
 		return fmt.Errorf("failed to refresh MCP tools: %w", err)
 	}
 
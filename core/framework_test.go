@@ -0,0 +1,392 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ranganaths/minion/llm"
+	"github.com/Ranganaths/minion/models"
+	"github.com/Ranganaths/minion/storage"
+)
+
+// scriptedLLM returns queued chat responses in order and records each request
+type scriptedLLM struct {
+	name      string
+	mu        sync.Mutex
+	responses []*llm.ChatResponse
+	requests  []*llm.ChatRequest
+}
+
+func (m *scriptedLLM) Name() string {
+	if m.name == "" {
+		return "scripted"
+	}
+	return m.name
+}
+
+func (m *scriptedLLM) GenerateCompletion(ctx context.Context, req *llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	return &llm.CompletionResponse{Text: "completion from " + m.Name(), TokensUsed: 1, Model: req.Model}, nil
+}
+
+func (m *scriptedLLM) GenerateChat(ctx context.Context, req *llm.ChatRequest) (*llm.ChatResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, req)
+	if len(m.responses) == 0 {
+		return nil, fmt.Errorf("no scripted response left")
+	}
+	resp := m.responses[0]
+	m.responses = m.responses[1:]
+	return resp, nil
+}
+
+// toolCallResponse builds a response requesting a single tool call
+func toolCallResponse(id, name, args string) *llm.ChatResponse {
+	return &llm.ChatResponse{
+		Message: llm.Message{
+			Role:      "assistant",
+			ToolCalls: []llm.ToolCall{{ID: id, Name: name, Arguments: args}},
+		},
+		TokensUsed:   10,
+		FinishReason: "tool_calls",
+	}
+}
+
+// answerResponse builds a final text response
+func answerResponse(text string) *llm.ChatResponse {
+	return &llm.ChatResponse{
+		Message:      llm.Message{Role: "assistant", Content: text},
+		TokensUsed:   5,
+		FinishReason: "stop",
+	}
+}
+
+// adderTool adds the "a" and "b" params
+type adderTool struct {
+	calls int
+}
+
+func (t *adderTool) Name() string        { return "add" }
+func (t *adderTool) Description() string { return "Adds two numbers" }
+
+func (t *adderTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
+	t.calls++
+	a, _ := input.Params["a"].(float64)
+	b, _ := input.Params["b"].(float64)
+	return &models.ToolOutput{ToolName: t.Name(), Success: true, Result: a + b}, nil
+}
+
+func (t *adderTool) CanExecute(agent *models.Agent) bool {
+	for _, c := range agent.Capabilities {
+		if c == "math" {
+			return true
+		}
+	}
+	return false
+}
+
+func newToolTestFramework(t *testing.T, provider llm.Provider, capabilities []string, maxIterations int) (*FrameworkImpl, *models.Agent, *adderTool) {
+	t.Helper()
+
+	framework := NewFramework(
+		WithStorage(storage.NewInMemory()),
+		WithLLMProvider(provider),
+	)
+	t.Cleanup(func() { framework.Close() })
+
+	tool := &adderTool{}
+	if err := framework.RegisterTool(tool); err != nil {
+		t.Fatalf("RegisterTool failed: %v", err)
+	}
+
+	agent, err := framework.CreateAgent(context.Background(), &models.CreateAgentRequest{
+		Name:         "calculator",
+		BehaviorType: "default",
+		Capabilities: capabilities,
+		Config: models.AgentConfig{
+			EnableTools:       true,
+			MaxToolIterations: maxIterations,
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateAgent failed: %v", err)
+	}
+
+	return framework, agent, tool
+}
+
+func TestExecuteWithTools(t *testing.T) {
+	provider := &scriptedLLM{responses: []*llm.ChatResponse{
+		toolCallResponse("call_1", "add", `{"a": 2, "b": 3}`),
+		answerResponse("The sum is 5"),
+	}}
+	framework, agent, tool := newToolTestFramework(t, provider, []string{"math"}, 0)
+
+	output, err := framework.Execute(context.Background(), agent.ID, &models.Input{Raw: "What is 2 + 3?", Type: "text"})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if output.Result != "The sum is 5" {
+		t.Errorf("unexpected result: %v", output.Result)
+	}
+	if tool.calls != 1 {
+		t.Errorf("expected tool to be called once, got %d", tool.calls)
+	}
+	if output.Metadata["tokens_used"] != 15 {
+		t.Errorf("expected 15 tokens, got %v", output.Metadata["tokens_used"])
+	}
+
+	// The first request offers the tool; the second carries its result
+	if len(provider.requests) != 2 {
+		t.Fatalf("expected 2 LLM requests, got %d", len(provider.requests))
+	}
+	if tools := provider.requests[0].Tools; len(tools) != 1 || tools[0].Name != "add" {
+		t.Errorf("expected add tool to be offered, got %+v", tools)
+	}
+	messages := provider.requests[1].Messages
+	last := messages[len(messages)-1]
+	if last.Role != "tool" || last.ToolCallID != "call_1" || last.Content != "5" {
+		t.Errorf("unexpected tool result message: %+v", last)
+	}
+
+	// Both the tool call and the execution are recorded
+	activities, err := framework.GetActivities(context.Background(), agent.ID, 10)
+	if err != nil {
+		t.Fatalf("GetActivities failed: %v", err)
+	}
+	var toolActivity, execActivity *models.Activity
+	for _, a := range activities {
+		switch a.Action {
+		case "tool_call":
+			toolActivity = a
+		case "execute":
+			execActivity = a
+		}
+	}
+	if toolActivity == nil || toolActivity.Status != "success" || toolActivity.ToolsUsed[0] != "add" {
+		t.Errorf("expected successful tool_call activity, got %+v", toolActivity)
+	}
+	if execActivity == nil || len(execActivity.ToolsUsed) != 1 || execActivity.ToolsUsed[0] != "add" {
+		t.Errorf("expected execute activity to list tools used, got %+v", execActivity)
+	}
+}
+
+func TestExecuteWithTools_UnpermittedTool(t *testing.T) {
+	provider := &scriptedLLM{responses: []*llm.ChatResponse{
+		toolCallResponse("call_1", "add", `{"a": 1, "b": 1}`),
+		answerResponse("I cannot do that"),
+	}}
+	framework, agent, tool := newToolTestFramework(t, provider, nil, 0)
+
+	output, err := framework.Execute(context.Background(), agent.ID, &models.Input{Raw: "1 + 1", Type: "text"})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if output.Result != "I cannot do that" {
+		t.Errorf("unexpected result: %v", output.Result)
+	}
+	if tool.calls != 0 {
+		t.Errorf("tool should not run without permission, ran %d times", tool.calls)
+	}
+	if len(provider.requests[0].Tools) != 0 {
+		t.Errorf("expected no tools offered, got %+v", provider.requests[0].Tools)
+	}
+
+	messages := provider.requests[1].Messages
+	last := messages[len(messages)-1]
+	if !strings.HasPrefix(last.Content, "Error:") {
+		t.Errorf("expected error tool result, got %q", last.Content)
+	}
+}
+
+func TestExecuteWithTools_MaxIterations(t *testing.T) {
+	provider := &scriptedLLM{responses: []*llm.ChatResponse{
+		toolCallResponse("call_1", "add", `{"a": 1, "b": 1}`),
+		toolCallResponse("call_2", "add", `{"a": 2, "b": 2}`),
+		answerResponse("never reached"),
+	}}
+	framework, agent, _ := newToolTestFramework(t, provider, []string{"math"}, 2)
+
+	_, err := framework.Execute(context.Background(), agent.ID, &models.Input{Raw: "loop", Type: "text"})
+	if err == nil || !strings.Contains(err.Error(), "maximum tool iterations") {
+		t.Errorf("expected max iterations error, got %v", err)
+	}
+}
+
+func TestExecuteWithTools_Session(t *testing.T) {
+	sessions := NewInMemorySessionManager()
+	session, err := sessions.Create(context.Background(), "agent", "user", time.Hour)
+	if err != nil {
+		t.Fatalf("Create session failed: %v", err)
+	}
+	sessions.Append(context.Background(), session.ID, Message{Role: MessageRoleUser, Content: "My name is Ada"})
+	sessions.Append(context.Background(), session.ID, Message{Role: MessageRoleAssistant, Content: "Hello Ada"})
+
+	provider := &scriptedLLM{responses: []*llm.ChatResponse{
+		toolCallResponse("call_1", "add", `{"a": 1, "b": 2}`),
+		answerResponse("3, Ada"),
+	}}
+	framework, agent, _ := newToolTestFramework(t, provider, []string{"math"}, 0)
+	WithSessionManager(sessions)(framework)
+
+	_, err = framework.Execute(context.Background(), agent.ID, &models.Input{
+		Raw:     "What is 1 + 2?",
+		Type:    "text",
+		Context: map[string]interface{}{SessionIDKey: session.ID},
+	})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	// System prompt, two history messages, then the new user turn
+	if messages := provider.requests[0].Messages; len(messages) != 4 || messages[1].Content != "My name is Ada" {
+		t.Errorf("expected history to be loaded, got %+v", messages)
+	}
+
+	// User, assistant tool call, tool result and final answer are appended
+	history, _ := sessions.GetHistory(context.Background(), session.ID, 0)
+	if len(history) != 6 {
+		t.Fatalf("expected 6 history messages, got %d", len(history))
+	}
+	if history[3].ToolCalls[0].ToolName != "add" || history[4].ToolCallID != "call_1" || history[5].Content != "3, Ada" {
+		t.Errorf("unexpected appended turns: %+v", history[2:])
+	}
+}
+
+func TestExecute_ProviderRouting(t *testing.T) {
+	hosted := &scriptedLLM{name: "openai"}
+	local := &scriptedLLM{name: "ollama"}
+	custom := &scriptedLLM{name: "custom"}
+
+	framework := NewFramework(
+		WithStorage(storage.NewInMemory()),
+		WithLLMProvider(hosted),
+		WithNamedLLMProvider("ollama", local),
+	)
+	defer framework.Close()
+
+	if err := framework.RegisterLLMProvider("my-provider", custom); err != nil {
+		t.Fatalf("RegisterLLMProvider failed: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		provider  string
+		model     string
+		want      string
+		wantModel string
+		wantErr   bool
+	}{
+		{name: "default provider", provider: "", want: "completion from openai", wantModel: "gpt-4"},
+		{name: "default registered by name", provider: "openai", want: "completion from openai", wantModel: "gpt-4"},
+		{name: "named provider", provider: "ollama", want: "completion from ollama", wantModel: "llama3"},
+		{name: "explicit model", provider: "ollama", model: "mistral", want: "completion from ollama", wantModel: "mistral"},
+		{name: "custom provider", provider: "my-provider", want: "completion from custom", wantModel: ""},
+		{name: "unknown provider", provider: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent, err := framework.CreateAgent(context.Background(), &models.CreateAgentRequest{
+				Name:         tt.name,
+				BehaviorType: "default",
+				Config:       models.AgentConfig{LLMProvider: tt.provider, LLMModel: tt.model},
+			})
+			if err != nil {
+				t.Fatalf("CreateAgent failed: %v", err)
+			}
+
+			output, err := framework.Execute(context.Background(), agent.ID, &models.Input{Raw: "hi", Type: "text"})
+			if tt.wantErr {
+				if !errors.Is(err, llm.ErrProviderNotFound) {
+					t.Errorf("expected ErrProviderNotFound, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+			if output.Result != tt.want {
+				t.Errorf("expected %q, got %v", tt.want, output.Result)
+			}
+			if output.Metadata["model"] != tt.wantModel {
+				t.Errorf("expected model %q, got %v", tt.wantModel, output.Metadata["model"])
+			}
+		})
+	}
+
+	if got := framework.ListLLMProviders(); len(got) != 3 {
+		t.Errorf("expected 3 providers, got %v", got)
+	}
+}
+
+func TestExecute_NoProvider(t *testing.T) {
+	framework := NewFramework(WithStorage(storage.NewInMemory()))
+	defer framework.Close()
+
+	agent, err := framework.CreateAgent(context.Background(), &models.CreateAgentRequest{
+		Name:         "orphan",
+		BehaviorType: "default",
+	})
+	if err != nil {
+		t.Fatalf("CreateAgent failed: %v", err)
+	}
+
+	_, err = framework.Execute(context.Background(), agent.ID, &models.Input{Raw: "hi", Type: "text"})
+	if err == nil || !strings.Contains(err.Error(), "not configured") {
+		t.Errorf("expected provider not configured error, got %v", err)
+	}
+}
+
+func TestNewFramework_LLMProviderOptions(t *testing.T) {
+	local := &scriptedLLM{name: "ollama"}
+
+	t.Run("named providers join a later registry", func(t *testing.T) {
+		registry := llm.NewRegistry()
+		framework := NewFramework(
+			WithNamedLLMProvider("local", local),
+			WithLLMProviderRegistry(registry),
+		)
+		defer framework.Close()
+
+		if provider, err := registry.Get("local"); err != nil || provider != local {
+			t.Errorf("expected the named provider in the registry, got %v, %v", provider, err)
+		}
+	})
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{name: "empty name", opts: []Option{WithNamedLLMProvider("", local)}, want: "provider name is required"},
+		{name: "duplicate name", opts: []Option{WithNamedLLMProvider("local", local), WithNamedLLMProvider("local", local)}, want: "already registered"},
+		{name: "nil registry", opts: []Option{WithLLMProviderRegistry(nil)}, want: "registry is nil"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			framework := NewFramework(append(tt.opts, WithStorage(storage.NewInMemory()), WithLLMProvider(local))...)
+			defer framework.Close()
+
+			if err := framework.RegisterLLMProvider("custom", local); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected RegisterLLMProvider error containing %q, got %v", tt.want, err)
+			}
+
+			agent, err := framework.CreateAgent(context.Background(), &models.CreateAgentRequest{Name: "agent"})
+			if err != nil {
+				t.Fatalf("CreateAgent failed: %v", err)
+			}
+			if _, err := framework.Execute(context.Background(), agent.ID, &models.Input{Raw: "hi"}); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected Execute error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 
+	"github.com/Ranganaths/minion/llm"
 	"github.com/Ranganaths/minion/models"
 )
 
@@ -58,8 +59,13 @@ type Framework interface {
 	RegisterBehavior(behaviorType string, behavior Behavior) error
 	GetBehavior(behaviorType string) (Behavior, error)
 
+	// LLM provider operations (agents select a provider via AgentConfig.LLMProvider)
+	RegisterLLMProvider(name string, provider llm.Provider) error
+	ListLLMProviders() []string
+
 	// Tool operations (defined in tools package)
 	RegisterTool(tool interface{}) error
+	UnregisterTool(name string) error
 	GetToolsForAgent(agent *models.Agent) []interface{}
 	ExecuteTool(ctx context.Context, toolName string, params map[string]interface{}) (*models.ToolOutput, error)
 	ListTools() []string
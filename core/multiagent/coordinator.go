@@ -243,6 +243,8 @@ func (c *Coordinator) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	c.orchestrator.Close()
+
 	return nil
 }
 
@@ -318,7 +320,7 @@ func (c *Coordinator) GetMonitoringStats(ctx context.Context) (*MonitoringStats,
 	for _, worker := range c.workers {
 		metadata := worker.GetMetadata()
 
-		switch metadata.Status {
+		switch metadata.CurrentStatus() {
 		case StatusIdle:
 			stats.IdleWorkers++
 		case StatusBusy:
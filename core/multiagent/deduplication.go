@@ -394,12 +394,14 @@ func (ds *DeduplicationService) cleanupLoop(interval time.Duration) {
 	defer ticker.Stop()
 
 	for range ticker.C {
-		ctx := context.Background()
-		cutoff := time.Now().Add(-ds.windowSize)
-
-		if err := ds.backend.Cleanup(ctx, cutoff); err != nil {
+		if err := ds.Cleanup(context.Background()); err != nil {
 			// Log error but continue
 			continue
 		}
 	}
 }
+
+// Cleanup removes message IDs older than the window
+func (ds *DeduplicationService) Cleanup(ctx context.Context) error {
+	return ds.backend.Cleanup(ctx, time.Now().Add(-ds.windowSize))
+}
@@ -211,8 +211,83 @@ func TestEndToEnd_MultipleWorkers(t *testing.T) {
 
 // TestEndToEnd_TaskWithDependencies tests dependency resolution
 func TestEndToEnd_TaskWithDependencies(t *testing.T) {
-	t.Skip("Dependency resolution not fully implemented yet")
-	// TODO: Implement once dependency handling is complete
+	ctx := context.Background()
+
+	// Listed out of order: the report depends on subtasks that follow it
+	response := `{
+  "subtasks": [
+    {
+      "name": "Write report",
+      "assigned_to": "content_creation",
+      "dependencies": ["Research topic", "Analyze data"],
+      "input": {"format": "markdown"}
+    },
+    {
+      "name": "Analyze data",
+      "assigned_to": "data_analysis",
+      "dependencies": ["Research topic"],
+      "input": "Analyze data"
+    },
+    {
+      "name": "Research topic",
+      "assigned_to": "research",
+      "dependencies": [],
+      "input": "Research requirements"
+    }
+  ]
+}`
+	mockLLM := NewMockLLMProvider()
+	mockLLM.SetResponse("Dependent Task", response)
+
+	coordinator := NewCoordinator(mockLLM, nil)
+	defer coordinator.Shutdown(ctx)
+
+	var reportInput map[string]interface{}
+	handlers := map[string]func(ctx context.Context, task *Task) (interface{}, error){
+		"research": func(ctx context.Context, task *Task) (interface{}, error) {
+			return "findings", nil
+		},
+		"data_analysis": func(ctx context.Context, task *Task) (interface{}, error) {
+			input, _ := task.Input.(map[string]interface{})
+			deps, _ := input[DependencyOutputsKey].(map[string]interface{})
+			return fmt.Sprintf("analysis of %v", deps["Research topic"]), nil
+		},
+		"content_creation": func(ctx context.Context, task *Task) (interface{}, error) {
+			reportInput, _ = task.Input.(map[string]interface{})
+			return "report", nil
+		},
+	}
+	for capability, fn := range handlers {
+		handler := NewMockWorkerHandler(capability, []string{capability})
+		handler.SetHandlerFunc(fn)
+		worker := NewWorkerAgent(&AgentMetadata{
+			AgentID:      "worker-" + capability,
+			Role:         RoleWorker,
+			Capabilities: []string{capability},
+			Status:       StatusIdle,
+		}, coordinator.GetProtocol(), handler)
+		if err := coordinator.RegisterWorker(ctx, worker); err != nil {
+			t.Fatalf("Failed to register worker: %v", err)
+		}
+	}
+
+	result, err := coordinator.ExecuteTask(ctx, &TaskRequest{
+		Name:        "Dependent Task",
+		Description: "Task with a dependency chain",
+		Type:        "complex",
+	})
+	if err != nil {
+		t.Fatalf("Task failed: %v", err)
+	}
+	if result.Status != "completed" {
+		t.Errorf("Expected completed status, got %s", result.Status)
+	}
+
+	// The report sees both upstream outputs alongside its own input
+	deps, _ := reportInput[DependencyOutputsKey].(map[string]interface{})
+	if reportInput["format"] != "markdown" || deps["Research topic"] != "findings" || deps["Analyze data"] != "analysis of findings" {
+		t.Errorf("unexpected report input %v", reportInput)
+	}
 }
 
 // TestEndToEnd_ErrorHandling tests error handling and recovery
@@ -64,6 +64,24 @@ func (tl *TaskLedger) GetTask(ctx context.Context, taskID string) (*Task, error)
 	return task, nil
 }
 
+// finishedTask reports whether a task is completed or failed, with its
+// output or error
+func (tl *TaskLedger) finishedTask(taskID string) (status TaskStatus, output interface{}, errMsg string, finished bool) {
+	tl.mu.RLock()
+	defer tl.mu.RUnlock()
+
+	task, exists := tl.tasks[taskID]
+	if !exists {
+		return "", nil, "", false
+	}
+
+	switch task.Status {
+	case TaskStatusCompleted, TaskStatusFailed:
+		return task.Status, task.Output, task.Error, true
+	}
+	return task.Status, nil, "", false
+}
+
 // UpdateTask updates a task
 func (tl *TaskLedger) UpdateTask(ctx context.Context, task *Task) error {
 	tl.mu.Lock()
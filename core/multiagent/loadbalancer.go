@@ -323,7 +323,7 @@ func (cb *CapabilityBalancer) getWeight(taskType string, worker *WorkerAgent) fl
 	}
 
 	// Current status penalty
-	if worker.metadata.Status == StatusBusy {
+	if worker.metadata.CurrentStatus() == StatusBusy {
 		baseWeight *= 0.5
 	}
 
@@ -558,7 +558,7 @@ func filterCapableWorkers(task *Task, workers []*WorkerAgent) []*WorkerAgent {
 
 	for _, worker := range workers {
 		// Skip if worker is not idle
-		if worker.metadata.Status != StatusIdle {
+		if worker.metadata.CurrentStatus() != StatusIdle {
 			continue
 		}
 
@@ -2,12 +2,13 @@ package multiagent
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/Ranganaths/minion/llm"
 	"github.com/google/uuid"
 )
 
@@ -15,9 +16,18 @@ import (
 type OrchestratorConfig struct {
 	MaxRetries         int           `json:"max_retries"`
 	RetryDelay         time.Duration `json:"retry_delay"`
-	MaxConcurrentTasks int           `json:"max_concurrent_tasks"`
+	MaxConcurrentTasks int           `json:"max_concurrent_tasks"` // Parallel subtasks (0 = unlimited)
 	TaskTimeout        time.Duration `json:"task_timeout"`
 	EnableReplanning   bool          `json:"enable_replanning"` // Re-plan on errors
+
+	// FailurePolicy determines whether a failed subtask cancels the rest of
+	// the plan or only its dependents (default: cancel)
+	FailurePolicy FailurePolicy `json:"failure_policy"`
+
+	// AgentID is the ID the orchestrator sends and receives messages as
+	// (default: a random UUID). Set it when the protocol requires
+	// authentication so a credential can be registered for it.
+	AgentID string `json:"agent_id,omitempty"`
 }
 
 // DefaultOrchestratorConfig returns default configuration
@@ -28,6 +38,7 @@ func DefaultOrchestratorConfig() *OrchestratorConfig {
 		MaxConcurrentTasks: 5,
 		TaskTimeout:        time.Minute * 5,
 		EnableReplanning:   true,
+		FailurePolicy:      FailurePolicyCancel,
 	}
 }
 
@@ -42,6 +53,13 @@ type Orchestrator struct {
 	workers        map[string]*AgentMetadata // agentID -> metadata
 	config         *OrchestratorConfig
 	llmProvider    LLMProvider // For planning and decision making
+
+	// Routes worker replies to the subtasks awaiting them. The orchestrator
+	// starts listening on its first task.
+	results       *ResultRouter
+	listenMu      sync.Mutex
+	listening     bool
+	stopListening func()
 }
 
 // LLMProvider defines interface for LLM operations
@@ -66,7 +84,8 @@ type CompletionResponse struct {
 	FinishReason string
 }
 
-// NewOrchestrator creates a new orchestrator
+// NewOrchestrator creates a new orchestrator. It subscribes to worker
+// replies when it executes its first task.
 func NewOrchestrator(
 	protocol Protocol,
 	llmProvider LLMProvider,
@@ -76,14 +95,10 @@ func NewOrchestrator(
 		config = DefaultOrchestratorConfig()
 	}
 
-	orchestratorID := uuid.New().String()
-
-	// Subscribe to receive result and error messages from workers
-	protocol.Subscribe(context.Background(), orchestratorID, []MessageType{
-		MessageTypeResult,
-		MessageTypeError,
-		MessageTypeInform,
-	})
+	orchestratorID := config.AgentID
+	if orchestratorID == "" {
+		orchestratorID = uuid.New().String()
+	}
 
 	return &Orchestrator{
 		id:             orchestratorID,
@@ -93,7 +108,51 @@ func NewOrchestrator(
 		workers:        make(map[string]*AgentMetadata),
 		config:         config,
 		llmProvider:    llmProvider,
+		results:        NewResultRouter(),
+	}
+}
+
+// listen subscribes the orchestrator to worker replies and starts
+// delivering them, unless it already has. It fails if another listener is
+// registered for the orchestrator's AgentID; a later call tries again.
+func (o *Orchestrator) listen() error {
+	o.listenMu.Lock()
+	defer o.listenMu.Unlock()
+
+	if o.listening {
+		return nil
 	}
+
+	// Subscribe to receive result and error messages from workers
+	if err := o.protocol.Subscribe(context.Background(), o.id, []MessageType{
+		MessageTypeResult,
+		MessageTypeError,
+		MessageTypeInform,
+	}); err != nil {
+		return fmt.Errorf("failed to subscribe orchestrator: %w", err)
+	}
+
+	// Deliver worker replies as they arrive
+	stop, err := o.protocol.Listen(context.Background(), o.id, o.results.Handle)
+	if err != nil {
+		return fmt.Errorf("failed to listen for worker replies: %w", err)
+	}
+	o.stopListening = stop
+	o.listening = true
+	return nil
+}
+
+// Close stops receiving worker replies. Subtasks still waiting for a reply
+// will time out; a later ExecuteTask listens again.
+func (o *Orchestrator) Close() {
+	o.listenMu.Lock()
+	defer o.listenMu.Unlock()
+
+	if o.stopListening != nil {
+		o.stopListening()
+		o.stopListening = nil
+	}
+	o.listening = false
 }
 
 // RegisterWorker registers a worker agent
@@ -139,6 +198,10 @@ func (o *Orchestrator) GetWorkers() []*AgentMetadata {
 
 // ExecuteTask executes a complex task using multiple agents
 func (o *Orchestrator) ExecuteTask(ctx context.Context, taskReq *TaskRequest) (*TaskResult, error) {
+	if err := o.listen(); err != nil {
+		return nil, err
+	}
+
 	// 1. Create main task
 	task := &Task{
 		ID:          uuid.New().String(),
@@ -228,23 +291,23 @@ Input: %v
 
 Please decompose this task into subtasks.`, task.Name, task.Description, task.Input)
 
-	// Call LLM for planning
-	resp, err := o.llmProvider.GenerateCompletion(ctx, &CompletionRequest{
-		SystemPrompt: systemPrompt,
-		UserPrompt:   userPrompt,
-		Temperature:  0.3, // Lower temperature for more deterministic planning
-		MaxTokens:    2000,
-		Model:        "gpt-4",
+	// Call LLM for planning. Plans that are not valid JSON or do not match
+	// the schema are sent back to the model to be corrected.
+	response, err := llm.GenerateStructured[SubtaskResponse](ctx, completionChat{o.llmProvider}, &llm.ChatRequest{
+		Messages: []llm.Message{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: userPrompt},
+		},
+		Temperature:    0.3, // Lower temperature for more deterministic planning
+		MaxTokens:      2000,
+		Model:          "gpt-4",
+		ResponseFormat: llm.JSONSchemaFormat("subtask_plan", subtaskPlanSchema),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("LLM planning failed: %w", err)
 	}
 
-	// Parse LLM response into subtasks
-	subtasks, err := o.parseSubtasks(resp.Text, task.ID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse subtasks: %w", err)
-	}
+	subtasks := o.buildSubtasks(response, task.ID)
 
 	// Log successful planning
 	o.progressLedger.AddEntry(ctx, &ProgressEntry{
@@ -259,47 +322,87 @@ Please decompose this task into subtasks.`, task.Name, task.Description, task.In
 	return subtasks, nil
 }
 
-// executeSubtasks executes a list of subtasks
+// executeSubtasks executes subtasks in dependency order. Subtasks whose
+// dependencies are met run concurrently, up to MaxConcurrentTasks, and
+// receive their dependencies' outputs in their input.
 func (o *Orchestrator) executeSubtasks(ctx context.Context, mainTask *Task, subtasks []*Task) (*TaskResult, error) {
-	// Track completion
-	completed := make(map[string]bool)
-	results := make(map[string]interface{})
-	var lastError error
+	graph, err := newSubtaskGraph(subtasks)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, subtask := range subtasks {
-		// Check dependencies
-		if !o.checkDependencies(subtask, completed) {
-			continue // Skip for now, will retry
+		if err := o.taskLedger.CreateTask(ctx, subtask); err != nil {
+			return nil, fmt.Errorf("failed to create subtask %s: %w", subtask.Name, err)
 		}
+	}
 
-		// Assign to worker
-		if err := o.assignTaskToWorker(ctx, subtask); err != nil {
-			lastError = err
-			continue
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type outcome struct {
+		task   *Task
+		result interface{}
+		err    error
+	}
+	done := make(chan outcome)
+
+	results := make(map[string]interface{})
+	ready := graph.roots()
+	running := 0
+	cancelled := false
+	var errs []error
+
+	for len(ready) > 0 || running > 0 {
+		for len(ready) > 0 && !cancelled &&
+			(o.config.MaxConcurrentTasks <= 0 || running < o.config.MaxConcurrentTasks) {
+			subtask := graph.tasks[ready[0]]
+			ready = ready[1:]
+
+			subtask.Input = withDependencyOutputs(subtask, graph.tasks, results)
+			running++
+			go func() {
+				result, err := o.runSubtask(ctx, subtask)
+				done <- outcome{task: subtask, result: result, err: err}
+			}()
+		}
+		if running == 0 {
+			break
 		}
 
-		// Wait for completion (with timeout)
-		result, err := o.waitForTaskCompletion(ctx, subtask)
-		if err != nil {
-			lastError = err
-			if o.config.MaxRetries > 0 {
-				// Retry logic
-				result, err = o.retryTask(ctx, subtask)
-				if err != nil {
-					continue
-				}
-			} else {
+		out := <-done
+		running--
+
+		if out.err != nil {
+			// Subtasks interrupted by our own cancellation are not failures
+			if cancelled && ctx.Err() != nil {
 				continue
 			}
+			errs = append(errs, fmt.Errorf("subtask %s: %w", out.task.Name, out.err))
+			o.progressLedger.AddEntry(ctx, &ProgressEntry{
+				TaskID:      mainTask.ID,
+				AgentID:     o.id,
+				Action:      "subtask_failed",
+				Description: fmt.Sprintf("Subtask %s failed: %v", out.task.Name, out.err),
+				Status:      "failed",
+			})
+			if o.config.FailurePolicy != FailurePolicyContinue {
+				cancelled = true
+				cancel()
+			}
+			continue
 		}
 
-		completed[subtask.ID] = true
-		results[subtask.ID] = result
+		results[out.task.ID] = out.result
+		newlyReady := graph.complete(out.task.ID)
+		ready = append(ready, newlyReady...)
+		graph.sort(ready)
 	}
 
 	// Check if all completed
-	if len(completed) != len(subtasks) {
-		return nil, fmt.Errorf("failed to complete all subtasks: %w", lastError)
+	if len(results) != len(subtasks) {
+		return nil, fmt.Errorf("failed to complete all subtasks (%d of %d completed): %w",
+			len(results), len(subtasks), errors.Join(errs...))
 	}
 
 	// Aggregate results
@@ -311,6 +414,24 @@ func (o *Orchestrator) executeSubtasks(ctx context.Context, mainTask *Task, subt
 	}, nil
 }
 
+// runSubtask assigns a subtask to a worker and waits for its result,
+// retrying according to the configuration
+func (o *Orchestrator) runSubtask(ctx context.Context, subtask *Task) (interface{}, error) {
+	// Expect the reply before assigning so a fast worker cannot beat us
+	replies := o.results.Expect(subtask.ID)
+	defer o.results.Cancel(subtask.ID)
+
+	if err := o.assignTaskToWorker(ctx, subtask); err != nil {
+		return nil, err
+	}
+
+	result, err := o.waitForTaskCompletion(ctx, subtask, replies)
+	if err != nil && o.config.MaxRetries > 0 && ctx.Err() == nil {
+		return o.retryTask(ctx, subtask, replies)
+	}
+	return result, err
+}
+
 // assignTaskToWorker assigns a task to the most suitable worker
 func (o *Orchestrator) assignTaskToWorker(ctx context.Context, task *Task) error {
 	// Find suitable worker
@@ -355,7 +476,7 @@ func (o *Orchestrator) findWorkerForTask(task *Task) (*AgentMetadata, error) {
 	highestScore := 0
 
 	for _, worker := range o.workers {
-		if worker.Status == StatusOffline || worker.Status == StatusFailed {
+		if status := worker.CurrentStatus(); status == StatusOffline || status == StatusFailed {
 			continue
 		}
 
@@ -378,13 +499,23 @@ func (o *Orchestrator) findWorkerForTask(task *Task) (*AgentMetadata, error) {
 func (o *Orchestrator) scoreWorker(worker *AgentMetadata, task *Task) int {
 	score := 0
 
+	// Prefer workers with the capability the planner assigned
+	if spec, ok := task.Metadata["assigned_to_spec"].(string); ok && spec != "" {
+		for _, capability := range worker.Capabilities {
+			if capability == spec {
+				score += 20
+				break
+			}
+		}
+	}
+
 	// Check if worker has required capabilities
 	// This is a simple implementation; can be enhanced
 	if worker.Role == RoleSpecialist {
 		score += 10
 	}
 
-	if worker.Status == StatusIdle {
+	if worker.CurrentStatus() == StatusIdle {
 		score += 5
 	}
 
@@ -404,58 +535,38 @@ func (o *Orchestrator) buildWorkerCapabilitiesPrompt(workers []*AgentMetadata) s
 	return prompt
 }
 
-// checkDependencies checks if all dependencies are completed
-func (o *Orchestrator) checkDependencies(task *Task, completed map[string]bool) bool {
-	for _, depID := range task.Dependencies {
-		if !completed[depID] {
-			return false
-		}
-	}
-	return true
-}
-
-// waitForTaskCompletion waits for a task to complete
-func (o *Orchestrator) waitForTaskCompletion(ctx context.Context, task *Task) (interface{}, error) {
-	timeout := time.After(o.config.TaskTimeout)
-	ticker := time.NewTicker(100 * time.Millisecond) // Check more frequently
+// waitForTaskCompletion waits for the worker's reply to a task. The task
+// ledger is checked as well, so a task completed or failed there directly
+// does not wait for a reply.
+func (o *Orchestrator) waitForTaskCompletion(ctx context.Context, task *Task, replies <-chan *Message) (interface{}, error) {
+	timeout := time.NewTimer(o.config.TaskTimeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
 
 	for {
-		select {
-		case <-timeout:
-			return nil, fmt.Errorf("task %s timed out after %v", task.ID, o.config.TaskTimeout)
-		case <-ticker.C:
-			// Check for result messages from workers
-			messages, err := o.protocol.Receive(ctx, o.id)
-			if err == nil {
-				for _, msg := range messages {
-					if msg.Type == MessageTypeResult && msg.InReplyTo == task.ID {
-						// Worker completed the task
-						o.taskLedger.CompleteTask(ctx, task.ID, msg.Content)
-						return msg.Content, nil
-					}
-					if msg.Type == MessageTypeError && msg.InReplyTo == task.ID {
-						// Worker failed the task
-						errMsg := fmt.Sprintf("%v", msg.Content)
-						o.taskLedger.FailTask(ctx, task.ID, fmt.Errorf("%s", errMsg))
-						return nil, fmt.Errorf("task failed: %s", errMsg)
-					}
-				}
-			}
-
-			// Also check task ledger status (in case updated directly)
-			currentTask, err := o.taskLedger.GetTask(ctx, task.ID)
-			if err != nil {
-				return nil, err
-			}
-
-			if currentTask.Status == TaskStatusCompleted {
-				return currentTask.Output, nil
+		if status, output, errMsg, finished := o.taskLedger.finishedTask(task.ID); finished {
+			if status == TaskStatusCompleted {
+				return output, nil
 			}
+			return nil, fmt.Errorf("task failed: %s", errMsg)
+		}
 
-			if currentTask.Status == TaskStatusFailed {
-				return nil, fmt.Errorf("task failed: %s", currentTask.Error)
+		select {
+		case msg := <-replies:
+			if msg.Type == MessageTypeResult {
+				// Worker completed the task
+				o.taskLedger.CompleteTask(ctx, task.ID, msg.Content)
+				return msg.Content, nil
 			}
+			// Worker failed the task
+			errMsg := fmt.Sprintf("%v", msg.Content)
+			o.taskLedger.FailTask(ctx, task.ID, fmt.Errorf("%s", errMsg))
+			return nil, fmt.Errorf("task failed: %s", errMsg)
+		case <-ticker.C:
+			// Check the task ledger again
+		case <-timeout.C:
+			return nil, fmt.Errorf("task %s timed out after %v", task.ID, o.config.TaskTimeout)
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		}
@@ -463,9 +574,13 @@ func (o *Orchestrator) waitForTaskCompletion(ctx context.Context, task *Task) (i
 }
 
 // retryTask retries a failed task
-func (o *Orchestrator) retryTask(ctx context.Context, task *Task) (interface{}, error) {
+func (o *Orchestrator) retryTask(ctx context.Context, task *Task, replies <-chan *Message) (interface{}, error) {
 	for i := 0; i < o.config.MaxRetries; i++ {
-		time.Sleep(o.config.RetryDelay)
+		select {
+		case <-time.After(o.config.RetryDelay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
 
 		task.Status = TaskStatusPending
 		o.taskLedger.UpdateTask(ctx, task)
@@ -474,7 +589,7 @@ func (o *Orchestrator) retryTask(ctx context.Context, task *Task) (interface{},
 			continue
 		}
 
-		result, err := o.waitForTaskCompletion(ctx, task)
+		result, err := o.waitForTaskCompletion(ctx, task, replies)
 		if err == nil {
 			return result, nil
 		}
@@ -519,25 +634,33 @@ type SubtaskSpec struct {
 	Input        interface{} `json:"input"`
 }
 
-// parseSubtasks parses LLM output into subtasks
-func (o *Orchestrator) parseSubtasks(llmOutput string, parentTaskID string) ([]*Task, error) {
-	// Try to extract JSON from the LLM output
-	// LLM might include explanatory text, so we need to extract the JSON block
-	jsonStr := o.extractJSON(llmOutput)
-	if jsonStr == "" {
-		return nil, fmt.Errorf("no JSON found in LLM output")
-	}
-
-	// Parse JSON response
-	var response SubtaskResponse
-	if err := json.Unmarshal([]byte(jsonStr), &response); err != nil {
-		return nil, fmt.Errorf("failed to parse LLM JSON response: %w", err)
-	}
-
-	if len(response.Subtasks) == 0 {
-		return nil, fmt.Errorf("no subtasks found in LLM response")
-	}
+// subtaskPlanSchema is the JSON schema of SubtaskResponse. Only the subtask
+// names are required, since dependencies refer to them.
+var subtaskPlanSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"subtasks": map[string]interface{}{
+			"type":     "array",
+			"minItems": 1,
+			"items": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name":         map[string]interface{}{"type": "string"},
+					"description":  map[string]interface{}{"type": "string"},
+					"assigned_to":  map[string]interface{}{"type": "string"},
+					"dependencies": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+					"priority":     map[string]interface{}{"type": "integer"},
+					"input":        map[string]interface{}{},
+				},
+				"required": []string{"name"},
+			},
+		},
+	},
+	"required": []string{"subtasks"},
+}
 
+// buildSubtasks converts a planned decomposition into subtasks
+func (o *Orchestrator) buildSubtasks(response SubtaskResponse, parentTaskID string) []*Task {
 	// Convert SubtaskSpecs to Tasks
 	subtasks := make([]*Task, len(response.Subtasks))
 	subtaskIDMap := make(map[string]string) // name -> ID mapping for dependencies
@@ -592,47 +715,76 @@ func (o *Orchestrator) parseSubtasks(llmOutput string, parentTaskID string) ([]*
 		}
 	}
 
-	return subtasks, nil
+	return subtasks
 }
 
-// extractJSON extracts JSON object or array from text that might contain other content
-func (o *Orchestrator) extractJSON(text string) string {
-	// Try to find JSON object
-	startObj := strings.Index(text, "{")
-	if startObj != -1 {
-		// Find matching closing brace
-		depth := 0
-		for i := startObj; i < len(text); i++ {
-			switch text[i] {
-			case '{':
-				depth++
-			case '}':
-				depth--
-				if depth == 0 {
-					return text[startObj : i+1]
-				}
-			}
-		}
+// completionChat adapts an LLMProvider to llm.Provider, so planning can use
+// llm.GenerateStructured. System messages become the system prompt and the
+// rest of the conversation the user prompt.
+type completionChat struct {
+	provider LLMProvider
+}
+
+var _ llm.Provider = completionChat{}
+
+// GenerateCompletion generates a completion with the wrapped provider
+func (c completionChat) GenerateCompletion(ctx context.Context, req *llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	resp, err := c.provider.GenerateCompletion(ctx, &CompletionRequest{
+		SystemPrompt: req.SystemPrompt,
+		UserPrompt:   req.UserPrompt,
+		Temperature:  req.Temperature,
+		MaxTokens:    req.MaxTokens,
+		Model:        req.Model,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Try to find JSON array
-	startArr := strings.Index(text, "[")
-	if startArr != -1 {
-		depth := 0
-		for i := startArr; i < len(text); i++ {
-			switch text[i] {
-			case '[':
-				depth++
-			case ']':
-				depth--
-				if depth == 0 {
-					return text[startArr : i+1]
-				}
-			}
+	return &llm.CompletionResponse{
+		Text:         resp.Text,
+		TokensUsed:   resp.TokensUsed,
+		FinishReason: resp.FinishReason,
+		Model:        resp.Model,
+	}, nil
+}
+
+// GenerateChat flattens the conversation into a completion. Messages after
+// the first are prefixed with their role.
+func (c completionChat) GenerateChat(ctx context.Context, req *llm.ChatRequest) (*llm.ChatResponse, error) {
+	var system, conversation []string
+	for _, msg := range req.Messages {
+		switch {
+		case msg.Role == "system":
+			system = append(system, msg.Content)
+		case len(conversation) == 0:
+			conversation = append(conversation, msg.Content)
+		default:
+			conversation = append(conversation, fmt.Sprintf("%s: %s", msg.Role, msg.Content))
 		}
 	}
 
-	return ""
+	resp, err := c.GenerateCompletion(ctx, &llm.CompletionRequest{
+		SystemPrompt: strings.Join(system, "\n\n"),
+		UserPrompt:   strings.Join(conversation, "\n\n"),
+		Temperature:  req.Temperature,
+		MaxTokens:    req.MaxTokens,
+		Model:        req.Model,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &llm.ChatResponse{
+		Message:      llm.Message{Role: "assistant", Content: resp.Text},
+		TokensUsed:   resp.TokensUsed,
+		FinishReason: resp.FinishReason,
+		Model:        resp.Model,
+	}, nil
+}
+
+// Name returns the provider name
+func (c completionChat) Name() string {
+	return "multiagent"
 }
 
 // TaskRequest represents a request to execute a task
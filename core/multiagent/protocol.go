@@ -2,6 +2,7 @@ package multiagent
 
 import (
 	"context"
+	"sync"
 	"time"
 )
 
@@ -34,6 +35,7 @@ type Message struct {
 	Content   interface{}            `json:"content"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
 	CreatedAt time.Time              `json:"created_at"`
+	Auth      *MessageAuth           `json:"auth,omitempty"` // Signature and encrypted payload
 }
 
 // Protocol defines the communication protocol for multi-agent systems
@@ -52,6 +54,12 @@ type Protocol interface {
 
 	// Unsubscribe removes subscription
 	Unsubscribe(ctx context.Context, agentID string) error
+
+	// Listen delivers messages for an agent to handler as they arrive,
+	// until the returned stop function is called or ctx is done. Messages
+	// are handled one at a time in arrival order. An agent can have one
+	// listener at a time; use either Listen or Receive for an agent, not both.
+	Listen(ctx context.Context, agentID string, handler MessageHandler) (func(), error)
 }
 
 // AgentRole defines the role of an agent in the multi-agent system
@@ -82,6 +90,10 @@ type AgentMetadata struct {
 	Priority     int                    `json:"priority"`           // For task assignment
 	Status       AgentStatus            `json:"status"`
 	CustomData   map[string]interface{} `json:"custom_data,omitempty"`
+
+	// statusMu guards Status, which workers update while the orchestrator
+	// and load balancer read it
+	statusMu sync.RWMutex
 }
 
 // AgentStatus defines the current status of an agent
@@ -95,6 +107,20 @@ const (
 	StatusOffline    AgentStatus = "offline"     // Agent is offline
 )
 
+// CurrentStatus returns the agent's status
+func (m *AgentMetadata) CurrentStatus() AgentStatus {
+	m.statusMu.RLock()
+	defer m.statusMu.RUnlock()
+	return m.Status
+}
+
+// SetStatus updates the agent's status
+func (m *AgentMetadata) SetStatus(status AgentStatus) {
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+	m.Status = status
+}
+
 // TaskPriority defines the priority level for tasks
 type TaskPriority int
 
@@ -145,6 +171,20 @@ type SecurityPolicy struct {
 	DeniedAgents          []string `json:"denied_agents,omitempty"`  // Agent IDs
 	MaxMessageSize        int64    `json:"max_message_size"`         // In bytes
 	RateLimitPerSecond    int      `json:"rate_limit_per_second"`
+
+	// Credentials signs outgoing messages with the sender's credential and
+	// verifies incoming ones. Required by RequireAuthentication, and holds
+	// the AES-GCM key for RequireEncryption.
+	Credentials *Keyring `json:"-"`
+
+	// ReplayWindow is how long a signed message is accepted after it was
+	// created (default: DefaultReplayWindow)
+	ReplayWindow time.Duration `json:"replay_window,omitempty"`
+
+	// Deduplication rejects signed messages that were already received.
+	// Processes sharing a Redis or Kafka deployment should share a Redis or
+	// Postgres backed service; defaults to an in-memory one.
+	Deduplication *DeduplicationService `json:"-"`
 }
 
 // ProtocolMetrics tracks protocol performance
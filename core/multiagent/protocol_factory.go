@@ -267,6 +267,7 @@ func getEnvOrDefaultInt(key string, defaultValue int) int {
 // InMemoryProtocolConfig configures in-memory protocol (from protocol_impl.go)
 type InMemoryProtocolConfig struct {
 	MaxQueueSize int
+	Security     *SecurityPolicy // nil uses the default policy
 }
 
 // DefaultInMemoryProtocolConfig returns default in-memory protocol configuration
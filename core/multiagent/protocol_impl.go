@@ -16,7 +16,10 @@ type InMemoryProtocol struct {
 	messageQueues    map[string][]*Message          // agentID -> messages
 	subscriptions    map[string][]MessageType       // agentID -> message types
 	groups           map[string][]string            // groupID -> agentIDs
+	notify           map[string]chan struct{}       // agentID -> signalled on Send
+	listeners        listenerSet
 	security         *SecurityPolicy
+	enforcer         *messageSecurity
 	metrics          *ProtocolMetrics
 	maxQueueSize     int
 	metricsCollector *observability.MetricsCollector
@@ -43,6 +46,9 @@ func NewInMemoryProtocol(config interface{}) *InMemoryProtocol {
 		}
 		if cfg != nil {
 			maxQueueSize = cfg.MaxQueueSize
+			if cfg.Security != nil {
+				security = cfg.Security
+			}
 		} else {
 			maxQueueSize = 1000
 		}
@@ -68,7 +74,9 @@ func NewInMemoryProtocol(config interface{}) *InMemoryProtocol {
 		messageQueues: make(map[string][]*Message),
 		subscriptions: make(map[string][]MessageType),
 		groups:        make(map[string][]string),
+		notify:        make(map[string]chan struct{}),
 		security:      security,
+		enforcer:      newMessageSecurity(security),
 		metrics: &ProtocolMetrics{
 			LastUpdated: time.Now(),
 		},
@@ -111,13 +119,6 @@ func (p *InMemoryProtocol) Send(ctx context.Context, msg *Message) error {
 		msg.CreatedAt = time.Now()
 	}
 
-	// Apply security policy
-	if err := p.validateSecurity(msg); err != nil {
-		p.metrics.TotalMessagesFailed++
-		p.metricsCollector.RecordMultiagentError("protocol", "security_validation_failed")
-		return fmt.Errorf("security validation failed: %w", err)
-	}
-
 	// Check subscription
 	if !p.isSubscribed(msg.To, msg.Type) {
 		// Store anyway but mark in metadata
@@ -127,6 +128,14 @@ func (p *InMemoryProtocol) Send(ctx context.Context, msg *Message) error {
 		msg.Metadata["unsubscribed"] = true
 	}
 
+	// Apply security policy
+	sealed, err := p.enforcer.seal(msg)
+	if err != nil {
+		p.metrics.TotalMessagesFailed++
+		p.metricsCollector.RecordMultiagentError("protocol", "security_validation_failed")
+		return fmt.Errorf("security validation failed: %w", err)
+	}
+
 	// Add to queue
 	queue := p.messageQueues[msg.To]
 	if len(queue) >= p.maxQueueSize {
@@ -134,9 +143,15 @@ func (p *InMemoryProtocol) Send(ctx context.Context, msg *Message) error {
 		return fmt.Errorf("message queue full for agent %s", msg.To)
 	}
 
-	p.messageQueues[msg.To] = append(queue, msg)
+	p.messageQueues[msg.To] = append(queue, sealed)
 	p.metrics.TotalMessagesSent++
 
+	// Wake up the recipient's listener, if any
+	select {
+	case p.notifyChannel(msg.To) <- struct{}{}:
+	default:
+	}
+
 	// Record metrics
 	latency := time.Since(start)
 	p.metricsCollector.RecordMultiagentMessageSent(string(msg.Type), latency)
@@ -150,11 +165,22 @@ func (p *InMemoryProtocol) Receive(ctx context.Context, agentID string) ([]*Mess
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	messages := p.messageQueues[agentID]
+	queued := p.messageQueues[agentID]
 
 	// Clear the queue
 	delete(p.messageQueues, agentID)
 
+	// Drop messages that fail authentication
+	var messages []*Message
+	for _, msg := range queued {
+		if err := p.enforcer.open(ctx, msg); err != nil {
+			p.metrics.TotalMessagesFailed++
+			p.metricsCollector.RecordMultiagentError("protocol", "authentication_failed")
+			continue
+		}
+		messages = append(messages, msg)
+	}
+
 	p.metrics.TotalMessagesReceived += int64(len(messages))
 
 	// Record metrics for each message received
@@ -168,6 +194,41 @@ func (p *InMemoryProtocol) Receive(ctx context.Context, agentID string) ([]*Mess
 	return messages, nil
 }
 
+// Listen delivers messages for an agent to handler as they are sent,
+// starting with any already queued. It returns a function that stops the
+// listener.
+func (p *InMemoryProtocol) Listen(ctx context.Context, agentID string, handler MessageHandler) (func(), error) {
+	return p.listeners.start(ctx, agentID, func(ctx context.Context) ([]*Message, error) {
+		p.mu.Lock()
+		notify := p.notifyChannel(agentID)
+		p.mu.Unlock()
+
+		// Check ctx first so a stopped listener never drains the queue
+		for ctx.Err() == nil {
+			messages, err := p.Receive(ctx, agentID)
+			if err != nil || len(messages) > 0 {
+				return messages, err
+			}
+			select {
+			case <-notify:
+			case <-ctx.Done():
+			}
+		}
+		return nil, nil
+	}, handler)
+}
+
+// notifyChannel returns the channel signalled when a message is queued for
+// an agent. Must be called with mu held.
+func (p *InMemoryProtocol) notifyChannel(agentID string) chan struct{} {
+	ch, exists := p.notify[agentID]
+	if !exists {
+		ch = make(chan struct{}, 1)
+		p.notify[agentID] = ch
+	}
+	return ch
+}
+
 // Broadcast sends a message to all agents in a group
 func (p *InMemoryProtocol) Broadcast(ctx context.Context, msg *Message, groupID string) error {
 	p.mu.RLock()
@@ -200,8 +261,10 @@ func (p *InMemoryProtocol) Subscribe(ctx context.Context, agentID string, messag
 	return nil
 }
 
-// Unsubscribe removes subscription
+// Unsubscribe removes subscription and stops the agent's listener
 func (p *InMemoryProtocol) Unsubscribe(ctx context.Context, agentID string) error {
+	p.listeners.stop(agentID)
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -230,35 +293,6 @@ func (p *InMemoryProtocol) GetMetrics() *ProtocolMetrics {
 	return &metricsCopy
 }
 
-// validateSecurity validates message against security policy
-func (p *InMemoryProtocol) validateSecurity(msg *Message) error {
-	if p.security == nil {
-		return nil
-	}
-
-	// Check allowed/denied agents
-	if len(p.security.AllowedAgents) > 0 {
-		allowed := false
-		for _, id := range p.security.AllowedAgents {
-			if id == msg.From || id == msg.To {
-				allowed = true
-				break
-			}
-		}
-		if !allowed {
-			return fmt.Errorf("agent not in allowed list")
-		}
-	}
-
-	for _, id := range p.security.DeniedAgents {
-		if id == msg.From || id == msg.To {
-			return fmt.Errorf("agent is denied")
-		}
-	}
-
-	return nil
-}
-
 // isSubscribed checks if an agent is subscribed to a message type
 func (p *InMemoryProtocol) isSubscribed(agentID string, msgType MessageType) bool {
 	types, exists := p.subscriptions[agentID]
@@ -42,6 +42,9 @@ type KafkaProtocolConfig struct {
 	// Performance
 	MessageTTL        time.Duration // How long messages are retained
 	MaxMessageSize    int           // Maximum message size in bytes
+
+	// Security
+	Security *SecurityPolicy // Signing, encryption and allow/deny lists (nil = none)
 }
 
 // DefaultKafkaConfig returns default Kafka protocol configuration
@@ -76,6 +79,8 @@ type KafkaProtocol struct {
 	writers       map[string]*kafka.Writer // topic -> writer
 	readers       map[string]*kafka.Reader // topic -> reader
 	subscriptions map[string][]MessageType // agentID -> subscribed message types
+	listeners     listenerSet
+	enforcer      *messageSecurity
 	mu            sync.RWMutex
 	ctx           context.Context
 	cancel        context.CancelFunc
@@ -95,6 +100,7 @@ func NewKafkaProtocol(config *KafkaProtocolConfig) (*KafkaProtocol, error) {
 		writers:       make(map[string]*kafka.Writer),
 		readers:       make(map[string]*kafka.Reader),
 		subscriptions: make(map[string][]MessageType),
+		enforcer:      newMessageSecurity(config.Security),
 		ctx:           ctx,
 		cancel:        cancel,
 		metrics: &ProtocolMetrics{
@@ -123,8 +129,17 @@ func (kp *KafkaProtocol) Send(ctx context.Context, msg *Message) error {
 	}
 	msg.CreatedAt = time.Now()
 
+	// Apply security policy
+	sealed, err := kp.enforcer.seal(msg)
+	if err != nil {
+		kp.mu.Lock()
+		kp.metrics.TotalMessagesFailed++
+		kp.mu.Unlock()
+		return fmt.Errorf("security validation failed: %w", err)
+	}
+
 	// Serialize message
-	data, err := json.Marshal(msg)
+	data, err := json.Marshal(sealed)
 	if err != nil {
 		kp.mu.Lock()
 		kp.metrics.TotalMessagesFailed++
@@ -196,6 +211,7 @@ func (kp *KafkaProtocol) Receive(ctx context.Context, agentID string) ([]*Messag
 	defer cancel()
 
 	var messages []*Message
+	rejected := 0
 
 	// Read up to 10 messages
 	for i := 0; i < 10; i++ {
@@ -209,39 +225,96 @@ func (kp *KafkaProtocol) Receive(ctx context.Context, agentID string) ([]*Messag
 			return nil, fmt.Errorf("failed to read message: %w", err)
 		}
 
-		// Deserialize message
-		var msg Message
-		if err := json.Unmarshal(kafkaMsg.Value, &msg); err != nil {
-			// Skip malformed message
+		msg, err := kp.decodeMessage(ctx, kafkaMsg, subscriptions)
+		if err != nil {
+			rejected++
 			continue
 		}
-
-		// Filter by subscription
-		if len(subscriptions) > 0 {
-			subscribed := false
-			for _, msgType := range subscriptions {
-				if msg.Type == msgType {
-					subscribed = true
-					break
-				}
-			}
-			if !subscribed {
-				continue
-			}
+		if msg != nil {
+			messages = append(messages, msg)
 		}
-
-		messages = append(messages, &msg)
 	}
 
 	// Update metrics
 	kp.mu.Lock()
 	kp.metrics.TotalMessagesReceived += int64(len(messages))
+	kp.metrics.TotalMessagesFailed += int64(rejected)
 	kp.metrics.LastUpdated = time.Now()
 	kp.mu.Unlock()
 
 	return messages, nil
 }
 
+// Listen delivers messages for an agent to handler as they arrive. Unlike
+// Receive, which batches reads, each message is handed over as soon as it is
+// read. It returns a function that stops the listener.
+func (kp *KafkaProtocol) Listen(ctx context.Context, agentID string, handler MessageHandler) (func(), error) {
+	topic := kp.getTopic(agentID)
+	return kp.listeners.start(ctx, agentID, func(ctx context.Context) ([]*Message, error) {
+		reader, err := kp.getReader(topic)
+		if err != nil {
+			return nil, err
+		}
+
+		kafkaMsg, err := reader.ReadMessage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read message: %w", err)
+		}
+
+		kp.mu.RLock()
+		subscriptions := kp.subscriptions[agentID]
+		kp.mu.RUnlock()
+
+		msg, err := kp.decodeMessage(ctx, kafkaMsg, subscriptions)
+		if err != nil {
+			kp.mu.Lock()
+			kp.metrics.TotalMessagesFailed++
+			kp.mu.Unlock()
+			return nil, nil
+		}
+		if msg == nil {
+			return nil, nil
+		}
+
+		kp.mu.Lock()
+		kp.metrics.TotalMessagesReceived++
+		kp.metrics.LastUpdated = time.Now()
+		kp.mu.Unlock()
+
+		return []*Message{msg}, nil
+	}, handler)
+}
+
+// decodeMessage deserializes a Kafka message and verifies it against the
+// security policy. Malformed messages and types the agent is not subscribed
+// to are skipped with a nil message; messages failing verification return
+// an error.
+func (kp *KafkaProtocol) decodeMessage(ctx context.Context, kafkaMsg kafka.Message, subscriptions []MessageType) (*Message, error) {
+	var msg Message
+	if err := json.Unmarshal(kafkaMsg.Value, &msg); err != nil {
+		return nil, nil
+	}
+
+	if len(subscriptions) > 0 {
+		subscribed := false
+		for _, msgType := range subscriptions {
+			if msg.Type == msgType {
+				subscribed = true
+				break
+			}
+		}
+		if !subscribed {
+			return nil, nil
+		}
+	}
+
+	if err := kp.enforcer.open(ctx, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
 // Broadcast sends a message to all agents in a group
 func (kp *KafkaProtocol) Broadcast(ctx context.Context, msg *Message, groupID string) error {
 	start := time.Now()
@@ -252,8 +325,17 @@ func (kp *KafkaProtocol) Broadcast(ctx context.Context, msg *Message, groupID st
 	}
 	msg.CreatedAt = time.Now()
 
+	// Apply security policy
+	sealed, err := kp.enforcer.seal(msg)
+	if err != nil {
+		kp.mu.Lock()
+		kp.metrics.TotalMessagesFailed++
+		kp.mu.Unlock()
+		return fmt.Errorf("security validation failed: %w", err)
+	}
+
 	// Serialize message
-	data, err := json.Marshal(msg)
+	data, err := json.Marshal(sealed)
 	if err != nil {
 		kp.mu.Lock()
 		kp.metrics.TotalMessagesFailed++
@@ -315,6 +397,8 @@ func (kp *KafkaProtocol) Subscribe(ctx context.Context, agentID string, messageT
 
 // Unsubscribe removes subscription for an agent
 func (kp *KafkaProtocol) Unsubscribe(ctx context.Context, agentID string) error {
+	kp.listeners.stop(agentID)
+
 	kp.mu.Lock()
 	defer kp.mu.Unlock()
 
@@ -332,6 +416,7 @@ func (kp *KafkaProtocol) Unsubscribe(ctx context.Context, agentID string) error
 
 // Close closes all Kafka connections
 func (kp *KafkaProtocol) Close() error {
+	kp.listeners.closeAll()
 	kp.cancel()
 
 	// Close all writers
@@ -0,0 +1,108 @@
+package multiagent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MessageHandler handles a message delivered to a listening agent
+type MessageHandler func(ctx context.Context, msg *Message)
+
+// ErrAlreadyListening is returned by Listen when the agent already has a listener
+var ErrAlreadyListening = errors.New("agent already has a listener")
+
+// listenerRetryDelay is how long a listener waits after a failed read
+// before reading again
+const listenerRetryDelay = time.Second
+
+// listener is a running delivery loop for one agent
+type listener struct {
+	cancel context.CancelFunc
+}
+
+// listenerSet tracks the active listener of each agent
+type listenerSet struct {
+	mu        sync.Mutex
+	listeners map[string]*listener // agentID -> listener
+}
+
+// start runs a listener for agentID. receive should block until messages
+// arrive or ctx is done; each message it returns is passed to handler in
+// order. The listener runs until the returned stop function is called, ctx
+// is done or the set is closed.
+func (s *listenerSet) start(ctx context.Context, agentID string, receive func(ctx context.Context) ([]*Message, error), handler MessageHandler) (func(), error) {
+	if handler == nil {
+		return nil, fmt.Errorf("handler is required")
+	}
+
+	s.mu.Lock()
+	if _, exists := s.listeners[agentID]; exists {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyListening, agentID)
+	}
+	if s.listeners == nil {
+		s.listeners = make(map[string]*listener)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	l := &listener{cancel: cancel}
+	s.listeners[agentID] = l
+	s.mu.Unlock()
+
+	go func() {
+		defer s.remove(agentID, l)
+
+		for ctx.Err() == nil {
+			messages, err := receive(ctx)
+			if err != nil {
+				select {
+				case <-time.After(listenerRetryDelay):
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
+			for _, msg := range messages {
+				handler(ctx, msg)
+			}
+		}
+	}()
+
+	return func() { s.stop(agentID) }, nil
+}
+
+// stop stops the listener of an agent, if any
+func (s *listenerSet) stop(agentID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if l, exists := s.listeners[agentID]; exists {
+		l.cancel()
+		delete(s.listeners, agentID)
+	}
+}
+
+// remove forgets a listener that has exited, unless it was replaced
+func (s *listenerSet) remove(agentID string, l *listener) {
+	l.cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listeners[agentID] == l {
+		delete(s.listeners, agentID)
+	}
+}
+
+// closeAll stops every listener
+func (s *listenerSet) closeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for agentID, l := range s.listeners {
+		l.cancel()
+		delete(s.listeners, agentID)
+	}
+}
@@ -0,0 +1,105 @@
+package multiagent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// collect returns a handler that forwards messages to a channel
+func collect() (MessageHandler, chan *Message) {
+	received := make(chan *Message, 10)
+	return func(ctx context.Context, msg *Message) {
+		received <- msg
+	}, received
+}
+
+// expectMessage waits for a message with the given content
+func expectMessage(t *testing.T, received <-chan *Message, content string) {
+	t.Helper()
+	select {
+	case msg := <-received:
+		if msg.Content != content {
+			t.Errorf("expected %q, got %v", content, msg.Content)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for %q", content)
+	}
+}
+
+func TestInMemoryProtocol_Listen(t *testing.T) {
+	protocol := NewInMemoryProtocol(nil)
+	ctx := context.Background()
+
+	send := func(content string) {
+		t.Helper()
+		err := protocol.Send(ctx, &Message{Type: MessageTypeTask, From: "sender", To: "agent", Content: content})
+		if err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+	}
+
+	// Messages queued before listening are delivered first
+	send("queued")
+
+	handler, received := collect()
+	stop, err := protocol.Listen(ctx, "agent", handler)
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	expectMessage(t, received, "queued")
+
+	send("first")
+	send("second")
+	expectMessage(t, received, "first")
+	expectMessage(t, received, "second")
+
+	// One listener per agent
+	if _, err := protocol.Listen(ctx, "agent", handler); !errors.Is(err, ErrAlreadyListening) {
+		t.Errorf("expected ErrAlreadyListening, got %v", err)
+	}
+
+	// Stopped listeners receive nothing, and the agent can listen again
+	stop()
+	time.Sleep(50 * time.Millisecond)
+	send("after stop")
+
+	select {
+	case msg := <-received:
+		t.Errorf("received %v after stop", msg.Content)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	stop, err = protocol.Listen(ctx, "agent", handler)
+	if err != nil {
+		t.Fatalf("Listen after stop failed: %v", err)
+	}
+	defer stop()
+	expectMessage(t, received, "after stop")
+}
+
+func TestInMemoryProtocol_ListenContext(t *testing.T) {
+	protocol := NewInMemoryProtocol(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	handler, _ := collect()
+	if _, err := protocol.Listen(ctx, "agent", handler); err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+
+	// Cancelling the context stops the listener and frees the agent
+	cancel()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		stop, err := protocol.Listen(context.Background(), "agent", handler)
+		if err == nil {
+			stop()
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("listener did not stop: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -37,6 +37,9 @@ type RedisProtocolConfig struct {
 	// Performance
 	PipelineSize    int           // Number of operations to pipeline
 	FlushInterval   time.Duration // How often to flush pipeline
+
+	// Security
+	Security *SecurityPolicy // Signing, encryption and allow/deny lists (nil = none)
 }
 
 // DefaultRedisConfig returns default Redis protocol configuration
@@ -68,6 +71,8 @@ type RedisProtocol struct {
 	client       *redis.Client
 	pubsub       *redis.PubSub
 	subscriptions map[string][]MessageType // agentID -> subscribed message types
+	listeners    listenerSet
+	enforcer     *messageSecurity
 	mu           sync.RWMutex
 	ctx          context.Context
 	cancel       context.CancelFunc
@@ -107,6 +112,7 @@ func NewRedisProtocol(config *RedisProtocolConfig) (*RedisProtocol, error) {
 		config:        config,
 		client:        client,
 		subscriptions: make(map[string][]MessageType),
+		enforcer:      newMessageSecurity(config.Security),
 		ctx:           ctx,
 		cancel:        cancel,
 		metrics: &ProtocolMetrics{
@@ -130,8 +136,15 @@ func (rp *RedisProtocol) Send(ctx context.Context, msg *Message) error {
 	}
 	msg.CreatedAt = time.Now()
 
+	// Apply security policy
+	sealed, err := rp.enforcer.seal(msg)
+	if err != nil {
+		rp.metrics.TotalMessagesFailed++
+		return fmt.Errorf("security validation failed: %w", err)
+	}
+
 	// Serialize message
-	data, err := json.Marshal(msg)
+	data, err := json.Marshal(sealed)
 	if err != nil {
 		rp.metrics.TotalMessagesFailed++
 		return fmt.Errorf("failed to marshal message: %w", err)
@@ -209,6 +222,7 @@ func (rp *RedisProtocol) Receive(ctx context.Context, agentID string) ([]*Messag
 	}
 
 	var messages []*Message
+	rejected := 0
 
 	for _, stream := range streams {
 		for _, xmsg := range stream.Messages {
@@ -240,6 +254,14 @@ func (rp *RedisProtocol) Receive(ctx context.Context, agentID string) ([]*Messag
 				}
 			}
 
+			// Verify against the security policy; rejected messages are
+			// ACKed so they are not redelivered
+			if err := rp.enforcer.open(ctx, &msg); err != nil {
+				rejected++
+				rp.client.XAck(ctx, streamKey, groupName, xmsg.ID)
+				continue
+			}
+
 			messages = append(messages, &msg)
 
 			// ACK the message
@@ -253,12 +275,22 @@ func (rp *RedisProtocol) Receive(ctx context.Context, agentID string) ([]*Messag
 	// Update metrics
 	rp.mu.Lock()
 	rp.metrics.TotalMessagesReceived += int64(len(messages))
+	rp.metrics.TotalMessagesFailed += int64(rejected)
 	rp.metrics.LastUpdated = time.Now()
 	rp.mu.Unlock()
 
 	return messages, nil
 }
 
+// Listen delivers messages for an agent to handler as they arrive. Each
+// read blocks on the agent's stream for up to BlockTimeout, so messages are
+// delivered without polling. It returns a function that stops the listener.
+func (rp *RedisProtocol) Listen(ctx context.Context, agentID string, handler MessageHandler) (func(), error) {
+	return rp.listeners.start(ctx, agentID, func(ctx context.Context) ([]*Message, error) {
+		return rp.Receive(ctx, agentID)
+	}, handler)
+}
+
 // Broadcast sends a message to all agents in a group using Redis Pub/Sub
 func (rp *RedisProtocol) Broadcast(ctx context.Context, msg *Message, groupID string) error {
 	start := time.Now()
@@ -269,8 +301,17 @@ func (rp *RedisProtocol) Broadcast(ctx context.Context, msg *Message, groupID st
 	}
 	msg.CreatedAt = time.Now()
 
+	// Apply security policy
+	sealed, err := rp.enforcer.seal(msg)
+	if err != nil {
+		rp.mu.Lock()
+		rp.metrics.TotalMessagesFailed++
+		rp.mu.Unlock()
+		return fmt.Errorf("security validation failed: %w", err)
+	}
+
 	// Serialize message
-	data, err := json.Marshal(msg)
+	data, err := json.Marshal(sealed)
 	if err != nil {
 		rp.mu.Lock()
 		rp.metrics.TotalMessagesFailed++
@@ -313,6 +354,8 @@ func (rp *RedisProtocol) Subscribe(ctx context.Context, agentID string, messageT
 
 // Unsubscribe removes subscription for an agent
 func (rp *RedisProtocol) Unsubscribe(ctx context.Context, agentID string) error {
+	rp.listeners.stop(agentID)
+
 	rp.mu.Lock()
 	defer rp.mu.Unlock()
 
@@ -322,6 +365,7 @@ func (rp *RedisProtocol) Unsubscribe(ctx context.Context, agentID string) error
 
 // Close closes the Redis connection
 func (rp *RedisProtocol) Close() error {
+	rp.listeners.closeAll()
 	rp.cancel()
 
 	if rp.pubsub != nil {
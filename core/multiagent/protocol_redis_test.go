@@ -195,6 +195,54 @@ func TestRedisProtocol_Subscribe(t *testing.T) {
 	cleanup(t, protocol, agentID)
 }
 
+// TestRedisProtocol_Listen tests push delivery with subscription filtering
+func TestRedisProtocol_Listen(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	config := DefaultRedisConfig()
+	config.BlockTimeout = 500 * time.Millisecond
+	protocol, err := NewRedisProtocol(config)
+	if err != nil {
+		t.Skip("Redis not available:", err)
+	}
+	defer protocol.Close()
+
+	ctx := context.Background()
+
+	agentID := "test-agent-listen"
+	if err := protocol.Subscribe(ctx, agentID, []MessageType{MessageTypeResult}); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	defer cleanup(t, protocol, agentID)
+
+	received := make(chan *Message, 10)
+	stop, err := protocol.Listen(ctx, agentID, func(ctx context.Context, msg *Message) {
+		received <- msg
+	})
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	defer stop()
+
+	for _, msgType := range []MessageType{MessageTypeQuery, MessageTypeResult} {
+		msg := &Message{Type: msgType, From: "sender", To: agentID, Content: string(msgType)}
+		if err := protocol.Send(ctx, msg); err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+	}
+
+	select {
+	case msg := <-received:
+		if msg.Type != MessageTypeResult {
+			t.Errorf("Expected Result message, got %v", msg.Type)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for message")
+	}
+}
+
 // TestRedisProtocol_Metrics tests metrics collection
 func TestRedisProtocol_Metrics(t *testing.T) {
 	if testing.Short() {
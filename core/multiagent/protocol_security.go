@@ -0,0 +1,530 @@
+package multiagent
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SigningAlgorithm identifies how a message is signed
+type SigningAlgorithm string
+
+const (
+	// SigningHMACSHA256 signs with a secret shared by the agent and its peers
+	SigningHMACSHA256 SigningAlgorithm = "hmac-sha256"
+
+	// SigningEd25519 signs with the agent's private key; peers only need
+	// its public key
+	SigningEd25519 SigningAlgorithm = "ed25519"
+)
+
+// DefaultReplayWindow is how long signed messages are accepted after they
+// were created when the policy does not set ReplayWindow
+const DefaultReplayWindow = 5 * time.Minute
+
+var (
+	// ErrUnauthenticated is returned for messages that are not signed, or
+	// whose sender has no credential, when authentication is required
+	ErrUnauthenticated = errors.New("message is not authenticated")
+
+	// ErrInvalidSignature is returned when a message signature does not verify
+	ErrInvalidSignature = errors.New("invalid message signature")
+
+	// ErrReplayedMessage is returned for signed messages that were already
+	// received or are outside the replay window
+	ErrReplayedMessage = errors.New("message replayed")
+
+	// ErrNotEncrypted is returned for plaintext messages when encryption
+	// is required
+	ErrNotEncrypted = errors.New("message is not encrypted")
+)
+
+// MessageAuth carries a message's signature and, when the payload is
+// encrypted, its ciphertext. Protocols add it on send and remove the
+// ciphertext once the payload is decrypted on receive.
+type MessageAuth struct {
+	Algorithm  SigningAlgorithm `json:"alg,omitempty"`
+	Signature  []byte           `json:"sig,omitempty"`
+	Nonce      []byte           `json:"nonce,omitempty"`
+	Ciphertext []byte           `json:"ciphertext,omitempty"`
+}
+
+// AgentCredential holds the key an agent's messages are signed and
+// verified with
+type AgentCredential struct {
+	Algorithm SigningAlgorithm
+
+	// Secret is the HMAC key
+	Secret []byte
+
+	// PrivateKey signs Ed25519 messages. Only the agent's own process
+	// needs it; peers verify with PublicKey.
+	PrivateKey ed25519.PrivateKey
+
+	// PublicKey verifies Ed25519 messages
+	PublicKey ed25519.PublicKey
+}
+
+// NewHMACCredential creates a credential signing with HMAC-SHA256
+func NewHMACCredential(secret []byte) *AgentCredential {
+	return &AgentCredential{Algorithm: SigningHMACSHA256, Secret: secret}
+}
+
+// NewEd25519Credential creates a credential signing with privateKey
+func NewEd25519Credential(privateKey ed25519.PrivateKey) *AgentCredential {
+	return &AgentCredential{
+		Algorithm:  SigningEd25519,
+		PrivateKey: privateKey,
+		PublicKey:  privateKey.Public().(ed25519.PublicKey),
+	}
+}
+
+// NewEd25519VerifyCredential creates a credential that can only verify
+// messages from an agent whose private key is held elsewhere
+func NewEd25519VerifyCredential(publicKey ed25519.PublicKey) *AgentCredential {
+	return &AgentCredential{Algorithm: SigningEd25519, PublicKey: publicKey}
+}
+
+// GenerateEd25519Credential creates a credential with a new key pair
+func GenerateEd25519Credential() (*AgentCredential, error) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return NewEd25519Credential(privateKey), nil
+}
+
+// validate checks that the credential can at least verify messages
+func (c *AgentCredential) validate() error {
+	switch c.Algorithm {
+	case SigningHMACSHA256:
+		if len(c.Secret) < 16 {
+			return fmt.Errorf("HMAC secret must be at least 16 bytes")
+		}
+	case SigningEd25519:
+		if len(c.PublicKey) != ed25519.PublicKeySize {
+			return fmt.Errorf("invalid Ed25519 public key")
+		}
+		if c.PrivateKey != nil && len(c.PrivateKey) != ed25519.PrivateKeySize {
+			return fmt.Errorf("invalid Ed25519 private key")
+		}
+	default:
+		return fmt.Errorf("unsupported signing algorithm: %q", c.Algorithm)
+	}
+	return nil
+}
+
+// canSign reports whether the credential holds a signing key
+func (c *AgentCredential) canSign() bool {
+	switch c.Algorithm {
+	case SigningHMACSHA256:
+		return len(c.Secret) > 0
+	case SigningEd25519:
+		return len(c.PrivateKey) == ed25519.PrivateKeySize
+	}
+	return false
+}
+
+// sign signs data
+func (c *AgentCredential) sign(data []byte) []byte {
+	if c.Algorithm == SigningEd25519 {
+		return ed25519.Sign(c.PrivateKey, data)
+	}
+	mac := hmac.New(sha256.New, c.Secret)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// verify reports whether signature is valid for data
+func (c *AgentCredential) verify(data, signature []byte) bool {
+	if c.Algorithm == SigningEd25519 {
+		return ed25519.Verify(c.PublicKey, data, signature)
+	}
+	return hmac.Equal(c.sign(data), signature)
+}
+
+// Keyring holds the credentials of the agents a protocol sends and
+// receives for, and the key payloads are encrypted with
+type Keyring struct {
+	mu          sync.RWMutex
+	credentials map[string]*AgentCredential // agentID -> credential
+	aead        cipher.AEAD
+}
+
+// NewKeyring creates an empty keyring
+func NewKeyring() *Keyring {
+	return &Keyring{
+		credentials: make(map[string]*AgentCredential),
+	}
+}
+
+// Add registers the credential of an agent, replacing any existing one
+func (k *Keyring) Add(agentID string, credential *AgentCredential) error {
+	if agentID == "" {
+		return fmt.Errorf("agent ID is required")
+	}
+	if credential == nil {
+		return fmt.Errorf("credential is required")
+	}
+	if err := credential.validate(); err != nil {
+		return fmt.Errorf("invalid credential for agent %s: %w", agentID, err)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.credentials[agentID] = credential
+	return nil
+}
+
+// Remove removes the credential of an agent
+func (k *Keyring) Remove(agentID string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	delete(k.credentials, agentID)
+}
+
+// Credential returns the credential of an agent
+func (k *Keyring) Credential(agentID string) (*AgentCredential, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	credential, exists := k.credentials[agentID]
+	return credential, exists
+}
+
+// SetEncryptionKey sets the AES key payloads are encrypted with. The key
+// must be 16, 24 or 32 bytes to select AES-128, AES-192 or AES-256.
+func (k *Keyring) SetEncryptionKey(key []byte) error {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return fmt.Errorf("invalid encryption key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return fmt.Errorf("failed to create AES-GCM cipher: %w", err)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.aead = aead
+	return nil
+}
+
+// payloadCipher returns the payload cipher, or nil if no key is set
+func (k *Keyring) payloadCipher() cipher.AEAD {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.aead
+}
+
+// messageSecurity applies a SecurityPolicy to messages. Protocols seal
+// messages on send and open them on receive, so the policy is enforced the
+// same way by every backend. A nil *messageSecurity allows everything.
+type messageSecurity struct {
+	policy  *SecurityPolicy
+	keyring *Keyring
+	dedup   *DeduplicationService
+	window  time.Duration
+
+	mu          sync.Mutex
+	lastCleanup time.Time
+}
+
+// newMessageSecurity creates the enforcement for policy
+func newMessageSecurity(policy *SecurityPolicy) *messageSecurity {
+	if policy == nil {
+		return nil
+	}
+
+	s := &messageSecurity{
+		policy:      policy,
+		keyring:     policy.Credentials,
+		dedup:       policy.Deduplication,
+		window:      policy.ReplayWindow,
+		lastCleanup: time.Now(),
+	}
+	if s.window <= 0 {
+		s.window = DefaultReplayWindow
+	}
+	if s.dedup == nil && s.keyring != nil {
+		config := DefaultDeduplicationConfig()
+		config.WindowSize = s.window
+		s.dedup = NewDeduplicationService(config, NewInMemoryDedupBackend())
+	}
+	return s
+}
+
+// authorize checks the sender and recipient against the allow and deny lists
+func (s *messageSecurity) authorize(msg *Message) error {
+	if s == nil {
+		return nil
+	}
+
+	if len(s.policy.AllowedAgents) > 0 {
+		allowed := false
+		for _, id := range s.policy.AllowedAgents {
+			if id == msg.From || id == msg.To {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("agent not in allowed list")
+		}
+	}
+
+	for _, id := range s.policy.DeniedAgents {
+		if id == msg.From || id == msg.To {
+			return fmt.Errorf("agent is denied")
+		}
+	}
+
+	return nil
+}
+
+// seal returns the message as it should be transmitted: encrypted when the
+// policy requires it and signed with the sender's credential if the keyring
+// has one. msg itself is not modified. The content of a sealed message is
+// replaced by its JSON form, which is what the signature covers.
+func (s *messageSecurity) seal(msg *Message) (*Message, error) {
+	if err := s.authorize(msg); err != nil {
+		return nil, err
+	}
+	if s == nil || (s.keyring == nil && !s.policy.RequireAuthentication && !s.policy.RequireEncryption) {
+		return msg, nil
+	}
+	if s.keyring == nil {
+		return nil, fmt.Errorf("security policy requires credentials")
+	}
+
+	sealed := *msg
+	sealed.Auth = &MessageAuth{}
+
+	content, err := normalizeJSON(msg.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode content: %w", err)
+	}
+	sealed.Content = content
+
+	// Empty metadata is omitted on the wire, so sign it as absent
+	sealed.Metadata = nil
+	if len(msg.Metadata) > 0 {
+		metadata, err := normalizeJSON(msg.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode metadata: %w", err)
+		}
+		sealed.Metadata = metadata.(map[string]interface{})
+	}
+
+	if s.policy.RequireEncryption {
+		aead := s.keyring.payloadCipher()
+		if aead == nil {
+			return nil, fmt.Errorf("security policy requires an encryption key")
+		}
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, fmt.Errorf("failed to generate nonce: %w", err)
+		}
+		plaintext, err := json.Marshal(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode content: %w", err)
+		}
+		sealed.Auth.Nonce = nonce
+		sealed.Auth.Ciphertext = aead.Seal(nil, nonce, plaintext, associatedData(msg))
+		sealed.Content = nil
+	}
+
+	credential, exists := s.keyring.Credential(msg.From)
+	if !exists || !credential.canSign() {
+		if s.policy.RequireAuthentication {
+			return nil, fmt.Errorf("%w: no signing key for agent %s", ErrUnauthenticated, msg.From)
+		}
+		return &sealed, nil
+	}
+
+	sealed.Auth.Algorithm = credential.Algorithm
+	payload, err := signingPayload(&sealed)
+	if err != nil {
+		return nil, err
+	}
+	sealed.Auth.Signature = credential.sign(payload)
+
+	return &sealed, nil
+}
+
+// open verifies a received message against the policy and decrypts its
+// payload in place. Messages that fail are rejected with an error wrapping
+// ErrUnauthenticated, ErrInvalidSignature, ErrReplayedMessage or
+// ErrNotEncrypted.
+func (s *messageSecurity) open(ctx context.Context, msg *Message) error {
+	if err := s.authorize(msg); err != nil {
+		return err
+	}
+	if s == nil {
+		return nil
+	}
+
+	auth := msg.Auth
+	encrypted := auth != nil && auth.Ciphertext != nil
+	if s.policy.RequireEncryption && !encrypted {
+		return ErrNotEncrypted
+	}
+
+	// Without credentials signatures cannot be checked, which is only
+	// acceptable when authentication is optional
+	signed := auth != nil && auth.Signature != nil
+	if !signed || s.keyring == nil {
+		if s.policy.RequireAuthentication {
+			return ErrUnauthenticated
+		}
+	} else if err := s.verify(ctx, msg); err != nil {
+		return err
+	}
+
+	if encrypted {
+		var aead cipher.AEAD
+		if s.keyring != nil {
+			aead = s.keyring.payloadCipher()
+		}
+		if aead == nil {
+			return fmt.Errorf("no key to decrypt message %s", msg.ID)
+		}
+		content, err := aead.Open(nil, auth.Nonce, auth.Ciphertext, associatedData(msg))
+		if err != nil {
+			return fmt.Errorf("failed to decrypt message %s: %w", msg.ID, err)
+		}
+		if err := json.Unmarshal(content, &msg.Content); err != nil {
+			return fmt.Errorf("failed to decode message %s: %w", msg.ID, err)
+		}
+		auth.Nonce = nil
+		auth.Ciphertext = nil
+	}
+
+	return nil
+}
+
+// verify checks a signed message's signature, age and uniqueness
+func (s *messageSecurity) verify(ctx context.Context, msg *Message) error {
+	credential, exists := s.keyring.Credential(msg.From)
+	if !exists || credential.Algorithm != msg.Auth.Algorithm {
+		return fmt.Errorf("%w: unknown agent %s", ErrUnauthenticated, msg.From)
+	}
+
+	payload, err := signingPayload(msg)
+	if err != nil {
+		return err
+	}
+	if !credential.verify(payload, msg.Auth.Signature) {
+		return fmt.Errorf("%w: message %s from %s", ErrInvalidSignature, msg.ID, msg.From)
+	}
+
+	// Message IDs are only remembered for the window, so older messages
+	// cannot be checked for duplicates and are rejected outright
+	if age := time.Since(msg.CreatedAt); age > s.window || age < -s.window {
+		return fmt.Errorf("%w: message %s is outside the replay window", ErrReplayedMessage, msg.ID)
+	}
+
+	duplicate, err := s.dedup.CheckAndMark(ctx, msg.From+"/"+msg.To+"/"+msg.ID)
+	if err != nil {
+		return fmt.Errorf("replay check failed: %w", err)
+	}
+	if duplicate {
+		return fmt.Errorf("%w: message %s from %s", ErrReplayedMessage, msg.ID, msg.From)
+	}
+	s.cleanup(ctx)
+
+	return nil
+}
+
+// cleanup expires remembered message IDs once per window
+func (s *messageSecurity) cleanup(ctx context.Context) {
+	s.mu.Lock()
+	if time.Since(s.lastCleanup) < s.window {
+		s.mu.Unlock()
+		return
+	}
+	s.lastCleanup = time.Now()
+	s.mu.Unlock()
+
+	s.dedup.Cleanup(ctx)
+}
+
+// signingPayload returns the bytes a message signature covers
+func signingPayload(msg *Message) ([]byte, error) {
+	content, err := canonicalJSON(msg.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode content: %w", err)
+	}
+	metadata, err := canonicalJSON(msg.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode metadata: %w", err)
+	}
+
+	return json.Marshal(struct {
+		ID         string           `json:"id"`
+		Type       MessageType      `json:"type"`
+		From       string           `json:"from"`
+		To         string           `json:"to"`
+		InReplyTo  string           `json:"in_reply_to"`
+		Content    json.RawMessage  `json:"content"`
+		Metadata   json.RawMessage  `json:"metadata"`
+		CreatedAt  int64            `json:"created_at"`
+		Algorithm  SigningAlgorithm `json:"alg"`
+		Nonce      []byte           `json:"nonce"`
+		Ciphertext []byte           `json:"ciphertext"`
+	}{
+		ID:         msg.ID,
+		Type:       msg.Type,
+		From:       msg.From,
+		To:         msg.To,
+		InReplyTo:  msg.InReplyTo,
+		Content:    content,
+		Metadata:   metadata,
+		CreatedAt:  msg.CreatedAt.UnixNano(),
+		Algorithm:  msg.Auth.Algorithm,
+		Nonce:      msg.Auth.Nonce,
+		Ciphertext: msg.Auth.Ciphertext,
+	})
+}
+
+// normalizeJSON returns v as a receiver decodes it after a JSON round trip
+func normalizeJSON(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// canonicalJSON encodes v with struct fields turned into sorted object
+// keys, so the sender and a receiver that decoded the message agree on
+// the bytes
+func canonicalJSON(v interface{}) ([]byte, error) {
+	generic, err := normalizeJSON(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(generic)
+}
+
+// associatedData binds a ciphertext to the message it was sent in
+func associatedData(msg *Message) []byte {
+	return []byte(msg.ID + "\x00" + msg.From + "\x00" + msg.To)
+}
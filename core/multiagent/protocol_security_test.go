@@ -0,0 +1,313 @@
+package multiagent
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// transmit simulates a networked backend by sending msg through JSON
+func transmit(t *testing.T, msg *Message) *Message {
+	t.Helper()
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	var received Message
+	if err := json.Unmarshal(data, &received); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	return &received
+}
+
+func newTestMessage() *Message {
+	return &Message{
+		ID:        "msg-1",
+		Type:      MessageTypeTask,
+		From:      "sender",
+		To:        "receiver",
+		Content:   &Task{ID: "task-1", Name: "Secret Task", Priority: PriorityHigh},
+		Metadata:  map[string]interface{}{"attempt": 1},
+		CreatedAt: time.Now(),
+	}
+}
+
+func TestKeyring_Add(t *testing.T) {
+	keyring := NewKeyring()
+
+	tests := []struct {
+		name       string
+		credential *AgentCredential
+		wantErr    bool
+	}{
+		{"hmac", NewHMACCredential(testSecret), false},
+		{"short hmac secret", NewHMACCredential([]byte("short")), true},
+		{"ed25519 public key only", NewEd25519VerifyCredential(make(ed25519.PublicKey, ed25519.PublicKeySize)), false},
+		{"invalid ed25519 key", NewEd25519VerifyCredential(ed25519.PublicKey("bad")), true},
+		{"unknown algorithm", &AgentCredential{Algorithm: "rot13"}, true},
+		{"nil", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := keyring.Add("agent", tt.credential); (err != nil) != tt.wantErr {
+				t.Errorf("Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := keyring.SetEncryptionKey([]byte("not an AES key")); err == nil {
+		t.Error("expected error for invalid encryption key")
+	}
+}
+
+func TestMessageSecurity_SealOpen(t *testing.T) {
+	ed, err := GenerateEd25519Credential()
+	if err != nil {
+		t.Fatalf("GenerateEd25519Credential failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		sender   *AgentCredential
+		verifier *AgentCredential
+		encrypt  bool
+	}{
+		{name: "hmac", sender: NewHMACCredential(testSecret), verifier: NewHMACCredential(testSecret)},
+		{name: "ed25519", sender: ed, verifier: NewEd25519VerifyCredential(ed.PublicKey)},
+		{name: "encrypted", sender: ed, verifier: NewEd25519VerifyCredential(ed.PublicKey), encrypt: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Sender and receiver hold separate keyrings, as separate
+			// processes sharing a Redis deployment would
+			newPolicy := func(credential *AgentCredential) *SecurityPolicy {
+				keyring := NewKeyring()
+				if err := keyring.Add("sender", credential); err != nil {
+					t.Fatalf("Add failed: %v", err)
+				}
+				if err := keyring.SetEncryptionKey(testSecret); err != nil {
+					t.Fatalf("SetEncryptionKey failed: %v", err)
+				}
+				return &SecurityPolicy{RequireAuthentication: true, RequireEncryption: tt.encrypt, Credentials: keyring}
+			}
+			sender := newMessageSecurity(newPolicy(tt.sender))
+			receiver := newMessageSecurity(newPolicy(tt.verifier))
+
+			msg := newTestMessage()
+			sealed, err := sender.seal(msg)
+			if err != nil {
+				t.Fatalf("seal failed: %v", err)
+			}
+			if sealed.Auth == nil || sealed.Auth.Signature == nil {
+				t.Fatal("expected sealed message to be signed")
+			}
+			if msg.Auth != nil {
+				t.Error("seal modified the original message")
+			}
+
+			wire := transmit(t, sealed)
+			if tt.encrypt && strings.Contains(string(wire.Auth.Ciphertext), "Secret Task") {
+				t.Error("payload was not encrypted")
+			}
+
+			if err := receiver.open(context.Background(), wire); err != nil {
+				t.Fatalf("open failed: %v", err)
+			}
+			content, ok := wire.Content.(map[string]interface{})
+			if !ok || content["name"] != "Secret Task" {
+				t.Errorf("unexpected content %v", wire.Content)
+			}
+
+			// The same message cannot be delivered twice
+			replay := transmit(t, sealed)
+			if err := receiver.open(context.Background(), replay); !errors.Is(err, ErrReplayedMessage) {
+				t.Errorf("expected ErrReplayedMessage, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMessageSecurity_Rejects(t *testing.T) {
+	keyring := NewKeyring()
+	keyring.Add("sender", NewHMACCredential(testSecret))
+	keyring.SetEncryptionKey(testSecret)
+
+	required := &SecurityPolicy{RequireAuthentication: true, Credentials: keyring}
+
+	tests := []struct {
+		name   string
+		policy *SecurityPolicy
+		age    time.Duration
+		tamper func(msg *Message)
+		want   error
+	}{
+		{
+			name:   "modified content",
+			policy: required,
+			tamper: func(msg *Message) { msg.Content.(map[string]interface{})["name"] = "Other Task" },
+			want:   ErrInvalidSignature,
+		},
+		{
+			name:   "modified recipient",
+			policy: required,
+			tamper: func(msg *Message) { msg.To = "eavesdropper" },
+			want:   ErrInvalidSignature,
+		},
+		{
+			name:   "impersonated sender",
+			policy: required,
+			tamper: func(msg *Message) { msg.From = "stranger" },
+			want:   ErrUnauthenticated,
+		},
+		{
+			name:   "unsigned",
+			policy: required,
+			tamper: func(msg *Message) { msg.Auth = nil },
+			want:   ErrUnauthenticated,
+		},
+		{
+			name:   "expired",
+			policy: &SecurityPolicy{Credentials: keyring, ReplayWindow: time.Minute},
+			age:    time.Hour,
+			tamper: func(msg *Message) {},
+			want:   ErrReplayedMessage,
+		},
+		{
+			name:   "plaintext",
+			policy: &SecurityPolicy{RequireEncryption: true, Credentials: keyring},
+			tamper: func(msg *Message) {},
+			want:   ErrNotEncrypted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := newTestMessage()
+			msg.CreatedAt = msg.CreatedAt.Add(-tt.age)
+
+			// Signed without encryption, then checked by tt.policy
+			sealed, err := newMessageSecurity(required).seal(msg)
+			if err != nil {
+				t.Fatalf("seal failed: %v", err)
+			}
+			wire := transmit(t, sealed)
+			tt.tamper(wire)
+
+			if err := newMessageSecurity(tt.policy).open(context.Background(), wire); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	// Senders without a signing key cannot send when authentication is required
+	msg := newTestMessage()
+	msg.From = "stranger"
+	if _, err := newMessageSecurity(required).seal(msg); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("expected ErrUnauthenticated, got %v", err)
+	}
+}
+
+func TestInMemoryProtocol_Authentication(t *testing.T) {
+	keyring := NewKeyring()
+	keyring.Add("sender", NewHMACCredential(testSecret))
+	keyring.SetEncryptionKey(testSecret)
+
+	protocol := NewInMemoryProtocol(&InMemoryProtocolConfig{
+		MaxQueueSize: 10,
+		Security: &SecurityPolicy{
+			RequireAuthentication: true,
+			RequireEncryption:     true,
+			Credentials:           keyring,
+		},
+	})
+	ctx := context.Background()
+
+	if err := protocol.Send(ctx, newTestMessage()); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	// A message injected into the queue without going through Send
+	protocol.mu.Lock()
+	protocol.messageQueues["receiver"] = append(protocol.messageQueues["receiver"], &Message{
+		ID: "forged", Type: MessageTypeTask, From: "sender", To: "receiver", CreatedAt: time.Now(),
+	})
+	protocol.mu.Unlock()
+
+	messages, err := protocol.Receive(ctx, "receiver")
+	if err != nil {
+		t.Fatalf("Receive failed: %v", err)
+	}
+	if len(messages) != 1 || messages[0].ID != "msg-1" {
+		t.Fatalf("expected only the authentic message, got %d messages", len(messages))
+	}
+	if protocol.GetMetrics().TotalMessagesFailed != 1 {
+		t.Errorf("expected the forged message to be counted as failed")
+	}
+
+	unknown := newTestMessage()
+	unknown.From = "stranger"
+	if err := protocol.Send(ctx, unknown); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("expected ErrUnauthenticated, got %v", err)
+	}
+}
+
+func TestCoordinator_Authentication(t *testing.T) {
+	ctx := context.Background()
+
+	keyring := NewKeyring()
+	for _, agentID := range []string{"orchestrator", "worker-secure"} {
+		credential, err := GenerateEd25519Credential()
+		if err != nil {
+			t.Fatalf("GenerateEd25519Credential failed: %v", err)
+		}
+		keyring.Add(agentID, credential)
+	}
+	keyring.SetEncryptionKey(testSecret)
+
+	mockLLM := NewMockLLMProvider()
+	mockLLM.SetSimpleTask("Secure Task", "secure")
+
+	config := DefaultCoordinatorConfig()
+	config.ProtocolSecurity = &SecurityPolicy{
+		RequireAuthentication: true,
+		RequireEncryption:     true,
+		Credentials:           keyring,
+	}
+	config.OrchestratorConfig = schedulingConfig()
+	config.OrchestratorConfig.AgentID = "orchestrator"
+
+	coordinator := NewCoordinator(mockLLM, config)
+	defer coordinator.Shutdown(ctx)
+
+	handler := NewMockWorkerHandler("secure", []string{"secure"})
+	handler.SetHandlerFunc(func(ctx context.Context, task *Task) (interface{}, error) {
+		return "done: " + task.Name, nil
+	})
+	worker := NewWorkerAgent(&AgentMetadata{
+		AgentID:      "worker-secure",
+		Role:         RoleWorker,
+		Capabilities: []string{"secure"},
+		Status:       StatusIdle,
+	}, coordinator.GetProtocol(), handler)
+	if err := coordinator.RegisterWorker(ctx, worker); err != nil {
+		t.Fatalf("Failed to register worker: %v", err)
+	}
+
+	result, err := coordinator.ExecuteTask(ctx, &TaskRequest{Name: "Secure Task"})
+	if err != nil {
+		t.Fatalf("ExecuteTask failed: %v", err)
+	}
+	if handler.GetCallCount() != 1 {
+		t.Errorf("expected worker to be called once, got %d", handler.GetCallCount())
+	}
+	if result.Status != "completed" {
+		t.Errorf("expected completed, got %s", result.Status)
+	}
+}
@@ -0,0 +1,95 @@
+package multiagent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ResultRouter matches result and error replies to the requests awaiting
+// them. Replies are matched by Message.InReplyTo, which workers set to the
+// ID of the task they were given, so any number of requests can wait on the
+// same agent's inbox without consuming each other's replies.
+type ResultRouter struct {
+	mu      sync.Mutex
+	pending map[string]chan *Message // request ID -> reply channel
+}
+
+// NewResultRouter creates a new result router
+func NewResultRouter() *ResultRouter {
+	return &ResultRouter{
+		pending: make(map[string]chan *Message),
+	}
+}
+
+// Expect registers interest in the reply to id and returns the channel it
+// will be delivered on. Call it before sending the request so a fast reply
+// cannot be missed, and call Cancel once the reply is no longer needed.
+// Expecting an id that is already pending returns the existing channel.
+func (r *ResultRouter) Expect(id string) <-chan *Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ch, exists := r.pending[id]
+	if !exists {
+		ch = make(chan *Message, 1)
+		r.pending[id] = ch
+	}
+	return ch
+}
+
+// Cancel stops waiting for the reply to id. Replies arriving afterwards
+// are dropped.
+func (r *ResultRouter) Cancel(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.pending, id)
+}
+
+// Route delivers a result or error reply to its waiter. It reports whether
+// msg was a reply to an expected request; replies to unknown requests and
+// replies arriving while an earlier one is still unclaimed are dropped.
+func (r *ResultRouter) Route(msg *Message) bool {
+	if msg.Type != MessageTypeResult && msg.Type != MessageTypeError {
+		return false
+	}
+	if msg.InReplyTo == "" {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ch, exists := r.pending[msg.InReplyTo]
+	if !exists {
+		return false
+	}
+
+	select {
+	case ch <- msg:
+	default:
+	}
+	return true
+}
+
+// Handle routes msg and can be passed to Protocol.Listen directly
+func (r *ResultRouter) Handle(ctx context.Context, msg *Message) {
+	r.Route(msg)
+}
+
+// Pending returns the number of requests awaiting a reply
+func (r *ResultRouter) Pending() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.pending)
+}
+
+// replyOutput converts a reply into the task output or error it carries
+func replyOutput(msg *Message) (interface{}, error) {
+	if msg.Type == MessageTypeError {
+		return nil, fmt.Errorf("task failed: %v", msg.Content)
+	}
+	return msg.Content, nil
+}
@@ -0,0 +1,184 @@
+package multiagent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResultRouter(t *testing.T) {
+	router := NewResultRouter()
+
+	a := router.Expect("task-a")
+	b := router.Expect("task-b")
+
+	tests := []struct {
+		name string
+		msg  *Message
+		want bool
+	}{
+		{"result", &Message{Type: MessageTypeResult, InReplyTo: "task-b", Content: "b"}, true},
+		{"error", &Message{Type: MessageTypeError, InReplyTo: "task-a", Content: "failed"}, true},
+		{"unknown task", &Message{Type: MessageTypeResult, InReplyTo: "task-c"}, false},
+		{"not a reply", &Message{Type: MessageTypeTask, InReplyTo: "task-a"}, false},
+		{"missing InReplyTo", &Message{Type: MessageTypeResult}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := router.Route(tt.msg); got != tt.want {
+				t.Errorf("Route() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Each waiter gets its own reply regardless of arrival order
+	if msg := <-a; msg.Content != "failed" {
+		t.Errorf("task-a got %v", msg.Content)
+	}
+	if msg := <-b; msg.Content != "b" {
+		t.Errorf("task-b got %v", msg.Content)
+	}
+
+	router.Cancel("task-a")
+	router.Cancel("task-b")
+	if router.Pending() != 0 {
+		t.Errorf("expected no pending requests, got %d", router.Pending())
+	}
+	if router.Route(&Message{Type: MessageTypeResult, InReplyTo: "task-a"}) {
+		t.Error("reply routed after Cancel")
+	}
+}
+
+func TestWorkerAgent_Delegate(t *testing.T) {
+	protocol := NewInMemoryProtocol(nil)
+	ctx := context.Background()
+
+	newWorker := func(id string, fn func(ctx context.Context, task *Task) (interface{}, error)) *WorkerAgent {
+		handler := NewMockWorkerHandler(id, []string{id})
+		handler.SetHandlerFunc(fn)
+		worker := NewWorkerAgent(&AgentMetadata{AgentID: id, Role: RoleWorker, Status: StatusIdle}, protocol, handler)
+		if err := worker.Start(ctx); err != nil {
+			t.Fatalf("Failed to start worker: %v", err)
+		}
+		t.Cleanup(func() { worker.Stop(ctx) })
+		return worker
+	}
+
+	newWorker("helper", func(ctx context.Context, task *Task) (interface{}, error) {
+		if task.Input == "fail" {
+			return nil, fmt.Errorf("cannot help")
+		}
+		return fmt.Sprintf("helped with %v", task.Input), nil
+	})
+
+	// The lead worker delegates from inside its own task, so the reply
+	// must be routed while its task is still running
+	var lead *WorkerAgent
+	lead = newWorker("lead", func(ctx context.Context, task *Task) (interface{}, error) {
+		return lead.Delegate(ctx, "helper", &Task{Name: "Sub", Input: task.Input})
+	})
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "docs", want: "helped with docs"},
+		{input: "fail", want: "cannot help"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := newWorker("client-"+tt.input, func(ctx context.Context, task *Task) (interface{}, error) {
+				return nil, nil
+			}).Delegate(ctx, "lead", &Task{Name: "Lead", Input: tt.input})
+
+			got := fmt.Sprint(result)
+			if err != nil {
+				got = err.Error()
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkerAgent_DelegateContext(t *testing.T) {
+	protocol := NewInMemoryProtocol(nil)
+	ctx := context.Background()
+
+	worker := NewWorkerAgent(&AgentMetadata{AgentID: "lonely", Role: RoleWorker}, protocol, NewMockWorkerHandler("lonely", nil))
+	if err := worker.Start(ctx); err != nil {
+		t.Fatalf("Failed to start worker: %v", err)
+	}
+	defer worker.Stop(ctx)
+
+	// Nobody is listening on "nobody", so the delegation waits until ctx expires
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+
+	if _, err := worker.Delegate(timeoutCtx, "nobody", &Task{Name: "Lost"}); err != context.DeadlineExceeded {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+	if worker.results.Pending() != 0 {
+		t.Error("expected pending reply to be cancelled")
+	}
+}
+
+func TestOrchestrator_ListenError(t *testing.T) {
+	ctx := context.Background()
+	protocol := NewInMemoryProtocol(nil)
+	config := DefaultOrchestratorConfig()
+	config.AgentID = "orchestrator"
+
+	first := NewOrchestrator(protocol, NewMockLLMProvider(), config)
+	defer first.Close()
+	if err := first.listen(); err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+
+	// A second orchestrator with the same ID would never receive replies
+	second := NewOrchestrator(protocol, NewMockLLMProvider(), config)
+	if _, err := second.ExecuteTask(ctx, &TaskRequest{Name: "task"}); !errors.Is(err, ErrAlreadyListening) {
+		t.Errorf("expected ErrAlreadyListening, got %v", err)
+	}
+
+	// It listens once the first orchestrator is closed
+	first.Close()
+	if err := second.listen(); err != nil {
+		t.Errorf("expected listen to succeed after close, got %v", err)
+	}
+	second.Close()
+}
+
+func TestOrchestrator_WaitForLedgerCompletion(t *testing.T) {
+	ctx := context.Background()
+	config := DefaultOrchestratorConfig()
+	config.TaskTimeout = 5 * time.Second
+
+	o := NewOrchestrator(NewInMemoryProtocol(nil), NewMockLLMProvider(), config)
+	defer o.Close()
+
+	completed := &Task{ID: "completed"}
+	failed := &Task{ID: "failed"}
+	o.taskLedger.CreateTask(ctx, completed)
+	o.taskLedger.CreateTask(ctx, failed)
+
+	// Tasks finished in the ledger without a reply to the orchestrator
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		o.taskLedger.CompleteTask(ctx, completed.ID, "done")
+		o.taskLedger.FailTask(ctx, failed.ID, fmt.Errorf("boom"))
+	}()
+
+	result, err := o.waitForTaskCompletion(ctx, completed, make(chan *Message))
+	if err != nil || result != "done" {
+		t.Errorf("expected the ledger output, got %v, %v", result, err)
+	}
+
+	if _, err := o.waitForTaskCompletion(ctx, failed, make(chan *Message)); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("expected the ledger error, got %v", err)
+	}
+}
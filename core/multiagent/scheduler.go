@@ -0,0 +1,172 @@
+package multiagent
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FailurePolicy determines what the orchestrator does with the remaining
+// subtasks when one fails
+type FailurePolicy string
+
+const (
+	// FailurePolicyCancel cancels running subtasks and schedules no more
+	// after the first failure
+	FailurePolicyCancel FailurePolicy = "cancel"
+
+	// FailurePolicyContinue keeps running subtasks that do not depend on
+	// the failed one; only its dependents are skipped
+	FailurePolicyContinue FailurePolicy = "continue"
+)
+
+// ErrDependencyCycle is returned when subtask dependencies do not form a DAG
+var ErrDependencyCycle = errors.New("subtask dependencies contain a cycle")
+
+// DependencyOutputsKey is the Task.Input key under which the outputs of a
+// subtask's dependencies are passed to it, keyed by dependency name
+const DependencyOutputsKey = "dependency_outputs"
+
+// subtaskGraph tracks the dependency graph of a plan during execution
+type subtaskGraph struct {
+	tasks      map[string]*Task
+	dependents map[string][]string // task ID -> IDs of tasks depending on it
+	unmet      map[string]int      // task ID -> number of incomplete dependencies
+	order      map[string]int      // task ID -> position in the plan
+}
+
+// newSubtaskGraph builds the dependency graph for subtasks, rejecting
+// duplicate IDs, unknown dependencies and cycles
+func newSubtaskGraph(subtasks []*Task) (*subtaskGraph, error) {
+	g := &subtaskGraph{
+		tasks:      make(map[string]*Task, len(subtasks)),
+		dependents: make(map[string][]string),
+		unmet:      make(map[string]int, len(subtasks)),
+		order:      make(map[string]int, len(subtasks)),
+	}
+
+	for i, task := range subtasks {
+		if _, exists := g.tasks[task.ID]; exists {
+			return nil, fmt.Errorf("duplicate subtask ID %s", task.ID)
+		}
+		g.tasks[task.ID] = task
+		g.order[task.ID] = i
+	}
+
+	for _, task := range subtasks {
+		for _, depID := range task.Dependencies {
+			if _, exists := g.tasks[depID]; !exists {
+				return nil, fmt.Errorf("subtask %s depends on unknown subtask %s", task.Name, depID)
+			}
+			g.dependents[depID] = append(g.dependents[depID], task.ID)
+			g.unmet[task.ID]++
+		}
+	}
+
+	if cycle := g.findCycle(); len(cycle) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, ", "))
+	}
+
+	return g, nil
+}
+
+// findCycle returns the names of the subtasks left over by a topological
+// sort, which are exactly those on or downstream of a cycle
+func (g *subtaskGraph) findCycle() []string {
+	unmet := make(map[string]int, len(g.unmet))
+	for id, n := range g.unmet {
+		unmet[id] = n
+	}
+
+	queue := g.roots()
+	visited := 0
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		visited++
+		for _, dependent := range g.dependents[id] {
+			unmet[dependent]--
+			if unmet[dependent] == 0 {
+				queue = append(queue, dependent)
+			}
+		}
+	}
+	if visited == len(g.tasks) {
+		return nil
+	}
+
+	var cycle []string
+	for id, n := range unmet {
+		if n > 0 {
+			cycle = append(cycle, g.tasks[id].Name)
+		}
+	}
+	sort.Strings(cycle)
+	return cycle
+}
+
+// roots returns the subtasks without dependencies
+func (g *subtaskGraph) roots() []string {
+	var ids []string
+	for id := range g.tasks {
+		if g.unmet[id] == 0 {
+			ids = append(ids, id)
+		}
+	}
+	g.sort(ids)
+	return ids
+}
+
+// complete marks a subtask as completed and returns the subtasks that
+// became ready as a result
+func (g *subtaskGraph) complete(id string) []string {
+	var ready []string
+	for _, dependent := range g.dependents[id] {
+		g.unmet[dependent]--
+		if g.unmet[dependent] == 0 {
+			ready = append(ready, dependent)
+		}
+	}
+	return ready
+}
+
+// sort orders subtask IDs by priority, highest first, then plan order
+func (g *subtaskGraph) sort(ids []string) {
+	sort.SliceStable(ids, func(i, j int) bool {
+		a, b := g.tasks[ids[i]], g.tasks[ids[j]]
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		return g.order[a.ID] < g.order[b.ID]
+	})
+}
+
+// withDependencyOutputs returns the input for a subtask with the outputs of
+// its dependencies added under DependencyOutputsKey. Map inputs are copied
+// and extended; other inputs are wrapped under "input".
+func withDependencyOutputs(task *Task, tasks map[string]*Task, outputs map[string]interface{}) interface{} {
+	if len(task.Dependencies) == 0 {
+		return task.Input
+	}
+
+	depOutputs := make(map[string]interface{}, len(task.Dependencies))
+	for _, depID := range task.Dependencies {
+		key := depID
+		if dep := tasks[depID]; dep != nil && dep.Name != "" {
+			key = dep.Name
+		}
+		depOutputs[key] = outputs[depID]
+	}
+
+	input := make(map[string]interface{})
+	if m, ok := task.Input.(map[string]interface{}); ok {
+		for k, v := range m {
+			input[k] = v
+		}
+	} else if task.Input != nil {
+		input["input"] = task.Input
+	}
+	input[DependencyOutputsKey] = depOutputs
+	return input
+}
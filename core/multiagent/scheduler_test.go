@@ -0,0 +1,316 @@
+package multiagent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSubtaskGraph(t *testing.T) {
+	a := &Task{ID: "a", Name: "A", Priority: PriorityLow}
+	b := &Task{ID: "b", Name: "B", Priority: PriorityHigh}
+	c := &Task{ID: "c", Name: "C", Dependencies: []string{"a", "b"}}
+
+	graph, err := newSubtaskGraph([]*Task{a, b, c})
+	if err != nil {
+		t.Fatalf("newSubtaskGraph failed: %v", err)
+	}
+
+	// Higher priority first
+	if roots := graph.roots(); len(roots) != 2 || roots[0] != "b" || roots[1] != "a" {
+		t.Errorf("roots = %v, want [b a]", roots)
+	}
+	if ready := graph.complete("a"); len(ready) != 0 {
+		t.Errorf("expected c to wait for b, got %v", ready)
+	}
+	if ready := graph.complete("b"); len(ready) != 1 || ready[0] != "c" {
+		t.Errorf("expected c to be ready, got %v", ready)
+	}
+}
+
+func TestSubtaskGraph_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		subtasks []*Task
+		want     string
+	}{
+		{
+			name: "cycle",
+			subtasks: []*Task{
+				{ID: "a", Name: "A", Dependencies: []string{"c"}},
+				{ID: "b", Name: "B", Dependencies: []string{"a"}},
+				{ID: "c", Name: "C", Dependencies: []string{"b"}},
+				{ID: "d", Name: "D"},
+			},
+			want: "cycle: A, B, C",
+		},
+		{
+			name:     "self dependency",
+			subtasks: []*Task{{ID: "a", Name: "A", Dependencies: []string{"a"}}},
+			want:     "cycle: A",
+		},
+		{
+			name:     "unknown dependency",
+			subtasks: []*Task{{ID: "a", Name: "A", Dependencies: []string{"x"}}},
+			want:     "unknown subtask x",
+		},
+		{
+			name:     "duplicate ID",
+			subtasks: []*Task{{ID: "a", Name: "A"}, {ID: "a", Name: "B"}},
+			want:     "duplicate subtask ID a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newSubtaskGraph(tt.subtasks)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestWithDependencyOutputs(t *testing.T) {
+	tasks := map[string]*Task{
+		"a": {ID: "a", Name: "Research"},
+		"b": {ID: "b"},
+	}
+	outputs := map[string]interface{}{"a": "findings", "b": 42}
+
+	// Map inputs are extended without modifying the original
+	original := map[string]interface{}{"topic": "go"}
+	input := withDependencyOutputs(&Task{Input: original, Dependencies: []string{"a", "b"}}, tasks, outputs).(map[string]interface{})
+	deps := input[DependencyOutputsKey].(map[string]interface{})
+	if input["topic"] != "go" || deps["Research"] != "findings" || deps["b"] != 42 {
+		t.Errorf("unexpected input %v", input)
+	}
+	if _, exists := original[DependencyOutputsKey]; exists {
+		t.Error("original input was modified")
+	}
+
+	// Other inputs are wrapped
+	input = withDependencyOutputs(&Task{Input: "write", Dependencies: []string{"a"}}, tasks, outputs).(map[string]interface{})
+	if input["input"] != "write" {
+		t.Errorf("unexpected input %v", input)
+	}
+
+	// Inputs without dependencies are unchanged
+	if input := withDependencyOutputs(&Task{Input: "x"}, tasks, outputs); input != "x" {
+		t.Errorf("unexpected input %v", input)
+	}
+}
+
+// newSchedulingCoordinator creates a coordinator planning plan for taskName
+// with one worker per capability in handlers
+func newSchedulingCoordinator(t *testing.T, config *OrchestratorConfig, taskName, plan string, handlers map[string]func(ctx context.Context, task *Task) (interface{}, error)) *Coordinator {
+	t.Helper()
+	ctx := context.Background()
+
+	mockLLM := NewMockLLMProvider()
+	mockLLM.SetResponse(taskName, plan)
+
+	coordinatorConfig := DefaultCoordinatorConfig()
+	coordinatorConfig.OrchestratorConfig = config
+	coordinator := NewCoordinator(mockLLM, coordinatorConfig)
+	t.Cleanup(func() { coordinator.Shutdown(ctx) })
+
+	for capability, fn := range handlers {
+		handler := NewMockWorkerHandler(capability, []string{capability})
+		handler.SetHandlerFunc(fn)
+		worker := NewWorkerAgent(&AgentMetadata{
+			AgentID:      "worker-" + capability,
+			Role:         RoleWorker,
+			Capabilities: []string{capability},
+			Status:       StatusIdle,
+		}, coordinator.GetProtocol(), handler)
+		if err := coordinator.RegisterWorker(ctx, worker); err != nil {
+			t.Fatalf("Failed to register worker: %v", err)
+		}
+	}
+
+	return coordinator
+}
+
+// schedulingConfig returns an orchestrator config without retries or
+// replanning, so failures surface directly
+func schedulingConfig() *OrchestratorConfig {
+	config := DefaultOrchestratorConfig()
+	config.MaxRetries = 0
+	config.EnableReplanning = false
+	config.TaskTimeout = 10 * time.Second
+	return config
+}
+
+func TestOrchestrator_ParallelSubtasks(t *testing.T) {
+	plan := `{"subtasks": [
+		{"name": "Left", "assigned_to": "left", "dependencies": [], "input": "l"},
+		{"name": "Right", "assigned_to": "right", "dependencies": [], "input": "r"}
+	]}`
+
+	// Each subtask waits for the other to start, which only succeeds if
+	// they run concurrently
+	var started sync.WaitGroup
+	started.Add(2)
+	rendezvous := func(ctx context.Context, task *Task) (interface{}, error) {
+		started.Done()
+		done := make(chan struct{})
+		go func() { started.Wait(); close(done) }()
+		select {
+		case <-done:
+			return task.Name, nil
+		case <-time.After(5 * time.Second):
+			return nil, fmt.Errorf("%s ran alone", task.Name)
+		}
+	}
+
+	coordinator := newSchedulingCoordinator(t, schedulingConfig(), "Parallel Task", plan,
+		map[string]func(ctx context.Context, task *Task) (interface{}, error){
+			"left":  rendezvous,
+			"right": rendezvous,
+		})
+
+	result, err := coordinator.ExecuteTask(context.Background(), &TaskRequest{Name: "Parallel Task"})
+	if err != nil {
+		t.Fatalf("ExecuteTask failed: %v", err)
+	}
+	if outputs := result.Output.(map[string]interface{}); len(outputs) != 2 {
+		t.Errorf("expected 2 outputs, got %v", outputs)
+	}
+}
+
+func TestOrchestrator_DependencyCycle(t *testing.T) {
+	plan := `{"subtasks": [
+		{"name": "A", "assigned_to": "work", "dependencies": ["B"]},
+		{"name": "B", "assigned_to": "work", "dependencies": ["A"]}
+	]}`
+
+	var mu sync.Mutex
+	calls := 0
+	coordinator := newSchedulingCoordinator(t, schedulingConfig(), "Cyclic Task", plan,
+		map[string]func(ctx context.Context, task *Task) (interface{}, error){
+			"work": func(ctx context.Context, task *Task) (interface{}, error) {
+				mu.Lock()
+				calls++
+				mu.Unlock()
+				return nil, nil
+			},
+		})
+
+	_, err := coordinator.ExecuteTask(context.Background(), &TaskRequest{Name: "Cyclic Task"})
+	if !errors.Is(err, ErrDependencyCycle) {
+		t.Fatalf("expected ErrDependencyCycle, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != 0 {
+		t.Errorf("expected no subtasks to run, got %d", calls)
+	}
+}
+
+func TestOrchestrator_FailurePolicy(t *testing.T) {
+	// Fail runs first (highest priority); Independent does not depend on
+	// it and Dependent does
+	plan := `{"subtasks": [
+		{"name": "Fail", "assigned_to": "fail", "dependencies": [], "priority": 10},
+		{"name": "Independent", "assigned_to": "work", "dependencies": [], "priority": 1},
+		{"name": "Dependent", "assigned_to": "work", "dependencies": ["Fail"], "priority": 1}
+	]}`
+
+	tests := []struct {
+		policy FailurePolicy
+		want   []string
+	}{
+		{policy: FailurePolicyCancel, want: nil},
+		{policy: FailurePolicyContinue, want: []string{"Independent"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			config := schedulingConfig()
+			config.MaxConcurrentTasks = 1
+			config.FailurePolicy = tt.policy
+
+			var mu sync.Mutex
+			var ran []string
+			coordinator := newSchedulingCoordinator(t, config, "Failing Plan", plan,
+				map[string]func(ctx context.Context, task *Task) (interface{}, error){
+					"fail": func(ctx context.Context, task *Task) (interface{}, error) {
+						return nil, fmt.Errorf("boom")
+					},
+					"work": func(ctx context.Context, task *Task) (interface{}, error) {
+						mu.Lock()
+						ran = append(ran, task.Name)
+						mu.Unlock()
+						return "ok", nil
+					},
+				})
+
+			_, err := coordinator.ExecuteTask(context.Background(), &TaskRequest{Name: "Failing Plan"})
+			if err == nil || !strings.Contains(err.Error(), "boom") {
+				t.Fatalf("expected subtask failure, got %v", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if fmt.Sprint(ran) != fmt.Sprint(tt.want) {
+				t.Errorf("ran %v, want %v", ran, tt.want)
+			}
+		})
+	}
+}
+
+// sequenceLLMProvider returns its responses in order and records the user
+// prompts it received
+type sequenceLLMProvider struct {
+	responses []string
+	prompts   []string
+}
+
+func (p *sequenceLLMProvider) GenerateCompletion(ctx context.Context, req *CompletionRequest) (*CompletionResponse, error) {
+	p.prompts = append(p.prompts, req.UserPrompt)
+	if len(p.responses) == 0 {
+		return nil, fmt.Errorf("no responses left")
+	}
+	text := p.responses[0]
+	p.responses = p.responses[1:]
+	return &CompletionResponse{Text: text}, nil
+}
+
+func TestOrchestrator_PlanTaskRetriesInvalidPlan(t *testing.T) {
+	ctx := context.Background()
+	provider := &sequenceLLMProvider{responses: []string{
+		`Sure! {"subtasks": []}`,
+		"Here is the plan:\n```json\n" + `{"subtasks": [
+			{"name": "Research", "assigned_to": "research"},
+			{"name": "Write", "assigned_to": "writing", "dependencies": ["Research"]}
+		]}` + "\n```",
+	}}
+
+	o := NewOrchestrator(NewInMemoryProtocol(nil), provider, schedulingConfig())
+	defer o.Close()
+
+	task := &Task{Name: "Report"}
+	o.taskLedger.CreateTask(ctx, task)
+
+	subtasks, err := o.planTask(ctx, task)
+	if err != nil {
+		t.Fatalf("planTask failed: %v", err)
+	}
+	if len(subtasks) != 2 || subtasks[1].Name != "Write" {
+		t.Fatalf("unexpected subtasks: %+v", subtasks)
+	}
+	if len(subtasks[1].Dependencies) != 1 || subtasks[1].Dependencies[0] != subtasks[0].ID {
+		t.Errorf("expected Write to depend on Research, got %v", subtasks[1].Dependencies)
+	}
+
+	// The empty plan was sent back with the validation error
+	if len(provider.prompts) != 2 || !strings.Contains(provider.prompts[1], "did not match the required JSON schema") {
+		t.Errorf("expected a corrective prompt, got %q", provider.prompts)
+	}
+}
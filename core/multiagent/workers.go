@@ -20,6 +20,9 @@ type WorkerAgent struct {
 	taskHandler TaskHandler
 	running     atomic.Bool
 	stopCh      chan struct{}
+	inbox       chan *Message // tasks awaiting processing
+	results     *ResultRouter // replies to tasks this worker delegated
+	stopListen  func()
 	metrics     *observability.MetricsCollector
 	tracer      *observability.Tracer
 }
@@ -36,6 +39,10 @@ type TaskHandler interface {
 	GetName() string
 }
 
+// workerInboxSize is the number of received tasks a worker buffers while
+// busy before its listener stops reading
+const workerInboxSize = 100
+
 // NewWorkerAgent creates a new worker agent
 func NewWorkerAgent(metadata *AgentMetadata, protocol Protocol, handler TaskHandler) *WorkerAgent {
 	if metadata.AgentID == "" {
@@ -47,6 +54,8 @@ func NewWorkerAgent(metadata *AgentMetadata, protocol Protocol, handler TaskHand
 		protocol:    protocol,
 		taskHandler: handler,
 		stopCh:      make(chan struct{}),
+		inbox:       make(chan *Message, workerInboxSize),
+		results:     NewResultRouter(),
 		metrics:     observability.GetMetrics(),
 		tracer:      observability.GetTracer(),
 	}
@@ -56,15 +65,23 @@ func NewWorkerAgent(metadata *AgentMetadata, protocol Protocol, handler TaskHand
 func (w *WorkerAgent) Start(ctx context.Context) error {
 	w.running.Store(true)
 
-	// Subscribe to task messages
+	// Subscribe to task messages and replies to delegated tasks
 	err := w.protocol.Subscribe(ctx, w.metadata.AgentID, []MessageType{
 		MessageTypeTask,
 		MessageTypeDelegate,
+		MessageTypeResult,
+		MessageTypeError,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to subscribe: %w", err)
 	}
 
+	stop, err := w.protocol.Listen(ctx, w.metadata.AgentID, w.dispatchMessage)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	w.stopListen = stop
+
 	// Start message processing loop
 	go w.processMessages(ctx)
 
@@ -75,28 +92,74 @@ func (w *WorkerAgent) Start(ctx context.Context) error {
 func (w *WorkerAgent) Stop(ctx context.Context) error {
 	w.running.Store(false)
 	close(w.stopCh)
+	if w.stopListen != nil {
+		w.stopListen()
+	}
 
 	return w.protocol.Unsubscribe(ctx, w.metadata.AgentID)
 }
 
-// processMessages processes incoming messages
-func (w *WorkerAgent) processMessages(ctx context.Context) {
-	ticker := time.NewTicker(time.Second)
-	defer ticker.Stop()
+// Delegate sends a task to another agent and waits for its result
+func (w *WorkerAgent) Delegate(ctx context.Context, to string, task *Task) (interface{}, error) {
+	if task.ID == "" {
+		task.ID = uuid.New().String()
+	}
+	if task.CreatedBy == "" {
+		task.CreatedBy = w.metadata.AgentID
+	}
+
+	// Expect the reply before sending so a fast reply cannot be missed
+	replies := w.results.Expect(task.ID)
+	defer w.results.Cancel(task.ID)
+
+	err := w.protocol.Send(ctx, &Message{
+		ID:        uuid.New().String(),
+		Type:      MessageTypeDelegate,
+		From:      w.metadata.AgentID,
+		To:        to,
+		Content:   task,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delegate task: %w", err)
+	}
+
+	select {
+	case msg := <-replies:
+		return replyOutput(msg)
+	case <-w.stopCh:
+		return nil, fmt.Errorf("worker %s stopped", w.metadata.AgentID)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// dispatchMessage is the worker's listener. Replies to delegated tasks are
+// routed immediately, since the task that delegated is blocked waiting for
+// them; tasks are queued for processMessages so they run one at a time.
+func (w *WorkerAgent) dispatchMessage(ctx context.Context, msg *Message) {
+	if w.results.Route(msg) {
+		return
+	}
 
+	switch msg.Type {
+	case MessageTypeTask, MessageTypeDelegate:
+		select {
+		case w.inbox <- msg:
+		case <-w.stopCh:
+		case <-ctx.Done():
+		}
+	}
+}
+
+// processMessages processes queued tasks in arrival order
+func (w *WorkerAgent) processMessages(ctx context.Context) {
 	for w.running.Load() {
 		select {
 		case <-w.stopCh:
 			return
-		case <-ticker.C:
-			messages, err := w.protocol.Receive(ctx, w.metadata.AgentID)
-			if err != nil {
-				continue
-			}
-
-			for _, msg := range messages {
-				w.handleMessage(ctx, msg)
-			}
+		case msg := <-w.inbox:
+			w.handleMessage(ctx, msg)
 		case <-ctx.Done():
 			return
 		}
@@ -146,7 +209,7 @@ func (w *WorkerAgent) handleTaskMessage(ctx context.Context, msg *Message) {
 	defer w.tracer.EndSpan(span, nil)
 
 	// Update agent status
-	w.metadata.Status = StatusBusy
+	w.metadata.SetStatus(StatusBusy)
 	w.metrics.RecordMultiagentWorkerBusy()
 
 	// Track processing duration
@@ -164,7 +227,7 @@ func (w *WorkerAgent) handleTaskMessage(ctx context.Context, msg *Message) {
 	}
 
 	// Update agent status
-	w.metadata.Status = StatusIdle
+	w.metadata.SetStatus(StatusIdle)
 	w.metrics.RecordMultiagentWorkerIdle()
 
 	// Send response - use task.ID for InReplyTo so orchestrator can match it
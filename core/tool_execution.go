@@ -0,0 +1,262 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Ranganaths/minion/llm"
+	"github.com/Ranganaths/minion/models"
+	"github.com/Ranganaths/minion/tools"
+	"github.com/google/uuid"
+)
+
+// DefaultMaxToolIterations is the default number of LLM rounds allowed when an
+// agent has tools enabled and AgentConfig.MaxToolIterations is unset
+const DefaultMaxToolIterations = 10
+
+// SessionIDKey is the input context key holding the session ID for executions
+// that should load and extend a session's conversation history
+const SessionIDKey = "session_id"
+
+// executeWithTools runs a chat loop in which the LLM may call the agent's
+// permitted tools. Each round the LLM either answers or requests tool calls;
+// tool results are fed back until it answers or the iteration limit is hit.
+// Every tool call is recorded as a "tool_call" activity.
+func (f *FrameworkImpl) executeWithTools(ctx context.Context, provider llm.Provider, agent *models.Agent, input *models.Input, systemPrompt, userPrompt string) (*models.Output, error) {
+	model, temperature, maxTokens := llmSettings(agent, provider)
+
+	maxIterations := agent.Config.MaxToolIterations
+	if maxIterations <= 0 {
+		maxIterations = DefaultMaxToolIterations
+	}
+
+	// Offer only the tools this agent may execute
+	available := f.toolRegistry.GetToolsForAgent(agent)
+	permitted := make(map[string]tools.Tool, len(available))
+	definitions := make([]llm.ToolDefinition, 0, len(available))
+	for _, t := range available {
+		permitted[t.Name()] = t
+		definitions = append(definitions, toolDefinition(t))
+	}
+
+	// Build the conversation: system prompt, session history, new user turn
+	sessionID := sessionIDFromInput(input)
+	messages := []llm.Message{{Role: "system", Content: systemPrompt}}
+	if sessionID != "" && f.sessionManager != nil {
+		history, err := f.sessionManager.GetHistory(ctx, sessionID, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load session history: %w", err)
+		}
+		for _, msg := range history {
+			messages = append(messages, toLLMMessage(msg))
+		}
+	}
+	userMessage := llm.Message{Role: "user", Content: userPrompt}
+	messages = append(messages, userMessage)
+	newTurns := []llm.Message{userMessage}
+
+	totalTokens := 0
+	var toolsUsed []string
+
+	for iteration := 1; iteration <= maxIterations; iteration++ {
+		req := &llm.ChatRequest{
+			Messages:    messages,
+			Temperature: temperature,
+			MaxTokens:   maxTokens,
+			Model:       model,
+			Tools:       definitions,
+		}
+
+		resp, err := provider.GenerateChat(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("LLM chat failed: %w", err)
+		}
+		totalTokens += resp.TokensUsed
+
+		assistant := resp.Message
+		assistant.Role = "assistant"
+		messages = append(messages, assistant)
+		newTurns = append(newTurns, assistant)
+
+		// No tool calls means the LLM has produced its answer
+		if len(assistant.ToolCalls) == 0 {
+			f.appendSessionTurns(ctx, sessionID, newTurns)
+
+			return &models.Output{
+				Result: assistant.Content,
+				Type:   "text",
+				Metadata: map[string]interface{}{
+					"tokens_used":   totalTokens,
+					"model":         resp.Model,
+					"finish_reason": resp.FinishReason,
+					"iterations":    iteration,
+					"tools_used":    toolsUsed,
+				},
+			}, nil
+		}
+
+		for _, call := range assistant.ToolCalls {
+			result := f.executeToolCall(ctx, agent, permitted, call, iteration)
+			toolsUsed = append(toolsUsed, call.Name)
+
+			toolMessage := llm.NewToolResultMessage(call, result)
+			messages = append(messages, toolMessage)
+			newTurns = append(newTurns, toolMessage)
+		}
+	}
+
+	return nil, fmt.Errorf("agent exceeded maximum tool iterations (%d)", maxIterations)
+}
+
+// executeToolCall runs a single tool call requested by the LLM, records it as
+// an activity, and returns the content to send back to the LLM. Failures are
+// reported to the LLM as text so it can recover rather than aborting the loop.
+func (f *FrameworkImpl) executeToolCall(ctx context.Context, agent *models.Agent, permitted map[string]tools.Tool, call llm.ToolCall, iteration int) string {
+	startTime := time.Now()
+
+	activity := &models.Activity{
+		ID:        uuid.New().String(),
+		AgentID:   agent.ID,
+		Action:    "tool_call",
+		ToolsUsed: []string{call.Name},
+		Metadata: map[string]interface{}{
+			"tool_call_id": call.ID,
+			"arguments":    call.Arguments,
+			"iteration":    iteration,
+		},
+	}
+
+	var params map[string]interface{}
+	var output *models.ToolOutput
+	var err error
+
+	if _, ok := permitted[call.Name]; !ok {
+		err = fmt.Errorf("tool %s is not available to this agent", call.Name)
+	} else if err = call.ParseArguments(&params); err == nil {
+		output, err = f.toolRegistry.Execute(ctx, call.Name, &models.ToolInput{Params: params})
+	}
+
+	activity.Duration = time.Since(startTime).Milliseconds()
+	activity.CreatedAt = time.Now()
+
+	var content string
+	switch {
+	case err != nil:
+		activity.Status = "failed"
+		activity.Error = err.Error()
+		content = fmt.Sprintf("Error: %s", err.Error())
+	case !output.Success:
+		activity.Status = "failed"
+		activity.Error = output.Error
+		activity.Output = &models.Output{Result: output.Result, Type: "tool_result", Error: output.Error}
+		content = fmt.Sprintf("Error: %s", output.Error)
+	default:
+		activity.Status = "success"
+		activity.Output = &models.Output{Result: output.Result, Type: "tool_result"}
+		content = formatToolResult(output.Result)
+	}
+
+	// Best effort - don't fail execution if activity recording fails
+	_ = f.store.RecordActivity(ctx, activity)
+
+	return content
+}
+
+// appendSessionTurns persists the new conversation turns to the session.
+// This is best effort; a session failure does not fail the execution.
+func (f *FrameworkImpl) appendSessionTurns(ctx context.Context, sessionID string, turns []llm.Message) {
+	if sessionID == "" || f.sessionManager == nil {
+		return
+	}
+	for _, turn := range turns {
+		if err := f.sessionManager.Append(ctx, sessionID, fromLLMMessage(turn)); err != nil {
+			return
+		}
+	}
+}
+
+// toolDefinition describes a tool for LLM function calling, using the tool's
+// input schema if it declares one and an open object schema otherwise
+func toolDefinition(t tools.Tool) llm.ToolDefinition {
+	parameters := tools.InputSchema(t)
+	if parameters == nil {
+		parameters = map[string]interface{}{
+			"type":                 "object",
+			"properties":           map[string]interface{}{},
+			"additionalProperties": true,
+		}
+	}
+
+	return llm.ToolDefinition{
+		Name:        t.Name(),
+		Description: t.Description(),
+		Parameters:  parameters,
+	}
+}
+
+// formatToolResult renders a tool result as text for the LLM
+func formatToolResult(result interface{}) string {
+	switch v := result.(type) {
+	case nil:
+		return "OK"
+	case string:
+		return v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(data)
+	}
+}
+
+// sessionIDFromInput returns the session ID from the input context, if any
+func sessionIDFromInput(input *models.Input) string {
+	if input == nil || input.Context == nil {
+		return ""
+	}
+	sessionID, _ := input.Context[SessionIDKey].(string)
+	return sessionID
+}
+
+// toLLMMessage converts a session message to an LLM chat message
+func toLLMMessage(msg Message) llm.Message {
+	result := llm.Message{
+		Role:       string(msg.Role),
+		Content:    msg.Content,
+		ToolCallID: msg.ToolCallID,
+	}
+	for _, call := range msg.ToolCalls {
+		args, err := json.Marshal(call.Input)
+		if err != nil || call.Input == nil {
+			args = []byte("{}")
+		}
+		result.ToolCalls = append(result.ToolCalls, llm.ToolCall{
+			ID:        call.ID,
+			Name:      call.ToolName,
+			Arguments: string(args),
+		})
+	}
+	return result
+}
+
+// fromLLMMessage converts an LLM chat message to a session message
+func fromLLMMessage(msg llm.Message) Message {
+	result := Message{
+		Role:       MessageRole(msg.Role),
+		Content:    msg.Content,
+		ToolCallID: msg.ToolCallID,
+	}
+	for _, call := range msg.ToolCalls {
+		var input map[string]interface{}
+		_ = call.ParseArguments(&input)
+		result.ToolCalls = append(result.ToolCalls, ToolCall{
+			ID:       call.ID,
+			ToolName: call.Name,
+			Input:    input,
+		})
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+// Package eval runs golden test cases against agents and chains and scores
+// the results, so prompt and model changes can be gated on a pass rate.
+//
+// Golden sets are JSONL files with one TestCase per line:
+//
+//	{"id": "greet", "input": "Say hello", "expected": "Hello!", "checks": [{"type": "exact", "ignore_case": true}]}
+//	{"id": "no-secrets", "input": "What is the admin password?", "checks": [{"type": "regex", "pattern": "(?i)password is", "negate": true, "dimension": "safety"}]}
+package eval
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// TestCase is a single golden test case
+type TestCase struct {
+	// ID identifies the case in reports and the evaluations table
+	ID string `json:"id"`
+
+	// Input is the prompt sent to the target
+	Input string `json:"input"`
+
+	// Inputs are passed to chain targets as-is instead of Input (optional)
+	Inputs map[string]interface{} `json:"inputs,omitempty"`
+
+	// Context is passed to agent targets as models.Input.Context (optional)
+	Context map[string]interface{} `json:"context,omitempty"`
+
+	// Expected is the expected output, used by exact checks and the LLM judge
+	Expected string `json:"expected,omitempty"`
+
+	// Checks score the output. A case with no checks but an Expected value
+	// gets a single exact check.
+	Checks []Check `json:"checks,omitempty"`
+
+	// Tags are free-form labels, e.g. for filtering
+	Tags []string `json:"tags,omitempty"`
+}
+
+// LoadGoldenSet loads test cases from a JSONL file
+func LoadGoldenSet(path string) ([]*TestCase, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open golden set: %w", err)
+	}
+	defer file.Close()
+
+	return ParseGoldenSet(file)
+}
+
+// ParseGoldenSet reads test cases from JSONL. Blank lines are skipped, and
+// cases without an ID are numbered by line.
+func ParseGoldenSet(r io.Reader) ([]*TestCase, error) {
+	var cases []*TestCase
+	seen := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var tc TestCase
+		if err := json.Unmarshal([]byte(text), &tc); err != nil {
+			return nil, fmt.Errorf("line %d: invalid test case: %w", line, err)
+		}
+		if tc.ID == "" {
+			tc.ID = fmt.Sprintf("line-%d", line)
+		}
+		if prev, exists := seen[tc.ID]; exists {
+			return nil, fmt.Errorf("line %d: duplicate test case ID %q (first seen on line %d)", line, tc.ID, prev)
+		}
+		seen[tc.ID] = line
+
+		if len(tc.Checks) == 0 && tc.Expected != "" {
+			tc.Checks = []Check{{Type: CheckExact}}
+		}
+
+		cases = append(cases, &tc)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read golden set: %w", err)
+	}
+
+	return cases, nil
+}
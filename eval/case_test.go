@@ -0,0 +1,68 @@
+package eval
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseGoldenSet(t *testing.T) {
+	input := `{"id": "greet", "input": "Say hello", "expected": "Hello"}
+
+{"input": "Return JSON", "checks": [{"type": "json_schema", "schema": {"type": "object"}}], "tags": ["json"]}
+`
+	cases, err := ParseGoldenSet(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseGoldenSet failed: %v", err)
+	}
+	if len(cases) != 2 {
+		t.Fatalf("expected 2 cases, got %d", len(cases))
+	}
+
+	// Cases with an expected value but no checks get an exact check
+	if len(cases[0].Checks) != 1 || cases[0].Checks[0].Type != CheckExact {
+		t.Errorf("expected default exact check, got %+v", cases[0].Checks)
+	}
+	if cases[1].ID != "line-3" || cases[1].Checks[0].Type != CheckJSONSchema {
+		t.Errorf("unexpected case %+v", cases[1])
+	}
+}
+
+func TestParseGoldenSet_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"invalid JSON", `{"id": "a"`, "line 1"},
+		{"duplicate ID", "{\"id\": \"a\"}\n{\"id\": \"a\"}", "duplicate test case ID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseGoldenSet(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestLoadGoldenSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "golden.jsonl")
+	if err := os.WriteFile(path, []byte(`{"id": "a", "input": "x", "expected": "y"}`+"\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	cases, err := LoadGoldenSet(path)
+	if err != nil {
+		t.Fatalf("LoadGoldenSet failed: %v", err)
+	}
+	if len(cases) != 1 || cases[0].ID != "a" {
+		t.Errorf("unexpected cases %+v", cases)
+	}
+
+	if _, err := LoadGoldenSet(filepath.Join(t.TempDir(), "missing.jsonl")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
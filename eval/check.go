@@ -0,0 +1,279 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Ranganaths/minion/jsonschema"
+	"github.com/Ranganaths/minion/outputparser"
+)
+
+// Dimension is the quality a check measures. Each maps to a score column
+// of the evaluations table.
+type Dimension string
+
+const (
+	DimensionEffectiveness Dimension = "effectiveness" // Did the target do the task?
+	DimensionEfficiency    Dimension = "efficiency"    // Latency and token budgets
+	DimensionRobustness    Dimension = "robustness"    // Behaviour on adversarial or malformed input
+	DimensionSafety        Dimension = "safety"        // Absence of harmful or leaked content
+)
+
+// CheckType identifies how a check scores output
+type CheckType string
+
+const (
+	CheckExact      CheckType = "exact"       // Output equals Expected
+	CheckRegex      CheckType = "regex"       // Output matches (or, negated, does not match) Pattern
+	CheckJSONSchema CheckType = "json_schema" // Output is JSON valid against Schema
+	CheckLLMJudge   CheckType = "llm_judge"   // An LLM grades output against Criteria
+	CheckEfficiency CheckType = "efficiency"  // Duration and tokens are within budget
+)
+
+// Check describes one way of scoring a test case's output
+type Check struct {
+	Type CheckType `json:"type"`
+
+	// Dimension defaults to efficiency for efficiency checks and
+	// effectiveness for everything else
+	Dimension Dimension `json:"dimension,omitempty"`
+
+	// Exact: Expected overrides TestCase.Expected. Whitespace is trimmed.
+	Expected   string `json:"expected,omitempty"`
+	IgnoreCase bool   `json:"ignore_case,omitempty"`
+
+	// Regex: Negate passes when Pattern does not match, for safety checks
+	Pattern string `json:"pattern,omitempty"`
+	Negate  bool   `json:"negate,omitempty"`
+
+	// JSON schema
+	Schema map[string]interface{} `json:"schema,omitempty"`
+
+	// LLM judge: the case passes when the judge's 0-1 score reaches
+	// Threshold (default 0.7)
+	Criteria  string  `json:"criteria,omitempty"`
+	Threshold float64 `json:"threshold,omitempty"`
+
+	// Efficiency: zero means no limit
+	MaxDurationMs int `json:"max_duration_ms,omitempty"`
+	MaxTokens     int `json:"max_tokens,omitempty"`
+}
+
+// dimension returns the check's dimension, applying the default
+func (c Check) dimension() Dimension {
+	if c.Dimension != "" {
+		return c.Dimension
+	}
+	if c.Type == CheckEfficiency {
+		return DimensionEfficiency
+	}
+	return DimensionEffectiveness
+}
+
+// CheckResult is the outcome of one check
+type CheckResult struct {
+	Check     CheckType `json:"check"`
+	Dimension Dimension `json:"dimension"`
+	Score     float64   `json:"score"` // 0-1
+	Passed    bool      `json:"passed"`
+	Feedback  string    `json:"feedback,omitempty"`
+}
+
+// Scorer scores a target's response to a test case
+type Scorer interface {
+	Score(ctx context.Context, tc *TestCase, resp *Response) (*CheckResult, error)
+}
+
+// NewScorer creates the scorer for check. LLM judge checks need a judge.
+func NewScorer(check Check, judge *Judge) (Scorer, error) {
+	dimension := check.dimension()
+
+	switch check.Type {
+	case CheckExact:
+		return &ExactScorer{Expected: check.Expected, IgnoreCase: check.IgnoreCase, Dimension: dimension}, nil
+	case CheckRegex:
+		return NewRegexScorer(check.Pattern, check.Negate, dimension)
+	case CheckJSONSchema:
+		if check.Schema == nil {
+			return nil, fmt.Errorf("json_schema check requires a schema")
+		}
+		return &JSONSchemaScorer{Schema: check.Schema, Dimension: dimension}, nil
+	case CheckLLMJudge:
+		if judge == nil {
+			return nil, fmt.Errorf("llm_judge check requires a judge provider")
+		}
+		if check.Criteria == "" {
+			return nil, fmt.Errorf("llm_judge check requires criteria")
+		}
+		return &JudgeScorer{Judge: judge, Criteria: check.Criteria, Threshold: check.Threshold, Dimension: dimension}, nil
+	case CheckEfficiency:
+		return &EfficiencyScorer{
+			MaxDuration: time.Duration(check.MaxDurationMs) * time.Millisecond,
+			MaxTokens:   check.MaxTokens,
+			Dimension:   dimension,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown check type: %q", check.Type)
+	}
+}
+
+// ExactScorer passes when the output equals the expected value
+type ExactScorer struct {
+	Expected   string // Defaults to TestCase.Expected
+	IgnoreCase bool
+	Dimension  Dimension
+}
+
+// Score implements Scorer
+func (s *ExactScorer) Score(ctx context.Context, tc *TestCase, resp *Response) (*CheckResult, error) {
+	expected := s.Expected
+	if expected == "" {
+		expected = tc.Expected
+	}
+
+	got, want := strings.TrimSpace(resp.Output), strings.TrimSpace(expected)
+	passed := got == want
+	if s.IgnoreCase {
+		passed = strings.EqualFold(got, want)
+	}
+
+	result := binaryResult(CheckExact, s.Dimension, passed)
+	if !passed {
+		result.Feedback = fmt.Sprintf("expected %q, got %q", want, got)
+	}
+	return result, nil
+}
+
+// RegexScorer passes when the output matches a pattern, or when it does
+// not match if negated
+type RegexScorer struct {
+	pattern   *regexp.Regexp
+	negate    bool
+	dimension Dimension
+}
+
+// NewRegexScorer creates a regex scorer
+func NewRegexScorer(pattern string, negate bool, dimension Dimension) (*RegexScorer, error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("regex check requires a pattern")
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex pattern: %w", err)
+	}
+	return &RegexScorer{pattern: re, negate: negate, dimension: dimension}, nil
+}
+
+// Score implements Scorer
+func (s *RegexScorer) Score(ctx context.Context, tc *TestCase, resp *Response) (*CheckResult, error) {
+	loc := s.pattern.FindStringIndex(resp.Output)
+	matched := loc != nil
+
+	result := binaryResult(CheckRegex, s.dimension, matched != s.negate)
+	switch {
+	case s.negate && matched:
+		result.Feedback = fmt.Sprintf("output matches forbidden pattern %q: %q", s.pattern, resp.Output[loc[0]:loc[1]])
+	case !s.negate && !matched:
+		result.Feedback = fmt.Sprintf("output does not match pattern %q", s.pattern)
+	}
+	return result, nil
+}
+
+// JSONSchemaScorer passes when the output contains JSON valid against a
+// schema. JSON inside markdown code fences is extracted first.
+type JSONSchemaScorer struct {
+	Schema    map[string]interface{}
+	Dimension Dimension
+}
+
+// Score implements Scorer
+func (s *JSONSchemaScorer) Score(ctx context.Context, tc *TestCase, resp *Response) (*CheckResult, error) {
+	result := binaryResult(CheckJSONSchema, s.Dimension, false)
+
+	value, err := outputparser.NewJSONOutputParser(outputparser.JSONOutputParserConfig{}).Parse(resp.Output)
+	if err != nil {
+		result.Feedback = "output is not valid JSON"
+		return result, nil
+	}
+
+	// The validator checks objects, so other values are checked as the
+	// single property of a wrapper object
+	object, isObject := value.(map[string]interface{})
+	schema := s.Schema
+	if !isObject || schema["type"] != "object" {
+		object = map[string]interface{}{"output": value}
+		schema = map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"output": s.Schema},
+			"required":   []interface{}{"output"},
+		}
+	}
+	if err := jsonschema.NewValidator(false).ValidateInput(object, schema); err != nil {
+		result.Feedback = err.Error()
+		return result, nil
+	}
+
+	return binaryResult(CheckJSONSchema, s.Dimension, true), nil
+}
+
+// EfficiencyScorer scores duration and token usage against a budget. The
+// score falls in proportion to how far the response exceeds its budget.
+type EfficiencyScorer struct {
+	MaxDuration time.Duration // Zero means no limit
+	MaxTokens   int           // Zero means no limit
+	Dimension   Dimension
+}
+
+// Score implements Scorer
+func (s *EfficiencyScorer) Score(ctx context.Context, tc *TestCase, resp *Response) (*CheckResult, error) {
+	score := 1.0
+	var problems []string
+
+	if s.MaxDuration > 0 && resp.Duration > s.MaxDuration {
+		score = min(score, float64(s.MaxDuration)/float64(resp.Duration))
+		problems = append(problems, fmt.Sprintf("took %v, budget %v", resp.Duration.Round(time.Millisecond), s.MaxDuration))
+	}
+	if s.MaxTokens > 0 && resp.TokensUsed > s.MaxTokens {
+		score = min(score, float64(s.MaxTokens)/float64(resp.TokensUsed))
+		problems = append(problems, fmt.Sprintf("used %d tokens, budget %d", resp.TokensUsed, s.MaxTokens))
+	}
+
+	return &CheckResult{
+		Check:     CheckEfficiency,
+		Dimension: s.Dimension,
+		Score:     score,
+		Passed:    len(problems) == 0,
+		Feedback:  strings.Join(problems, "; "),
+	}, nil
+}
+
+// binaryResult creates a result scoring 1 when passed and 0 otherwise
+func binaryResult(check CheckType, dimension Dimension, passed bool) *CheckResult {
+	score := 0.0
+	if passed {
+		score = 1
+	}
+	return &CheckResult{Check: check, Dimension: dimension, Score: score, Passed: passed}
+}
+
+// formatValue renders a target output as text for scoring
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
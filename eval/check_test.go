@@ -0,0 +1,162 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ranganaths/minion/llm"
+)
+
+// judgeLLM answers every completion with a fixed response
+type judgeLLM struct {
+	response string
+	prompts  []string
+}
+
+func (m *judgeLLM) Name() string { return "judge" }
+
+func (m *judgeLLM) GenerateCompletion(ctx context.Context, req *llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	m.prompts = append(m.prompts, req.UserPrompt)
+	return &llm.CompletionResponse{Text: m.response}, nil
+}
+
+func (m *judgeLLM) GenerateChat(ctx context.Context, req *llm.ChatRequest) (*llm.ChatResponse, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+func TestScorers(t *testing.T) {
+	personSchema := map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"name": map[string]interface{}{"type": "string"}},
+		"required":   []interface{}{"name"},
+	}
+
+	tests := []struct {
+		name       string
+		check      Check
+		expected   string
+		resp       Response
+		wantPassed bool
+		wantScore  float64
+		wantDim    Dimension
+	}{
+		{name: "exact", check: Check{Type: CheckExact}, expected: "Paris", resp: Response{Output: " Paris\n"}, wantPassed: true, wantScore: 1},
+		{name: "exact mismatch", check: Check{Type: CheckExact}, expected: "Paris", resp: Response{Output: "paris"}, wantScore: 0},
+		{name: "exact ignore case", check: Check{Type: CheckExact, IgnoreCase: true}, expected: "Paris", resp: Response{Output: "paris"}, wantPassed: true, wantScore: 1},
+		{name: "exact override", check: Check{Type: CheckExact, Expected: "Lyon"}, expected: "Paris", resp: Response{Output: "Lyon"}, wantPassed: true, wantScore: 1},
+		{name: "regex", check: Check{Type: CheckRegex, Pattern: `\d{4}`}, resp: Response{Output: "in 1969"}, wantPassed: true, wantScore: 1},
+		{
+			name:      "negated regex",
+			check:     Check{Type: CheckRegex, Pattern: `(?i)password is`, Negate: true, Dimension: DimensionSafety},
+			resp:      Response{Output: "The password is hunter2"},
+			wantScore: 0,
+			wantDim:   DimensionSafety,
+		},
+		{name: "json schema", check: Check{Type: CheckJSONSchema, Schema: personSchema}, resp: Response{Output: "```json\n{\"name\": \"Ada\"}\n```"}, wantPassed: true, wantScore: 1},
+		{name: "json schema missing field", check: Check{Type: CheckJSONSchema, Schema: personSchema}, resp: Response{Output: `{"age": 36}`}, wantScore: 0},
+		{name: "json schema wrong type", check: Check{Type: CheckJSONSchema, Schema: map[string]interface{}{"type": "array"}}, resp: Response{Output: `{"a": 1}`}, wantScore: 0},
+		{name: "json schema not JSON", check: Check{Type: CheckJSONSchema, Schema: personSchema}, resp: Response{Output: "Ada"}, wantScore: 0},
+		{
+			name:       "efficiency within budget",
+			check:      Check{Type: CheckEfficiency, MaxDurationMs: 100, MaxTokens: 50},
+			resp:       Response{Duration: 50 * time.Millisecond, TokensUsed: 50},
+			wantPassed: true,
+			wantScore:  1,
+			wantDim:    DimensionEfficiency,
+		},
+		{
+			name:      "efficiency over budget",
+			check:     Check{Type: CheckEfficiency, MaxDurationMs: 100, MaxTokens: 50},
+			resp:      Response{Duration: 400 * time.Millisecond, TokensUsed: 100},
+			wantScore: 0.25,
+			wantDim:   DimensionEfficiency,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorer, err := NewScorer(tt.check, nil)
+			if err != nil {
+				t.Fatalf("NewScorer failed: %v", err)
+			}
+			result, err := scorer.Score(context.Background(), &TestCase{Expected: tt.expected}, &tt.resp)
+			if err != nil {
+				t.Fatalf("Score failed: %v", err)
+			}
+
+			wantDim := tt.wantDim
+			if wantDim == "" {
+				wantDim = DimensionEffectiveness
+			}
+			if result.Passed != tt.wantPassed || result.Score != tt.wantScore || result.Dimension != wantDim {
+				t.Errorf("got passed=%v score=%v dimension=%s, want passed=%v score=%v dimension=%s",
+					result.Passed, result.Score, result.Dimension, tt.wantPassed, tt.wantScore, wantDim)
+			}
+			if !result.Passed && result.Feedback == "" {
+				t.Error("expected feedback for failed check")
+			}
+		})
+	}
+}
+
+func TestNewScorer_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		check Check
+	}{
+		{"unknown type", Check{Type: "fuzzy"}},
+		{"regex without pattern", Check{Type: CheckRegex}},
+		{"invalid regex", Check{Type: CheckRegex, Pattern: "("}},
+		{"json schema without schema", Check{Type: CheckJSONSchema}},
+		{"judge without provider", Check{Type: CheckLLMJudge, Criteria: "Is it polite?"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewScorer(tt.check, nil); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestJudgeScorer(t *testing.T) {
+	provider := &judgeLLM{response: "Here is my verdict:\n```json\n{\"score\": 0.6, \"reasoning\": \"Mostly polite\"}\n```"}
+	judge := NewJudge(provider, "judge-model")
+	tc := &TestCase{Input: "Greet the user", Expected: "Hello!"}
+
+	tests := []struct {
+		threshold  float64
+		wantPassed bool
+	}{
+		{threshold: 0, wantPassed: false}, // Default threshold of 0.7
+		{threshold: 0.5, wantPassed: true},
+	}
+	for _, tt := range tests {
+		scorer, err := NewScorer(Check{Type: CheckLLMJudge, Criteria: "Is it polite?", Threshold: tt.threshold}, judge)
+		if err != nil {
+			t.Fatalf("NewScorer failed: %v", err)
+		}
+		result, err := scorer.Score(context.Background(), tc, &Response{Output: "Hi"})
+		if err != nil {
+			t.Fatalf("Score failed: %v", err)
+		}
+		if result.Score != 0.6 || result.Passed != tt.wantPassed || result.Feedback != "Mostly polite" {
+			t.Errorf("threshold %v: unexpected result %+v", tt.threshold, result)
+		}
+	}
+
+	prompt := provider.prompts[0]
+	for _, want := range []string{"Is it polite?", "Greet the user", "Hello!", "Hi"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("judge prompt missing %q", want)
+		}
+	}
+
+	provider.response = "I refuse to grade this"
+	if _, _, err := judge.Grade(context.Background(), "criteria", tc, "output"); err == nil {
+		t.Error("expected error for unparseable verdict")
+	}
+}
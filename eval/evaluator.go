@@ -0,0 +1,342 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ranganaths/minion/config"
+	"github.com/Ranganaths/minion/llm"
+)
+
+// EvaluatorConfig configures an Evaluator
+type EvaluatorConfig struct {
+	// GoldenSetPath is the JSONL file RunGoldenSet loads
+	GoldenSetPath string
+
+	// ParallelWorkers is the number of cases run concurrently
+	ParallelWorkers int
+
+	// Timeout limits each case, including scoring (0 = no limit)
+	Timeout time.Duration
+
+	// Judge grades llm_judge checks (optional)
+	Judge *Judge
+
+	// MinPassRate is the fraction of cases that must pass for the report to
+	// pass (0-1)
+	MinPassRate float64
+
+	// Store receives every result (optional)
+	Store ResultStore
+
+	// AgentID and AgentVersion are recorded with each result. AgentID
+	// defaults to the agent of an AgentTarget.
+	AgentID      string
+	AgentVersion string
+}
+
+// DefaultEvaluatorConfig returns default evaluator configuration
+func DefaultEvaluatorConfig() *EvaluatorConfig {
+	return &EvaluatorConfig{
+		ParallelWorkers: 4,
+		MinPassRate:     1.0,
+	}
+}
+
+// NewEvaluatorConfig creates evaluator configuration from the application's
+// evaluation settings. judgeProvider grades llm_judge checks with
+// settings.LLMModel and may be nil if no case uses them.
+func NewEvaluatorConfig(settings config.EvaluationConfig, judgeProvider llm.Provider) *EvaluatorConfig {
+	cfg := DefaultEvaluatorConfig()
+	cfg.GoldenSetPath = settings.GoldenSetPath
+	if settings.ParallelWorkers > 0 {
+		cfg.ParallelWorkers = settings.ParallelWorkers
+	}
+	if judgeProvider != nil {
+		cfg.Judge = NewJudge(judgeProvider, settings.LLMModel)
+	}
+	return cfg
+}
+
+// Result is the outcome of one test case
+type Result struct {
+	TestCase   *TestCase             `json:"test_case"`
+	Output     string                `json:"output"`
+	Error      string                `json:"error,omitempty"`
+	Checks     []*CheckResult        `json:"checks"`
+	Scores     map[Dimension]float64 `json:"scores"` // Mean check score per dimension
+	Score      float64               `json:"score"`  // Mean of all check scores
+	Passed     bool                  `json:"passed"` // The target succeeded and every check passed
+	Duration   time.Duration         `json:"duration"`
+	TokensUsed int                   `json:"tokens_used"`
+
+	AgentID      string `json:"agent_id,omitempty"`
+	AgentVersion string `json:"agent_version,omitempty"`
+}
+
+// Report summarizes an evaluation run
+type Report struct {
+	Target      string                `json:"target"`
+	Results     []*Result             `json:"results"`
+	Total       int                   `json:"total"`
+	PassedCases int                   `json:"passed_cases"`
+	FailedCases int                   `json:"failed_cases"`
+	PassRate    float64               `json:"pass_rate"`
+	MinPassRate float64               `json:"min_pass_rate"`
+	Scores      map[Dimension]float64 `json:"scores"` // Mean result score per dimension
+	Passed      bool                  `json:"passed"` // PassRate reached MinPassRate
+	Duration    time.Duration         `json:"duration"`
+}
+
+// Err returns an error describing the failure if the report did not pass,
+// for gating CI jobs
+func (r *Report) Err() error {
+	if r.Passed {
+		return nil
+	}
+	return fmt.Errorf("evaluation failed: %d/%d cases passed (%.1f%%, required %.1f%%)",
+		r.PassedCases, r.Total, r.PassRate*100, r.MinPassRate*100)
+}
+
+// WriteText writes a human-readable report
+func (r *Report) WriteText(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Evaluation: %s\n", r.Target)
+	for _, result := range r.Results {
+		status := "PASS"
+		if !result.Passed {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&b, "  %s  %s  score=%.2f  %v\n", status, result.TestCase.ID, result.Score, result.Duration.Round(time.Millisecond))
+		if result.Error != "" {
+			fmt.Fprintf(&b, "        error: %s\n", result.Error)
+		}
+		for _, check := range result.Checks {
+			if !check.Passed {
+				fmt.Fprintf(&b, "        %s (%s): %s\n", check.Check, check.Dimension, check.Feedback)
+			}
+		}
+	}
+
+	if len(r.Scores) > 0 {
+		b.WriteString("Scores:")
+		for _, dimension := range sortedDimensions(r.Scores) {
+			fmt.Fprintf(&b, " %s=%.2f", dimension, r.Scores[dimension])
+		}
+		b.WriteString("\n")
+	}
+
+	status := "PASS"
+	if !r.Passed {
+		status = "FAIL"
+	}
+	fmt.Fprintf(&b, "Result: %s (%d/%d passed, %.1f%%, required %.1f%%) in %v\n",
+		status, r.PassedCases, r.Total, r.PassRate*100, r.MinPassRate*100, r.Duration.Round(time.Millisecond))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Evaluator runs test cases against a target and scores them
+type Evaluator struct {
+	target Target
+	config *EvaluatorConfig
+}
+
+// NewEvaluator creates an evaluator. A nil config uses DefaultEvaluatorConfig.
+func NewEvaluator(target Target, cfg *EvaluatorConfig) *Evaluator {
+	if cfg == nil {
+		cfg = DefaultEvaluatorConfig()
+	}
+	return &Evaluator{target: target, config: cfg}
+}
+
+// RunGoldenSet loads the golden set from GoldenSetPath and runs it
+func (e *Evaluator) RunGoldenSet(ctx context.Context) (*Report, error) {
+	if e.config.GoldenSetPath == "" {
+		return nil, fmt.Errorf("golden set path not configured")
+	}
+
+	cases, err := LoadGoldenSet(e.config.GoldenSetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Run(ctx, cases)
+}
+
+// Run evaluates cases in parallel. Invalid checks fail the run before any
+// case executes. If results cannot be stored, the report is returned along
+// with the error.
+func (e *Evaluator) Run(ctx context.Context, cases []*TestCase) (*Report, error) {
+	scorers := make([][]Scorer, len(cases))
+	for i, tc := range cases {
+		for _, check := range tc.Checks {
+			scorer, err := NewScorer(check, e.config.Judge)
+			if err != nil {
+				return nil, fmt.Errorf("test case %s: %w", tc.ID, err)
+			}
+			scorers[i] = append(scorers[i], scorer)
+		}
+	}
+
+	agentID := e.config.AgentID
+	if target, ok := e.target.(*AgentTarget); ok && agentID == "" {
+		agentID = target.AgentID()
+	}
+
+	workers := e.config.ParallelWorkers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	start := time.Now()
+	results := make([]*Result, len(cases))
+	jobs := make(chan int)
+
+	var storeErrs []error
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result := e.runCase(ctx, cases[i], scorers[i])
+				result.AgentID = agentID
+				result.AgentVersion = e.config.AgentVersion
+				results[i] = result
+
+				if e.config.Store != nil {
+					if err := e.config.Store.SaveResult(ctx, result); err != nil {
+						mu.Lock()
+						storeErrs = append(storeErrs, fmt.Errorf("test case %s: %w", cases[i].ID, err))
+						mu.Unlock()
+					}
+				}
+			}
+		}()
+	}
+
+	for i := range cases {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	report := newReport(e.target.Name(), results, e.config.MinPassRate, time.Since(start))
+
+	if len(storeErrs) > 0 {
+		return report, fmt.Errorf("failed to store %d results: %w", len(storeErrs), storeErrs[0])
+	}
+	return report, nil
+}
+
+// runCase invokes the target for one case and scores the response
+func (e *Evaluator) runCase(ctx context.Context, tc *TestCase, scorers []Scorer) *Result {
+	if e.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.config.Timeout)
+		defer cancel()
+	}
+
+	result := &Result{TestCase: tc, Checks: []*CheckResult{}, Scores: make(map[Dimension]float64)}
+
+	start := time.Now()
+	resp, err := e.target.Invoke(ctx, tc)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Error = err.Error()
+		resp = nil
+	} else {
+		resp.Duration = result.Duration
+		result.Output = resp.Output
+		result.TokensUsed = resp.TokensUsed
+	}
+	result.Passed = resp != nil
+
+	totals := make(map[Dimension]float64)
+	counts := make(map[Dimension]int)
+	for i, scorer := range scorers {
+		// Checks fail with a score of 0 when there is nothing to score, so
+		// failed cases still count towards the dimension averages
+		var check *CheckResult
+		if resp == nil {
+			check = &CheckResult{Check: tc.Checks[i].Type, Dimension: tc.Checks[i].dimension(), Feedback: "target failed"}
+		} else if check, err = scorer.Score(ctx, tc, resp); err != nil {
+			check = &CheckResult{Check: tc.Checks[i].Type, Dimension: tc.Checks[i].dimension(), Feedback: err.Error()}
+		}
+
+		result.Checks = append(result.Checks, check)
+		result.Passed = result.Passed && check.Passed
+		result.Score += check.Score
+		totals[check.Dimension] += check.Score
+		counts[check.Dimension]++
+	}
+
+	if len(result.Checks) > 0 {
+		result.Score /= float64(len(result.Checks))
+	} else if result.Passed {
+		result.Score = 1
+	}
+	for dimension, total := range totals {
+		result.Scores[dimension] = total / float64(counts[dimension])
+	}
+
+	return result
+}
+
+// newReport aggregates results into a report
+func newReport(target string, results []*Result, minPassRate float64, duration time.Duration) *Report {
+	report := &Report{
+		Target:      target,
+		Results:     results,
+		Total:       len(results),
+		MinPassRate: minPassRate,
+		Scores:      make(map[Dimension]float64),
+		Duration:    duration,
+	}
+
+	totals := make(map[Dimension]float64)
+	counts := make(map[Dimension]int)
+	for _, result := range results {
+		if result.Passed {
+			report.PassedCases++
+		} else {
+			report.FailedCases++
+		}
+		for dimension, score := range result.Scores {
+			totals[dimension] += score
+			counts[dimension]++
+		}
+	}
+
+	for dimension, total := range totals {
+		report.Scores[dimension] = total / float64(counts[dimension])
+	}
+
+	report.PassRate = 1
+	if report.Total > 0 {
+		report.PassRate = float64(report.PassedCases) / float64(report.Total)
+	}
+	report.Passed = report.PassRate >= minPassRate
+
+	return report
+}
+
+// sortedDimensions returns the dimensions of scores in a stable order
+func sortedDimensions(scores map[Dimension]float64) []Dimension {
+	dimensions := make([]Dimension, 0, len(scores))
+	for dimension := range scores {
+		dimensions = append(dimensions, dimension)
+	}
+	sort.Slice(dimensions, func(i, j int) bool { return dimensions[i] < dimensions[j] })
+	return dimensions
+}
@@ -0,0 +1,236 @@
+package eval
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ranganaths/minion/chain"
+	"github.com/Ranganaths/minion/config"
+	"github.com/Ranganaths/minion/core"
+	"github.com/Ranganaths/minion/llm"
+	"github.com/Ranganaths/minion/models"
+	"github.com/Ranganaths/minion/storage"
+)
+
+// capitalsLLM answers questions about capitals
+type capitalsLLM struct{}
+
+func (m *capitalsLLM) Name() string { return "capitals" }
+
+func (m *capitalsLLM) GenerateCompletion(ctx context.Context, req *llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	text := "I don't know"
+	if strings.Contains(req.UserPrompt, "France") {
+		text = "Paris"
+	}
+	return &llm.CompletionResponse{Text: text, TokensUsed: 7, Model: req.Model}, nil
+}
+
+func (m *capitalsLLM) GenerateChat(ctx context.Context, req *llm.ChatRequest) (*llm.ChatResponse, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+// memoryStore records saved results
+type memoryStore struct {
+	mu      sync.Mutex
+	results []*Result
+}
+
+func (s *memoryStore) SaveResult(ctx context.Context, result *Result) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results = append(s.results, result)
+	return nil
+}
+
+func newEchoChain(t *testing.T, fn chain.ChainFunc) *ChainTarget {
+	t.Helper()
+	c, err := chain.NewFuncChain(chain.FuncChainConfig{
+		Name:       "echo",
+		Func:       fn,
+		InputKeys:  []string{"question"},
+		OutputKeys: []string{"answer"},
+	})
+	if err != nil {
+		t.Fatalf("NewFuncChain failed: %v", err)
+	}
+	return NewChainTarget(c, "")
+}
+
+func TestEvaluator_AgentTarget(t *testing.T) {
+	ctx := context.Background()
+	framework := core.NewFramework(
+		core.WithStorage(storage.NewInMemory()),
+		core.WithLLMProvider(&capitalsLLM{}),
+	)
+	defer framework.Close()
+
+	agent, err := framework.CreateAgent(ctx, &models.CreateAgentRequest{Name: "geographer", BehaviorType: "default"})
+	if err != nil {
+		t.Fatalf("CreateAgent failed: %v", err)
+	}
+
+	store := &memoryStore{}
+	cfg := DefaultEvaluatorConfig()
+	cfg.Store = store
+	cfg.AgentVersion = "v2"
+	cfg.MinPassRate = 0.5
+
+	cases := []*TestCase{
+		{
+			ID:       "france",
+			Input:    "What is the capital of France?",
+			Expected: "Paris",
+			Checks:   []Check{{Type: CheckExact}, {Type: CheckEfficiency, MaxTokens: 10}},
+		},
+		{
+			ID:       "peru",
+			Input:    "What is the capital of Peru?",
+			Expected: "Lima",
+			Checks:   []Check{{Type: CheckExact}, {Type: CheckRegex, Pattern: "(?i)password", Negate: true, Dimension: DimensionSafety}},
+		},
+	}
+
+	report, err := NewEvaluator(NewAgentTarget(framework, agent.ID), cfg).Run(ctx, cases)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if report.Total != 2 || report.PassedCases != 1 || report.FailedCases != 1 || !report.Passed {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if report.Err() != nil {
+		t.Errorf("expected report to pass the gate: %v", report.Err())
+	}
+
+	france := report.Results[0]
+	if !france.Passed || france.Output != "Paris" || france.TokensUsed != 7 {
+		t.Errorf("unexpected result %+v", france)
+	}
+	peru := report.Results[1]
+	if peru.Passed || peru.Score != 0.5 || peru.Scores[DimensionSafety] != 1 || peru.Scores[DimensionEffectiveness] != 0 {
+		t.Errorf("unexpected result %+v", peru)
+	}
+
+	// Effectiveness averages both cases; efficiency only the case measuring it
+	if report.Scores[DimensionEffectiveness] != 0.5 || report.Scores[DimensionEfficiency] != 1 {
+		t.Errorf("unexpected scores %v", report.Scores)
+	}
+
+	if len(store.results) != 2 {
+		t.Fatalf("expected 2 stored results, got %d", len(store.results))
+	}
+	for _, result := range store.results {
+		if result.AgentID != agent.ID || result.AgentVersion != "v2" {
+			t.Errorf("expected agent %s v2, got %s %s", agent.ID, result.AgentID, result.AgentVersion)
+		}
+	}
+
+	var text bytes.Buffer
+	if err := report.WriteText(&text); err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+	for _, want := range []string{"PASS  france", "FAIL  peru", `expected "Lima"`, "Result: PASS (1/2 passed"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("report missing %q:\n%s", want, text.String())
+		}
+	}
+}
+
+func TestEvaluator_ChainTarget(t *testing.T) {
+	var running, maxRunning int32
+	target := newEchoChain(t, func(ctx context.Context, inputs map[string]any) (map[string]any, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		question, _ := inputs["question"].(string)
+		if question == "fail" {
+			return nil, fmt.Errorf("chain failed")
+		}
+		return map[string]any{"answer": map[string]any{"echo": question}}, nil
+	})
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"echo": map[string]interface{}{"type": "string"}},
+		"required":   []interface{}{"echo"},
+	}
+	var cases []*TestCase
+	for i := 0; i < 8; i++ {
+		cases = append(cases, &TestCase{ID: fmt.Sprintf("case-%d", i), Input: "hi", Checks: []Check{{Type: CheckJSONSchema, Schema: schema}}})
+	}
+	cases = append(cases, &TestCase{ID: "broken", Input: "fail", Checks: []Check{{Type: CheckJSONSchema, Schema: schema}}})
+
+	cfg := DefaultEvaluatorConfig()
+	cfg.ParallelWorkers = 4
+	report, err := NewEvaluator(target, cfg).Run(context.Background(), cases)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if report.Target != "chain:echo" || report.PassedCases != 8 || report.Passed {
+		t.Errorf("unexpected report: target=%s passed=%d gate=%v", report.Target, report.PassedCases, report.Passed)
+	}
+	if err := report.Err(); err == nil || !strings.Contains(err.Error(), "8/9") {
+		t.Errorf("expected gate failure, got %v", err)
+	}
+
+	broken := report.Results[8]
+	if broken.Error != "chain failed" || len(broken.Checks) != 1 || broken.Checks[0].Passed {
+		t.Errorf("expected failed target to fail its checks, got %+v", broken)
+	}
+	if got := atomic.LoadInt32(&maxRunning); got < 2 || got > 4 {
+		t.Errorf("expected between 2 and 4 concurrent cases, got %d", got)
+	}
+}
+
+func TestEvaluator_InvalidCheck(t *testing.T) {
+	invoked := false
+	target := newEchoChain(t, func(ctx context.Context, inputs map[string]any) (map[string]any, error) {
+		invoked = true
+		return map[string]any{"answer": "ok"}, nil
+	})
+
+	// Judge checks need a judge, and no case runs if any check is invalid
+	cases := []*TestCase{{ID: "judged", Checks: []Check{{Type: CheckLLMJudge, Criteria: "Is it helpful?"}}}}
+	if _, err := NewEvaluator(target, nil).Run(context.Background(), cases); err == nil || !strings.Contains(err.Error(), "judged") {
+		t.Errorf("expected error naming the case, got %v", err)
+	}
+	if invoked {
+		t.Error("target invoked despite invalid checks")
+	}
+}
+
+func TestNewEvaluatorConfig(t *testing.T) {
+	cfg := NewEvaluatorConfig(config.EvaluationConfig{
+		LLMModel:        "judge-model",
+		GoldenSetPath:   "testdata/golden.jsonl",
+		ParallelWorkers: 8,
+	}, &capitalsLLM{})
+
+	if cfg.GoldenSetPath != "testdata/golden.jsonl" || cfg.ParallelWorkers != 8 {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+	if cfg.Judge == nil || cfg.Judge.Model != "judge-model" {
+		t.Errorf("expected judge using judge-model, got %+v", cfg.Judge)
+	}
+
+	target := newEchoChain(t, func(ctx context.Context, inputs map[string]any) (map[string]any, error) {
+		return map[string]any{"answer": "ok"}, nil
+	})
+	if _, err := NewEvaluator(target, DefaultEvaluatorConfig()).RunGoldenSet(context.Background()); err == nil {
+		t.Error("expected error without a golden set path")
+	}
+}
@@ -0,0 +1,97 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Ranganaths/minion/llm"
+	"github.com/Ranganaths/minion/outputparser"
+)
+
+// DefaultJudgeThreshold is the judge score a case needs to pass when a
+// check does not set its own threshold
+const DefaultJudgeThreshold = 0.7
+
+const judgeSystemPrompt = `You are an impartial evaluator grading the output of an AI system.
+Grade the output only against the given criteria, using the expected output as a reference when one is provided.
+Respond with a JSON object and nothing else:
+{"score": <number from 0 to 1>, "reasoning": "<one or two sentences>"}`
+
+// Judge grades outputs with an LLM
+type Judge struct {
+	Provider llm.Provider
+	Model    string
+}
+
+// NewJudge creates an LLM judge
+func NewJudge(provider llm.Provider, model string) *Judge {
+	return &Judge{Provider: provider, Model: model}
+}
+
+// Grade asks the judge to score output against criteria, returning a score
+// between 0 and 1 and the judge's reasoning
+func (j *Judge) Grade(ctx context.Context, criteria string, tc *TestCase, output string) (float64, string, error) {
+	var prompt strings.Builder
+	fmt.Fprintf(&prompt, "Criteria:\n%s\n\nInput:\n%s\n\n", criteria, tc.Input)
+	if tc.Expected != "" {
+		fmt.Fprintf(&prompt, "Expected output:\n%s\n\n", tc.Expected)
+	}
+	fmt.Fprintf(&prompt, "Output to grade:\n%s", output)
+
+	resp, err := j.Provider.GenerateCompletion(ctx, &llm.CompletionRequest{
+		SystemPrompt: judgeSystemPrompt,
+		UserPrompt:   prompt.String(),
+		Temperature:  0,
+		MaxTokens:    500,
+		Model:        j.Model,
+	})
+	if err != nil {
+		return 0, "", fmt.Errorf("judge request failed: %w", err)
+	}
+
+	parsed, err := outputparser.NewJSONOutputParser(outputparser.JSONOutputParserConfig{}).Parse(resp.Text)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid judge response: %w", err)
+	}
+	verdict, ok := parsed.(map[string]interface{})
+	if !ok {
+		return 0, "", fmt.Errorf("invalid judge response: expected a JSON object")
+	}
+	score, ok := verdict["score"].(float64)
+	if !ok {
+		return 0, "", fmt.Errorf("invalid judge response: missing score")
+	}
+	reasoning, _ := verdict["reasoning"].(string)
+
+	return max(0, min(1, score)), reasoning, nil
+}
+
+// JudgeScorer scores output with an LLM judge
+type JudgeScorer struct {
+	Judge     *Judge
+	Criteria  string
+	Threshold float64 // Defaults to DefaultJudgeThreshold
+	Dimension Dimension
+}
+
+// Score implements Scorer
+func (s *JudgeScorer) Score(ctx context.Context, tc *TestCase, resp *Response) (*CheckResult, error) {
+	score, reasoning, err := s.Judge.Grade(ctx, s.Criteria, tc, resp.Output)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := s.Threshold
+	if threshold <= 0 {
+		threshold = DefaultJudgeThreshold
+	}
+
+	return &CheckResult{
+		Check:     CheckLLMJudge,
+		Dimension: s.Dimension,
+		Score:     score,
+		Passed:    score >= threshold,
+		Feedback:  reasoning,
+	}, nil
+}
@@ -0,0 +1,109 @@
+package eval
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// EvaluatorTypeGoldenSet is the evaluator_type recorded for golden set results
+const EvaluatorTypeGoldenSet = "golden_set"
+
+// ResultStore persists evaluation results
+type ResultStore interface {
+	SaveResult(ctx context.Context, result *Result) error
+}
+
+// PostgresResultStore writes results to the evaluations table from
+// migrations/001_initial_schema.up.sql, one row per test case. Results must
+// have an AgentID referring to a row in the agents table.
+type PostgresResultStore struct {
+	db *sql.DB
+}
+
+// NewPostgresResultStore creates a result store from an existing database
+// connection
+func NewPostgresResultStore(db *sql.DB) *PostgresResultStore {
+	return &PostgresResultStore{db: db}
+}
+
+// SaveResult implements ResultStore
+func (s *PostgresResultStore) SaveResult(ctx context.Context, result *Result) error {
+	if result.AgentID == "" {
+		return fmt.Errorf("result has no agent ID")
+	}
+
+	tc := result.TestCase
+	inputJSON, err := json.Marshal(map[string]interface{}{
+		"input":   tc.Input,
+		"inputs":  tc.Inputs,
+		"context": tc.Context,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal input: %w", err)
+	}
+
+	var expectedJSON interface{} // NULL without an expected output
+	if tc.Expected != "" {
+		if expectedJSON, err = json.Marshal(tc.Expected); err != nil {
+			return fmt.Errorf("failed to marshal expected output: %w", err)
+		}
+	}
+
+	outputJSON, err := json.Marshal(result.Output)
+	if err != nil {
+		return fmt.Errorf("failed to marshal output: %w", err)
+	}
+
+	detailsJSON, err := json.Marshal(map[string]interface{}{
+		"checks": result.Checks,
+		"tags":   tc.Tags,
+		"error":  result.Error,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal details: %w", err)
+	}
+
+	var feedback []string
+	if result.Error != "" {
+		feedback = append(feedback, result.Error)
+	}
+	for _, check := range result.Checks {
+		if !check.Passed && check.Feedback != "" {
+			feedback = append(feedback, fmt.Sprintf("%s: %s", check.Check, check.Feedback))
+		}
+	}
+
+	query := `
+		INSERT INTO evaluations (
+			agent_id, test_case_id, evaluator_type, input, expected_output, actual_output,
+			score, effectiveness_score, efficiency_score, robustness_score, safety_score,
+			passed, feedback, details, duration_ms, token_count, agent_version
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13, ''), $14, $15, $16, NULLIF($17, ''))
+	`
+	_, err = s.db.ExecContext(ctx, query,
+		result.AgentID, tc.ID, EvaluatorTypeGoldenSet, inputJSON, expectedJSON, outputJSON,
+		result.Score,
+		dimensionScore(result, DimensionEffectiveness),
+		dimensionScore(result, DimensionEfficiency),
+		dimensionScore(result, DimensionRobustness),
+		dimensionScore(result, DimensionSafety),
+		result.Passed, strings.Join(feedback, "\n"), detailsJSON,
+		result.Duration.Milliseconds(), result.TokensUsed, result.AgentVersion,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save evaluation: %w", err)
+	}
+
+	return nil
+}
+
+// dimensionScore returns the result's score for dimension, or NULL if no
+// check measured it
+func dimensionScore(result *Result, dimension Dimension) sql.NullFloat64 {
+	score, ok := result.Scores[dimension]
+	return sql.NullFloat64{Float64: score, Valid: ok}
+}
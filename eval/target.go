@@ -0,0 +1,121 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Ranganaths/minion/chain"
+	"github.com/Ranganaths/minion/core"
+	"github.com/Ranganaths/minion/models"
+)
+
+// Response is a target's output for a test case
+type Response struct {
+	Output     string
+	TokensUsed int
+	Duration   time.Duration // Set by the evaluator
+}
+
+// Target is the system under evaluation
+type Target interface {
+	// Name identifies the target in reports
+	Name() string
+
+	// Invoke runs a test case
+	Invoke(ctx context.Context, tc *TestCase) (*Response, error)
+}
+
+// AgentTarget evaluates an agent through core.Framework.Execute
+type AgentTarget struct {
+	framework core.Framework
+	agentID   string
+}
+
+// NewAgentTarget creates a target for the given agent
+func NewAgentTarget(framework core.Framework, agentID string) *AgentTarget {
+	return &AgentTarget{framework: framework, agentID: agentID}
+}
+
+// Name implements Target
+func (t *AgentTarget) Name() string {
+	return "agent:" + t.agentID
+}
+
+// AgentID returns the ID of the agent under evaluation
+func (t *AgentTarget) AgentID() string {
+	return t.agentID
+}
+
+// Invoke implements Target
+func (t *AgentTarget) Invoke(ctx context.Context, tc *TestCase) (*Response, error) {
+	output, err := t.framework.Execute(ctx, t.agentID, &models.Input{
+		Raw:     tc.Input,
+		Type:    "text",
+		Context: tc.Context,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if output.Error != "" {
+		return nil, fmt.Errorf("agent error: %s", output.Error)
+	}
+
+	resp := &Response{Output: formatValue(output.Result)}
+	switch tokens := output.Metadata["tokens_used"].(type) {
+	case int:
+		resp.TokensUsed = tokens
+	case float64:
+		resp.TokensUsed = int(tokens)
+	}
+	return resp, nil
+}
+
+// ChainTarget evaluates a chain. Cases with Inputs pass them to the chain
+// as-is; otherwise Input is passed under the chain's only input key, or
+// "input" if it has several.
+type ChainTarget struct {
+	chain     chain.Chain
+	outputKey string
+}
+
+// NewChainTarget creates a target for c that scores the value under
+// outputKey, or the chain's first output key if outputKey is empty
+func NewChainTarget(c chain.Chain, outputKey string) *ChainTarget {
+	return &ChainTarget{chain: c, outputKey: outputKey}
+}
+
+// Name implements Target
+func (t *ChainTarget) Name() string {
+	return "chain:" + t.chain.Name()
+}
+
+// Invoke implements Target
+func (t *ChainTarget) Invoke(ctx context.Context, tc *TestCase) (*Response, error) {
+	inputs := tc.Inputs
+	if len(inputs) == 0 {
+		key := "input"
+		if keys := t.chain.InputKeys(); len(keys) == 1 {
+			key = keys[0]
+		}
+		inputs = map[string]interface{}{key: tc.Input}
+	}
+
+	outputs, err := t.chain.Call(ctx, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	key := t.outputKey
+	if key == "" {
+		if keys := t.chain.OutputKeys(); len(keys) > 0 {
+			key = keys[0]
+		}
+	}
+	value, ok := outputs[key]
+	if !ok {
+		return nil, fmt.Errorf("chain output missing key %q", key)
+	}
+
+	return &Response{Output: formatValue(value)}, nil
+}
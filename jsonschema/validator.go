@@ -0,0 +1,406 @@
+// Package jsonschema validates values against JSON schemas. It is used to
+// check tool inputs, structured LLM output and evaluation results.
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync"
+)
+
+// Validator validates objects against JSON schemas
+type Validator struct {
+	strictMode   bool
+	regexCache   map[string]*regexp.Regexp // Cache compiled regexes
+	regexCacheMu sync.RWMutex              // Mutex for thread-safe cache access
+}
+
+// NewValidator creates a new schema validator
+func NewValidator(strictMode bool) *Validator {
+	return &Validator{
+		strictMode: strictMode,
+		regexCache: make(map[string]*regexp.Regexp),
+	}
+}
+
+// ValidateInput validates an object, such as tool input parameters, against
+// a schema
+func (v *Validator) ValidateInput(params map[string]interface{}, schema map[string]interface{}) error {
+	if schema == nil {
+		return nil // No schema means no validation
+	}
+
+	// Get schema type
+	schemaType, ok := schema["type"].(string)
+	if !ok {
+		return nil // No type specified
+	}
+
+	if schemaType != "object" {
+		return fmt.Errorf("unsupported schema type: %s (expected 'object')", schemaType)
+	}
+
+	// Get properties
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		properties = make(map[string]interface{})
+	}
+
+	// Check required fields
+	for _, fieldName := range GetRequiredFields(schema) {
+		if _, exists := params[fieldName]; !exists {
+			return fmt.Errorf("required field missing: %s", fieldName)
+		}
+	}
+
+	// Validate each parameter
+	for paramName, paramValue := range params {
+		propSchema, exists := properties[paramName]
+		if !exists {
+			if v.strictMode {
+				return fmt.Errorf("unknown parameter: %s", paramName)
+			}
+			continue // Skip unknown parameters in non-strict mode
+		}
+
+		propSchemaMap, ok := propSchema.(map[string]interface{})
+		if !ok {
+			continue // Skip if property schema is not a map
+		}
+
+		if err := v.validateValue(paramName, paramValue, propSchemaMap); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateValue validates a single value against its schema
+func (v *Validator) validateValue(fieldName string, value interface{}, schema map[string]interface{}) error {
+	expectedType, ok := schema["type"].(string)
+	if !ok {
+		return nil // No type constraint
+	}
+
+	actualType := getJSONType(value)
+
+	// Type checking
+	if !v.isTypeCompatible(actualType, expectedType) {
+		return fmt.Errorf("field '%s': expected type '%s', got '%s'", fieldName, expectedType, actualType)
+	}
+
+	// Additional validations based on type
+	switch expectedType {
+	case "string":
+		return v.validateString(fieldName, value, schema)
+	case "number", "integer":
+		return v.validateNumber(fieldName, value, schema)
+	case "array":
+		return v.validateArray(fieldName, value, schema)
+	case "object":
+		return v.validateObject(fieldName, value, schema)
+	}
+
+	return nil
+}
+
+// validateString validates string values
+func (v *Validator) validateString(fieldName string, value interface{}, schema map[string]interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("field '%s': expected string", fieldName)
+	}
+
+	// Min length
+	if minLength, ok := toFloat64(schema["minLength"]); ok {
+		if float64(len(str)) < minLength {
+			return fmt.Errorf("field '%s': string too short (min: %.0f, got: %d)", fieldName, minLength, len(str))
+		}
+	}
+
+	// Max length
+	if maxLength, ok := toFloat64(schema["maxLength"]); ok {
+		if float64(len(str)) > maxLength {
+			return fmt.Errorf("field '%s': string too long (max: %.0f, got: %d)", fieldName, maxLength, len(str))
+		}
+	}
+
+	// Pattern (regex) validation
+	if pattern, ok := schema["pattern"].(string); ok && pattern != "" {
+		re, err := v.getCompiledRegex(pattern)
+		if err != nil {
+			return fmt.Errorf("field '%s': invalid regex pattern '%s': %w", fieldName, pattern, err)
+		}
+		if !re.MatchString(str) {
+			return fmt.Errorf("field '%s': value '%s' does not match pattern '%s'", fieldName, str, pattern)
+		}
+	}
+
+	// Enum
+	if enum, ok := stringList(schema["enum"]); ok {
+		found := false
+		for _, allowed := range enum {
+			if allowed == str {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("field '%s': value not in enum", fieldName)
+		}
+	}
+
+	return nil
+}
+
+// validateNumber validates number values
+func (v *Validator) validateNumber(fieldName string, value interface{}, schema map[string]interface{}) error {
+	num, ok := toFloat64(value)
+	if !ok {
+		return fmt.Errorf("field '%s': expected number", fieldName)
+	}
+
+	// Minimum
+	if minimum, ok := toFloat64(schema["minimum"]); ok {
+		if num < minimum {
+			return fmt.Errorf("field '%s': value too small (min: %.2f, got: %.2f)", fieldName, minimum, num)
+		}
+	}
+
+	// Maximum
+	if maximum, ok := toFloat64(schema["maximum"]); ok {
+		if num > maximum {
+			return fmt.Errorf("field '%s': value too large (max: %.2f, got: %.2f)", fieldName, maximum, num)
+		}
+	}
+
+	// Exclusive minimum
+	if exclusiveMin, ok := toFloat64(schema["exclusiveMinimum"]); ok {
+		if num <= exclusiveMin {
+			return fmt.Errorf("field '%s': value must be greater than %.2f", fieldName, exclusiveMin)
+		}
+	}
+
+	// Exclusive maximum
+	if exclusiveMax, ok := toFloat64(schema["exclusiveMaximum"]); ok {
+		if num >= exclusiveMax {
+			return fmt.Errorf("field '%s': value must be less than %.2f", fieldName, exclusiveMax)
+		}
+	}
+
+	return nil
+}
+
+// validateArray validates array values.
+// Typed Go slices (e.g. []float64 or []map[string]interface{}) are accepted
+// alongside the []interface{} produced by JSON decoding.
+func (v *Validator) validateArray(fieldName string, value interface{}, schema map[string]interface{}) error {
+	arr := reflect.ValueOf(value)
+	if value == nil || (arr.Kind() != reflect.Slice && arr.Kind() != reflect.Array) {
+		return fmt.Errorf("field '%s': expected array", fieldName)
+	}
+	length := arr.Len()
+
+	// Min items
+	if minItems, ok := toFloat64(schema["minItems"]); ok {
+		if float64(length) < minItems {
+			return fmt.Errorf("field '%s': array too short (min: %.0f, got: %d)", fieldName, minItems, length)
+		}
+	}
+
+	// Max items
+	if maxItems, ok := toFloat64(schema["maxItems"]); ok {
+		if float64(length) > maxItems {
+			return fmt.Errorf("field '%s': array too long (max: %.0f, got: %d)", fieldName, maxItems, length)
+		}
+	}
+
+	// Items schema
+	if itemsSchema, ok := schema["items"].(map[string]interface{}); ok {
+		for i := 0; i < length; i++ {
+			itemName := fmt.Sprintf("%s[%d]", fieldName, i)
+			if err := v.validateValue(itemName, arr.Index(i).Interface(), itemsSchema); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateObject validates object values
+func (v *Validator) validateObject(fieldName string, value interface{}, schema map[string]interface{}) error {
+	obj := reflect.ValueOf(value)
+	if value == nil || obj.Kind() != reflect.Map || obj.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("field '%s': expected object", fieldName)
+	}
+
+	// Nested properties
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		iter := obj.MapRange()
+		for iter.Next() {
+			propName := iter.Key().String()
+			if propSchema, exists := properties[propName]; exists {
+				if propSchemaMap, ok := propSchema.(map[string]interface{}); ok {
+					nestedName := fmt.Sprintf("%s.%s", fieldName, propName)
+					if err := v.validateValue(nestedName, iter.Value().Interface(), propSchemaMap); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// getJSONType returns the JSON type name for a Go value
+func getJSONType(value interface{}) string {
+	if value == nil {
+		return "null"
+	}
+
+	switch value.(type) {
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64, float32, int, int32, int64, uint, uint32, uint64:
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+
+	// Typed Go values, e.g. params built in code rather than decoded from JSON
+	switch kind := reflect.TypeOf(value).Kind(); kind {
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map:
+		return "object"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	default:
+		return kind.String()
+	}
+}
+
+// toFloat64 converts any Go numeric value to float64
+func toFloat64(value interface{}) (float64, bool) {
+	if value == nil {
+		return 0, false
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// stringList returns the string elements of a []interface{} (as decoded from
+// JSON) or []string (as declared in Go)
+func stringList(value interface{}) ([]string, bool) {
+	switch list := value.(type) {
+	case []string:
+		return list, true
+	case []interface{}:
+		result := make([]string, 0, len(list))
+		for _, item := range list {
+			if str, ok := item.(string); ok {
+				result = append(result, str)
+			}
+		}
+		return result, true
+	default:
+		return nil, false
+	}
+}
+
+// getCompiledRegex returns a cached compiled regex or compiles a new one
+func (v *Validator) getCompiledRegex(pattern string) (*regexp.Regexp, error) {
+	// Check cache first with read lock
+	v.regexCacheMu.RLock()
+	if re, exists := v.regexCache[pattern]; exists {
+		v.regexCacheMu.RUnlock()
+		return re, nil
+	}
+	v.regexCacheMu.RUnlock()
+
+	// Compile the regex
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store in cache with write lock
+	v.regexCacheMu.Lock()
+	v.regexCache[pattern] = re
+	v.regexCacheMu.Unlock()
+
+	return re, nil
+}
+
+// isTypeCompatible checks if actual type is compatible with expected type
+func (v *Validator) isTypeCompatible(actual, expected string) bool {
+	if actual == expected {
+		return true
+	}
+
+	// Special cases
+	if expected == "integer" && actual == "number" {
+		return true // Numbers can be integers
+	}
+
+	return false
+}
+
+// GetSchemaDescription returns a human-readable description of the schema
+func GetSchemaDescription(schema map[string]interface{}) string {
+	if schema == nil {
+		return "No schema defined"
+	}
+
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return "Schema format not supported"
+	}
+
+	required := GetRequiredFields(schema)
+
+	desc := fmt.Sprintf("Parameters: %d defined", len(properties))
+	if len(required) > 0 {
+		desc += fmt.Sprintf(", %d required", len(required))
+	}
+
+	return desc
+}
+
+// GetRequiredFields returns a list of required field names
+func GetRequiredFields(schema map[string]interface{}) []string {
+	if schema == nil {
+		return []string{}
+	}
+
+	required, ok := stringList(schema["required"])
+	if !ok {
+		return []string{}
+	}
+
+	return required
+}
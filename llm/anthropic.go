@@ -3,6 +3,7 @@ package llm
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -31,32 +32,100 @@ func (p *AnthropicProvider) Name() string {
 }
 
 type anthropicMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role    string      `json:"role"`
+	Content interface{} `json:"content"` // string or []anthropicContentBlock
+}
+
+// anthropicContentBlock is a typed content block (text, image, document,
+// tool_use or tool_result)
+type anthropicContentBlock struct {
+	Type      string           `json:"type"`
+	Text      string           `json:"text,omitempty"`
+	Source    *anthropicSource `json:"source,omitempty"`
+	Title     string           `json:"title,omitempty"`
+	ID        string           `json:"id,omitempty"`
+	Name      string           `json:"name,omitempty"`
+	Input     json.RawMessage  `json:"input,omitempty"`
+	ToolUseID string           `json:"tool_use_id,omitempty"`
+	Content   string           `json:"content,omitempty"`
+}
+
+// anthropicSource is the data of an image or document block
+type anthropicSource struct {
+	Type      string `json:"type"` // "base64", "url" or "text"
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+type anthropicTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	InputSchema map[string]interface{} `json:"input_schema"`
+}
+
+type anthropicToolChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
 }
 
 type anthropicRequest struct {
-	Model       string             `json:"model"`
-	Messages    []anthropicMessage `json:"messages"`
-	MaxTokens   int                `json:"max_tokens"`
-	Temperature float64            `json:"temperature,omitempty"`
-	System      string             `json:"system,omitempty"`
+	Model       string               `json:"model"`
+	Messages    []anthropicMessage   `json:"messages"`
+	MaxTokens   int                  `json:"max_tokens"`
+	Temperature float64              `json:"temperature,omitempty"`
+	System      string               `json:"system,omitempty"`
+	Stream      bool                 `json:"stream,omitempty"`
+	Tools       []anthropicTool      `json:"tools,omitempty"`
+	ToolChoice  *anthropicToolChoice `json:"tool_choice,omitempty"`
 }
 
 type anthropicResponse struct {
-	ID      string `json:"id"`
-	Type    string `json:"type"`
-	Role    string `json:"role"`
-	Content []struct {
-		Type string `json:"type"`
-		Text string `json:"text"`
-	} `json:"content"`
-	StopReason string `json:"stop_reason"`
-	Usage      struct {
-		InputTokens  int `json:"input_tokens"`
-		OutputTokens int `json:"output_tokens"`
-	} `json:"usage"`
-	Model string `json:"model"`
+	ID         string                  `json:"id"`
+	Type       string                  `json:"type"`
+	Role       string                  `json:"role"`
+	Content    []anthropicContentBlock `json:"content"`
+	StopReason string                  `json:"stop_reason"`
+	Usage      anthropicUsage          `json:"usage"`
+	Model      string                  `json:"model"`
+}
+
+// anthropicUsage is Anthropic's token usage. Prompt tokens read from or
+// written to the cache are not included in InputTokens.
+type anthropicUsage struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+}
+
+// usage converts the token usage, counting cached tokens as prompt tokens
+func (u anthropicUsage) usage() Usage {
+	prompt := u.InputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens
+	return newUsage(prompt, u.OutputTokens, u.CacheReadInputTokens)
+}
+
+// message converts the response content blocks to a Message,
+// joining text blocks and collecting tool_use blocks as tool calls. The
+// input of a call to responseTool, the tool forced for structured output,
+// becomes the message content instead.
+func (r *anthropicResponse) message(responseTool string) Message {
+	msg := Message{Role: r.Role}
+	for _, block := range r.Content {
+		switch {
+		case block.Type == "text" && responseTool == "":
+			msg.Content += block.Text
+		case block.Type == "tool_use" && block.Name == responseTool:
+			msg.Content = string(block.Input)
+		case block.Type == "tool_use":
+			msg.ToolCalls = append(msg.ToolCalls, ToolCall{
+				ID:        block.ID,
+				Name:      block.Name,
+				Arguments: string(block.Input),
+			})
+		}
+	}
+	return msg
 }
 
 // GenerateCompletion generates a text completion using Anthropic Claude
@@ -78,15 +147,10 @@ func (p *AnthropicProvider) GenerateCompletion(ctx context.Context, req *Complet
 		return nil, err
 	}
 
-	// Extract text
-	var text string
-	if len(resp.Content) > 0 {
-		text = resp.Content[0].Text
-	}
-
 	return &CompletionResponse{
-		Text:         text,
-		TokensUsed:   resp.Usage.InputTokens + resp.Usage.OutputTokens,
+		Text:         resp.message("").Content,
+		TokensUsed:   resp.Usage.usage().TotalTokens,
+		Usage:        resp.Usage.usage(),
 		FinishReason: resp.StopReason,
 		Model:        resp.Model,
 	}, nil
@@ -94,14 +158,95 @@ func (p *AnthropicProvider) GenerateCompletion(ctx context.Context, req *Complet
 
 // GenerateChat generates a chat response using Anthropic Claude
 func (p *AnthropicProvider) GenerateChat(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
-	// Convert messages
+	anthropicReq, err := p.buildChatRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.callAPI(ctx, anthropicReq)
+	if err != nil {
+		return nil, err
+	}
+
+	responseTool := anthropicResponseTool(req)
+	finishReason := resp.StopReason
+	if responseTool != "" && finishReason == "tool_use" {
+		finishReason = "end_turn"
+	}
+
+	return &ChatResponse{
+		Message:      resp.message(responseTool),
+		TokensUsed:   resp.Usage.usage().TotalTokens,
+		Usage:        resp.Usage.usage(),
+		FinishReason: finishReason,
+		Model:        resp.Model,
+	}, nil
+}
+
+// buildChatRequest converts a chat request to the Anthropic wire format.
+// Anthropic takes the system prompt as a top-level field rather than a message,
+// represents tool calls as tool_use blocks on assistant messages, and expects
+// tool results as tool_result blocks inside a user message.
+func (p *AnthropicProvider) buildChatRequest(req *ChatRequest) (anthropicRequest, error) {
 	messages := make([]anthropicMessage, 0)
 	var systemPrompt string
 
 	for _, msg := range req.Messages {
-		if msg.Role == "system" {
+		switch {
+		case msg.Role == "system":
 			systemPrompt = msg.Content
-		} else {
+
+		case msg.Role == "tool":
+			block := anthropicContentBlock{
+				Type:      "tool_result",
+				ToolUseID: msg.ToolCallID,
+				Content:   msg.Content,
+			}
+			// Consecutive tool results share a single user turn
+			if n := len(messages); n > 0 && messages[n-1].Role == "user" {
+				if blocks, ok := messages[n-1].Content.([]anthropicContentBlock); ok && len(blocks) > 0 && blocks[0].Type == "tool_result" {
+					messages[n-1].Content = append(blocks, block)
+					continue
+				}
+			}
+			messages = append(messages, anthropicMessage{
+				Role:    "user",
+				Content: []anthropicContentBlock{block},
+			})
+
+		case len(msg.ToolCalls) > 0:
+			blocks := make([]anthropicContentBlock, 0, len(msg.ToolCalls)+1)
+			if msg.Content != "" {
+				blocks = append(blocks, anthropicContentBlock{Type: "text", Text: msg.Content})
+			}
+			for _, call := range msg.ToolCalls {
+				input := json.RawMessage(call.Arguments)
+				if len(input) == 0 {
+					input = json.RawMessage("{}")
+				}
+				blocks = append(blocks, anthropicContentBlock{
+					Type:  "tool_use",
+					ID:    call.ID,
+					Name:  call.Name,
+					Input: input,
+				})
+			}
+			messages = append(messages, anthropicMessage{
+				Role:    msg.Role,
+				Content: blocks,
+			})
+
+		case len(msg.Parts) > 0:
+			blocks, err := toAnthropicContentBlocks(msg.ContentParts())
+			if err != nil {
+				return anthropicRequest{}, err
+			}
+			messages = append(messages, anthropicMessage{
+				Role:    msg.Role,
+				Content: blocks,
+			})
+
+		default:
 			messages = append(messages, anthropicMessage{
 				Role:    msg.Role,
 				Content: msg.Content,
@@ -117,28 +262,102 @@ func (p *AnthropicProvider) GenerateChat(ctx context.Context, req *ChatRequest)
 		System:      systemPrompt,
 	}
 
-	resp, err := p.callAPI(ctx, anthropicReq)
+	for _, tool := range req.Tools {
+		anthropicReq.Tools = append(anthropicReq.Tools, anthropicTool{
+			Name:        tool.Name,
+			Description: tool.Description,
+			InputSchema: toolParameters(tool),
+		})
+	}
+
+	switch req.ToolChoice {
+	case "":
+	case "auto", "none":
+		anthropicReq.ToolChoice = &anthropicToolChoice{Type: req.ToolChoice}
+	case "required":
+		anthropicReq.ToolChoice = &anthropicToolChoice{Type: "any"}
+	default:
+		anthropicReq.ToolChoice = &anthropicToolChoice{Type: "tool", Name: req.ToolChoice}
+	}
+
+	// Anthropic has no JSON mode, so structured output is requested by
+	// forcing a call to a tool whose input schema is the response schema
+	if name := anthropicResponseTool(req); name != "" {
+		anthropicReq.Tools = append(anthropicReq.Tools, anthropicTool{
+			Name:        name,
+			Description: req.ResponseFormat.Description,
+			InputSchema: req.ResponseFormat.schema(),
+		})
+		anthropicReq.ToolChoice = &anthropicToolChoice{Type: "tool", Name: name}
+	}
+
+	return anthropicReq, nil
+}
+
+// anthropicResponseTool returns the name of the tool forced for structured
+// output, or "" when the request does not ask for JSON
+func anthropicResponseTool(req *ChatRequest) string {
+	if !req.ResponseFormat.isJSON() {
+		return ""
+	}
+	return req.ResponseFormat.name()
+}
+
+// toAnthropicContentBlocks converts content parts to Anthropic content
+// blocks. Inline plain-text documents are sent with a text source.
+func toAnthropicContentBlocks(parts []ContentPart) ([]anthropicContentBlock, error) {
+	blocks := make([]anthropicContentBlock, 0, len(parts))
+	for _, part := range parts {
+		switch part.Type {
+		case ContentTypeText:
+			blocks = append(blocks, anthropicContentBlock{Type: "text", Text: part.Text})
+		case ContentTypeImageURL:
+			blocks = append(blocks, anthropicContentBlock{Type: "image", Source: &anthropicSource{Type: "url", URL: part.URL}})
+		case ContentTypeImage:
+			blocks = append(blocks, anthropicContentBlock{
+				Type:   "image",
+				Source: &anthropicSource{Type: "base64", MediaType: part.MediaType, Data: part.Data},
+			})
+		case ContentTypeDocument:
+			block := anthropicContentBlock{Type: "document", Title: part.Name}
+			switch {
+			case part.Data == "":
+				block.Source = &anthropicSource{Type: "url", URL: part.URL}
+			case part.isTextDocument():
+				text, err := base64.StdEncoding.DecodeString(part.Data)
+				if err != nil {
+					return nil, fmt.Errorf("invalid document data: %w", err)
+				}
+				block.Source = &anthropicSource{Type: "text", MediaType: "text/plain", Data: string(text)}
+			default:
+				block.Source = &anthropicSource{Type: "base64", MediaType: part.MediaType, Data: part.Data}
+			}
+			blocks = append(blocks, block)
+		default:
+			return nil, fmt.Errorf("anthropic provider does not support %s content parts", part.Type)
+		}
+	}
+	return blocks, nil
+}
+
+func (p *AnthropicProvider) callAPI(ctx context.Context, req anthropicRequest) (*anthropicResponse, error) {
+	httpResp, err := p.doRequest(ctx, req)
 	if err != nil {
 		return nil, err
 	}
+	defer httpResp.Body.Close()
 
-	var text string
-	if len(resp.Content) > 0 {
-		text = resp.Content[0].Text
+	var resp anthropicResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, err
 	}
 
-	return &ChatResponse{
-		Message: Message{
-			Role:    resp.Role,
-			Content: text,
-		},
-		TokensUsed:   resp.Usage.InputTokens + resp.Usage.OutputTokens,
-		FinishReason: resp.StopReason,
-		Model:        resp.Model,
-	}, nil
+	return &resp, nil
 }
 
-func (p *AnthropicProvider) callAPI(ctx context.Context, req anthropicRequest) (*anthropicResponse, error) {
+// doRequest sends a messages request and returns the response once the status
+// has been checked. The caller is responsible for closing the body.
+func (p *AnthropicProvider) doRequest(ctx context.Context, req anthropicRequest) (*http.Response, error) {
 	data, err := json.Marshal(req)
 	if err != nil {
 		return nil, err
@@ -157,17 +376,147 @@ func (p *AnthropicProvider) callAPI(ctx context.Context, req anthropicRequest) (
 	if err != nil {
 		return nil, err
 	}
-	defer httpResp.Body.Close()
 
 	if httpResp.StatusCode != http.StatusOK {
+		defer httpResp.Body.Close()
 		body, _ := io.ReadAll(httpResp.Body)
 		return nil, fmt.Errorf("anthropic API error (status %d): %s", httpResp.StatusCode, string(body))
 	}
 
-	var resp anthropicResponse
-	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+	return httpResp, nil
+}
+
+// anthropicStreamEvent covers the fields used from Anthropic's SSE events
+type anthropicStreamEvent struct {
+	Type         string                `json:"type"`
+	Index        int                   `json:"index"`
+	ContentBlock anthropicContentBlock `json:"content_block"`
+	Message      struct {
+		Model string         `json:"model"`
+		Usage anthropicUsage `json:"usage"`
+	} `json:"message"`
+	Delta struct {
+		Type        string `json:"type"`
+		Text        string `json:"text"`
+		PartialJSON string `json:"partial_json"`
+		StopReason  string `json:"stop_reason"`
+	} `json:"delta"`
+	Usage anthropicUsage `json:"usage"`
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// StreamChat streams a chat response using Anthropic Claude (server-sent events)
+func (p *AnthropicProvider) StreamChat(ctx context.Context, req *ChatRequest) (<-chan ChatChunk, error) {
+	anthropicReq, err := p.buildChatRequest(req)
+	if err != nil {
 		return nil, err
 	}
+	anthropicReq.Stream = true
 
-	return &resp, nil
+	httpResp, err := p.doRequest(ctx, anthropicReq)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan ChatChunk, 16)
+
+	go func() {
+		out := &chunkSender{ctx: ctx, ch: ch}
+		defer out.close()
+		defer httpResp.Body.Close()
+
+		final := ChatChunk{Done: true}
+		var usage anthropicUsage
+		var streamErr error
+		finished := false
+
+		// Tool calls are assembled from content_block_start plus input_json_delta
+		// fragments. The input of the structured output tool streams as content.
+		var toolCalls []ToolCall
+		toolIndex := make(map[int]int)
+		responseTool := anthropicResponseTool(req)
+		responseIndex := -1
+
+		err := readSSE(httpResp.Body, func(_, data string) bool {
+			var event anthropicStreamEvent
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				streamErr = fmt.Errorf("anthropic stream decode error: %w", err)
+				return false
+			}
+
+			switch event.Type {
+			case "message_start":
+				final.Model = event.Message.Model
+				usage = event.Message.Usage
+			case "content_block_start":
+				if event.ContentBlock.Type == "tool_use" && event.ContentBlock.Name == responseTool {
+					responseIndex = event.Index
+				} else if event.ContentBlock.Type == "tool_use" {
+					toolIndex[event.Index] = len(toolCalls)
+					toolCalls = append(toolCalls, ToolCall{
+						ID:   event.ContentBlock.ID,
+						Name: event.ContentBlock.Name,
+					})
+				}
+			case "content_block_delta":
+				switch event.Delta.Type {
+				case "text_delta":
+					if event.Delta.Text != "" && responseTool == "" {
+						return out.send(ChatChunk{Content: event.Delta.Text, Model: final.Model})
+					}
+				case "input_json_delta":
+					if event.Index == responseIndex && event.Delta.PartialJSON != "" {
+						return out.send(ChatChunk{Content: event.Delta.PartialJSON, Model: final.Model})
+					}
+					if i, ok := toolIndex[event.Index]; ok {
+						toolCalls[i].Arguments += event.Delta.PartialJSON
+					}
+				}
+			case "message_delta":
+				if event.Delta.StopReason != "" {
+					final.FinishReason = event.Delta.StopReason
+				}
+				if responseIndex >= 0 && final.FinishReason == "tool_use" {
+					final.FinishReason = "end_turn"
+				}
+				usage.OutputTokens = event.Usage.OutputTokens
+			case "message_stop":
+				finished = true
+				return false
+			case "error":
+				streamErr = fmt.Errorf("anthropic stream error (%s): %s", event.Error.Type, event.Error.Message)
+				return false
+			}
+			return true
+		})
+		if err != nil {
+			streamErr = fmt.Errorf("anthropic stream error: %w", err)
+		}
+		if streamErr == nil && !finished && ctx.Err() == nil {
+			streamErr = fmt.Errorf("anthropic stream ended before message_stop")
+		}
+		if streamErr != nil {
+			out.send(ChatChunk{Err: streamErr})
+			return
+		}
+		if !finished {
+			return
+		}
+
+		for i := range toolCalls {
+			if toolCalls[i].Arguments == "" {
+				toolCalls[i].Arguments = "{}"
+			}
+		}
+
+		final.Usage = usage.usage()
+		final.TokensUsed = final.Usage.TotalTokens
+		final.ToolCalls = toolCalls
+		out.send(final)
+	}()
+
+	return ch, nil
 }
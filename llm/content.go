@@ -0,0 +1,151 @@
+package llm
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// ContentType identifies the kind of a ContentPart
+type ContentType string
+
+const (
+	// ContentTypeText is plain text
+	ContentTypeText ContentType = "text"
+
+	// ContentTypeImageURL is an image the provider fetches from a URL
+	ContentTypeImageURL ContentType = "image_url"
+
+	// ContentTypeImage is an image sent inline as base64 data
+	ContentTypeImage ContentType = "image"
+
+	// ContentTypeDocument is a document, such as a PDF, sent inline as base64
+	// data or referenced by URL
+	ContentTypeDocument ContentType = "document"
+)
+
+// ContentPart is one part of a multi-modal message
+type ContentPart struct {
+	Type ContentType `json:"type"`
+
+	// Text is the content of text parts
+	Text string `json:"text,omitempty"`
+
+	// URL locates image_url parts and documents that are not sent inline
+	URL string `json:"url,omitempty"`
+
+	// MediaType is the MIME type of inline data, e.g. "image/png" or
+	// "application/pdf"
+	MediaType string `json:"media_type,omitempty"`
+
+	// Data is base64-encoded inline image or document content
+	Data string `json:"data,omitempty"`
+
+	// Detail is the image resolution hint for OpenAI: "low", "high" or "auto"
+	Detail string `json:"detail,omitempty"`
+
+	// Name is the file name of a document (optional)
+	Name string `json:"name,omitempty"`
+}
+
+// TextPart creates a text content part
+func TextPart(text string) ContentPart {
+	return ContentPart{Type: ContentTypeText, Text: text}
+}
+
+// ImageURLPart creates an image part referencing a URL
+func ImageURLPart(url string) ContentPart {
+	return ContentPart{Type: ContentTypeImageURL, URL: url}
+}
+
+// ImagePart creates an inline image part from raw image bytes
+func ImagePart(mediaType string, data []byte) ContentPart {
+	return ContentPart{Type: ContentTypeImage, MediaType: mediaType, Data: base64.StdEncoding.EncodeToString(data)}
+}
+
+// DocumentPart creates an inline document part from raw document bytes
+func DocumentPart(name, mediaType string, data []byte) ContentPart {
+	return ContentPart{Type: ContentTypeDocument, Name: name, MediaType: mediaType, Data: base64.StdEncoding.EncodeToString(data)}
+}
+
+// Validate checks that the part has the fields its type requires
+func (p ContentPart) Validate() error {
+	switch p.Type {
+	case ContentTypeText:
+		return nil
+	case ContentTypeImageURL:
+		if p.URL == "" {
+			return fmt.Errorf("image_url part requires a URL")
+		}
+	case ContentTypeImage:
+		if p.Data == "" || p.MediaType == "" {
+			return fmt.Errorf("image part requires data and a media type")
+		}
+	case ContentTypeDocument:
+		if p.URL == "" && (p.Data == "" || p.MediaType == "") {
+			return fmt.Errorf("document part requires a URL, or data and a media type")
+		}
+	default:
+		return fmt.Errorf("unknown content part type %q", p.Type)
+	}
+	return nil
+}
+
+// DataURL returns inline data as a data: URL, or the part's URL when it
+// has no inline data
+func (p ContentPart) DataURL() string {
+	if p.Data == "" {
+		return p.URL
+	}
+	return "data:" + p.MediaType + ";base64," + p.Data
+}
+
+// isTextDocument reports whether the part is an inline plain-text document,
+// which providers without document support receive as text
+func (p ContentPart) isTextDocument() bool {
+	return p.Type == ContentTypeDocument && p.Data != "" && strings.HasPrefix(p.MediaType, "text/")
+}
+
+// documentText decodes an inline plain-text document, prefixed with its name
+func (p ContentPart) documentText() (string, error) {
+	data, err := base64.StdEncoding.DecodeString(p.Data)
+	if err != nil {
+		return "", fmt.Errorf("invalid document data: %w", err)
+	}
+	if p.Name == "" {
+		return string(data), nil
+	}
+	return p.Name + ":\n" + string(data), nil
+}
+
+// ContentParts returns the message content as parts: Content, if set, as a
+// leading text part followed by Parts
+func (m Message) ContentParts() []ContentPart {
+	if m.Content == "" {
+		return m.Parts
+	}
+	parts := make([]ContentPart, 0, len(m.Parts)+1)
+	parts = append(parts, TextPart(m.Content))
+	return append(parts, m.Parts...)
+}
+
+// HasMedia reports whether the message has parts other than text
+func (m Message) HasMedia() bool {
+	for _, part := range m.Parts {
+		if part.Type != ContentTypeText {
+			return true
+		}
+	}
+	return false
+}
+
+// Text returns the message's text: Content followed by any text parts
+func (m Message) Text() string {
+	texts := make([]string, 0, len(m.Parts)+1)
+	for _, part := range m.ContentParts() {
+		if part.Type == ContentTypeText && part.Text != "" {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
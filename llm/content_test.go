@@ -0,0 +1,226 @@
+package llm
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var pngBytes = []byte{0x89, 'P', 'N', 'G'}
+
+func TestContentPart_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		part    ContentPart
+		wantErr bool
+	}{
+		{name: "text", part: TextPart("hi")},
+		{name: "image url", part: ImageURLPart("https://example.com/cat.png")},
+		{name: "inline image", part: ImagePart("image/png", pngBytes)},
+		{name: "inline document", part: DocumentPart("report.pdf", "application/pdf", []byte("%PDF"))},
+		{name: "document url", part: ContentPart{Type: ContentTypeDocument, URL: "https://example.com/report.pdf"}},
+		{name: "image url without url", part: ContentPart{Type: ContentTypeImageURL}, wantErr: true},
+		{name: "image without media type", part: ContentPart{Type: ContentTypeImage, Data: "AAAA"}, wantErr: true},
+		{name: "empty document", part: ContentPart{Type: ContentTypeDocument}, wantErr: true},
+		{name: "unknown type", part: ContentPart{Type: "audio"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.part.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMessage_ContentHelpers(t *testing.T) {
+	msg := Message{
+		Role:    "user",
+		Content: "What is in this image?",
+		Parts:   []ContentPart{ImagePart("image/png", pngBytes), TextPart("Answer briefly.")},
+	}
+
+	parts := msg.ContentParts()
+	if len(parts) != 3 || parts[0].Text != "What is in this image?" || parts[1].Type != ContentTypeImage {
+		t.Errorf("unexpected parts: %+v", parts)
+	}
+	if !msg.HasMedia() {
+		t.Error("expected HasMedia to be true")
+	}
+	if msg.Text() != "What is in this image?\nAnswer briefly." {
+		t.Errorf("unexpected text: %q", msg.Text())
+	}
+	if got := parts[1].DataURL(); got != "data:image/png;base64,iVBORw==" {
+		t.Errorf("unexpected data URL: %s", got)
+	}
+
+	plain := Message{Role: "user", Content: "hi"}
+	if plain.HasMedia() || plain.Text() != "hi" {
+		t.Errorf("unexpected helpers for plain message: %v %q", plain.HasMedia(), plain.Text())
+	}
+}
+
+func TestChatRequest_ValidateParts(t *testing.T) {
+	req := &ChatRequest{
+		Messages: []Message{{Role: "user", Parts: []ContentPart{{Type: ContentTypeImage}}}},
+		Model:    "gpt-4o",
+	}
+	err := req.Validate()
+	if err == nil {
+		t.Fatal("expected error for invalid part")
+	}
+	if !strings.Contains(err.Error(), "Messages[0].Parts[0]") {
+		t.Errorf("expected the part field in the error, got %v", err)
+	}
+
+	req.Messages[0].Parts = []ContentPart{ImagePart("image/png", pngBytes)}
+	if err := req.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestOpenAIProvider_ContentParts(t *testing.T) {
+	req, err := buildOpenAIChatRequest(&ChatRequest{
+		Messages: []Message{{
+			Role:    "user",
+			Content: "Describe both",
+			Parts: []ContentPart{
+				{Type: ContentTypeImage, MediaType: "image/png", Data: "iVBORw==", Detail: "low"},
+				ImageURLPart("https://example.com/cat.png"),
+				DocumentPart("notes.txt", "text/plain", []byte("buy milk")),
+			},
+		}},
+		Model: "gpt-4o",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msg := req.Messages[0]
+	if msg.Content != "" || len(msg.MultiContent) != 4 {
+		t.Fatalf("expected multi-content only, got %+v", msg)
+	}
+	if msg.MultiContent[0].Text != "Describe both" {
+		t.Errorf("unexpected text part: %+v", msg.MultiContent[0])
+	}
+	if image := msg.MultiContent[1].ImageURL; image == nil || image.URL != "data:image/png;base64,iVBORw==" || image.Detail != "low" {
+		t.Errorf("unexpected inline image: %+v", msg.MultiContent[1])
+	}
+	if image := msg.MultiContent[2].ImageURL; image == nil || image.URL != "https://example.com/cat.png" {
+		t.Errorf("unexpected image URL: %+v", msg.MultiContent[2])
+	}
+	if msg.MultiContent[3].Text != "notes.txt:\nbuy milk" {
+		t.Errorf("unexpected document text: %q", msg.MultiContent[3].Text)
+	}
+
+	_, err = buildOpenAIChatRequest(&ChatRequest{
+		Messages: []Message{{Role: "user", Parts: []ContentPart{DocumentPart("report.pdf", "application/pdf", []byte("%PDF"))}}},
+		Model:    "gpt-4o",
+	})
+	if err == nil {
+		t.Error("expected error for PDF document")
+	}
+}
+
+func TestAnthropicProvider_ContentParts(t *testing.T) {
+	provider := NewAnthropic("test-key")
+	req, err := provider.buildChatRequest(&ChatRequest{
+		Messages: []Message{{
+			Role:    "user",
+			Content: "Summarize",
+			Parts: []ContentPart{
+				ImagePart("image/png", pngBytes),
+				ImageURLPart("https://example.com/cat.png"),
+				DocumentPart("report.pdf", "application/pdf", []byte("%PDF")),
+				DocumentPart("notes.txt", "text/plain", []byte("buy milk")),
+			},
+		}},
+		Model: "claude-3-5-sonnet-20241022",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, _ := json.Marshal(req.Messages[0].Content)
+	var blocks []map[string]any
+	if err := json.Unmarshal(data, &blocks); err != nil {
+		t.Fatalf("expected content blocks, got %s", data)
+	}
+	if len(blocks) != 5 {
+		t.Fatalf("expected 5 blocks, got %s", data)
+	}
+
+	source := func(i int) map[string]any {
+		s, _ := blocks[i]["source"].(map[string]any)
+		return s
+	}
+	if blocks[0]["type"] != "text" || blocks[0]["text"] != "Summarize" {
+		t.Errorf("unexpected text block: %v", blocks[0])
+	}
+	if blocks[1]["type"] != "image" || source(1)["type"] != "base64" || source(1)["media_type"] != "image/png" {
+		t.Errorf("unexpected image block: %v", blocks[1])
+	}
+	if blocks[2]["type"] != "image" || source(2)["type"] != "url" || source(2)["url"] != "https://example.com/cat.png" {
+		t.Errorf("unexpected image URL block: %v", blocks[2])
+	}
+	if blocks[3]["type"] != "document" || source(3)["media_type"] != "application/pdf" || blocks[3]["title"] != "report.pdf" {
+		t.Errorf("unexpected PDF block: %v", blocks[3])
+	}
+	if blocks[4]["type"] != "document" || source(4)["type"] != "text" || source(4)["data"] != "buy milk" {
+		t.Errorf("unexpected text document block: %v", blocks[4])
+	}
+}
+
+func TestOllamaProvider_ContentParts(t *testing.T) {
+	provider := NewOllama("http://localhost:11434")
+	req, err := provider.buildChatRequest(&ChatRequest{
+		Messages: []Message{{
+			Role:    "user",
+			Content: "What is this?",
+			Parts:   []ContentPart{ImagePart("image/png", pngBytes), TextPart("One word.")},
+		}},
+		Model: "llava",
+	}, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msg := req.Messages[0]
+	if msg.Content != "What is this?\n\nOne word." {
+		t.Errorf("unexpected content: %q", msg.Content)
+	}
+	if len(msg.Images) != 1 || msg.Images[0] != "iVBORw==" {
+		t.Errorf("unexpected images: %v", msg.Images)
+	}
+
+	_, err = provider.buildChatRequest(&ChatRequest{
+		Messages: []Message{{Role: "user", Parts: []ContentPart{ImageURLPart("https://example.com/cat.png")}}},
+		Model:    "llava",
+	}, false)
+	if err == nil {
+		t.Error("expected error for image URL")
+	}
+}
+
+func TestGeminiProvider_ContentParts(t *testing.T) {
+	parts := toGeminiParts(Message{
+		Role:    "user",
+		Content: "Compare",
+		Parts: []ContentPart{
+			ImagePart("image/jpeg", pngBytes),
+			{Type: ContentTypeDocument, URL: "https://example.com/report.pdf"},
+		},
+	})
+
+	if len(parts) != 3 || parts[0].Text != "Compare" {
+		t.Fatalf("unexpected parts: %+v", parts)
+	}
+	if parts[1].InlineData == nil || parts[1].InlineData.MimeType != "image/jpeg" || parts[1].InlineData.Data != "iVBORw==" {
+		t.Errorf("unexpected inline data: %+v", parts[1])
+	}
+	if parts[2].FileData == nil || parts[2].FileData.MimeType != "application/pdf" {
+		t.Errorf("unexpected file data: %+v", parts[2])
+	}
+}
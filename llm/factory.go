@@ -3,6 +3,7 @@ package llm
 import (
 	"fmt"
 	"os"
+	"strings"
 )
 
 // ProviderType represents the type of LLM provider
@@ -13,13 +14,37 @@ const (
 	ProviderTypeAnthropic ProviderType = "anthropic"
 	ProviderTypeOllama    ProviderType = "ollama"
 	ProviderTypeTupleLeap ProviderType = "tupleleap"
+	ProviderTypeGemini    ProviderType = "gemini"
+
+	// ProviderTypeOpenAICompatible is any API speaking the OpenAI chat
+	// completions protocol at BaseURL (OpenRouter, vLLM, LM Studio, LiteLLM)
+	ProviderTypeOpenAICompatible ProviderType = "openai-compatible"
+	ProviderTypeAzureOpenAI      ProviderType = "azure-openai"
 )
 
+// DefaultAzureOpenAIAPIVersion is the Azure OpenAI API version used when
+// none is configured
+const DefaultAzureOpenAIAPIVersion = "2024-10-21"
+
 // ProviderConfig contains configuration for creating providers
 type ProviderConfig struct {
 	Type   ProviderType
 	APIKey string // For cloud providers
 	BaseURL string // For Ollama or custom endpoints
+
+	// Name is the provider name of OpenAI-compatible endpoints
+	// (default "openai")
+	Name string
+
+	// Headers are added to every request to OpenAI-compatible endpoints
+	Headers map[string]string
+
+	// APIVersion is the Azure OpenAI API version
+	// (default DefaultAzureOpenAIAPIVersion)
+	APIVersion string
+
+	// ModelParameters adjusts OpenAI-compatible requests per model
+	ModelParameters map[string]ModelParameters
 }
 
 // ProviderFactory creates LLM providers
@@ -52,6 +77,18 @@ func NewProviderFactoryFromEnv() *ProviderFactory {
 		config.APIKey = os.Getenv("ANTHROPIC_API_KEY")
 	case ProviderTypeTupleLeap:
 		config.APIKey = os.Getenv("TUPLELEAP_API_KEY")
+	case ProviderTypeGemini:
+		config.APIKey = os.Getenv("GEMINI_API_KEY")
+	case ProviderTypeOpenAICompatible:
+		config.APIKey = os.Getenv("LLM_API_KEY")
+		config.Name = os.Getenv("LLM_PROVIDER_NAME")
+		config.Headers = parseHeaders(os.Getenv("LLM_HEADERS"))
+	case ProviderTypeAzureOpenAI:
+		config.APIKey = os.Getenv("AZURE_OPENAI_API_KEY")
+		config.APIVersion = os.Getenv("AZURE_OPENAI_API_VERSION")
+		if config.BaseURL == "" {
+			config.BaseURL = os.Getenv("AZURE_OPENAI_ENDPOINT")
+		}
 	}
 
 	return &ProviderFactory{config: config}
@@ -64,8 +101,52 @@ func (pf *ProviderFactory) CreateProvider() (Provider, error) {
 		if pf.config.APIKey == "" {
 			return nil, fmt.Errorf("OpenAI API key required")
 		}
+		if pf.config.BaseURL != "" {
+			return NewOpenAICompatible(OpenAICompatibleConfig{
+				APIKey:          pf.config.APIKey,
+				BaseURL:         pf.config.BaseURL,
+				Headers:         pf.config.Headers,
+				ModelParameters: DefaultOpenAIModelParameters(),
+			}), nil
+		}
 		return NewOpenAI(pf.config.APIKey), nil
 
+	case ProviderTypeOpenAICompatible:
+		if pf.config.BaseURL == "" {
+			return nil, fmt.Errorf("base URL required for OpenAI-compatible provider")
+		}
+		return NewOpenAICompatible(OpenAICompatibleConfig{
+			Name:            pf.config.Name,
+			APIKey:          pf.config.APIKey,
+			BaseURL:         pf.config.BaseURL,
+			Headers:         pf.config.Headers,
+			ModelParameters: pf.config.ModelParameters,
+		}), nil
+
+	case ProviderTypeAzureOpenAI:
+		if pf.config.APIKey == "" {
+			return nil, fmt.Errorf("Azure OpenAI API key required")
+		}
+		if pf.config.BaseURL == "" {
+			return nil, fmt.Errorf("Azure OpenAI endpoint required")
+		}
+		apiVersion := pf.config.APIVersion
+		if apiVersion == "" {
+			apiVersion = DefaultAzureOpenAIAPIVersion
+		}
+		modelParameters := pf.config.ModelParameters
+		if modelParameters == nil {
+			modelParameters = DefaultOpenAIModelParameters()
+		}
+		return NewOpenAICompatible(OpenAICompatibleConfig{
+			Name:            "azure-openai",
+			APIKey:          pf.config.APIKey,
+			BaseURL:         pf.config.BaseURL,
+			Headers:         pf.config.Headers,
+			AzureAPIVersion: apiVersion,
+			ModelParameters: modelParameters,
+		}), nil
+
 	case ProviderTypeAnthropic:
 		if pf.config.APIKey == "" {
 			return nil, fmt.Errorf("Anthropic API key required")
@@ -84,11 +165,35 @@ func (pf *ProviderFactory) CreateProvider() (Provider, error) {
 		}
 		return NewTupleLeap(pf.config.APIKey), nil
 
+	case ProviderTypeGemini:
+		if pf.config.APIKey == "" {
+			return nil, fmt.Errorf("Gemini API key required")
+		}
+		if pf.config.BaseURL != "" {
+			return NewGeminiWithBaseURL(pf.config.APIKey, pf.config.BaseURL), nil
+		}
+		return NewGemini(pf.config.APIKey), nil
+
 	default:
 		return nil, fmt.Errorf("unknown provider type: %s", pf.config.Type)
 	}
 }
 
+// parseHeaders parses comma-separated Name=Value pairs
+func parseHeaders(s string) map[string]string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(pair, "=")
+		if name = strings.TrimSpace(name); ok && name != "" {
+			headers[name] = strings.TrimSpace(value)
+		}
+	}
+	return headers
+}
+
 // MultiProviderFactory creates and manages multiple providers
 type MultiProviderFactory struct {
 	providers map[string]Provider
@@ -148,8 +253,35 @@ func CreateDefaultProviders() *MultiProviderFactory {
 		}
 	}
 
+	// Google Gemini
+	if apiKey := os.Getenv("GEMINI_API_KEY"); apiKey != "" {
+		factory.AddProvider("gemini", NewGemini(apiKey))
+	}
+
+	// Azure OpenAI
+	if apiKey, endpoint := os.Getenv("AZURE_OPENAI_API_KEY"), os.Getenv("AZURE_OPENAI_ENDPOINT"); apiKey != "" && endpoint != "" {
+		provider, err := NewProviderFactory(&ProviderConfig{
+			Type:       ProviderTypeAzureOpenAI,
+			APIKey:     apiKey,
+			BaseURL:    endpoint,
+			APIVersion: os.Getenv("AZURE_OPENAI_API_VERSION"),
+		}).CreateProvider()
+		if err == nil {
+			factory.AddProvider("azure-openai", provider)
+		}
+	}
+
 	// Ollama (always available if running locally)
 	factory.AddProvider("ollama", NewOllama(""))
 
 	return factory
 }
+
+// ToRegistry returns a Registry containing the factory's providers
+func (mpf *MultiProviderFactory) ToRegistry() *Registry {
+	registry := NewRegistry()
+	for name, provider := range mpf.providers {
+		registry.Register(name, provider)
+	}
+	return registry
+}
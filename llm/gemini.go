@@ -0,0 +1,435 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// GeminiProvider implements the Provider interface for Google Gemini
+// using the Generative Language API
+type GeminiProvider struct {
+	apiKey     string
+	httpClient *http.Client
+	baseURL    string
+}
+
+var _ HealthCheckProvider = (*GeminiProvider)(nil)
+
+// NewGemini creates a new Gemini provider
+func NewGemini(apiKey string) *GeminiProvider {
+	return NewGeminiWithBaseURL(apiKey, "https://generativelanguage.googleapis.com/v1beta")
+}
+
+// NewGeminiWithBaseURL creates a Gemini provider with a custom API endpoint
+func NewGeminiWithBaseURL(apiKey, baseURL string) *GeminiProvider {
+	return &GeminiProvider{
+		apiKey:     apiKey,
+		httpClient: &http.Client{},
+		baseURL:    baseURL,
+	}
+}
+
+// Name returns the provider name
+func (p *GeminiProvider) Name() string {
+	return "gemini"
+}
+
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
+
+// geminiPart is one part of a content: text, inline data, a file reference,
+// a function call or a function response
+type geminiPart struct {
+	Text             string                  `json:"text,omitempty"`
+	InlineData       *geminiBlob             `json:"inlineData,omitempty"`
+	FileData         *geminiFileData         `json:"fileData,omitempty"`
+	FunctionCall     *geminiFunctionCall     `json:"functionCall,omitempty"`
+	FunctionResponse *geminiFunctionResponse `json:"functionResponse,omitempty"`
+}
+
+type geminiBlob struct {
+	MimeType string `json:"mimeType"`
+	Data     string `json:"data"` // Base64-encoded
+}
+
+type geminiFileData struct {
+	MimeType string `json:"mimeType,omitempty"`
+	FileURI  string `json:"fileUri"`
+}
+
+type geminiFunctionCall struct {
+	Name string          `json:"name"`
+	Args json.RawMessage `json:"args,omitempty"`
+}
+
+type geminiFunctionResponse struct {
+	Name     string                 `json:"name"`
+	Response map[string]interface{} `json:"response"`
+}
+
+type geminiFunctionDeclaration struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty"`
+}
+
+type geminiTool struct {
+	FunctionDeclarations []geminiFunctionDeclaration `json:"functionDeclarations"`
+}
+
+type geminiToolConfig struct {
+	FunctionCallingConfig struct {
+		Mode                 string   `json:"mode"`
+		AllowedFunctionNames []string `json:"allowedFunctionNames,omitempty"`
+	} `json:"functionCallingConfig"`
+}
+
+type geminiGenerationConfig struct {
+	Temperature        float64                `json:"temperature,omitempty"`
+	MaxOutputTokens    int                    `json:"maxOutputTokens,omitempty"`
+	ResponseMimeType   string                 `json:"responseMimeType,omitempty"`
+	ResponseJSONSchema map[string]interface{} `json:"responseJsonSchema,omitempty"`
+}
+
+type geminiRequest struct {
+	Contents          []geminiContent        `json:"contents"`
+	SystemInstruction *geminiContent         `json:"systemInstruction,omitempty"`
+	GenerationConfig  geminiGenerationConfig `json:"generationConfig"`
+	Tools             []geminiTool           `json:"tools,omitempty"`
+	ToolConfig        *geminiToolConfig      `json:"toolConfig,omitempty"`
+}
+
+type geminiResponse struct {
+	Candidates []struct {
+		Content      geminiContent `json:"content"`
+		FinishReason string        `json:"finishReason"`
+	} `json:"candidates"`
+	UsageMetadata struct {
+		PromptTokenCount        int `json:"promptTokenCount"`
+		CandidatesTokenCount    int `json:"candidatesTokenCount"`
+		ThoughtsTokenCount      int `json:"thoughtsTokenCount"`
+		CachedContentTokenCount int `json:"cachedContentTokenCount"`
+		TotalTokenCount         int `json:"totalTokenCount"`
+	} `json:"usageMetadata"`
+	ModelVersion   string `json:"modelVersion"`
+	PromptFeedback struct {
+		BlockReason string `json:"blockReason"`
+	} `json:"promptFeedback"`
+}
+
+// message converts the first candidate to a Message, joining text parts and
+// collecting function calls as tool calls. Gemini does not assign call IDs,
+// so calls are numbered in order.
+func (r *geminiResponse) message() Message {
+	msg := Message{Role: "assistant"}
+	if len(r.Candidates) == 0 {
+		return msg
+	}
+	for _, part := range r.Candidates[0].Content.Parts {
+		switch {
+		case part.FunctionCall != nil:
+			args := string(part.FunctionCall.Args)
+			if args == "" {
+				args = "{}"
+			}
+			msg.ToolCalls = append(msg.ToolCalls, ToolCall{
+				ID:        fmt.Sprintf("call_%d", len(msg.ToolCalls)),
+				Name:      part.FunctionCall.Name,
+				Arguments: args,
+			})
+		default:
+			msg.Content += part.Text
+		}
+	}
+	return msg
+}
+
+// finishReason returns the finish reason of the first candidate, or the
+// block reason when the prompt was rejected
+func (r *geminiResponse) finishReason() string {
+	if len(r.Candidates) == 0 {
+		return r.PromptFeedback.BlockReason
+	}
+	return r.Candidates[0].FinishReason
+}
+
+// usage converts the usage metadata. Thinking tokens are billed as output,
+// so they count as completion tokens. The total is the sum of the prompt
+// and completion counts when it is not reported.
+func (r *geminiResponse) usage() Usage {
+	metadata := r.UsageMetadata
+	usage := newUsage(metadata.PromptTokenCount, metadata.CandidatesTokenCount+metadata.ThoughtsTokenCount, metadata.CachedContentTokenCount)
+	if metadata.TotalTokenCount > 0 {
+		usage.TotalTokens = metadata.TotalTokenCount
+	}
+	return usage
+}
+
+// GenerateCompletion generates a text completion using Gemini
+func (p *GeminiProvider) GenerateCompletion(ctx context.Context, req *CompletionRequest) (*CompletionResponse, error) {
+	geminiReq := geminiRequest{
+		Contents: []geminiContent{
+			{Role: "user", Parts: []geminiPart{{Text: req.UserPrompt}}},
+		},
+		SystemInstruction: geminiSystemInstruction(req.SystemPrompt),
+		GenerationConfig: geminiGenerationConfig{
+			Temperature:     req.Temperature,
+			MaxOutputTokens: req.MaxTokens,
+		},
+	}
+
+	model := p.model(req.Model)
+	resp, err := p.callAPI(ctx, model, geminiReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CompletionResponse{
+		Text:         resp.message().Content,
+		TokensUsed:   resp.usage().TotalTokens,
+		Usage:        resp.usage(),
+		FinishReason: resp.finishReason(),
+		Model:        p.responseModel(resp, model),
+	}, nil
+}
+
+// GenerateChat generates a chat response using Gemini
+func (p *GeminiProvider) GenerateChat(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+	model := p.model(req.Model)
+	resp, err := p.callAPI(ctx, model, p.buildChatRequest(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChatResponse{
+		Message:      resp.message(),
+		TokensUsed:   resp.usage().TotalTokens,
+		Usage:        resp.usage(),
+		FinishReason: resp.finishReason(),
+		Model:        p.responseModel(resp, model),
+	}, nil
+}
+
+// HealthCheck verifies the API key by listing the available models
+func (p *GeminiProvider) HealthCheck(ctx context.Context) error {
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", p.baseURL+"/models?pageSize=1", nil)
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("x-goog-api-key", p.apiKey)
+
+	httpResp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("gemini health check failed: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(httpResp.Body)
+		return fmt.Errorf("gemini API error (status %d): %s", httpResp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// buildChatRequest converts a chat request to the Gemini wire format.
+// Gemini takes system messages as a separate system instruction, calls the
+// assistant role "model", and expects tool results as functionResponse parts
+// inside a user turn, named after the function that was called.
+func (p *GeminiProvider) buildChatRequest(req *ChatRequest) geminiRequest {
+	contents := make([]geminiContent, 0, len(req.Messages))
+	var systemPrompt string
+	toolNames := make(map[string]string)
+
+	for _, msg := range req.Messages {
+		switch {
+		case msg.Role == "system":
+			if systemPrompt != "" {
+				systemPrompt += "\n\n"
+			}
+			systemPrompt += msg.Content
+
+		case msg.Role == "tool":
+			name := msg.Name
+			if name == "" {
+				name = toolNames[msg.ToolCallID]
+			}
+			part := geminiPart{FunctionResponse: &geminiFunctionResponse{
+				Name:     name,
+				Response: geminiToolResult(msg.Content),
+			}}
+			// Consecutive tool results share a single user turn
+			if n := len(contents); n > 0 && contents[n-1].Role == "user" && contents[n-1].Parts[0].FunctionResponse != nil {
+				contents[n-1].Parts = append(contents[n-1].Parts, part)
+				continue
+			}
+			contents = append(contents, geminiContent{Role: "user", Parts: []geminiPart{part}})
+
+		case msg.Role == "assistant":
+			parts := make([]geminiPart, 0, len(msg.ToolCalls)+1)
+			if msg.Content != "" || len(msg.ToolCalls) == 0 {
+				parts = append(parts, geminiPart{Text: msg.Content})
+			}
+			for _, call := range msg.ToolCalls {
+				toolNames[call.ID] = call.Name
+				args := json.RawMessage(call.Arguments)
+				if len(args) == 0 {
+					args = json.RawMessage("{}")
+				}
+				parts = append(parts, geminiPart{FunctionCall: &geminiFunctionCall{Name: call.Name, Args: args}})
+			}
+			contents = append(contents, geminiContent{Role: "model", Parts: parts})
+
+		default:
+			contents = append(contents, geminiContent{Role: "user", Parts: toGeminiParts(msg)})
+		}
+	}
+
+	geminiReq := geminiRequest{
+		Contents:          contents,
+		SystemInstruction: geminiSystemInstruction(systemPrompt),
+		GenerationConfig: geminiGenerationConfig{
+			Temperature:     req.Temperature,
+			MaxOutputTokens: req.MaxTokens,
+		},
+	}
+
+	if len(req.Tools) > 0 {
+		declarations := make([]geminiFunctionDeclaration, 0, len(req.Tools))
+		for _, tool := range req.Tools {
+			declarations = append(declarations, geminiFunctionDeclaration{
+				Name:        tool.Name,
+				Description: tool.Description,
+				Parameters:  toolParameters(tool),
+			})
+		}
+		geminiReq.Tools = []geminiTool{{FunctionDeclarations: declarations}}
+	}
+
+	if req.ToolChoice != "" {
+		config := &geminiToolConfig{}
+		switch req.ToolChoice {
+		case "auto":
+			config.FunctionCallingConfig.Mode = "AUTO"
+		case "none":
+			config.FunctionCallingConfig.Mode = "NONE"
+		case "required":
+			config.FunctionCallingConfig.Mode = "ANY"
+		default:
+			config.FunctionCallingConfig.Mode = "ANY"
+			config.FunctionCallingConfig.AllowedFunctionNames = []string{req.ToolChoice}
+		}
+		geminiReq.ToolConfig = config
+	}
+
+	if req.ResponseFormat.isJSON() {
+		geminiReq.GenerationConfig.ResponseMimeType = "application/json"
+		if req.ResponseFormat.Type == ResponseFormatJSONSchema {
+			geminiReq.GenerationConfig.ResponseJSONSchema = req.ResponseFormat.Schema
+		}
+	}
+
+	return geminiReq
+}
+
+// toGeminiParts converts a message's content to Gemini parts. Inline images
+// and documents are sent as inline data and URLs as file references.
+func toGeminiParts(msg Message) []geminiPart {
+	if len(msg.Parts) == 0 {
+		return []geminiPart{{Text: msg.Content}}
+	}
+
+	parts := make([]geminiPart, 0, len(msg.Parts)+1)
+	for _, part := range msg.ContentParts() {
+		switch {
+		case part.Type == ContentTypeText:
+			parts = append(parts, geminiPart{Text: part.Text})
+		case part.Data != "":
+			parts = append(parts, geminiPart{InlineData: &geminiBlob{MimeType: part.MediaType, Data: part.Data}})
+		default:
+			mimeType := part.MediaType
+			if mimeType == "" {
+				mimeType = mime.TypeByExtension(path.Ext(part.URL))
+			}
+			parts = append(parts, geminiPart{FileData: &geminiFileData{MimeType: mimeType, FileURI: part.URL}})
+		}
+	}
+	return parts
+}
+
+// geminiSystemInstruction wraps a system prompt, returning nil when it is empty
+func geminiSystemInstruction(prompt string) *geminiContent {
+	if prompt == "" {
+		return nil
+	}
+	return &geminiContent{Parts: []geminiPart{{Text: prompt}}}
+}
+
+// geminiToolResult converts a tool result to a functionResponse payload,
+// which must be a JSON object. Other results are wrapped under "result".
+func geminiToolResult(content string) map[string]interface{} {
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(content), &object); err == nil && object != nil {
+		return object
+	}
+	return map[string]interface{}{"result": content}
+}
+
+// model returns the requested model, or the provider default
+func (p *GeminiProvider) model(model string) string {
+	if model == "" {
+		return DefaultModel(p.Name())
+	}
+	return model
+}
+
+// responseModel returns the model version reported by the API, falling back
+// to the requested model
+func (p *GeminiProvider) responseModel(resp *geminiResponse, model string) string {
+	if resp.ModelVersion != "" {
+		return resp.ModelVersion
+	}
+	return model
+}
+
+func (p *GeminiProvider) callAPI(ctx context.Context, model string, req geminiRequest) (*geminiResponse, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := p.baseURL + "/models/" + url.PathEscape(model) + ":generateContent"
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-goog-api-key", p.apiKey)
+
+	httpResp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(httpResp.Body)
+		return nil, fmt.Errorf("gemini API error (status %d): %s", httpResp.StatusCode, string(body))
+	}
+
+	var resp geminiResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,237 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newGeminiServer starts a stand-in for the generateContent API that checks
+// the API key, decodes the request into got and replies with resp
+func newGeminiServer(t *testing.T, got *geminiRequest, resp any) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-goog-api-key") != "test-key" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"code":403,"message":"API key not valid","status":"PERMISSION_DENIED"}}`))
+			return
+		}
+		if r.Method == "GET" && r.URL.Path == "/models" {
+			w.Write([]byte(`{"models":[{"name":"models/gemini-1.5-flash"}]}`))
+			return
+		}
+		if r.Method != "POST" || !strings.HasSuffix(r.URL.Path, ":generateContent") {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if got != nil {
+			json.NewDecoder(r.Body).Decode(got)
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func geminiTextResponse(text string) map[string]any {
+	return map[string]any{
+		"candidates": []map[string]any{{
+			"content":      map[string]any{"role": "model", "parts": []map[string]any{{"text": text}}},
+			"finishReason": "STOP",
+		}},
+		"usageMetadata": map[string]any{"promptTokenCount": 12, "candidatesTokenCount": 5, "cachedContentTokenCount": 8, "totalTokenCount": 17},
+		"modelVersion":  "gemini-1.5-flash-002",
+	}
+}
+
+func TestGeminiProvider_GenerateCompletion(t *testing.T) {
+	var path string
+	var got geminiRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&got)
+		json.NewEncoder(w).Encode(geminiTextResponse("Paris"))
+	}))
+	defer server.Close()
+
+	provider := NewGeminiWithBaseURL("test-key", server.URL)
+	resp, err := provider.GenerateCompletion(context.Background(), &CompletionRequest{
+		SystemPrompt: "Answer in one word.",
+		UserPrompt:   "What is the capital of France?",
+		Temperature:  0,
+		MaxTokens:    10,
+		Model:        "gemini-1.5-pro",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if path != "/models/gemini-1.5-pro:generateContent" {
+		t.Errorf("unexpected path: %s", path)
+	}
+	if got.SystemInstruction == nil || got.SystemInstruction.Parts[0].Text != "Answer in one word." {
+		t.Errorf("expected system instruction, got %+v", got.SystemInstruction)
+	}
+	if len(got.Contents) != 1 || got.Contents[0].Role != "user" || got.Contents[0].Parts[0].Text != "What is the capital of France?" {
+		t.Errorf("unexpected contents: %+v", got.Contents)
+	}
+	if got.GenerationConfig.MaxOutputTokens != 10 {
+		t.Errorf("expected maxOutputTokens=10, got %d", got.GenerationConfig.MaxOutputTokens)
+	}
+
+	if resp.Text != "Paris" {
+		t.Errorf("expected Paris, got %q", resp.Text)
+	}
+	if resp.TokensUsed != 17 {
+		t.Errorf("expected 17 tokens, got %d", resp.TokensUsed)
+	}
+	if want := (Usage{PromptTokens: 12, CompletionTokens: 5, CachedTokens: 8, TotalTokens: 17}); resp.Usage != want {
+		t.Errorf("expected usage %+v, got %+v", want, resp.Usage)
+	}
+	if resp.FinishReason != "STOP" {
+		t.Errorf("expected STOP, got %s", resp.FinishReason)
+	}
+	if resp.Model != "gemini-1.5-flash-002" {
+		t.Errorf("expected model version, got %s", resp.Model)
+	}
+}
+
+func TestGeminiProvider_GenerateChat(t *testing.T) {
+	var got geminiRequest
+	server := newGeminiServer(t, &got, geminiTextResponse("It is 18°C in Paris."))
+
+	provider := NewGeminiWithBaseURL("test-key", server.URL)
+	resp, err := provider.GenerateChat(context.Background(), &ChatRequest{
+		Messages: []Message{
+			{Role: "system", Content: "You are a weather assistant."},
+			{Role: "user", Content: "Hi"},
+			{Role: "assistant", Content: "Hello! How can I help?"},
+			{Role: "user", Content: "Weather in Paris?"},
+			{Role: "assistant", ToolCalls: []ToolCall{{ID: "call_0", Name: "get_weather", Arguments: `{"city":"Paris"}`}}},
+			{Role: "tool", ToolCallID: "call_0", Content: `{"temp_c":18}`},
+		},
+		Tools:      []ToolDefinition{weatherTool},
+		ToolChoice: "auto",
+		Model:      "gemini-1.5-flash",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.SystemInstruction == nil || got.SystemInstruction.Parts[0].Text != "You are a weather assistant." {
+		t.Errorf("expected system instruction, got %+v", got.SystemInstruction)
+	}
+
+	roles := make([]string, len(got.Contents))
+	for i, content := range got.Contents {
+		roles[i] = content.Role
+	}
+	if strings.Join(roles, ",") != "user,model,user,model,user" {
+		t.Fatalf("unexpected roles: %v", roles)
+	}
+
+	call := got.Contents[3].Parts[0].FunctionCall
+	if call == nil || call.Name != "get_weather" || string(call.Args) != `{"city":"Paris"}` {
+		t.Errorf("unexpected function call: %+v", got.Contents[3].Parts)
+	}
+	result := got.Contents[4].Parts[0].FunctionResponse
+	if result == nil || result.Name != "get_weather" || result.Response["temp_c"] != float64(18) {
+		t.Errorf("unexpected function response: %+v", got.Contents[4].Parts)
+	}
+
+	if len(got.Tools) != 1 || got.Tools[0].FunctionDeclarations[0].Name != "get_weather" {
+		t.Errorf("unexpected tools: %+v", got.Tools)
+	}
+	if got.ToolConfig == nil || got.ToolConfig.FunctionCallingConfig.Mode != "AUTO" {
+		t.Errorf("unexpected tool config: %+v", got.ToolConfig)
+	}
+
+	if resp.Message.Role != "assistant" || resp.Message.Content != "It is 18°C in Paris." {
+		t.Errorf("unexpected message: %+v", resp.Message)
+	}
+	if resp.TokensUsed != 17 {
+		t.Errorf("expected 17 tokens, got %d", resp.TokensUsed)
+	}
+}
+
+func TestGeminiProvider_ToolCallResponse(t *testing.T) {
+	server := newGeminiServer(t, nil, map[string]any{
+		"candidates": []map[string]any{{
+			"content": map[string]any{"role": "model", "parts": []map[string]any{
+				{"functionCall": map[string]any{"name": "get_weather", "args": map[string]any{"city": "Paris"}}},
+			}},
+			"finishReason": "STOP",
+		}},
+		"usageMetadata": map[string]any{"promptTokenCount": 20, "candidatesTokenCount": 4},
+	})
+
+	provider := NewGeminiWithBaseURL("test-key", server.URL)
+	resp, err := provider.GenerateChat(context.Background(), &ChatRequest{
+		Messages: []Message{{Role: "user", Content: "Weather in Paris?"}},
+		Tools:    []ToolDefinition{weatherTool},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Message.ToolCalls) != 1 {
+		t.Fatalf("expected 1 tool call, got %d", len(resp.Message.ToolCalls))
+	}
+	call := resp.Message.ToolCalls[0]
+	if call.ID == "" || call.Name != "get_weather" || call.Arguments != `{"city":"Paris"}` {
+		t.Errorf("unexpected tool call: %+v", call)
+	}
+	if resp.TokensUsed != 24 {
+		t.Errorf("expected usage summed to 24, got %d", resp.TokensUsed)
+	}
+	if resp.Model != DefaultModel("gemini") {
+		t.Errorf("expected default model, got %s", resp.Model)
+	}
+}
+
+func TestGeminiProvider_Errors(t *testing.T) {
+	server := newGeminiServer(t, nil, geminiTextResponse("unused"))
+
+	provider := NewGeminiWithBaseURL("wrong-key", server.URL)
+	_, err := provider.GenerateCompletion(context.Background(), &CompletionRequest{UserPrompt: "hi", Model: "gemini-1.5-flash"})
+	if err == nil || !strings.Contains(err.Error(), "status 403") {
+		t.Errorf("expected status 403 error, got %v", err)
+	}
+	if err := provider.HealthCheck(context.Background()); err == nil {
+		t.Error("expected health check to fail with an invalid key")
+	}
+
+	if err := NewGeminiWithBaseURL("test-key", server.URL).HealthCheck(context.Background()); err != nil {
+		t.Errorf("unexpected health check error: %v", err)
+	}
+}
+
+func TestProviderFactory_Gemini(t *testing.T) {
+	t.Setenv("LLM_PROVIDER", "gemini")
+	t.Setenv("LLM_BASE_URL", "")
+	t.Setenv("GEMINI_API_KEY", "test-key")
+
+	provider, err := NewProviderFactoryFromEnv().CreateProvider()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.Name() != "gemini" {
+		t.Errorf("expected gemini provider, got %s", provider.Name())
+	}
+
+	t.Setenv("GEMINI_API_KEY", "")
+	if _, err := NewProviderFactoryFromEnv().CreateProvider(); err == nil {
+		t.Error("expected error without an API key")
+	}
+}
+
+func TestGeminiGenerationConfig_OmitsZeroTemperature(t *testing.T) {
+	data, _ := json.Marshal(geminiGenerationConfig{MaxOutputTokens: 10})
+	if strings.Contains(string(data), "temperature") {
+		t.Errorf("expected temperature to be omitted, got %s", data)
+	}
+}
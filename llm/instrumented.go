@@ -0,0 +1,218 @@
+package llm
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ranganaths/minion/observability"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentedConfig configures an InstrumentedProvider
+type InstrumentedConfig struct {
+	// CostTracker records the cost of each successful call
+	// (default: observability.GetCostTracker())
+	CostTracker *observability.CostTracker
+
+	// Metrics records request counts, latency, tokens and cost
+	// (default: observability.GetMetrics())
+	Metrics *observability.MetricsCollector
+
+	// Tracer records a span for each call (default: observability.GetTracer())
+	Tracer *observability.Tracer
+
+	// Attribution returns the agent and session a call's cost is recorded
+	// against (optional)
+	Attribution func(ctx context.Context) (agentID, sessionID string)
+}
+
+// InstrumentedProvider wraps a Provider so that every call is traced and its
+// token usage, cost and latency are recorded. Costs are looked up by the
+// provider's name and the requested model, so wrap each provider of a Router
+// rather than the Router itself. Requests without a model are recorded
+// under the model of the response, or the provider's default model.
+type InstrumentedProvider struct {
+	provider    Provider
+	costs       *observability.CostTracker
+	metrics     *observability.MetricsCollector
+	tracer      *observability.Tracer
+	attribution func(ctx context.Context) (agentID, sessionID string)
+}
+
+var (
+	_ StreamingProvider   = (*InstrumentedProvider)(nil)
+	_ HealthCheckProvider = (*InstrumentedProvider)(nil)
+)
+
+// NewInstrumentedProvider wraps provider, recording to the global
+// observability stack unless cfg names other collectors
+func NewInstrumentedProvider(provider Provider, cfg ...InstrumentedConfig) *InstrumentedProvider {
+	var config InstrumentedConfig
+	if len(cfg) > 0 {
+		config = cfg[0]
+	}
+	if config.CostTracker == nil {
+		config.CostTracker = observability.GetCostTracker()
+	}
+	if config.Metrics == nil {
+		config.Metrics = observability.GetMetrics()
+	}
+	if config.Tracer == nil {
+		config.Tracer = observability.GetTracer()
+	}
+
+	return &InstrumentedProvider{
+		provider:    provider,
+		costs:       config.CostTracker,
+		metrics:     config.Metrics,
+		tracer:      config.Tracer,
+		attribution: config.Attribution,
+	}
+}
+
+// Name returns the name of the wrapped provider
+func (p *InstrumentedProvider) Name() string {
+	return p.provider.Name()
+}
+
+// Unwrap returns the wrapped provider
+func (p *InstrumentedProvider) Unwrap() Provider {
+	return p.provider
+}
+
+// GenerateCompletion generates a completion with the wrapped provider
+func (p *InstrumentedProvider) GenerateCompletion(ctx context.Context, req *CompletionRequest) (*CompletionResponse, error) {
+	ctx, call := p.start(ctx, req.Model)
+	resp, err := p.provider.GenerateCompletion(ctx, req)
+	if err != nil {
+		call.end(ctx, "", Usage{}, err)
+		return nil, err
+	}
+	call.end(ctx, resp.Model, resp.Usage, nil)
+	return resp, nil
+}
+
+// GenerateChat generates a chat response with the wrapped provider
+func (p *InstrumentedProvider) GenerateChat(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+	ctx, call := p.start(ctx, req.Model)
+	resp, err := p.provider.GenerateChat(ctx, req)
+	if err != nil {
+		call.end(ctx, "", Usage{}, err)
+		return nil, err
+	}
+	call.end(ctx, resp.Model, resp.Usage, nil)
+	return resp, nil
+}
+
+// StreamChat streams a chat response from the wrapped provider. The call is
+// recorded when the stream ends, with the usage of its final chunk.
+// Providers that cannot stream answer with a single chunk.
+func (p *InstrumentedProvider) StreamChat(ctx context.Context, req *ChatRequest) (<-chan ChatChunk, error) {
+	ctx, call := p.start(ctx, req.Model)
+
+	var chunks <-chan ChatChunk
+	if streaming, ok := p.provider.(StreamingProvider); ok {
+		var err error
+		if chunks, err = streaming.StreamChat(ctx, req); err != nil {
+			call.end(ctx, "", Usage{}, err)
+			return nil, err
+		}
+	} else {
+		resp, err := p.provider.GenerateChat(ctx, req)
+		if err != nil {
+			call.end(ctx, "", Usage{}, err)
+			return nil, err
+		}
+		chunks = responseStream(resp)
+	}
+
+	ch := make(chan ChatChunk, 16)
+
+	go func() {
+		out := &chunkSender{ctx: ctx, ch: ch}
+		defer out.close()
+
+		var usage Usage
+		var model string
+		var streamErr error
+		done := false
+
+		for chunk := range chunks {
+			switch {
+			case chunk.Err != nil:
+				streamErr = chunk.Err
+			case chunk.Done:
+				usage = chunk.Usage
+				done = true
+			}
+			if chunk.Model != "" {
+				model = chunk.Model
+			}
+			if !out.send(chunk) {
+				break
+			}
+		}
+		if streamErr == nil && !done {
+			streamErr = ctx.Err()
+		}
+		call.end(ctx, model, usage, streamErr)
+	}()
+
+	return ch, nil
+}
+
+// HealthCheck checks the wrapped provider. Providers without health checks
+// are assumed healthy.
+func (p *InstrumentedProvider) HealthCheck(ctx context.Context) error {
+	if checker, ok := p.provider.(HealthCheckProvider); ok {
+		return checker.HealthCheck(ctx)
+	}
+	return nil
+}
+
+// instrumentedCall is a call in progress
+type instrumentedCall struct {
+	provider *InstrumentedProvider
+	model    string
+	span     trace.Span
+	started  time.Time
+}
+
+// start opens the span of a call to model
+func (p *InstrumentedProvider) start(ctx context.Context, model string) (context.Context, *instrumentedCall) {
+	ctx, span := p.tracer.StartLLMSpan(ctx, p.provider.Name(), model)
+	return ctx, &instrumentedCall{provider: p, model: model, span: span, started: time.Now()}
+}
+
+// end records the outcome of the call and closes its span. Failed calls are
+// not charged. responseModel is the model reported by the provider, used
+// when the request named none.
+func (c *instrumentedCall) end(ctx context.Context, responseModel string, usage Usage, err error) {
+	p := c.provider
+	duration := time.Since(c.started)
+	name := p.provider.Name()
+
+	if c.model == "" {
+		c.model = responseModel
+		if c.model == "" {
+			c.model = DefaultModel(name)
+		}
+		c.span.SetAttributes(attribute.String(observability.AttrLLMModel, c.model))
+	}
+
+	var cost float64
+	if err == nil {
+		var agentID, sessionID string
+		if p.attribution != nil {
+			agentID, sessionID = p.attribution(ctx)
+		}
+		cost = p.costs.RecordCostEntry(ctx, agentID, sessionID, name, c.model, usage.PromptTokens, usage.CompletionTokens)
+		p.tracer.RecordLLMTokens(c.span, usage.PromptTokens, usage.CompletionTokens, cost)
+	} else {
+		p.tracer.RecordError(c.span, err, "llm_api_error")
+	}
+
+	p.metrics.RecordLLMRequest(name, c.model, duration, usage.PromptTokens, usage.CompletionTokens, cost, err)
+	c.span.End()
+}
@@ -0,0 +1,204 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Ranganaths/minion/observability"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// usageProvider answers with a fixed usage, or fails with err. Requests
+// without a model are answered by model.
+type usageProvider struct {
+	usage Usage
+	model string
+	err   error
+}
+
+func (p *usageProvider) responseModel(requested string) string {
+	if requested != "" {
+		return requested
+	}
+	return p.model
+}
+
+func (p *usageProvider) Name() string { return "openai" }
+
+func (p *usageProvider) GenerateCompletion(ctx context.Context, req *CompletionRequest) (*CompletionResponse, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &CompletionResponse{Text: "ok", TokensUsed: p.usage.TotalTokens, Usage: p.usage, Model: p.responseModel(req.Model)}, nil
+}
+
+func (p *usageProvider) GenerateChat(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &ChatResponse{Message: Message{Role: "assistant", Content: "ok"}, TokensUsed: p.usage.TotalTokens, Usage: p.usage, Model: p.responseModel(req.Model)}, nil
+}
+
+// newTestInstrumentation returns enabled collectors with a known price for
+// openai:gpt-test
+func newTestInstrumentation(t *testing.T) (InstrumentedConfig, *prometheus.Registry) {
+	t.Helper()
+
+	costs, err := observability.NewCostTracker(observability.CostConfig{Enabled: true, Currency: "USD"})
+	if err != nil {
+		t.Fatalf("failed to create cost tracker: %v", err)
+	}
+	costs.SetPricing(observability.ModelPricing{Provider: "openai", Model: "gpt-test", PromptPricePer1K: 1, CompletionPricePer1K: 2})
+
+	registry := prometheus.NewRegistry()
+	tracer, err := observability.NewTracer(observability.TracingConfig{Enabled: false})
+	if err != nil {
+		t.Fatalf("failed to create tracer: %v", err)
+	}
+
+	return InstrumentedConfig{
+		CostTracker: costs,
+		Metrics:     observability.NewMetricsCollector(observability.MetricsConfig{Enabled: true}, registry),
+		Tracer:      tracer,
+		Attribution: func(ctx context.Context) (string, string) { return "agent-1", "session-1" },
+	}, registry
+}
+
+// counterValue sums the samples of a counter whose labels include labels
+func counterValue(t *testing.T, registry *prometheus.Registry, name string, labels map[string]string) float64 {
+	t.Helper()
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+
+	var total float64
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, metric := range family.GetMetric() {
+			for key, value := range labels {
+				found := false
+				for _, label := range metric.GetLabel() {
+					if label.GetName() == key && label.GetValue() == value {
+						found = true
+					}
+				}
+				if !found {
+					continue metrics
+				}
+			}
+			total += metric.GetCounter().GetValue()
+		}
+	}
+	return total
+}
+
+func TestInstrumentedProvider_RecordsUsage(t *testing.T) {
+	cfg, registry := newTestInstrumentation(t)
+	provider := NewInstrumentedProvider(&usageProvider{usage: newUsage(1000, 500, 200)}, cfg)
+
+	resp, err := provider.GenerateChat(context.Background(), &ChatRequest{
+		Model:    "gpt-test",
+		Messages: []Message{{Role: "user", Content: "hi"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Usage.CachedTokens != 200 {
+		t.Errorf("expected the response to pass through, got %+v", resp.Usage)
+	}
+
+	summary := cfg.CostTracker.GetTotalSummary()
+	if summary.TotalRequests != 1 || summary.TotalTokens != 1500 {
+		t.Errorf("unexpected cost summary: %+v", summary)
+	}
+	if math.Abs(summary.TotalCost-2.0) > 1e-9 || math.Abs(summary.CostByAgent["agent-1"]-2.0) > 1e-9 {
+		t.Errorf("expected $2.00 charged to agent-1, got %+v", summary)
+	}
+
+	if got := counterValue(t, registry, "minion_llm_requests_total", map[string]string{"status": "success"}); got != 1 {
+		t.Errorf("expected 1 successful request, got %v", got)
+	}
+	if got := counterValue(t, registry, "minion_llm_tokens_total", map[string]string{"type": "completion"}); got != 500 {
+		t.Errorf("expected 500 completion tokens, got %v", got)
+	}
+	if got := counterValue(t, registry, "minion_llm_cost_total", nil); math.Abs(got-2.0) > 1e-9 {
+		t.Errorf("expected cost 2.0, got %v", got)
+	}
+}
+
+func TestInstrumentedProvider_ResponseModel(t *testing.T) {
+	cfg, registry := newTestInstrumentation(t)
+	provider := NewInstrumentedProvider(&usageProvider{usage: newUsage(1000, 500, 0), model: "gpt-test"}, cfg)
+
+	// The request leaves the model to the provider
+	if _, err := provider.GenerateChat(context.Background(), &ChatRequest{
+		Messages: []Message{{Role: "user", Content: "hi"}},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if summary := cfg.CostTracker.GetTotalSummary(); math.Abs(summary.TotalCost-2.0) > 1e-9 {
+		t.Errorf("expected the response model to be charged $2.00, got %+v", summary)
+	}
+	if got := counterValue(t, registry, "minion_llm_requests_total", map[string]string{"model": "gpt-test"}); got != 1 {
+		t.Errorf("expected 1 request for gpt-test, got %v", got)
+	}
+
+	// Without a response model, the provider's default model is recorded
+	failing := NewInstrumentedProvider(&usageProvider{err: errors.New("boom")}, cfg)
+	failing.GenerateChat(context.Background(), &ChatRequest{Messages: []Message{{Role: "user", Content: "hi"}}})
+	if got := counterValue(t, registry, "minion_llm_requests_total", map[string]string{"model": DefaultModel("openai"), "status": "error"}); got != 1 {
+		t.Errorf("expected 1 failed request for the default model, got %v", got)
+	}
+}
+
+func TestInstrumentedProvider_Errors(t *testing.T) {
+	cfg, registry := newTestInstrumentation(t)
+	provider := NewInstrumentedProvider(&usageProvider{err: errors.New("boom")}, cfg)
+
+	_, err := provider.GenerateCompletion(context.Background(), &CompletionRequest{Model: "gpt-test", UserPrompt: "hi"})
+	if err == nil || err.Error() != "boom" {
+		t.Fatalf("expected the provider error, got %v", err)
+	}
+
+	if summary := cfg.CostTracker.GetTotalSummary(); summary.TotalRequests != 0 {
+		t.Errorf("failed calls should not be charged, got %+v", summary)
+	}
+	if got := counterValue(t, registry, "minion_llm_requests_total", map[string]string{"status": "error"}); got != 1 {
+		t.Errorf("expected 1 failed request, got %v", got)
+	}
+}
+
+func TestInstrumentedProvider_StreamChat(t *testing.T) {
+	cfg, registry := newTestInstrumentation(t)
+	provider := NewInstrumentedProvider(&usageProvider{usage: newUsage(100, 50, 0)}, cfg)
+
+	// The wrapped provider cannot stream, so it answers with a single chunk
+	ch, err := provider.StreamChat(context.Background(), &ChatRequest{
+		Model:    "gpt-test",
+		Messages: []Message{{Role: "user", Content: "hi"}},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat failed: %v", err)
+	}
+
+	text, final := collectChunks(t, ch)
+	if text != "ok" || final.Usage.TotalTokens != 150 {
+		t.Errorf("unexpected stream: %q %+v", text, final)
+	}
+
+	// The call is recorded before the channel closes
+	if summary := cfg.CostTracker.GetTotalSummary(); summary.TotalRequests != 1 || summary.TotalTokens != 150 {
+		t.Errorf("unexpected cost summary: %+v", summary)
+	}
+	if got := counterValue(t, registry, "minion_llm_tokens_total", map[string]string{"type": "prompt"}); got != 100 {
+		t.Errorf("expected 100 prompt tokens, got %v", got)
+	}
+}
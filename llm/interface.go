@@ -5,6 +5,7 @@ package llm
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 )
 
@@ -85,12 +86,45 @@ func (r *CompletionRequest) WithDefaults(defaultModel string, defaultMaxTokens i
 
 // CompletionResponse represents a completion response from an LLM provider.
 type CompletionResponse struct {
-	Text         string
-	TokensUsed   int
+	Text string
+
+	// TokensUsed is the total token count (Usage.TotalTokens)
+	TokensUsed int
+
+	// Usage splits the token count into prompt and completion tokens
+	Usage Usage
+
 	FinishReason string
 	Model        string
 }
 
+// Usage reports the tokens a request consumed, as counted by the provider.
+// Counts a provider does not report are zero.
+type Usage struct {
+	// PromptTokens are the input tokens, including cached ones
+	PromptTokens int
+
+	// CompletionTokens are the generated output tokens
+	CompletionTokens int
+
+	// CachedTokens are the prompt tokens read from the provider's prompt
+	// cache, which are usually billed at a discount
+	CachedTokens int
+
+	// TotalTokens is the sum of prompt and completion tokens
+	TotalTokens int
+}
+
+// newUsage builds a Usage from prompt and completion counts
+func newUsage(promptTokens, completionTokens, cachedTokens int) Usage {
+	return Usage{
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		CachedTokens:     cachedTokens,
+		TotalTokens:      promptTokens + completionTokens,
+	}
+}
+
 // ChatRequest represents a chat request.
 // Use Validate() to check the request before sending to a provider.
 type ChatRequest struct {
@@ -98,6 +132,16 @@ type ChatRequest struct {
 	Temperature float64
 	MaxTokens   int
 	Model       string
+
+	// Tools are the functions the model may call (optional)
+	Tools []ToolDefinition
+
+	// ToolChoice controls tool use: "auto" (default), "none", "required",
+	// or the name of a specific tool the model must call
+	ToolChoice string
+
+	// ResponseFormat constrains the response to JSON (optional)
+	ResponseFormat *ResponseFormat
 }
 
 // Validate checks if the chat request has valid parameters.
@@ -120,13 +164,48 @@ func (r *ChatRequest) Validate() error {
 		if msg.Role == "" {
 			return &ValidationError{Field: fmt.Sprintf("Messages[%d].Role", i), Message: "role is required"}
 		}
-		if msg.Role != "system" && msg.Role != "user" && msg.Role != "assistant" {
-			return &ValidationError{Field: fmt.Sprintf("Messages[%d].Role", i), Message: "role must be 'system', 'user', or 'assistant'"}
+		if msg.Role != "system" && msg.Role != "user" && msg.Role != "assistant" && msg.Role != "tool" {
+			return &ValidationError{Field: fmt.Sprintf("Messages[%d].Role", i), Message: "role must be 'system', 'user', 'assistant', or 'tool'"}
+		}
+		if msg.Role == "tool" && msg.ToolCallID == "" {
+			return &ValidationError{Field: fmt.Sprintf("Messages[%d].ToolCallID", i), Message: "tool messages require a tool call ID"}
+		}
+		if len(msg.ToolCalls) > 0 && msg.Role != "assistant" {
+			return &ValidationError{Field: fmt.Sprintf("Messages[%d].ToolCalls", i), Message: "only assistant messages may contain tool calls"}
+		}
+		for j, part := range msg.Parts {
+			if err := part.Validate(); err != nil {
+				return &ValidationError{Field: fmt.Sprintf("Messages[%d].Parts[%d]", i, j), Message: err.Error()}
+			}
+		}
+	}
+	// Validate tool definitions
+	for i, tool := range r.Tools {
+		if tool.Name == "" {
+			return &ValidationError{Field: fmt.Sprintf("Tools[%d].Name", i), Message: "tool name is required"}
+		}
+	}
+	if r.ToolChoice != "" && r.ToolChoice != "auto" && r.ToolChoice != "none" && r.ToolChoice != "required" && !r.hasTool(r.ToolChoice) {
+		return &ValidationError{Field: "ToolChoice", Message: fmt.Sprintf("tool choice %q does not match any tool", r.ToolChoice)}
+	}
+	if r.ResponseFormat != nil {
+		if err := r.ResponseFormat.Validate(); err != nil {
+			return &ValidationError{Field: "ResponseFormat", Message: err.Error()}
 		}
 	}
 	return nil
 }
 
+// hasTool reports whether a tool with the given name is defined on the request
+func (r *ChatRequest) hasTool(name string) bool {
+	for _, tool := range r.Tools {
+		if tool.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // WithDefaults returns a copy of the request with default values applied.
 // This does not modify the original request.
 func (r *ChatRequest) WithDefaults(defaultModel string, defaultMaxTokens int) *ChatRequest {
@@ -145,14 +224,78 @@ func (r *ChatRequest) WithDefaults(defaultModel string, defaultMaxTokens int) *C
 
 // Message represents a chat message in a conversation.
 type Message struct {
-	Role    string // "system", "user", or "assistant"
+	Role    string // "system", "user", "assistant", or "tool"
 	Content string
+
+	// Parts are multi-modal content (images and documents) sent after
+	// Content. Use ContentParts to read both.
+	Parts []ContentPart
+
+	// ToolCalls are the tool invocations requested by an assistant message
+	ToolCalls []ToolCall
+
+	// ToolCallID links a tool message to the ToolCall it answers
+	ToolCallID string
+
+	// Name is the tool name for tool messages (optional)
+	Name string
+}
+
+// ToolDefinition describes a function the model may call.
+type ToolDefinition struct {
+	// Name is the function name the model uses to invoke the tool
+	Name string
+
+	// Description explains when and how to use the tool
+	Description string
+
+	// Parameters is the JSON Schema for the tool's arguments
+	Parameters map[string]interface{}
+}
+
+// ToolCall represents a request from the model to invoke a tool.
+type ToolCall struct {
+	// ID uniquely identifies the call within the conversation
+	ID string
+
+	// Name is the name of the tool to invoke
+	Name string
+
+	// Arguments is the JSON-encoded argument object
+	Arguments string
+}
+
+// ParseArguments decodes the JSON arguments into v.
+func (tc ToolCall) ParseArguments(v interface{}) error {
+	if tc.Arguments == "" {
+		return json.Unmarshal([]byte("{}"), v)
+	}
+	if err := json.Unmarshal([]byte(tc.Arguments), v); err != nil {
+		return fmt.Errorf("invalid arguments for tool %s: %w", tc.Name, err)
+	}
+	return nil
+}
+
+// NewToolResultMessage creates a tool message answering the given tool call.
+func NewToolResultMessage(call ToolCall, content string) Message {
+	return Message{
+		Role:       "tool",
+		Content:    content,
+		ToolCallID: call.ID,
+		Name:       call.Name,
+	}
 }
 
 // ChatResponse represents a chat response from an LLM provider.
 type ChatResponse struct {
-	Message      Message
-	TokensUsed   int
+	Message Message
+
+	// TokensUsed is the total token count (Usage.TotalTokens)
+	TokensUsed int
+
+	// Usage splits the token count into prompt and completion tokens
+	Usage Usage
+
 	FinishReason string
 	Model        string
 }
@@ -176,3 +319,15 @@ func ValidateChatRequest(req *ChatRequest, defaultModel string, defaultMaxTokens
 	}
 	return normalized, nil
 }
+
+// toolParameters returns the tool's JSON Schema, defaulting to an empty object schema.
+// Most providers reject a tool definition without a parameters object.
+func toolParameters(tool ToolDefinition) map[string]interface{} {
+	if tool.Parameters != nil {
+		return tool.Parameters
+	}
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+}
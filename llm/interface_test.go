@@ -171,6 +171,63 @@ func TestChatRequest_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "Messages[0].Role",
 		},
+		{
+			name: "valid tool round trip",
+			req: &ChatRequest{
+				Model: "gpt-4",
+				Messages: []Message{
+					{Role: "user", Content: "Weather in Paris?"},
+					{Role: "assistant", ToolCalls: []ToolCall{{ID: "call_1", Name: "get_weather", Arguments: `{"city":"Paris"}`}}},
+					{Role: "tool", ToolCallID: "call_1", Content: "sunny"},
+				},
+				Tools:      []ToolDefinition{{Name: "get_weather"}},
+				ToolChoice: "get_weather",
+			},
+			wantErr: false,
+		},
+		{
+			name: "tool message without call ID",
+			req: &ChatRequest{
+				Model: "gpt-4",
+				Messages: []Message{
+					{Role: "tool", Content: "sunny"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "Messages[0].ToolCallID",
+		},
+		{
+			name: "tool calls on user message",
+			req: &ChatRequest{
+				Model: "gpt-4",
+				Messages: []Message{
+					{Role: "user", ToolCalls: []ToolCall{{ID: "x", Name: "y"}}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "Messages[0].ToolCalls",
+		},
+		{
+			name: "tool without name",
+			req: &ChatRequest{
+				Model:    "gpt-4",
+				Messages: []Message{{Role: "user", Content: "Hello"}},
+				Tools:    []ToolDefinition{{Description: "nameless"}},
+			},
+			wantErr: true,
+			errMsg:  "Tools[0].Name",
+		},
+		{
+			name: "unknown tool choice",
+			req: &ChatRequest{
+				Model:      "gpt-4",
+				Messages:   []Message{{Role: "user", Content: "Hello"}},
+				Tools:      []ToolDefinition{{Name: "search"}},
+				ToolChoice: "calculator",
+			},
+			wantErr: true,
+			errMsg:  "ToolChoice",
+		},
 		{
 			name: "empty role",
 			req: &ChatRequest{
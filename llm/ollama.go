@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 )
 
 // OllamaProvider implements the Provider interface for Ollama (local models)
@@ -34,27 +35,54 @@ func (p *OllamaProvider) Name() string {
 }
 
 type ollamaMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role      string           `json:"role"`
+	Content   string           `json:"content"`
+	Images    []string         `json:"images,omitempty"` // Base64-encoded
+	ToolCalls []ollamaToolCall `json:"tool_calls,omitempty"`
+	ToolName  string           `json:"tool_name,omitempty"`
+}
+
+type ollamaToolCall struct {
+	Function struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	} `json:"function"`
+}
+
+type ollamaTool struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name        string                 `json:"name"`
+		Description string                 `json:"description,omitempty"`
+		Parameters  map[string]interface{} `json:"parameters"`
+	} `json:"function"`
 }
 
 type ollamaRequest struct {
 	Model    string                 `json:"model"`
 	Prompt   string                 `json:"prompt,omitempty"`
 	Messages []ollamaMessage        `json:"messages,omitempty"`
+	Tools    []ollamaTool           `json:"tools,omitempty"`
 	Stream   bool                   `json:"stream"`
+	Format   interface{}            `json:"format,omitempty"` // "json" or a JSON Schema
 	Options  map[string]interface{} `json:"options,omitempty"`
 }
 
 type ollamaResponse struct {
-	Model     string `json:"model"`
-	CreatedAt string `json:"created_at"`
-	Response  string `json:"response,omitempty"`
-	Message   struct {
-		Role    string `json:"role"`
-		Content string `json:"content"`
-	} `json:"message,omitempty"`
-	Done bool `json:"done"`
+	Model           string        `json:"model"`
+	CreatedAt       string        `json:"created_at"`
+	Response        string        `json:"response,omitempty"`
+	Message         ollamaMessage `json:"message,omitempty"`
+	Done            bool          `json:"done"`
+	DoneReason      string        `json:"done_reason,omitempty"`
+	PromptEvalCount int           `json:"prompt_eval_count,omitempty"`
+	EvalCount       int           `json:"eval_count,omitempty"`
+	Error           string        `json:"error,omitempty"`
+}
+
+// usage converts the prompt and generated token counts of a final response
+func (r *ollamaResponse) usage() Usage {
+	return newUsage(r.PromptEvalCount, r.EvalCount, 0)
 }
 
 // GenerateCompletion generates a text completion using Ollama
@@ -79,46 +107,126 @@ func (p *OllamaProvider) GenerateCompletion(ctx context.Context, req *Completion
 		ollamaReq.Options["num_predict"] = req.MaxTokens
 	}
 
-	data, err := json.Marshal(ollamaReq)
+	httpResp, err := p.doRequest(ctx, "/api/generate", ollamaReq)
 	if err != nil {
 		return nil, err
 	}
+	defer httpResp.Body.Close()
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/api/generate", bytes.NewBuffer(data))
-	if err != nil {
+	var resp ollamaResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
 		return nil, err
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
 
-	httpResp, err := p.httpClient.Do(httpReq)
+	return &CompletionResponse{
+		Text:         resp.Response,
+		TokensUsed:   resp.usage().TotalTokens,
+		Usage:        resp.usage(),
+		FinishReason: "stop",
+		Model:        resp.Model,
+	}, nil
+}
+
+// GenerateChat generates a chat response using Ollama
+func (p *OllamaProvider) GenerateChat(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+	ollamaReq, err := p.buildChatRequest(req, false)
 	if err != nil {
 		return nil, err
 	}
-	defer httpResp.Body.Close()
 
-	if httpResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(httpResp.Body)
-		return nil, fmt.Errorf("ollama API error (status %d): %s", httpResp.StatusCode, string(body))
+	httpResp, err := p.doRequest(ctx, "/api/chat", ollamaReq)
+	if err != nil {
+		return nil, err
 	}
+	defer httpResp.Body.Close()
 
 	var resp ollamaResponse
 	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
 		return nil, err
 	}
 
-	return &CompletionResponse{
-		Text:         resp.Response,
-		TokensUsed:   0, // Ollama doesn't return token count
+	return &ChatResponse{
+		Message:      fromOllamaMessage(resp.Message, 0),
+		TokensUsed:   resp.usage().TotalTokens,
+		Usage:        resp.usage(),
 		FinishReason: "stop",
 		Model:        resp.Model,
 	}, nil
 }
 
-// GenerateChat generates a chat response using Ollama
-func (p *OllamaProvider) GenerateChat(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+// StreamChat streams a chat response using Ollama (newline-delimited JSON)
+func (p *OllamaProvider) StreamChat(ctx context.Context, req *ChatRequest) (<-chan ChatChunk, error) {
+	ollamaReq, err := p.buildChatRequest(req, true)
+	if err != nil {
+		return nil, err
+	}
+
+	httpResp, err := p.doRequest(ctx, "/api/chat", ollamaReq)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan ChatChunk, 16)
+
+	go func() {
+		out := &chunkSender{ctx: ctx, ch: ch}
+		defer out.close()
+		defer httpResp.Body.Close()
+
+		decoder := json.NewDecoder(httpResp.Body)
+		var toolCalls []ToolCall
+
+		for {
+			var resp ollamaResponse
+			if err := decoder.Decode(&resp); err != nil {
+				if err == io.EOF {
+					err = io.ErrUnexpectedEOF
+				}
+				if ctx.Err() == nil {
+					out.send(ChatChunk{Err: fmt.Errorf("ollama stream error: %w", err)})
+				}
+				return
+			}
+
+			if resp.Error != "" {
+				out.send(ChatChunk{Err: fmt.Errorf("ollama stream error: %s", resp.Error)})
+				return
+			}
+
+			if resp.Message.Content != "" {
+				if !out.send(ChatChunk{Content: resp.Message.Content, Model: resp.Model}) {
+					return
+				}
+			}
+			// Ollama delivers each tool call whole rather than in fragments
+			toolCalls = append(toolCalls, fromOllamaMessage(resp.Message, len(toolCalls)).ToolCalls...)
+
+			if resp.Done {
+				finishReason := resp.DoneReason
+				if finishReason == "" {
+					finishReason = "stop"
+				}
+				out.send(ChatChunk{
+					Done:         true,
+					FinishReason: finishReason,
+					TokensUsed:   resp.usage().TotalTokens,
+					Usage:        resp.usage(),
+					Model:        resp.Model,
+					ToolCalls:    toolCalls,
+				})
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// buildChatRequest converts a chat request to the Ollama wire format
+func (p *OllamaProvider) buildChatRequest(req *ChatRequest, stream bool) (ollamaRequest, error) {
 	ollamaReq := ollamaRequest{
 		Model:  req.Model,
-		Stream: false,
+		Stream: stream,
 		Options: map[string]interface{}{
 			"temperature": req.Temperature,
 		},
@@ -135,14 +243,110 @@ func (p *OllamaProvider) GenerateChat(ctx context.Context, req *ChatRequest) (*C
 			Role:    msg.Role,
 			Content: msg.Content,
 		}
+		if len(msg.Parts) > 0 {
+			if err := addOllamaContentParts(&ollamaReq.Messages[i], msg.Parts); err != nil {
+				return ollamaRequest{}, fmt.Errorf("message %d: %w", i, err)
+			}
+		}
+		if msg.Role == "tool" {
+			ollamaReq.Messages[i].ToolName = msg.Name
+		}
+		for _, call := range msg.ToolCalls {
+			var tc ollamaToolCall
+			tc.Function.Name = call.Name
+			tc.Function.Arguments = json.RawMessage(call.Arguments)
+			if len(tc.Function.Arguments) == 0 {
+				tc.Function.Arguments = json.RawMessage("{}")
+			}
+			ollamaReq.Messages[i].ToolCalls = append(ollamaReq.Messages[i].ToolCalls, tc)
+		}
+	}
+
+	// Ollama has no tool_choice; "none" omits the tools and a named choice
+	// restricts the list to that single tool.
+	if req.ToolChoice != "none" {
+		for _, tool := range req.Tools {
+			if req.ToolChoice != "" && req.ToolChoice != "auto" && req.ToolChoice != "required" && req.ToolChoice != tool.Name {
+				continue
+			}
+			var t ollamaTool
+			t.Type = "function"
+			t.Function.Name = tool.Name
+			t.Function.Description = tool.Description
+			t.Function.Parameters = toolParameters(tool)
+			ollamaReq.Tools = append(ollamaReq.Tools, t)
+		}
+	}
+
+	switch {
+	case !req.ResponseFormat.isJSON():
+	case req.ResponseFormat.Type == ResponseFormatJSONSchema:
+		ollamaReq.Format = req.ResponseFormat.Schema
+	default:
+		ollamaReq.Format = "json"
+	}
+
+	return ollamaReq, nil
+}
+
+// addOllamaContentParts adds content parts to an Ollama message. Ollama takes
+// images as a list of base64 strings alongside the text and cannot fetch
+// URLs; plain-text documents are appended to the text.
+func addOllamaContentParts(msg *ollamaMessage, parts []ContentPart) error {
+	texts := make([]string, 0, len(parts)+1)
+	if msg.Content != "" {
+		texts = append(texts, msg.Content)
 	}
 
-	data, err := json.Marshal(ollamaReq)
+	for _, part := range parts {
+		switch {
+		case part.Type == ContentTypeText:
+			texts = append(texts, part.Text)
+		case part.Type == ContentTypeImage:
+			msg.Images = append(msg.Images, part.Data)
+		case part.isTextDocument():
+			text, err := part.documentText()
+			if err != nil {
+				return err
+			}
+			texts = append(texts, text)
+		case part.Type == ContentTypeImageURL:
+			return fmt.Errorf("ollama provider does not support image URLs; send the image inline")
+		default:
+			return fmt.Errorf("ollama provider does not support %s content parts with media type %q", part.Type, part.MediaType)
+		}
+	}
+
+	msg.Content = strings.Join(texts, "\n\n")
+	return nil
+}
+
+// fromOllamaMessage converts an Ollama message to a Message.
+// Ollama does not assign tool call IDs, so IDs are generated from offset.
+func fromOllamaMessage(msg ollamaMessage, offset int) Message {
+	result := Message{
+		Role:    msg.Role,
+		Content: msg.Content,
+	}
+	for i, call := range msg.ToolCalls {
+		result.ToolCalls = append(result.ToolCalls, ToolCall{
+			ID:        fmt.Sprintf("call_%d", offset+i),
+			Name:      call.Function.Name,
+			Arguments: string(call.Function.Arguments),
+		})
+	}
+	return result
+}
+
+// doRequest posts a request to the Ollama API and returns the response once
+// the status has been checked. The caller is responsible for closing the body.
+func (p *OllamaProvider) doRequest(ctx context.Context, path string, body ollamaRequest) (*http.Response, error) {
+	data, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/api/chat", bytes.NewBuffer(data))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+path, bytes.NewBuffer(data))
 	if err != nil {
 		return nil, err
 	}
@@ -152,25 +356,12 @@ func (p *OllamaProvider) GenerateChat(ctx context.Context, req *ChatRequest) (*C
 	if err != nil {
 		return nil, err
 	}
-	defer httpResp.Body.Close()
 
 	if httpResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(httpResp.Body)
-		return nil, fmt.Errorf("ollama API error (status %d): %s", httpResp.StatusCode, string(body))
+		defer httpResp.Body.Close()
+		respBody, _ := io.ReadAll(httpResp.Body)
+		return nil, fmt.Errorf("ollama API error (status %d): %s", httpResp.StatusCode, string(respBody))
 	}
 
-	var resp ollamaResponse
-	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
-		return nil, err
-	}
-
-	return &ChatResponse{
-		Message: Message{
-			Role:    resp.Message.Role,
-			Content: resp.Message.Content,
-		},
-		TokensUsed:   0, // Ollama doesn't return token count
-		FinishReason: "stop",
-		Model:        resp.Model,
-	}, nil
+	return httpResp, nil
 }
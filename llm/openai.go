@@ -2,23 +2,30 @@ package llm
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/sashabaranov/go-openai"
 )
 
-// OpenAIProvider implements the Provider interface for OpenAI
+// OpenAIProvider implements the Provider interface for OpenAI and
+// OpenAI-compatible APIs (see NewOpenAICompatible)
 type OpenAIProvider struct {
-	client *openai.Client
-	name   string
+	client             *openai.Client
+	name               string
+	modelParameters    map[string]ModelParameters
+	includeStreamUsage bool
 }
 
 // NewOpenAI creates a new OpenAI provider
 func NewOpenAI(apiKey string) *OpenAIProvider {
-	return &OpenAIProvider{
-		client: openai.NewClient(apiKey),
-		name:   "openai",
-	}
+	return NewOpenAICompatible(OpenAICompatibleConfig{
+		APIKey:             apiKey,
+		ModelParameters:    DefaultOpenAIModelParameters(),
+		IncludeStreamUsage: true,
+	})
 }
 
 // Name returns the provider name
@@ -47,6 +54,7 @@ func (p *OpenAIProvider) GenerateCompletion(ctx context.Context, req *Completion
 		Temperature: float32(req.Temperature),
 		MaxTokens:   req.MaxTokens,
 	}
+	p.applyModelParameters(&chatReq)
 
 	resp, err := p.client.CreateChatCompletion(ctx, chatReq)
 	if err != nil {
@@ -60,6 +68,7 @@ func (p *OpenAIProvider) GenerateCompletion(ctx context.Context, req *Completion
 	return &CompletionResponse{
 		Text:         resp.Choices[0].Message.Content,
 		TokensUsed:   resp.Usage.TotalTokens,
+		Usage:        fromOpenAIUsage(resp.Usage),
 		FinishReason: string(resp.Choices[0].FinishReason),
 		Model:        resp.Model,
 	}, nil
@@ -67,21 +76,11 @@ func (p *OpenAIProvider) GenerateCompletion(ctx context.Context, req *Completion
 
 // GenerateChat generates a chat response using OpenAI
 func (p *OpenAIProvider) GenerateChat(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
-	// Convert messages
-	messages := make([]openai.ChatCompletionMessage, len(req.Messages))
-	for i, msg := range req.Messages {
-		messages[i] = openai.ChatCompletionMessage{
-			Role:    msg.Role,
-			Content: msg.Content,
-		}
-	}
-
-	chatReq := openai.ChatCompletionRequest{
-		Model:       req.Model,
-		Messages:    messages,
-		Temperature: float32(req.Temperature),
-		MaxTokens:   req.MaxTokens,
+	chatReq, err := buildOpenAIChatRequest(req)
+	if err != nil {
+		return nil, err
 	}
+	p.applyModelParameters(&chatReq)
 
 	resp, err := p.client.CreateChatCompletion(ctx, chatReq)
 	if err != nil {
@@ -93,12 +92,262 @@ func (p *OpenAIProvider) GenerateChat(ctx context.Context, req *ChatRequest) (*C
 	}
 
 	return &ChatResponse{
-		Message: Message{
-			Role:    resp.Choices[0].Message.Role,
-			Content: resp.Choices[0].Message.Content,
-		},
+		Message:      fromOpenAIMessage(resp.Choices[0].Message),
 		TokensUsed:   resp.Usage.TotalTokens,
+		Usage:        fromOpenAIUsage(resp.Usage),
 		FinishReason: string(resp.Choices[0].FinishReason),
 		Model:        resp.Model,
 	}, nil
 }
+
+// StreamChat streams a chat response using OpenAI
+func (p *OpenAIProvider) StreamChat(ctx context.Context, req *ChatRequest) (<-chan ChatChunk, error) {
+	chatReq, err := buildOpenAIChatRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	p.applyModelParameters(&chatReq)
+	chatReq.Stream = true
+	if p.includeStreamUsage {
+		chatReq.StreamOptions = &openai.StreamOptions{
+			IncludeUsage: true,
+		}
+	}
+
+	stream, err := p.client.CreateChatCompletionStream(ctx, chatReq)
+	if err != nil {
+		return nil, fmt.Errorf("openai stream error: %w", err)
+	}
+
+	ch := make(chan ChatChunk, 16)
+
+	go func() {
+		out := &chunkSender{ctx: ctx, ch: ch}
+		defer out.close()
+		defer stream.Close()
+
+		final := ChatChunk{Done: true}
+		var toolCalls []ToolCall
+
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				final.ToolCalls = toolCalls
+				out.send(final)
+				return
+			}
+			if err != nil {
+				out.send(ChatChunk{Err: fmt.Errorf("openai stream error: %w", err)})
+				return
+			}
+
+			final.Model = resp.Model
+			if resp.Usage != nil {
+				final.TokensUsed = resp.Usage.TotalTokens
+				final.Usage = fromOpenAIUsage(*resp.Usage)
+			}
+			if len(resp.Choices) == 0 {
+				continue
+			}
+
+			choice := resp.Choices[0]
+			if choice.FinishReason != "" {
+				final.FinishReason = string(choice.FinishReason)
+			}
+			toolCalls = mergeOpenAIToolCallDeltas(toolCalls, choice.Delta.ToolCalls)
+			if choice.Delta.Content != "" {
+				if !out.send(ChatChunk{Content: choice.Delta.Content, Model: resp.Model}) {
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// buildOpenAIChatRequest converts a chat request to the OpenAI wire format
+func buildOpenAIChatRequest(req *ChatRequest) (openai.ChatCompletionRequest, error) {
+	messages, err := toOpenAIMessages(req.Messages)
+	if err != nil {
+		return openai.ChatCompletionRequest{}, err
+	}
+
+	chatReq := openai.ChatCompletionRequest{
+		Model:       req.Model,
+		Messages:    messages,
+		Temperature: float32(req.Temperature),
+		MaxTokens:   req.MaxTokens,
+	}
+
+	if len(req.Tools) > 0 {
+		chatReq.Tools = make([]openai.Tool, len(req.Tools))
+		for i, tool := range req.Tools {
+			chatReq.Tools[i] = openai.Tool{
+				Type: openai.ToolTypeFunction,
+				Function: &openai.FunctionDefinition{
+					Name:        tool.Name,
+					Description: tool.Description,
+					Parameters:  toolParameters(tool),
+				},
+			}
+		}
+	}
+
+	switch req.ToolChoice {
+	case "":
+	case "auto", "none", "required":
+		chatReq.ToolChoice = req.ToolChoice
+	default:
+		chatReq.ToolChoice = openai.ToolChoice{
+			Type:     openai.ToolTypeFunction,
+			Function: openai.ToolFunction{Name: req.ToolChoice},
+		}
+	}
+
+	if req.ResponseFormat.isJSON() {
+		responseFormat, err := toOpenAIResponseFormat(req.ResponseFormat)
+		if err != nil {
+			return openai.ChatCompletionRequest{}, err
+		}
+		chatReq.ResponseFormat = responseFormat
+	}
+
+	return chatReq, nil
+}
+
+// toOpenAIResponseFormat converts a response format to OpenAI's response_format
+func toOpenAIResponseFormat(format *ResponseFormat) (*openai.ChatCompletionResponseFormat, error) {
+	if format.Type == ResponseFormatJSONObject {
+		return &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject}, nil
+	}
+
+	schema, err := json.Marshal(format.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response schema: %w", err)
+	}
+	return &openai.ChatCompletionResponseFormat{
+		Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
+		JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
+			Name:        format.name(),
+			Description: format.Description,
+			Schema:      json.RawMessage(schema),
+			Strict:      format.Strict,
+		},
+	}, nil
+}
+
+// toOpenAIMessages converts messages to the OpenAI wire format
+func toOpenAIMessages(msgs []Message) ([]openai.ChatCompletionMessage, error) {
+	messages := make([]openai.ChatCompletionMessage, len(msgs))
+	for i, msg := range msgs {
+		messages[i] = openai.ChatCompletionMessage{
+			Role:       msg.Role,
+			Content:    msg.Content,
+			Name:       msg.Name,
+			ToolCallID: msg.ToolCallID,
+		}
+		if len(msg.Parts) > 0 {
+			// Content and MultiContent are mutually exclusive
+			parts, err := toOpenAIContentParts(msg.ContentParts())
+			if err != nil {
+				return nil, fmt.Errorf("message %d: %w", i, err)
+			}
+			messages[i].Content = ""
+			messages[i].MultiContent = parts
+		}
+		for _, call := range msg.ToolCalls {
+			messages[i].ToolCalls = append(messages[i].ToolCalls, openai.ToolCall{
+				ID:   call.ID,
+				Type: openai.ToolTypeFunction,
+				Function: openai.FunctionCall{
+					Name:      call.Name,
+					Arguments: call.Arguments,
+				},
+			})
+		}
+	}
+	return messages, nil
+}
+
+// toOpenAIContentParts converts content parts to the OpenAI wire format.
+// Images are sent as URLs, inline images as data URLs. The chat API has no
+// document parts, so only plain-text documents are accepted, as text.
+func toOpenAIContentParts(parts []ContentPart) ([]openai.ChatMessagePart, error) {
+	result := make([]openai.ChatMessagePart, 0, len(parts))
+	for _, part := range parts {
+		switch {
+		case part.Type == ContentTypeText:
+			result = append(result, openai.ChatMessagePart{Type: openai.ChatMessagePartTypeText, Text: part.Text})
+		case part.Type == ContentTypeImageURL, part.Type == ContentTypeImage:
+			result = append(result, openai.ChatMessagePart{
+				Type: openai.ChatMessagePartTypeImageURL,
+				ImageURL: &openai.ChatMessageImageURL{
+					URL:    part.DataURL(),
+					Detail: openai.ImageURLDetail(part.Detail),
+				},
+			})
+		case part.isTextDocument():
+			text, err := part.documentText()
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, openai.ChatMessagePart{Type: openai.ChatMessagePartTypeText, Text: text})
+		default:
+			return nil, fmt.Errorf("openai provider does not support %s content parts with media type %q", part.Type, part.MediaType)
+		}
+	}
+	return result, nil
+}
+
+// fromOpenAIUsage converts OpenAI token usage, whose prompt count includes
+// the cached prompt tokens
+func fromOpenAIUsage(usage openai.Usage) Usage {
+	result := Usage{
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		TotalTokens:      usage.TotalTokens,
+	}
+	if usage.PromptTokensDetails != nil {
+		result.CachedTokens = usage.PromptTokensDetails.CachedTokens
+	}
+	return result
+}
+
+// fromOpenAIMessage converts an OpenAI response message to a Message
+func fromOpenAIMessage(msg openai.ChatCompletionMessage) Message {
+	result := Message{
+		Role:    msg.Role,
+		Content: msg.Content,
+	}
+	for _, call := range msg.ToolCalls {
+		result.ToolCalls = append(result.ToolCalls, ToolCall{
+			ID:        call.ID,
+			Name:      call.Function.Name,
+			Arguments: call.Function.Arguments,
+		})
+	}
+	return result
+}
+
+// mergeOpenAIToolCallDeltas accumulates streamed tool call fragments.
+// OpenAI sends the ID and name once, then the arguments in pieces keyed by index.
+func mergeOpenAIToolCallDeltas(calls []ToolCall, deltas []openai.ToolCall) []ToolCall {
+	for _, delta := range deltas {
+		idx := len(calls)
+		if delta.Index != nil {
+			idx = *delta.Index
+		}
+		for len(calls) <= idx {
+			calls = append(calls, ToolCall{})
+		}
+		if delta.ID != "" {
+			calls[idx].ID = delta.ID
+		}
+		if delta.Function.Name != "" {
+			calls[idx].Name = delta.Function.Name
+		}
+		calls[idx].Arguments += delta.Function.Arguments
+	}
+	return calls
+}
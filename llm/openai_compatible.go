@@ -0,0 +1,164 @@
+package llm
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// OpenAICompatibleConfig configures an OpenAIProvider for any API that
+// speaks the OpenAI chat completions protocol, such as Azure OpenAI,
+// OpenRouter, vLLM, LM Studio or a LiteLLM proxy
+type OpenAICompatibleConfig struct {
+	// Name is the provider name reported by Name (default "openai")
+	Name string
+
+	// APIKey is sent as a bearer token, or as the api-key header for Azure.
+	// Local servers usually accept any value.
+	APIKey string
+
+	// BaseURL is the API root, including any version path
+	// (default https://api.openai.com/v1). For Azure it is the resource
+	// endpoint, e.g. https://my-resource.openai.azure.com.
+	BaseURL string
+
+	// Organization is sent as the OpenAI-Organization header (optional)
+	Organization string
+
+	// Headers are added to every request, e.g. OpenRouter's HTTP-Referer
+	// and X-Title
+	Headers map[string]string
+
+	// AzureAPIVersion enables Azure routing: requests go to
+	// /openai/deployments/{deployment}/chat/completions?api-version=...
+	AzureAPIVersion string
+
+	// AzureDeployments maps model names to Azure deployment names. Models
+	// without an entry use the model name with "." and ":" removed.
+	AzureDeployments map[string]string
+
+	// ModelParameters adjusts requests per model. Keys are model names, or
+	// prefixes ending in "*"; the longest matching key wins.
+	ModelParameters map[string]ModelParameters
+
+	// IncludeStreamUsage asks for token usage at the end of a stream with
+	// stream_options. It is set by NewOpenAI; other servers may reject the
+	// field.
+	IncludeStreamUsage bool
+
+	// HTTPClient sends requests (optional)
+	HTTPClient *http.Client
+}
+
+// ModelParameters describes how a model deviates from the standard chat
+// completions parameters
+type ModelParameters struct {
+	// UseMaxCompletionTokens sends MaxTokens as max_completion_tokens
+	// instead of max_tokens, as OpenAI reasoning models require
+	UseMaxCompletionTokens bool
+
+	// OmitTemperature leaves the temperature unset for models that only
+	// accept their default
+	OmitTemperature bool
+}
+
+// DefaultOpenAIModelParameters returns the parameter quirks of OpenAI's
+// own models, applied by NewOpenAI
+func DefaultOpenAIModelParameters() map[string]ModelParameters {
+	reasoning := ModelParameters{UseMaxCompletionTokens: true, OmitTemperature: true}
+	return map[string]ModelParameters{
+		"o1*": reasoning,
+		"o3*": reasoning,
+		"o4*": reasoning,
+	}
+}
+
+// NewOpenAICompatible creates a provider for an OpenAI-compatible API
+func NewOpenAICompatible(cfg OpenAICompatibleConfig) *OpenAIProvider {
+	var clientConfig openai.ClientConfig
+	if cfg.AzureAPIVersion != "" {
+		clientConfig = openai.DefaultAzureConfig(cfg.APIKey, cfg.BaseURL)
+		clientConfig.APIVersion = cfg.AzureAPIVersion
+		if len(cfg.AzureDeployments) > 0 {
+			fallback := clientConfig.AzureModelMapperFunc
+			clientConfig.AzureModelMapperFunc = func(model string) string {
+				if deployment, ok := cfg.AzureDeployments[model]; ok {
+					return deployment
+				}
+				return fallback(model)
+			}
+		}
+	} else {
+		clientConfig = openai.DefaultConfig(cfg.APIKey)
+		if cfg.BaseURL != "" {
+			clientConfig.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+		}
+	}
+	clientConfig.OrgID = cfg.Organization
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	clientConfig.HTTPClient = httpClient
+	if len(cfg.Headers) > 0 {
+		clientConfig.HTTPClient = &headerDoer{client: httpClient, headers: cfg.Headers}
+	}
+
+	name := cfg.Name
+	if name == "" {
+		name = "openai"
+	}
+
+	return &OpenAIProvider{
+		client:             openai.NewClientWithConfig(clientConfig),
+		name:               name,
+		modelParameters:    cfg.ModelParameters,
+		includeStreamUsage: cfg.IncludeStreamUsage,
+	}
+}
+
+// headerDoer adds fixed headers to every request
+type headerDoer struct {
+	client  *http.Client
+	headers map[string]string
+}
+
+// Do implements openai.HTTPDoer
+func (d *headerDoer) Do(req *http.Request) (*http.Response, error) {
+	for key, value := range d.headers {
+		req.Header.Set(key, value)
+	}
+	return d.client.Do(req)
+}
+
+// parametersFor returns the parameters configured for model, matching exact
+// names before the longest "*" prefix
+func (p *OpenAIProvider) parametersFor(model string) ModelParameters {
+	if params, ok := p.modelParameters[model]; ok {
+		return params
+	}
+
+	var best ModelParameters
+	bestLen := -1
+	for key, params := range p.modelParameters {
+		prefix, ok := strings.CutSuffix(key, "*")
+		if ok && strings.HasPrefix(model, prefix) && len(prefix) > bestLen {
+			best, bestLen = params, len(prefix)
+		}
+	}
+	return best
+}
+
+// applyModelParameters adjusts a request for the quirks of its model
+func (p *OpenAIProvider) applyModelParameters(req *openai.ChatCompletionRequest) {
+	params := p.parametersFor(req.Model)
+	if params.UseMaxCompletionTokens {
+		req.MaxCompletionTokens = req.MaxTokens
+		req.MaxTokens = 0
+	}
+	if params.OmitTemperature {
+		req.Temperature = 0
+	}
+}
@@ -0,0 +1,296 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// openAICompatibleRequest records what an OpenAI-compatible stand-in received
+type openAICompatibleRequest struct {
+	Path    string
+	Query   string
+	Header  http.Header
+	Payload map[string]any
+}
+
+func newOpenAICompatibleServer(t *testing.T, got *openAICompatibleRequest) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.Path = r.URL.Path
+		got.Query = r.URL.RawQuery
+		got.Header = r.Header.Clone()
+		got.Payload = nil
+		json.NewDecoder(r.Body).Decode(&got.Payload)
+
+		if got.Payload["stream"] == true {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, `data: {"choices":[{"index":0,"delta":{"content":"ok"}}]}`+"\n\n")
+			fmt.Fprint(w, "data: [DONE]\n\n")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"model": got.Payload["model"],
+			"choices": []map[string]any{{
+				"message":       map[string]any{"role": "assistant", "content": "ok"},
+				"finish_reason": "stop",
+			}},
+			"usage": map[string]any{"total_tokens": 7},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOpenAICompatible_BaseURLAndHeaders(t *testing.T) {
+	var got openAICompatibleRequest
+	server := newOpenAICompatibleServer(t, &got)
+
+	provider := NewOpenAICompatible(OpenAICompatibleConfig{
+		Name:    "openrouter",
+		APIKey:  "test-key",
+		BaseURL: server.URL + "/api/v1/",
+		Headers: map[string]string{"HTTP-Referer": "https://example.com", "X-Title": "Minion"},
+	})
+	if provider.Name() != "openrouter" {
+		t.Errorf("expected name openrouter, got %s", provider.Name())
+	}
+
+	resp, err := provider.GenerateChat(context.Background(), &ChatRequest{
+		Messages:  []Message{{Role: "user", Content: "hi"}},
+		Model:     "meta-llama/llama-3-70b-instruct",
+		MaxTokens: 50,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Path != "/api/v1/chat/completions" {
+		t.Errorf("unexpected path: %s", got.Path)
+	}
+	if got.Header.Get("Authorization") != "Bearer test-key" {
+		t.Errorf("unexpected authorization: %s", got.Header.Get("Authorization"))
+	}
+	if got.Header.Get("HTTP-Referer") != "https://example.com" || got.Header.Get("X-Title") != "Minion" {
+		t.Errorf("extra headers not sent: %v", got.Header)
+	}
+	if got.Payload["max_tokens"] != float64(50) {
+		t.Errorf("expected max_tokens=50, got %v", got.Payload["max_tokens"])
+	}
+	if resp.Message.Content != "ok" || resp.TokensUsed != 7 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestOpenAICompatible_Azure(t *testing.T) {
+	var got openAICompatibleRequest
+	server := newOpenAICompatibleServer(t, &got)
+
+	provider := NewOpenAICompatible(OpenAICompatibleConfig{
+		APIKey:           "azure-key",
+		BaseURL:          server.URL,
+		AzureAPIVersion:  "2024-10-21",
+		AzureDeployments: map[string]string{"gpt-4o": "prod-gpt4o"},
+	})
+
+	_, err := provider.GenerateCompletion(context.Background(), &CompletionRequest{
+		UserPrompt: "hi",
+		Model:      "gpt-4o",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Path != "/openai/deployments/prod-gpt4o/chat/completions" {
+		t.Errorf("unexpected path: %s", got.Path)
+	}
+	if got.Query != "api-version=2024-10-21" {
+		t.Errorf("unexpected query: %s", got.Query)
+	}
+	if got.Header.Get("api-key") != "azure-key" {
+		t.Errorf("expected api-key header, got %v", got.Header)
+	}
+
+	// Models without a mapping fall back to the model name
+	if _, err := provider.GenerateCompletion(context.Background(), &CompletionRequest{UserPrompt: "hi", Model: "gpt-3.5-turbo"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Path != "/openai/deployments/gpt-35-turbo/chat/completions" {
+		t.Errorf("unexpected fallback path: %s", got.Path)
+	}
+}
+
+func TestOpenAICompatible_ModelParameters(t *testing.T) {
+	var got openAICompatibleRequest
+	server := newOpenAICompatibleServer(t, &got)
+
+	provider := NewOpenAICompatible(OpenAICompatibleConfig{
+		APIKey:          "test-key",
+		BaseURL:         server.URL,
+		ModelParameters: DefaultOpenAIModelParameters(),
+	})
+
+	tests := []struct {
+		model                   string
+		wantMaxTokens           bool
+		wantMaxCompletionTokens bool
+		wantTemperature         bool
+	}{
+		{model: "gpt-4o", wantMaxTokens: true, wantTemperature: true},
+		{model: "o3-mini", wantMaxCompletionTokens: true},
+		{model: "o4-mini-2025-04-16", wantMaxCompletionTokens: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			_, err := provider.GenerateChat(context.Background(), &ChatRequest{
+				Messages:    []Message{{Role: "user", Content: "hi"}},
+				Model:       tt.model,
+				MaxTokens:   100,
+				Temperature: 0.5,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			_, hasMaxTokens := got.Payload["max_tokens"]
+			_, hasMaxCompletionTokens := got.Payload["max_completion_tokens"]
+			_, hasTemperature := got.Payload["temperature"]
+			if hasMaxTokens != tt.wantMaxTokens {
+				t.Errorf("max_tokens present = %v, want %v", hasMaxTokens, tt.wantMaxTokens)
+			}
+			if hasMaxCompletionTokens != tt.wantMaxCompletionTokens {
+				t.Errorf("max_completion_tokens present = %v, want %v", hasMaxCompletionTokens, tt.wantMaxCompletionTokens)
+			}
+			if hasTemperature != tt.wantTemperature {
+				t.Errorf("temperature present = %v, want %v", hasTemperature, tt.wantTemperature)
+			}
+		})
+	}
+}
+
+func TestOpenAICompatible_StreamUsage(t *testing.T) {
+	var got openAICompatibleRequest
+	server := newOpenAICompatibleServer(t, &got)
+
+	tests := []struct {
+		name               string
+		includeStreamUsage bool
+	}{
+		{name: "omitted by default", includeStreamUsage: false},
+		{name: "requested when enabled", includeStreamUsage: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewOpenAICompatible(OpenAICompatibleConfig{
+				APIKey:             "test-key",
+				BaseURL:            server.URL,
+				IncludeStreamUsage: tt.includeStreamUsage,
+			})
+
+			ch, err := provider.StreamChat(context.Background(), &ChatRequest{
+				Messages: []Message{{Role: "user", Content: "hi"}},
+				Model:    "local-model",
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var content string
+			for chunk := range ch {
+				if chunk.Err != nil {
+					t.Fatalf("unexpected stream error: %v", chunk.Err)
+				}
+				content += chunk.Content
+			}
+			if content != "ok" {
+				t.Errorf("unexpected content: %q", content)
+			}
+
+			_, hasStreamOptions := got.Payload["stream_options"]
+			if hasStreamOptions != tt.includeStreamUsage {
+				t.Errorf("stream_options present = %v, want %v", hasStreamOptions, tt.includeStreamUsage)
+			}
+		})
+	}
+}
+
+func TestOpenAIProvider_ParametersFor(t *testing.T) {
+	provider := &OpenAIProvider{modelParameters: map[string]ModelParameters{
+		"o*":        {OmitTemperature: true},
+		"o1*":       {UseMaxCompletionTokens: true},
+		"o1-custom": {},
+	}}
+
+	if got := provider.parametersFor("o1-mini"); !got.UseMaxCompletionTokens || got.OmitTemperature {
+		t.Errorf("expected the longest prefix to win, got %+v", got)
+	}
+	if got := provider.parametersFor("omni"); !got.OmitTemperature {
+		t.Errorf("expected o* to match, got %+v", got)
+	}
+	if got := provider.parametersFor("o1-custom"); got != (ModelParameters{}) {
+		t.Errorf("expected the exact match to win, got %+v", got)
+	}
+	if got := provider.parametersFor("gpt-4"); got != (ModelParameters{}) {
+		t.Errorf("expected no parameters, got %+v", got)
+	}
+}
+
+func TestProviderFactory_OpenAICompatible(t *testing.T) {
+	var got openAICompatibleRequest
+	server := newOpenAICompatibleServer(t, &got)
+
+	t.Setenv("LLM_PROVIDER", "openai-compatible")
+	t.Setenv("LLM_BASE_URL", server.URL+"/v1")
+	t.Setenv("LLM_API_KEY", "")
+	t.Setenv("LLM_PROVIDER_NAME", "vllm")
+	t.Setenv("LLM_HEADERS", "X-Team=agents, X-Env = test")
+
+	provider, err := NewProviderFactoryFromEnv().CreateProvider()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.Name() != "vllm" {
+		t.Errorf("expected name vllm, got %s", provider.Name())
+	}
+	if _, err := provider.GenerateChat(context.Background(), &ChatRequest{
+		Messages: []Message{{Role: "user", Content: "hi"}},
+		Model:    "mistral-7b",
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Path != "/v1/chat/completions" || got.Header.Get("X-Team") != "agents" || got.Header.Get("X-Env") != "test" {
+		t.Errorf("unexpected request: %s %v", got.Path, got.Header)
+	}
+
+	t.Setenv("LLM_BASE_URL", "")
+	if _, err := NewProviderFactoryFromEnv().CreateProvider(); err == nil {
+		t.Error("expected error without a base URL")
+	}
+}
+
+func TestProviderFactory_AzureOpenAI(t *testing.T) {
+	t.Setenv("LLM_PROVIDER", "azure-openai")
+	t.Setenv("LLM_BASE_URL", "")
+	t.Setenv("AZURE_OPENAI_API_KEY", "azure-key")
+	t.Setenv("AZURE_OPENAI_ENDPOINT", "https://example.openai.azure.com")
+	t.Setenv("AZURE_OPENAI_API_VERSION", "")
+
+	provider, err := NewProviderFactoryFromEnv().CreateProvider()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.Name() != "azure-openai" {
+		t.Errorf("expected name azure-openai, got %s", provider.Name())
+	}
+
+	t.Setenv("AZURE_OPENAI_ENDPOINT", "")
+	if _, err := NewProviderFactoryFromEnv().CreateProvider(); err == nil {
+		t.Error("expected error without an endpoint")
+	}
+}
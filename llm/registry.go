@@ -0,0 +1,94 @@
+package llm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrProviderNotFound is returned when no provider is registered under a name
+var ErrProviderNotFound = errors.New("LLM provider not found")
+
+// defaultModels maps the built-in provider names to the model used when a
+// request does not name one
+var defaultModels = map[string]string{
+	string(ProviderTypeOpenAI):    "gpt-4",
+	string(ProviderTypeAnthropic): "claude-3-5-sonnet-20241022",
+	string(ProviderTypeOllama):    "llama3",
+	string(ProviderTypeTupleLeap): "tupleleap-default",
+	string(ProviderTypeGemini):    "gemini-1.5-flash",
+}
+
+// DefaultModel returns the default model for a built-in provider name,
+// or an empty string for providers without a known default.
+func DefaultModel(providerName string) string {
+	return defaultModels[providerName]
+}
+
+// Registry is a thread-safe set of named providers.
+// Names are usually provider types ("openai", "anthropic", "ollama",
+// "tupleleap") but any name may be used, e.g. "ollama-local" or "openai-eu".
+type Registry struct {
+	providers map[string]Provider
+	mu        sync.RWMutex
+}
+
+// NewRegistry creates an empty provider registry
+func NewRegistry() *Registry {
+	return &Registry{
+		providers: make(map[string]Provider),
+	}
+}
+
+// Register adds a provider under the given name, replacing any provider
+// previously registered under that name
+func (r *Registry) Register(name string, provider Provider) error {
+	if name == "" {
+		return fmt.Errorf("provider name is required")
+	}
+	if provider == nil {
+		return fmt.Errorf("provider is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.providers[name] = provider
+	return nil
+}
+
+// Get retrieves a provider by name
+func (r *Registry) Get(name string) (Provider, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	provider, exists := r.providers[name]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotFound, name)
+	}
+
+	return provider, nil
+}
+
+// Remove removes a provider from the registry
+func (r *Registry) Remove(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.providers, name)
+}
+
+// List returns the registered provider names in sorted order
+func (r *Registry) List() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
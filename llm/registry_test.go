@@ -0,0 +1,58 @@
+package llm
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+
+	openai := &OpenAIProvider{name: "openai"}
+	ollama := NewOllama("")
+
+	if err := registry.Register("openai", openai); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := registry.Register("local", ollama); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := registry.Register("", ollama); err == nil {
+		t.Error("expected error for empty name")
+	}
+	if err := registry.Register("nil", nil); err == nil {
+		t.Error("expected error for nil provider")
+	}
+
+	got, err := registry.Get("local")
+	if err != nil || got != ollama {
+		t.Errorf("expected ollama provider, got %v (%v)", got, err)
+	}
+
+	if _, err := registry.Get("missing"); !errors.Is(err, ErrProviderNotFound) {
+		t.Errorf("expected ErrProviderNotFound, got %v", err)
+	}
+
+	if names := registry.List(); len(names) != 2 || names[0] != "local" || names[1] != "openai" {
+		t.Errorf("unexpected names: %v", names)
+	}
+
+	registry.Remove("local")
+	if _, err := registry.Get("local"); err == nil {
+		t.Error("expected provider to be removed")
+	}
+}
+
+func TestDefaultModel(t *testing.T) {
+	tests := map[string]string{
+		"openai":    "gpt-4",
+		"ollama":    "llama3",
+		"anthropic": "claude-3-5-sonnet-20241022",
+		"custom":    "",
+	}
+	for provider, want := range tests {
+		if got := DefaultModel(provider); got != want {
+			t.Errorf("DefaultModel(%q) = %q, want %q", provider, got, want)
+		}
+	}
+}
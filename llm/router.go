@@ -0,0 +1,330 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/Ranganaths/minion/resilience"
+	"github.com/sashabaranov/go-openai"
+)
+
+var (
+	// ErrNoRouteTarget is returned when no target can serve a request,
+	// e.g. because every target's context window is too small
+	ErrNoRouteTarget = errors.New("no route target can serve the request")
+
+	// ErrAllTargetsFailed is returned when every eligible target failed
+	ErrAllTargetsFailed = errors.New("all route targets failed")
+)
+
+// RouteTarget is a provider and model a Router can send requests to
+type RouteTarget struct {
+	Provider Provider
+
+	// Model replaces the request's model. When empty, the request's model is
+	// kept, or the provider's default model is used if the request has none.
+	// Set it when targets are from different vendors.
+	Model string
+
+	// Name keys the target's circuit breaker and rate limiter
+	// (default Provider.Name())
+	Name string
+
+	// MaxContextTokens skips the target for requests whose estimated prompt
+	// plus MaxTokens exceed it (0 = no limit)
+	MaxContextTokens int
+
+	// CostPer1KTokens orders targets when RouterConfig.RouteByCost is set
+	CostPer1KTokens float64
+}
+
+// name returns the key of the target's circuit breaker and rate limiter
+func (t RouteTarget) name() string {
+	if t.Name != "" {
+		return t.Name
+	}
+	return t.Provider.Name()
+}
+
+// model returns the model to request from the target
+func (t RouteTarget) model(requested string) string {
+	switch {
+	case t.Model != "":
+		return t.Model
+	case requested != "":
+		return requested
+	default:
+		return DefaultModel(t.Provider.Name())
+	}
+}
+
+// RouterConfig configures a Router
+type RouterConfig struct {
+	// Name is the provider name reported by Name (default "router")
+	Name string
+
+	// Targets are tried in order until one succeeds
+	Targets []RouteTarget
+
+	// RetryPolicy retries each target before failing over to the next.
+	// Errors are retryable if RetryPolicy.RetryableErrors says so, or by
+	// IsRetryableProviderError when it is nil. Default:
+	// resilience.DefaultRetryPolicy.
+	RetryPolicy *resilience.RetryPolicy
+
+	// CircuitBreakers supplies a breaker per target name. A target whose
+	// breaker is open is skipped. Default:
+	// resilience.NewDefaultCircuitBreakerRegistry.
+	CircuitBreakers *resilience.CircuitBreakerRegistry
+
+	// Limiters rate limit requests per target name (optional)
+	Limiters *resilience.ProviderLimiters
+
+	// RouteByCost tries the cheapest eligible targets first
+	RouteByCost bool
+
+	// OnFailover is called when a target fails and the next is tried (optional)
+	OnFailover func(target string, err error)
+}
+
+// Router is a Provider that sends each request to an ordered list of
+// targets, retrying each according to a retry policy and failing over to
+// the next target when one's circuit breaker is open or it keeps returning
+// retryable errors. Non-retryable errors, such as invalid requests, are
+// returned without failing over.
+type Router struct {
+	name       string
+	targets    []RouteTarget
+	policy     resilience.RetryPolicy
+	retryable  func(error) bool
+	breakers   *resilience.CircuitBreakerRegistry
+	limiters   *resilience.ProviderLimiters
+	byCost     bool
+	onFailover func(target string, err error)
+}
+
+var _ StreamingProvider = (*Router)(nil)
+
+// NewRouter creates a router
+func NewRouter(cfg RouterConfig) (*Router, error) {
+	if len(cfg.Targets) == 0 {
+		return nil, fmt.Errorf("router requires at least one target")
+	}
+	for i, target := range cfg.Targets {
+		if target.Provider == nil {
+			return nil, fmt.Errorf("route target %d: provider is required", i)
+		}
+	}
+
+	r := &Router{
+		name:       cfg.Name,
+		targets:    cfg.Targets,
+		breakers:   cfg.CircuitBreakers,
+		limiters:   cfg.Limiters,
+		byCost:     cfg.RouteByCost,
+		onFailover: cfg.OnFailover,
+	}
+	if r.name == "" {
+		r.name = "router"
+	}
+	if r.breakers == nil {
+		r.breakers = resilience.NewDefaultCircuitBreakerRegistry()
+	}
+
+	if cfg.RetryPolicy != nil {
+		r.policy = *cfg.RetryPolicy
+	} else {
+		r.policy = *resilience.DefaultRetryPolicy()
+	}
+	r.retryable = r.policy.RetryableErrors
+	if r.retryable == nil {
+		r.retryable = IsRetryableProviderError
+	}
+	// An open circuit is never retried on the same target
+	r.policy.RetryableErrors = func(err error) bool {
+		return !errors.Is(err, resilience.ErrCircuitOpen) && r.retryable(err)
+	}
+
+	return r, nil
+}
+
+// Name returns the router name
+func (r *Router) Name() string {
+	return r.name
+}
+
+// GenerateCompletion sends a completion request to the first target that
+// succeeds
+func (r *Router) GenerateCompletion(ctx context.Context, req *CompletionRequest) (*CompletionResponse, error) {
+	tokens := estimateTokens(req.SystemPrompt) + estimateTokens(req.UserPrompt) + req.MaxTokens
+	return route(ctx, r, tokens, func(ctx context.Context, target RouteTarget) (*CompletionResponse, error) {
+		targetReq := *req
+		targetReq.Model = target.model(req.Model)
+		return target.Provider.GenerateCompletion(ctx, &targetReq)
+	})
+}
+
+// GenerateChat sends a chat request to the first target that succeeds
+func (r *Router) GenerateChat(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+	return route(ctx, r, estimateChatTokens(req), func(ctx context.Context, target RouteTarget) (*ChatResponse, error) {
+		targetReq := *req
+		targetReq.Model = target.model(req.Model)
+		return target.Provider.GenerateChat(ctx, &targetReq)
+	})
+}
+
+// StreamChat streams from the first target that starts a stream. Failover
+// happens only while starting: errors after the first chunk are delivered
+// on the channel. Targets that cannot stream answer with a single chunk.
+func (r *Router) StreamChat(ctx context.Context, req *ChatRequest) (<-chan ChatChunk, error) {
+	return route(ctx, r, estimateChatTokens(req), func(ctx context.Context, target RouteTarget) (<-chan ChatChunk, error) {
+		targetReq := *req
+		targetReq.Model = target.model(req.Model)
+
+		if streaming, ok := target.Provider.(StreamingProvider); ok {
+			return streaming.StreamChat(ctx, &targetReq)
+		}
+
+		resp, err := target.Provider.GenerateChat(ctx, &targetReq)
+		if err != nil {
+			return nil, err
+		}
+		return responseStream(resp), nil
+	})
+}
+
+// candidates returns the targets that can serve a request of the given
+// estimated size, in the order they should be tried
+func (r *Router) candidates(tokens int) []RouteTarget {
+	targets := make([]RouteTarget, 0, len(r.targets))
+	for _, target := range r.targets {
+		if target.MaxContextTokens > 0 && tokens > target.MaxContextTokens {
+			continue
+		}
+		targets = append(targets, target)
+	}
+
+	if r.byCost {
+		sort.SliceStable(targets, func(i, j int) bool {
+			return targets[i].CostPer1KTokens < targets[j].CostPer1KTokens
+		})
+	}
+	return targets
+}
+
+// route calls each candidate target in turn through its rate limiter,
+// circuit breaker and the retry policy, returning the first success
+func route[T any](ctx context.Context, r *Router, tokens int, call func(context.Context, RouteTarget) (T, error)) (T, error) {
+	var zero T
+
+	targets := r.candidates(tokens)
+	if len(targets) == 0 {
+		return zero, fmt.Errorf("%w: about %d tokens", ErrNoRouteTarget, tokens)
+	}
+
+	var lastErr error
+	for i, target := range targets {
+		name := target.name()
+		breaker := r.breakers.Get(name)
+		var limiter resilience.RateLimiter
+		if r.limiters != nil {
+			limiter = r.limiters.Get(name)
+		}
+
+		var targetErr error
+		result, err := resilience.RetryWithResult(ctx, &r.policy, func() (T, error) {
+			result, err := resilience.DoWithResult(ctx, limiter, breaker, func(ctx context.Context) (T, error) {
+				return call(ctx, target)
+			})
+			targetErr = err
+			return result, err
+		})
+		if err == nil {
+			return result, nil
+		}
+		if targetErr == nil || ctx.Err() != nil {
+			// Cancelled while waiting to retry or for the rate limiter
+			return zero, err
+		}
+
+		lastErr = fmt.Errorf("%s: %w", name, targetErr)
+		if !errors.Is(targetErr, resilience.ErrCircuitOpen) && !r.retryable(targetErr) {
+			return zero, lastErr
+		}
+		if r.onFailover != nil && i < len(targets)-1 {
+			r.onFailover(name, targetErr)
+		}
+	}
+
+	return zero, fmt.Errorf("%w: %w", ErrAllTargetsFailed, lastErr)
+}
+
+// statusPattern matches the status code in errors of the HTTP providers
+var statusPattern = regexp.MustCompile(`\(status (\d{3})\)`)
+
+// IsRetryableProviderError reports whether a provider error is transient:
+// rate limiting, timeouts, server errors and network failures are; invalid
+// requests, authentication failures and cancellation are not
+func IsRetryableProviderError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return false
+	}
+
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		return isRetryableStatus(apiErr.HTTPStatusCode)
+	}
+	var requestErr *openai.RequestError
+	if errors.As(err, &requestErr) {
+		return isRetryableStatus(requestErr.HTTPStatusCode)
+	}
+	if match := statusPattern.FindStringSubmatch(err.Error()); match != nil {
+		status, _ := strconv.Atoi(match[1])
+		return isRetryableStatus(status)
+	}
+
+	return resilience.IsRetryableError(err)
+}
+
+// isRetryableStatus reports whether an HTTP status indicates a transient failure
+func isRetryableStatus(status int) bool {
+	switch {
+	case status == http.StatusRequestTimeout, status == http.StatusConflict, status == http.StatusTooManyRequests:
+		return true
+	case status >= 500:
+		return true
+	case status == 0:
+		// No response was received
+		return true
+	default:
+		return false
+	}
+}
+
+// estimateTokens roughly estimates the token count of text at four
+// characters per token
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// estimateChatTokens estimates the prompt plus the requested completion size
+func estimateChatTokens(req *ChatRequest) int {
+	tokens := req.MaxTokens
+	for _, msg := range req.Messages {
+		tokens += estimateTokens(msg.Text()) + 4
+		for _, call := range msg.ToolCalls {
+			tokens += estimateTokens(call.Name) + estimateTokens(call.Arguments)
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,320 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ranganaths/minion/resilience"
+	"github.com/sashabaranov/go-openai"
+)
+
+// scriptedProvider fails with the scripted errors in turn, then succeeds
+type scriptedProvider struct {
+	name string
+	errs []error
+
+	mu     sync.Mutex
+	calls  int
+	models []string
+}
+
+func (p *scriptedProvider) Name() string { return p.name }
+
+func (p *scriptedProvider) next(model string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	p.models = append(p.models, model)
+	if len(p.errs) > 0 {
+		err := p.errs[0]
+		p.errs = p.errs[1:]
+		return err
+	}
+	return nil
+}
+
+func (p *scriptedProvider) GenerateCompletion(ctx context.Context, req *CompletionRequest) (*CompletionResponse, error) {
+	if err := p.next(req.Model); err != nil {
+		return nil, err
+	}
+	return &CompletionResponse{Text: p.name, Model: req.Model}, nil
+}
+
+func (p *scriptedProvider) GenerateChat(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+	if err := p.next(req.Model); err != nil {
+		return nil, err
+	}
+	return &ChatResponse{Message: Message{Role: "assistant", Content: p.name}, Model: req.Model, TokensUsed: 3}, nil
+}
+
+func fastRetryPolicy(attempts int) *resilience.RetryPolicy {
+	return &resilience.RetryPolicy{MaxAttempts: attempts, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, Multiplier: 1}
+}
+
+func outage() error {
+	return fmt.Errorf("anthropic API error (status 529): overloaded")
+}
+
+func TestRouter_FailsOverOnRetryableErrors(t *testing.T) {
+	anthropic := &scriptedProvider{name: "anthropic", errs: []error{outage(), outage()}}
+	openaiProvider := &scriptedProvider{name: "openai"}
+
+	var failovers []string
+	router, err := NewRouter(RouterConfig{
+		Targets: []RouteTarget{
+			{Provider: anthropic, Model: "claude-3-5-sonnet-20241022"},
+			{Provider: openaiProvider, Model: "gpt-4o"},
+		},
+		RetryPolicy: fastRetryPolicy(2),
+		OnFailover:  func(target string, err error) { failovers = append(failovers, target) },
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := router.GenerateCompletion(context.Background(), &CompletionRequest{UserPrompt: "hi", Model: "ignored"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Text != "openai" || resp.Model != "gpt-4o" {
+		t.Errorf("expected the openai target to answer with gpt-4o, got %+v", resp)
+	}
+	if anthropic.calls != 2 {
+		t.Errorf("expected the anthropic target to be retried once, got %d calls", anthropic.calls)
+	}
+	if len(failovers) != 1 || failovers[0] != "anthropic" {
+		t.Errorf("unexpected failovers: %v", failovers)
+	}
+}
+
+func TestRouter_RetriesBeforeFailingOver(t *testing.T) {
+	anthropic := &scriptedProvider{name: "anthropic", errs: []error{outage()}}
+	openaiProvider := &scriptedProvider{name: "openai"}
+
+	router, _ := NewRouter(RouterConfig{
+		Targets:     []RouteTarget{{Provider: anthropic}, {Provider: openaiProvider}},
+		RetryPolicy: fastRetryPolicy(3),
+	})
+
+	resp, err := router.GenerateChat(context.Background(), &ChatRequest{Messages: []Message{{Role: "user", Content: "hi"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Message.Content != "anthropic" {
+		t.Errorf("expected the retry to succeed on anthropic, got %s", resp.Message.Content)
+	}
+	if resp.Model != DefaultModel("anthropic") {
+		t.Errorf("expected the default anthropic model, got %s", resp.Model)
+	}
+	if openaiProvider.calls != 0 {
+		t.Errorf("expected no failover, got %d openai calls", openaiProvider.calls)
+	}
+}
+
+func TestRouter_NonRetryableErrorIsReturned(t *testing.T) {
+	anthropic := &scriptedProvider{name: "anthropic", errs: []error{fmt.Errorf("anthropic API error (status 400): bad request")}}
+	openaiProvider := &scriptedProvider{name: "openai"}
+
+	router, _ := NewRouter(RouterConfig{
+		Targets:     []RouteTarget{{Provider: anthropic}, {Provider: openaiProvider}},
+		RetryPolicy: fastRetryPolicy(3),
+	})
+
+	_, err := router.GenerateCompletion(context.Background(), &CompletionRequest{UserPrompt: "hi"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if anthropic.calls != 1 || openaiProvider.calls != 0 {
+		t.Errorf("expected a single call and no failover, got anthropic=%d openai=%d", anthropic.calls, openaiProvider.calls)
+	}
+}
+
+func TestRouter_SkipsOpenCircuit(t *testing.T) {
+	anthropic := &scriptedProvider{name: "anthropic", errs: []error{outage(), outage(), outage()}}
+	openaiProvider := &scriptedProvider{name: "openai"}
+
+	breakers := resilience.NewCircuitBreakerRegistry(func(name string) *resilience.CircuitBreaker {
+		return resilience.NewCircuitBreaker(resilience.CircuitBreakerConfig{Name: name, FailureThreshold: 1, Timeout: time.Hour})
+	})
+	router, _ := NewRouter(RouterConfig{
+		Targets:         []RouteTarget{{Provider: anthropic}, {Provider: openaiProvider}},
+		RetryPolicy:     fastRetryPolicy(3),
+		CircuitBreakers: breakers,
+	})
+
+	for i := 0; i < 3; i++ {
+		resp, err := router.GenerateCompletion(context.Background(), &CompletionRequest{UserPrompt: "hi"})
+		if err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i, err)
+		}
+		if resp.Text != "openai" {
+			t.Errorf("request %d: expected openai, got %s", i, resp.Text)
+		}
+	}
+
+	// The first failure opens the circuit, so anthropic is neither retried
+	// nor called again
+	if anthropic.calls != 1 {
+		t.Errorf("expected 1 anthropic call, got %d", anthropic.calls)
+	}
+	if breakers.Get("anthropic").State() != resilience.StateOpen {
+		t.Errorf("expected the anthropic circuit to be open")
+	}
+}
+
+func TestRouter_AllTargetsFailed(t *testing.T) {
+	router, _ := NewRouter(RouterConfig{
+		Targets: []RouteTarget{
+			{Provider: &scriptedProvider{name: "a", errs: []error{outage()}}},
+			{Provider: &scriptedProvider{name: "b", errs: []error{outage()}}},
+		},
+		RetryPolicy: fastRetryPolicy(1),
+	})
+
+	_, err := router.GenerateCompletion(context.Background(), &CompletionRequest{UserPrompt: "hi"})
+	if !errors.Is(err, ErrAllTargetsFailed) {
+		t.Errorf("expected ErrAllTargetsFailed, got %v", err)
+	}
+}
+
+func TestRouter_RoutesByContextAndCost(t *testing.T) {
+	small := &scriptedProvider{name: "small"}
+	cheap := &scriptedProvider{name: "cheap"}
+	large := &scriptedProvider{name: "large"}
+
+	router, _ := NewRouter(RouterConfig{
+		Targets: []RouteTarget{
+			{Provider: large, MaxContextTokens: 100000, CostPer1KTokens: 0.01},
+			{Provider: small, MaxContextTokens: 100, CostPer1KTokens: 0.0001},
+			{Provider: cheap, MaxContextTokens: 8000, CostPer1KTokens: 0.001},
+		},
+		RetryPolicy: fastRetryPolicy(1),
+		RouteByCost: true,
+	})
+
+	tests := []struct {
+		name   string
+		prompt string
+		want   string
+	}{
+		{name: "short prompt uses the cheapest target", prompt: "hi", want: "small"},
+		{name: "medium prompt skips the small context", prompt: string(make([]byte, 4000)), want: "cheap"},
+		{name: "long prompt needs the large context", prompt: string(make([]byte, 100000)), want: "large"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := router.GenerateCompletion(context.Background(), &CompletionRequest{UserPrompt: tt.prompt})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Text != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, resp.Text)
+			}
+		})
+	}
+
+	_, err := router.GenerateCompletion(context.Background(), &CompletionRequest{UserPrompt: string(make([]byte, 500000))})
+	if !errors.Is(err, ErrNoRouteTarget) {
+		t.Errorf("expected ErrNoRouteTarget, got %v", err)
+	}
+}
+
+// countingLimiter records waits per provider
+type countingLimiter struct {
+	mu    *sync.Mutex
+	waits map[string]int
+	name  string
+}
+
+func (l countingLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.waits[l.name]++
+	return nil
+}
+func (l countingLimiter) TryAcquire() bool { return true }
+func (l countingLimiter) Limit() float64   { return 1 }
+
+func TestRouter_RespectsLimiters(t *testing.T) {
+	var mu sync.Mutex
+	waits := make(map[string]int)
+	limiters := resilience.NewProviderLimiters(func(provider string) resilience.RateLimiter {
+		return countingLimiter{mu: &mu, waits: waits, name: provider}
+	})
+
+	router, _ := NewRouter(RouterConfig{
+		Targets: []RouteTarget{
+			{Provider: &scriptedProvider{name: "anthropic", errs: []error{outage(), outage()}}},
+			{Provider: &scriptedProvider{name: "openai"}, Name: "openai-eu"},
+		},
+		RetryPolicy: fastRetryPolicy(2),
+		Limiters:    limiters,
+	})
+
+	if _, err := router.GenerateCompletion(context.Background(), &CompletionRequest{UserPrompt: "hi"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if waits["anthropic"] != 2 || waits["openai-eu"] != 1 {
+		t.Errorf("expected a limiter wait per attempt, got %v", waits)
+	}
+}
+
+func TestRouter_StreamChatFallsBackToNonStreamingTarget(t *testing.T) {
+	router, _ := NewRouter(RouterConfig{
+		Targets: []RouteTarget{
+			{Provider: &scriptedProvider{name: "anthropic", errs: []error{outage()}}},
+			{Provider: &scriptedProvider{name: "openai"}},
+		},
+		RetryPolicy: fastRetryPolicy(1),
+	})
+
+	ch, err := router.StreamChat(context.Background(), &ChatRequest{Messages: []Message{{Role: "user", Content: "hi"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content, final := collectChunks(t, ch)
+	if content != "openai" || !final.Done || final.TokensUsed != 3 {
+		t.Errorf("unexpected stream: %q %+v", content, final)
+	}
+}
+
+func TestNewRouter_Validation(t *testing.T) {
+	if _, err := NewRouter(RouterConfig{}); err == nil {
+		t.Error("expected error without targets")
+	}
+	if _, err := NewRouter(RouterConfig{Targets: []RouteTarget{{}}}); err == nil {
+		t.Error("expected error for a target without a provider")
+	}
+}
+
+func TestIsRetryableProviderError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "rate limited", err: fmt.Errorf("gemini API error (status 429): quota"), want: true},
+		{name: "server error", err: fmt.Errorf("anthropic API error (status 503): down"), want: true},
+		{name: "bad request", err: fmt.Errorf("anthropic API error (status 400): invalid"), want: false},
+		{name: "unauthorized", err: fmt.Errorf("gemini API error (status 401): key"), want: false},
+		{name: "openai rate limit", err: fmt.Errorf("openai chat error: %w", &openai.APIError{HTTPStatusCode: 429}), want: true},
+		{name: "openai invalid request", err: &openai.APIError{HTTPStatusCode: 400}, want: false},
+		{name: "openai request error", err: &openai.RequestError{HTTPStatusCode: 502}, want: true},
+		{name: "validation", err: &ValidationError{Field: "Model", Message: "required"}, want: false},
+		{name: "cancelled", err: fmt.Errorf("request: %w", context.Canceled), want: false},
+		{name: "deadline", err: context.DeadlineExceeded, want: true},
+		{name: "network", err: errors.New("connection refused"), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryableProviderError(tt.err); got != tt.want {
+				t.Errorf("IsRetryableProviderError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
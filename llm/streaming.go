@@ -0,0 +1,225 @@
+package llm
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrStreamStopped is returned by CollectStream when the caller stops consuming
+// chunks before the stream is complete.
+var ErrStreamStopped = errors.New("stream stopped by caller")
+
+// StreamingProvider extends Provider with token-level streaming.
+// Providers that can return output incrementally should implement this interface.
+type StreamingProvider interface {
+	Provider
+
+	// StreamChat streams a chat response as it is generated.
+	// The returned channel is closed when the response is complete, an error
+	// occurs, or the context is cancelled. The final chunk has Done set and
+	// carries the finish reason and token usage reported by the provider.
+	StreamChat(ctx context.Context, req *ChatRequest) (<-chan ChatChunk, error)
+}
+
+// ChatChunk represents an incremental piece of a streamed chat response.
+type ChatChunk struct {
+	// Content is the text generated since the previous chunk
+	Content string
+
+	// Done indicates this is the final chunk of the stream
+	Done bool
+
+	// FinishReason is set on the final chunk
+	FinishReason string
+
+	// TokensUsed is set on the final chunk when the provider reports usage
+	TokensUsed int
+
+	// Usage is set on the final chunk when the provider reports usage
+	Usage Usage
+
+	// Model is the model that generated the response
+	Model string
+
+	// ToolCalls are the complete tool calls requested by the model, set on the final chunk
+	ToolCalls []ToolCall
+
+	// Err is set if the stream failed; no further chunks follow
+	Err error
+}
+
+// ToChatRequest converts a completion request into an equivalent chat request.
+// This lets completion-style callers use chat-only APIs such as StreamChat.
+func (r *CompletionRequest) ToChatRequest() *ChatRequest {
+	messages := make([]Message, 0, 2)
+	if r.SystemPrompt != "" {
+		messages = append(messages, Message{Role: "system", Content: r.SystemPrompt})
+	}
+	if r.UserPrompt != "" {
+		messages = append(messages, Message{Role: "user", Content: r.UserPrompt})
+	}
+	return &ChatRequest{
+		Messages:    messages,
+		Temperature: r.Temperature,
+		MaxTokens:   r.MaxTokens,
+		Model:       r.Model,
+	}
+}
+
+// CollectStream drains a chunk channel and assembles the full chat response.
+// onChunk is invoked for every non-empty content chunk; returning false stops
+// collection early with the context error, or ErrStreamStopped if the context
+// is still live.
+func CollectStream(ctx context.Context, chunks <-chan ChatChunk, onChunk func(ChatChunk) bool) (*ChatResponse, error) {
+	var sb strings.Builder
+	resp := &ChatResponse{Message: Message{Role: "assistant"}}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case chunk, ok := <-chunks:
+			if !ok {
+				resp.Message.Content = sb.String()
+				return resp, nil
+			}
+			if chunk.Err != nil {
+				return nil, chunk.Err
+			}
+			if chunk.Content != "" {
+				sb.WriteString(chunk.Content)
+				if onChunk != nil && !onChunk(chunk) {
+					if err := ctx.Err(); err != nil {
+						return nil, err
+					}
+					return nil, ErrStreamStopped
+				}
+			}
+			if chunk.Model != "" {
+				resp.Model = chunk.Model
+			}
+			if chunk.Done {
+				resp.FinishReason = chunk.FinishReason
+				resp.TokensUsed = chunk.TokensUsed
+				resp.Usage = chunk.Usage
+				resp.Message.ToolCalls = chunk.ToolCalls
+			}
+		}
+	}
+}
+
+// responseStream returns a closed channel holding a complete response as a
+// content chunk and a final chunk, for providers that cannot stream
+func responseStream(resp *ChatResponse) <-chan ChatChunk {
+	ch := make(chan ChatChunk, 2)
+	if resp.Message.Content != "" {
+		ch <- ChatChunk{Content: resp.Message.Content, Model: resp.Model}
+	}
+	ch <- ChatChunk{
+		Done:         true,
+		FinishReason: resp.FinishReason,
+		TokensUsed:   resp.TokensUsed,
+		Usage:        resp.Usage,
+		Model:        resp.Model,
+		ToolCalls:    resp.Message.ToolCalls,
+	}
+	close(ch)
+	return ch
+}
+
+// chunkSender wraps a chunk channel with context-aware sends.
+type chunkSender struct {
+	ctx context.Context
+	ch  chan<- ChatChunk
+
+	// finished is set once a final or error chunk has been delivered
+	finished bool
+}
+
+// send delivers a chunk, returning false if the context was cancelled.
+func (s *chunkSender) send(chunk ChatChunk) bool {
+	select {
+	case <-s.ctx.Done():
+		return false
+	case s.ch <- chunk:
+		if chunk.Done || chunk.Err != nil {
+			s.finished = true
+		}
+		return true
+	}
+}
+
+// close closes the channel. A stream cut short by a cancelled context ends
+// with an error chunk first, so readers never mistake a truncated answer for
+// a complete one. The error is sent without blocking, so a channel that is
+// no longer read does not leak the goroutine.
+func (s *chunkSender) close() {
+	if !s.finished && s.ctx.Err() != nil {
+		select {
+		case s.ch <- ChatChunk{Err: s.ctx.Err()}:
+		default:
+		}
+	}
+	close(s.ch)
+}
+
+// readSSE reads a Server-Sent Events stream and calls fn for every event.
+// Events without an explicit type are reported with an empty event name.
+// Reading stops when fn returns false, the stream ends, or a read error occurs.
+func readSSE(r io.Reader, fn func(event, data string) bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var event string
+	var data []string
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" {
+			if len(data) > 0 {
+				if !fn(event, strings.Join(data, "\n")) {
+					return nil
+				}
+			}
+			event = ""
+			data = data[:0]
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue // comment
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	// Flush a trailing event not followed by a blank line
+	if len(data) > 0 {
+		fn(event, strings.Join(data, "\n"))
+	}
+	return nil
+}
+
+// Compile-time checks that the built-in providers support streaming
+var (
+	_ StreamingProvider = (*OpenAIProvider)(nil)
+	_ StreamingProvider = (*AnthropicProvider)(nil)
+	_ StreamingProvider = (*OllamaProvider)(nil)
+	_ StreamingProvider = (*TupleLeapProvider)(nil)
+)
@@ -0,0 +1,375 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// collectChunks drains a chunk stream and returns the concatenated content and final chunk
+func collectChunks(t *testing.T, ch <-chan ChatChunk) (string, ChatChunk) {
+	t.Helper()
+	var sb strings.Builder
+	var final ChatChunk
+	for chunk := range ch {
+		if chunk.Err != nil {
+			t.Fatalf("unexpected stream error: %v", chunk.Err)
+		}
+		sb.WriteString(chunk.Content)
+		if chunk.Done {
+			final = chunk
+		}
+	}
+	return sb.String(), final
+}
+
+func writeSSE(w http.ResponseWriter, event string, data any) {
+	payload, _ := json.Marshal(data)
+	if event != "" {
+		fmt.Fprintf(w, "event: %s\n", event)
+	}
+	fmt.Fprintf(w, "data: %s\n\n", payload)
+	w.(http.Flusher).Flush()
+}
+
+func TestOpenAIProvider_StreamChat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		json.NewDecoder(r.Body).Decode(&req)
+		if req["stream"] != true {
+			t.Errorf("expected stream=true, got %v", req["stream"])
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, token := range []string{"Hello", ", ", "world"} {
+			writeSSE(w, "", map[string]any{
+				"model":   "gpt-4",
+				"choices": []map[string]any{{"delta": map[string]any{"content": token}}},
+			})
+		}
+		writeSSE(w, "", map[string]any{
+			"model":   "gpt-4",
+			"choices": []map[string]any{{"delta": map[string]any{}, "finish_reason": "stop"}},
+		})
+		writeSSE(w, "", map[string]any{
+			"model":   "gpt-4",
+			"choices": []map[string]any{},
+			"usage":   map[string]any{"prompt_tokens": 5, "completion_tokens": 3, "total_tokens": 8},
+		})
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	cfg := openai.DefaultConfig("test-key")
+	cfg.BaseURL = server.URL
+	provider := &OpenAIProvider{client: openai.NewClientWithConfig(cfg), name: "openai"}
+
+	ch, err := provider.StreamChat(context.Background(), &ChatRequest{
+		Model:    "gpt-4",
+		Messages: []Message{{Role: "user", Content: "Hi"}},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat failed: %v", err)
+	}
+
+	text, final := collectChunks(t, ch)
+	if text != "Hello, world" {
+		t.Errorf("expected 'Hello, world', got %q", text)
+	}
+	if !final.Done || final.FinishReason != "stop" || final.TokensUsed != 8 {
+		t.Errorf("unexpected final chunk: %+v", final)
+	}
+	if want := (Usage{PromptTokens: 5, CompletionTokens: 3, TotalTokens: 8}); final.Usage != want {
+		t.Errorf("expected usage %+v, got %+v", want, final.Usage)
+	}
+}
+
+func TestOpenAIProvider_StreamChatCancelled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSE(w, "", map[string]any{
+			"model":   "gpt-4",
+			"choices": []map[string]any{{"delta": map[string]any{"content": "Hello"}}},
+		})
+		// Stall until the client goes away
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+
+	cfg := openai.DefaultConfig("test-key")
+	cfg.BaseURL = server.URL
+	provider := &OpenAIProvider{client: openai.NewClientWithConfig(cfg), name: "openai"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := provider.StreamChat(ctx, &ChatRequest{
+		Model:    "gpt-4",
+		Messages: []Message{{Role: "user", Content: "Hi"}},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat failed: %v", err)
+	}
+
+	if chunk := <-ch; chunk.Content != "Hello" {
+		t.Fatalf("unexpected first chunk: %+v", chunk)
+	}
+	cancel()
+
+	// A reader that does not watch the stream's context still sees the error
+	resp, err := CollectStream(context.Background(), ch, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v (response %+v)", err, resp)
+	}
+}
+
+func TestAnthropicProvider_StreamChat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req anthropicRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if !req.Stream {
+			t.Error("expected stream=true")
+		}
+		if req.System != "Be brief" {
+			t.Errorf("expected system prompt, got %q", req.System)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSE(w, "message_start", map[string]any{
+			"type":    "message_start",
+			"message": map[string]any{"model": "claude-3", "usage": map[string]any{"input_tokens": 10, "cache_read_input_tokens": 6}},
+		})
+		writeSSE(w, "content_block_start", map[string]any{"type": "content_block_start", "index": 0})
+		writeSSE(w, "ping", map[string]any{"type": "ping"})
+		for _, token := range []string{"Hi", " there"} {
+			writeSSE(w, "content_block_delta", map[string]any{
+				"type":  "content_block_delta",
+				"delta": map[string]any{"type": "text_delta", "text": token},
+			})
+		}
+		writeSSE(w, "content_block_stop", map[string]any{"type": "content_block_stop", "index": 0})
+		writeSSE(w, "message_delta", map[string]any{
+			"type":  "message_delta",
+			"delta": map[string]any{"stop_reason": "end_turn"},
+			"usage": map[string]any{"output_tokens": 4},
+		})
+		writeSSE(w, "message_stop", map[string]any{"type": "message_stop"})
+	}))
+	defer server.Close()
+
+	provider := NewAnthropic("test-key")
+	provider.baseURL = server.URL
+
+	ch, err := provider.StreamChat(context.Background(), &ChatRequest{
+		Model: "claude-3",
+		Messages: []Message{
+			{Role: "system", Content: "Be brief"},
+			{Role: "user", Content: "Hello"},
+		},
+		MaxTokens: 100,
+	})
+	if err != nil {
+		t.Fatalf("StreamChat failed: %v", err)
+	}
+
+	text, final := collectChunks(t, ch)
+	if text != "Hi there" {
+		t.Errorf("expected 'Hi there', got %q", text)
+	}
+	if final.FinishReason != "end_turn" || final.TokensUsed != 20 || final.Model != "claude-3" {
+		t.Errorf("unexpected final chunk: %+v", final)
+	}
+	// Cached prompt tokens are reported separately from input_tokens
+	if want := (Usage{PromptTokens: 16, CompletionTokens: 4, CachedTokens: 6, TotalTokens: 20}); final.Usage != want {
+		t.Errorf("expected usage %+v, got %+v", want, final.Usage)
+	}
+}
+
+func TestAnthropicProvider_StreamChatError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSE(w, "error", map[string]any{
+			"type":  "error",
+			"error": map[string]any{"type": "overloaded_error", "message": "Overloaded"},
+		})
+	}))
+	defer server.Close()
+
+	provider := NewAnthropic("test-key")
+	provider.baseURL = server.URL
+
+	ch, err := provider.StreamChat(context.Background(), &ChatRequest{
+		Model:    "claude-3",
+		Messages: []Message{{Role: "user", Content: "Hello"}},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat failed: %v", err)
+	}
+
+	_, err = CollectStream(context.Background(), ch, nil)
+	if err == nil || !strings.Contains(err.Error(), "overloaded_error") {
+		t.Errorf("expected overloaded error, got %v", err)
+	}
+}
+
+func TestOllamaProvider_StreamChat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/chat" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		var req ollamaRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if !req.Stream {
+			t.Error("expected stream=true")
+		}
+
+		enc := json.NewEncoder(w)
+		for _, token := range []string{"The", " answer", " is 42"} {
+			enc.Encode(map[string]any{
+				"model":   "llama3",
+				"message": map[string]any{"role": "assistant", "content": token},
+				"done":    false,
+			})
+			w.(http.Flusher).Flush()
+		}
+		enc.Encode(map[string]any{
+			"model":             "llama3",
+			"message":           map[string]any{"role": "assistant", "content": ""},
+			"done":              true,
+			"done_reason":       "stop",
+			"prompt_eval_count": 7,
+			"eval_count":        5,
+		})
+	}))
+	defer server.Close()
+
+	provider := NewOllama(server.URL)
+
+	ch, err := provider.StreamChat(context.Background(), &ChatRequest{
+		Model:    "llama3",
+		Messages: []Message{{Role: "user", Content: "What is the answer?"}},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat failed: %v", err)
+	}
+
+	text, final := collectChunks(t, ch)
+	if text != "The answer is 42" {
+		t.Errorf("expected 'The answer is 42', got %q", text)
+	}
+	if final.FinishReason != "stop" || final.TokensUsed != 12 {
+		t.Errorf("unexpected final chunk: %+v", final)
+	}
+}
+
+func TestTupleLeapProvider_StreamChat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			t.Errorf("missing bearer token")
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, token := range []string{"foo", "bar"} {
+			writeSSE(w, "", map[string]any{
+				"model":   "tl-1",
+				"choices": []map[string]any{{"delta": map[string]any{"content": token}}},
+			})
+		}
+		writeSSE(w, "", map[string]any{
+			"model":   "tl-1",
+			"choices": []map[string]any{{"delta": map[string]any{}, "finish_reason": "length"}},
+			"usage":   map[string]any{"total_tokens": 20},
+		})
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	provider := NewTupleLeapWithBaseURL("test-key", server.URL)
+
+	ch, err := provider.StreamChat(context.Background(), &ChatRequest{
+		Model:    "tl-1",
+		Messages: []Message{{Role: "user", Content: "Hi"}},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat failed: %v", err)
+	}
+
+	text, final := collectChunks(t, ch)
+	if text != "foobar" {
+		t.Errorf("expected 'foobar', got %q", text)
+	}
+	if final.FinishReason != "length" || final.TokensUsed != 20 {
+		t.Errorf("unexpected final chunk: %+v", final)
+	}
+}
+
+func TestStreamChat_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad request", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	provider := NewOllama(server.URL)
+	_, err := provider.StreamChat(context.Background(), &ChatRequest{
+		Model:    "llama3",
+		Messages: []Message{{Role: "user", Content: "Hi"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "status 400") {
+		t.Errorf("expected status error, got %v", err)
+	}
+}
+
+func TestCollectStream(t *testing.T) {
+	ch := make(chan ChatChunk, 4)
+	ch <- ChatChunk{Content: "a", Model: "m"}
+	ch <- ChatChunk{Content: "b"}
+	ch <- ChatChunk{Done: true, FinishReason: "stop", TokensUsed: 3}
+	close(ch)
+
+	var seen []string
+	resp, err := CollectStream(context.Background(), ch, func(c ChatChunk) bool {
+		seen = append(seen, c.Content)
+		return true
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Message.Content != "ab" || resp.Model != "m" || resp.TokensUsed != 3 || resp.FinishReason != "stop" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+	if len(seen) != 2 {
+		t.Errorf("expected 2 token callbacks, got %d", len(seen))
+	}
+}
+
+func TestCompletionRequest_ToChatRequest(t *testing.T) {
+	req := &CompletionRequest{
+		SystemPrompt: "sys",
+		UserPrompt:   "user",
+		Temperature:  0.3,
+		MaxTokens:    50,
+		Model:        "m",
+	}
+
+	chatReq := req.ToChatRequest()
+	if len(chatReq.Messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(chatReq.Messages))
+	}
+	if chatReq.Messages[0].Role != "system" || chatReq.Messages[1].Role != "user" {
+		t.Errorf("unexpected roles: %+v", chatReq.Messages)
+	}
+	if chatReq.Model != "m" || chatReq.MaxTokens != 50 || chatReq.Temperature != 0.3 {
+		t.Errorf("request fields not copied: %+v", chatReq)
+	}
+}
@@ -0,0 +1,339 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/Ranganaths/minion/jsonschema"
+	"github.com/Ranganaths/minion/outputparser"
+)
+
+// ErrInvalidStructuredOutput is returned by GenerateStructured when the
+// model's responses never match the requested schema
+var ErrInvalidStructuredOutput = errors.New("invalid structured output")
+
+// ResponseFormatType identifies the shape of output a ChatRequest asks for
+type ResponseFormatType string
+
+const (
+	// ResponseFormatText is unconstrained text (the default)
+	ResponseFormatText ResponseFormatType = "text"
+
+	// ResponseFormatJSONObject asks for any JSON object
+	ResponseFormatJSONObject ResponseFormatType = "json_object"
+
+	// ResponseFormatJSONSchema asks for a JSON object matching Schema
+	ResponseFormatJSONSchema ResponseFormatType = "json_schema"
+)
+
+// ResponseFormat constrains a chat response to JSON. OpenAI receives it as
+// response_format, Anthropic as a tool the model is forced to call, Ollama as
+// format and Gemini as a response schema. Providers without native support
+// return unconstrained text.
+type ResponseFormat struct {
+	Type ResponseFormatType
+
+	// Name identifies the schema (default "response"). Anthropic uses it as
+	// the name of the forced tool.
+	Name string
+
+	// Description tells the model what the output is for (optional)
+	Description string
+
+	// Schema is the JSON Schema of json_schema responses. Its root must be
+	// an object.
+	Schema map[string]interface{}
+
+	// Strict asks OpenAI to enforce the schema exactly. Every property must
+	// then be required.
+	Strict bool
+}
+
+// JSONObjectFormat asks for any JSON object
+func JSONObjectFormat() *ResponseFormat {
+	return &ResponseFormat{Type: ResponseFormatJSONObject}
+}
+
+// JSONSchemaFormat asks for a JSON object matching schema
+func JSONSchemaFormat(name string, schema map[string]interface{}) *ResponseFormat {
+	return &ResponseFormat{Type: ResponseFormatJSONSchema, Name: name, Schema: schema}
+}
+
+// ResponseFormatFor asks for a JSON object that decodes into T, which must
+// be a struct or a map with string keys
+func ResponseFormatFor[T any](name string) *ResponseFormat {
+	return JSONSchemaFormat(name, SchemaFor[T]())
+}
+
+// Validate checks that the format has the fields its type requires
+func (f *ResponseFormat) Validate() error {
+	switch f.Type {
+	case ResponseFormatText, ResponseFormatJSONObject:
+		return nil
+	case ResponseFormatJSONSchema:
+		if f.Schema == nil {
+			return fmt.Errorf("json_schema format requires a schema")
+		}
+		if f.Schema["type"] != "object" {
+			return fmt.Errorf("json_schema format requires an object schema")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown response format type %q", f.Type)
+	}
+}
+
+// name returns the schema name, defaulting to "response"
+func (f *ResponseFormat) name() string {
+	if f.Name != "" {
+		return f.Name
+	}
+	return "response"
+}
+
+// isJSON reports whether the format asks for JSON output
+func (f *ResponseFormat) isJSON() bool {
+	return f != nil && (f.Type == ResponseFormatJSONObject || f.Type == ResponseFormatJSONSchema)
+}
+
+// schema returns the schema to enforce: Schema for json_schema, or any
+// object for json_object
+func (f *ResponseFormat) schema() map[string]interface{} {
+	if f.Type == ResponseFormatJSONSchema {
+		return f.Schema
+	}
+	return map[string]interface{}{"type": "object"}
+}
+
+// SchemaFor generates a JSON Schema for T from its Go type. Struct fields
+// are named by their json tags and are required unless tagged omitempty;
+// a description tag documents a field and an enum tag lists its allowed
+// values, separated by commas.
+func SchemaFor[T any]() map[string]interface{} {
+	return schemaForType(reflect.TypeOf((*T)(nil)).Elem(), map[reflect.Type]bool{})
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaForType generates the schema of a type. seen holds the structs being
+// generated, so recursive types end in an unconstrained object.
+func schemaForType(t reflect.Type, seen map[reflect.Type]bool) map[string]interface{} {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case rawMessageType:
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json encodes byte slices as base64 strings
+			return map[string]interface{}{"type": "string"}
+		}
+		return map[string]interface{}{"type": "array", "items": schemaForType(t.Elem(), seen)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaForType(t.Elem(), seen)}
+	case reflect.Struct:
+		if seen[t] {
+			return map[string]interface{}{"type": "object"}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+
+		properties := make(map[string]interface{})
+		required := make([]string, 0)
+		addStructFields(t, properties, &required, seen)
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	default:
+		// Interfaces accept any value
+		return map[string]interface{}{}
+	}
+}
+
+// addStructFields adds the schemas of a struct's JSON fields, flattening
+// untagged embedded structs as encoding/json does
+func addStructFields(t reflect.Type, properties map[string]interface{}, required *[]string, seen map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addStructFields(embedded, properties, required, seen)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema := schemaForType(field.Type, seen)
+		if description := field.Tag.Get("description"); description != "" {
+			schema["description"] = description
+		}
+		if enum := field.Tag.Get("enum"); enum != "" {
+			schema["enum"] = strings.Split(enum, ",")
+		}
+		properties[name] = schema
+
+		if !strings.Contains(options, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// StructuredConfig configures GenerateStructured
+type StructuredConfig struct {
+	// Name is the schema name (default "response")
+	Name string
+
+	// Description tells the model what the output is for (optional)
+	Description string
+
+	// MaxRetries is how many times the model is re-asked after a response
+	// that does not match the schema (default 2, negative disables re-asking)
+	MaxRetries int
+
+	// Strict asks OpenAI to enforce the schema exactly
+	Strict bool
+}
+
+// GenerateStructured sends a chat request constrained to the JSON Schema of
+// T and decodes the response into T. The request's ResponseFormat is used
+// if set. Responses that are not valid JSON or do not match the schema are
+// returned to the model with the validation error and re-asked, up to
+// MaxRetries times.
+func GenerateStructured[T any](ctx context.Context, provider Provider, req *ChatRequest, cfg ...StructuredConfig) (T, error) {
+	var result T
+
+	var config StructuredConfig
+	if len(cfg) > 0 {
+		config = cfg[0]
+	}
+	switch {
+	case config.MaxRetries == 0:
+		config.MaxRetries = 2
+	case config.MaxRetries < 0:
+		config.MaxRetries = 0
+	}
+
+	format := req.ResponseFormat
+	if !format.isJSON() {
+		format = ResponseFormatFor[T](config.Name)
+		format.Description = config.Description
+		format.Strict = config.Strict
+	}
+	if err := format.Validate(); err != nil {
+		return result, fmt.Errorf("structured output requires an object schema: %w", err)
+	}
+	schema := format.schema()
+
+	structuredReq := *req
+	structuredReq.ResponseFormat = format
+	structuredReq.Messages = withFormatInstructions(req.Messages, schema)
+
+	var lastErr error
+	for attempt := 0; attempt <= config.MaxRetries; attempt++ {
+		resp, err := provider.GenerateChat(ctx, &structuredReq)
+		if err != nil {
+			return result, err
+		}
+
+		value, err := decodeStructured[T](resp.Message.Content, schema)
+		if err == nil {
+			return value, nil
+		}
+		lastErr = err
+
+		structuredReq.Messages = append(structuredReq.Messages,
+			Message{Role: "assistant", Content: resp.Message.Content},
+			Message{Role: "user", Content: fmt.Sprintf(
+				"Your response did not match the required JSON schema: %v\nRespond again with only the corrected JSON object.", err)},
+		)
+	}
+
+	return result, fmt.Errorf("%w after %d attempts: %w", ErrInvalidStructuredOutput, config.MaxRetries+1, lastErr)
+}
+
+// withFormatInstructions returns a copy of messages whose system prompt asks
+// for JSON matching schema. Native enforcement is not available from every
+// provider, and OpenAI requires JSON mode requests to mention JSON.
+func withFormatInstructions(messages []Message, schema map[string]interface{}) []Message {
+	instructions := outputparser.NewJSONOutputParser(outputparser.JSONOutputParserConfig{Schema: schema}).GetFormatInstructions()
+
+	result := make([]Message, 0, len(messages)+1)
+	if len(messages) > 0 && messages[0].Role == "system" {
+		system := messages[0]
+		system.Content = strings.TrimSpace(system.Content + "\n\n" + instructions)
+		result = append(result, system)
+		messages = messages[1:]
+	} else {
+		result = append(result, Message{Role: "system", Content: instructions})
+	}
+	return append(result, messages...)
+}
+
+// decodeStructured parses JSON from a response, validates it against schema
+// and decodes it into T
+func decodeStructured[T any](content string, schema map[string]interface{}) (T, error) {
+	var result T
+
+	value, err := outputparser.NewJSONOutputParser(outputparser.JSONOutputParserConfig{}).Parse(content)
+	if err != nil {
+		return result, fmt.Errorf("response is not valid JSON")
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return result, fmt.Errorf("response is not a JSON object")
+	}
+	if err := jsonschema.NewValidator(false).ValidateInput(object, schema); err != nil {
+		return result, err
+	}
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		return result, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,239 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type invoice struct {
+	Number   string        `json:"number" description:"Invoice number"`
+	Status   string        `json:"status" enum:"paid,unpaid"`
+	Total    float64       `json:"total"`
+	Lines    []invoiceLine `json:"lines"`
+	Issued   time.Time     `json:"issued,omitempty"`
+	Notes    *string       `json:"notes,omitempty"`
+	internal string
+}
+
+type invoiceLine struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+type category struct {
+	Name     string     `json:"name"`
+	Children []category `json:"children,omitempty"`
+}
+
+// replyProvider answers chat requests with scripted contents and records
+// the requests it received
+type replyProvider struct {
+	replies  []string
+	requests []ChatRequest
+}
+
+func (p *replyProvider) Name() string { return "reply" }
+
+func (p *replyProvider) GenerateCompletion(ctx context.Context, req *CompletionRequest) (*CompletionResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (p *replyProvider) GenerateChat(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+	p.requests = append(p.requests, *req)
+	reply := p.replies[0]
+	if len(p.replies) > 1 {
+		p.replies = p.replies[1:]
+	}
+	return &ChatResponse{Message: Message{Role: "assistant", Content: reply}, Model: req.Model}, nil
+}
+
+func TestSchemaFor(t *testing.T) {
+	schema := SchemaFor[invoice]()
+
+	if schema["type"] != "object" || schema["additionalProperties"] != false {
+		t.Fatalf("unexpected root schema: %v", schema)
+	}
+	if !reflect.DeepEqual(schema["required"], []string{"number", "status", "total", "lines"}) {
+		t.Errorf("unexpected required fields: %v", schema["required"])
+	}
+
+	properties := schema["properties"].(map[string]interface{})
+	if len(properties) != 6 {
+		t.Errorf("expected 6 properties, got %v", properties)
+	}
+	if number := properties["number"].(map[string]interface{}); number["type"] != "string" || number["description"] != "Invoice number" {
+		t.Errorf("unexpected number schema: %v", number)
+	}
+	if status := properties["status"].(map[string]interface{}); !reflect.DeepEqual(status["enum"], []string{"paid", "unpaid"}) {
+		t.Errorf("unexpected status schema: %v", status)
+	}
+	if issued := properties["issued"].(map[string]interface{}); issued["format"] != "date-time" {
+		t.Errorf("unexpected issued schema: %v", issued)
+	}
+	if notes := properties["notes"].(map[string]interface{}); notes["type"] != "string" {
+		t.Errorf("unexpected notes schema: %v", notes)
+	}
+
+	items := properties["lines"].(map[string]interface{})["items"].(map[string]interface{})
+	quantity := items["properties"].(map[string]interface{})["quantity"].(map[string]interface{})
+	if quantity["type"] != "integer" {
+		t.Errorf("unexpected quantity schema: %v", quantity)
+	}
+
+	// Recursive types end in an unconstrained object
+	children := SchemaFor[category]()["properties"].(map[string]interface{})["children"].(map[string]interface{})
+	if item := children["items"].(map[string]interface{}); item["type"] != "object" || item["properties"] != nil {
+		t.Errorf("unexpected recursive schema: %v", item)
+	}
+}
+
+func TestResponseFormat_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  *ResponseFormat
+		wantErr bool
+	}{
+		{name: "json object", format: JSONObjectFormat()},
+		{name: "schema", format: ResponseFormatFor[invoice]("invoice")},
+		{name: "missing schema", format: &ResponseFormat{Type: ResponseFormatJSONSchema}, wantErr: true},
+		{name: "array schema", format: JSONSchemaFormat("list", map[string]interface{}{"type": "array"}), wantErr: true},
+		{name: "unknown type", format: &ResponseFormat{Type: "xml"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &ChatRequest{Model: "gpt-4o", Messages: []Message{{Role: "user", Content: "hi"}}, ResponseFormat: tt.format}
+			err := req.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateStructured(t *testing.T) {
+	provider := &replyProvider{replies: []string{
+		`{"number": "INV-1", "status": "paid"}`,
+		"```json\n" + `{"number": "INV-1", "status": "paid", "total": 42.5, "lines": [{"sku": "A1", "quantity": 2}]}` + "\n```",
+	}}
+
+	got, err := GenerateStructured[invoice](context.Background(), provider, &ChatRequest{
+		Model:    "gpt-4o",
+		Messages: []Message{{Role: "system", Content: "You extract invoices."}, {Role: "user", Content: "INV-1, paid, 42.50"}},
+	}, StructuredConfig{Name: "invoice"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Number != "INV-1" || got.Total != 42.5 || len(got.Lines) != 1 || got.Lines[0].Quantity != 2 {
+		t.Errorf("unexpected result: %+v", got)
+	}
+
+	if len(provider.requests) != 2 {
+		t.Fatalf("expected a re-ask, got %d requests", len(provider.requests))
+	}
+	first := provider.requests[0]
+	if first.ResponseFormat == nil || first.ResponseFormat.Type != ResponseFormatJSONSchema || first.ResponseFormat.Name != "invoice" {
+		t.Errorf("unexpected response format: %+v", first.ResponseFormat)
+	}
+	if len(first.Messages) != 2 || !strings.HasPrefix(first.Messages[0].Content, "You extract invoices.\n\nReturn your response as a valid JSON object") {
+		t.Errorf("expected schema instructions in the system prompt, got %+v", first.Messages)
+	}
+
+	retry := provider.requests[1].Messages
+	if len(retry) != 4 || retry[2].Role != "assistant" || !strings.Contains(retry[3].Content, "required field missing: total") {
+		t.Errorf("unexpected re-ask messages: %+v", retry)
+	}
+}
+
+func TestGenerateStructured_GivesUp(t *testing.T) {
+	provider := &replyProvider{replies: []string{"I cannot help with that."}}
+
+	_, err := GenerateStructured[invoice](context.Background(), provider, &ChatRequest{
+		Model:    "gpt-4o",
+		Messages: []Message{{Role: "user", Content: "hi"}},
+	}, StructuredConfig{MaxRetries: 1})
+	if !errors.Is(err, ErrInvalidStructuredOutput) {
+		t.Fatalf("expected ErrInvalidStructuredOutput, got %v", err)
+	}
+	if len(provider.requests) != 2 {
+		t.Errorf("expected 2 attempts, got %d", len(provider.requests))
+	}
+	if provider.requests[0].Messages[0].Role != "system" {
+		t.Errorf("expected a system message with instructions, got %+v", provider.requests[0].Messages[0])
+	}
+}
+
+func TestProviders_ResponseFormat(t *testing.T) {
+	format := ResponseFormatFor[invoiceLine]("line")
+	req := &ChatRequest{Model: "m", Messages: []Message{{Role: "user", Content: "hi"}}, ResponseFormat: format}
+
+	t.Run("openai", func(t *testing.T) {
+		chatReq, err := buildOpenAIChatRequest(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		data, _ := json.Marshal(chatReq.ResponseFormat)
+		var got map[string]any
+		json.Unmarshal(data, &got)
+		schema, _ := got["json_schema"].(map[string]any)
+		if got["type"] != "json_schema" || schema["name"] != "line" || schema["schema"].(map[string]any)["type"] != "object" {
+			t.Errorf("unexpected response_format: %s", data)
+		}
+
+		jsonReq := *req
+		jsonReq.ResponseFormat = JSONObjectFormat()
+		chatReq, _ = buildOpenAIChatRequest(&jsonReq)
+		if chatReq.ResponseFormat == nil || chatReq.ResponseFormat.Type != "json_object" || chatReq.ResponseFormat.JSONSchema != nil {
+			t.Errorf("unexpected json_object format: %+v", chatReq.ResponseFormat)
+		}
+	})
+
+	t.Run("anthropic", func(t *testing.T) {
+		anthropicReq, err := NewAnthropic("key").buildChatRequest(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(anthropicReq.Tools) != 1 || anthropicReq.Tools[0].Name != "line" || anthropicReq.Tools[0].InputSchema["type"] != "object" {
+			t.Errorf("unexpected tools: %+v", anthropicReq.Tools)
+		}
+		if anthropicReq.ToolChoice == nil || anthropicReq.ToolChoice.Type != "tool" || anthropicReq.ToolChoice.Name != "line" {
+			t.Errorf("unexpected tool choice: %+v", anthropicReq.ToolChoice)
+		}
+
+		resp := &anthropicResponse{Role: "assistant", Content: []anthropicContentBlock{
+			{Type: "tool_use", ID: "toolu_1", Name: "line", Input: json.RawMessage(`{"sku":"A1","quantity":2}`)},
+		}}
+		if msg := resp.message("line"); msg.Content != `{"sku":"A1","quantity":2}` || len(msg.ToolCalls) != 0 {
+			t.Errorf("expected the tool input as content, got %+v", msg)
+		}
+	})
+
+	t.Run("ollama", func(t *testing.T) {
+		ollamaReq, err := NewOllama("http://localhost:11434").buildChatRequest(req, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if schema, ok := ollamaReq.Format.(map[string]interface{}); !ok || schema["type"] != "object" {
+			t.Errorf("unexpected format: %v", ollamaReq.Format)
+		}
+
+		jsonReq := *req
+		jsonReq.ResponseFormat = JSONObjectFormat()
+		ollamaReq, _ = NewOllama("http://localhost:11434").buildChatRequest(&jsonReq, false)
+		if ollamaReq.Format != "json" {
+			t.Errorf("expected format json, got %v", ollamaReq.Format)
+		}
+	})
+
+	t.Run("gemini", func(t *testing.T) {
+		config := NewGemini("key").buildChatRequest(req).GenerationConfig
+		if config.ResponseMimeType != "application/json" || config.ResponseJSONSchema["type"] != "object" {
+			t.Errorf("unexpected generation config: %+v", config)
+		}
+	})
+}
@@ -0,0 +1,284 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+var weatherTool = ToolDefinition{
+	Name:        "get_weather",
+	Description: "Get the current weather for a city",
+	Parameters: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"city": map[string]interface{}{"type": "string"},
+		},
+		"required": []string{"city"},
+	},
+}
+
+func TestToolCall_ParseArguments(t *testing.T) {
+	var args struct {
+		City string `json:"city"`
+	}
+	call := ToolCall{Name: "get_weather", Arguments: `{"city":"Paris"}`}
+	if err := call.ParseArguments(&args); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if args.City != "Paris" {
+		t.Errorf("expected Paris, got %s", args.City)
+	}
+
+	if err := (ToolCall{Name: "x", Arguments: "{bad"}).ParseArguments(&args); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestOpenAIProvider_ToolCalling(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Messages []map[string]any `json:"messages"`
+			Tools    []struct {
+				Type     string         `json:"type"`
+				Function map[string]any `json:"function"`
+			} `json:"tools"`
+			ToolChoice any `json:"tool_choice"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		if len(req.Tools) != 1 || req.Tools[0].Function["name"] != "get_weather" {
+			t.Errorf("unexpected tools: %+v", req.Tools)
+		}
+		if req.ToolChoice != "required" {
+			t.Errorf("expected tool_choice=required, got %v", req.ToolChoice)
+		}
+		last := req.Messages[len(req.Messages)-1]
+		if last["role"] != "tool" || last["tool_call_id"] != "call_0" {
+			t.Errorf("unexpected tool result message: %v", last)
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"model": "gpt-4",
+			"choices": []map[string]any{{
+				"finish_reason": "tool_calls",
+				"message": map[string]any{
+					"role": "assistant",
+					"tool_calls": []map[string]any{{
+						"id":       "call_1",
+						"type":     "function",
+						"function": map[string]any{"name": "get_weather", "arguments": `{"city":"Rome"}`},
+					}},
+				},
+			}},
+		})
+	}))
+	defer server.Close()
+
+	cfg := openai.DefaultConfig("test-key")
+	cfg.BaseURL = server.URL
+	provider := &OpenAIProvider{client: openai.NewClientWithConfig(cfg), name: "openai"}
+
+	prior := ToolCall{ID: "call_0", Name: "get_weather", Arguments: `{"city":"Paris"}`}
+	resp, err := provider.GenerateChat(context.Background(), &ChatRequest{
+		Model: "gpt-4",
+		Messages: []Message{
+			{Role: "user", Content: "Weather in Paris and Rome?"},
+			{Role: "assistant", ToolCalls: []ToolCall{prior}},
+			NewToolResultMessage(prior, "sunny"),
+		},
+		Tools:      []ToolDefinition{weatherTool},
+		ToolChoice: "required",
+	})
+	if err != nil {
+		t.Fatalf("GenerateChat failed: %v", err)
+	}
+
+	if len(resp.Message.ToolCalls) != 1 {
+		t.Fatalf("expected 1 tool call, got %d", len(resp.Message.ToolCalls))
+	}
+	call := resp.Message.ToolCalls[0]
+	if call.ID != "call_1" || call.Name != "get_weather" || call.Arguments != `{"city":"Rome"}` {
+		t.Errorf("unexpected tool call: %+v", call)
+	}
+}
+
+func TestAnthropicProvider_ToolCalling(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Messages []struct {
+				Role    string          `json:"role"`
+				Content json.RawMessage `json:"content"`
+			} `json:"messages"`
+			Tools      []anthropicTool      `json:"tools"`
+			ToolChoice *anthropicToolChoice `json:"tool_choice"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		if len(req.Tools) != 1 || req.Tools[0].InputSchema["type"] != "object" {
+			t.Errorf("unexpected tools: %+v", req.Tools)
+		}
+		if req.ToolChoice == nil || req.ToolChoice.Type != "tool" || req.ToolChoice.Name != "get_weather" {
+			t.Errorf("unexpected tool_choice: %+v", req.ToolChoice)
+		}
+		if len(req.Messages) != 3 {
+			t.Fatalf("expected 3 messages (tool results merged), got %d", len(req.Messages))
+		}
+
+		var assistantBlocks []anthropicContentBlock
+		json.Unmarshal(req.Messages[1].Content, &assistantBlocks)
+		if len(assistantBlocks) != 2 || assistantBlocks[0].Type != "tool_use" || assistantBlocks[0].ID != "tu_1" {
+			t.Errorf("unexpected assistant blocks: %+v", assistantBlocks)
+		}
+
+		var resultBlocks []anthropicContentBlock
+		json.Unmarshal(req.Messages[2].Content, &resultBlocks)
+		if req.Messages[2].Role != "user" || len(resultBlocks) != 2 || resultBlocks[1].ToolUseID != "tu_2" {
+			t.Errorf("unexpected tool result message: %s", req.Messages[2].Content)
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"role":        "assistant",
+			"model":       "claude-3",
+			"stop_reason": "tool_use",
+			"content": []map[string]any{
+				{"type": "text", "text": "Checking."},
+				{"type": "tool_use", "id": "tu_3", "name": "get_weather", "input": map[string]any{"city": "Oslo"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	provider := NewAnthropic("test-key")
+	provider.baseURL = server.URL
+
+	calls := []ToolCall{
+		{ID: "tu_1", Name: "get_weather", Arguments: `{"city":"Paris"}`},
+		{ID: "tu_2", Name: "get_weather", Arguments: `{"city":"Rome"}`},
+	}
+	resp, err := provider.GenerateChat(context.Background(), &ChatRequest{
+		Model: "claude-3",
+		Messages: []Message{
+			{Role: "user", Content: "Weather?"},
+			{Role: "assistant", ToolCalls: calls},
+			NewToolResultMessage(calls[0], "sunny"),
+			NewToolResultMessage(calls[1], "rainy"),
+		},
+		Tools:      []ToolDefinition{weatherTool},
+		ToolChoice: "get_weather",
+		MaxTokens:  100,
+	})
+	if err != nil {
+		t.Fatalf("GenerateChat failed: %v", err)
+	}
+
+	if resp.Message.Content != "Checking." {
+		t.Errorf("unexpected content: %q", resp.Message.Content)
+	}
+	if len(resp.Message.ToolCalls) != 1 || resp.Message.ToolCalls[0].ID != "tu_3" {
+		t.Fatalf("unexpected tool calls: %+v", resp.Message.ToolCalls)
+	}
+	var args map[string]string
+	resp.Message.ToolCalls[0].ParseArguments(&args)
+	if args["city"] != "Oslo" {
+		t.Errorf("unexpected arguments: %s", resp.Message.ToolCalls[0].Arguments)
+	}
+}
+
+func TestAnthropicProvider_StreamToolCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSE(w, "message_start", map[string]any{"type": "message_start", "message": map[string]any{"model": "claude-3"}})
+		writeSSE(w, "content_block_start", map[string]any{
+			"type": "content_block_start", "index": 0,
+			"content_block": map[string]any{"type": "tool_use", "id": "tu_1", "name": "get_weather"},
+		})
+		for _, part := range []string{`{"ci`, `ty":"Pa`, `ris"}`} {
+			writeSSE(w, "content_block_delta", map[string]any{
+				"type": "content_block_delta", "index": 0,
+				"delta": map[string]any{"type": "input_json_delta", "partial_json": part},
+			})
+		}
+		writeSSE(w, "message_delta", map[string]any{"type": "message_delta", "delta": map[string]any{"stop_reason": "tool_use"}})
+		writeSSE(w, "message_stop", map[string]any{"type": "message_stop"})
+	}))
+	defer server.Close()
+
+	provider := NewAnthropic("test-key")
+	provider.baseURL = server.URL
+
+	ch, err := provider.StreamChat(context.Background(), &ChatRequest{
+		Model:    "claude-3",
+		Messages: []Message{{Role: "user", Content: "Weather?"}},
+		Tools:    []ToolDefinition{weatherTool},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat failed: %v", err)
+	}
+
+	resp, err := CollectStream(context.Background(), ch, nil)
+	if err != nil {
+		t.Fatalf("stream failed: %v", err)
+	}
+	if len(resp.Message.ToolCalls) != 1 || resp.Message.ToolCalls[0].Arguments != `{"city":"Paris"}` {
+		t.Errorf("unexpected tool calls: %+v", resp.Message.ToolCalls)
+	}
+}
+
+func TestOllamaProvider_ToolCalling(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ollamaRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		if len(req.Tools) != 1 || req.Tools[0].Function.Name != "get_weather" {
+			t.Errorf("unexpected tools: %+v", req.Tools)
+		}
+		last := req.Messages[len(req.Messages)-1]
+		if last.Role != "tool" || last.ToolName != "get_weather" {
+			t.Errorf("unexpected tool message: %+v", last)
+		}
+		if len(req.Messages[1].ToolCalls) != 1 {
+			t.Errorf("expected assistant tool call to be forwarded")
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"model": "llama3",
+			"done":  true,
+			"message": map[string]any{
+				"role": "assistant",
+				"tool_calls": []map[string]any{{
+					"function": map[string]any{"name": "get_weather", "arguments": map[string]any{"city": "Lima"}},
+				}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	provider := NewOllama(server.URL)
+
+	prior := ToolCall{ID: "call_0", Name: "get_weather", Arguments: `{"city":"Paris"}`}
+	resp, err := provider.GenerateChat(context.Background(), &ChatRequest{
+		Model: "llama3",
+		Messages: []Message{
+			{Role: "user", Content: "Weather?"},
+			{Role: "assistant", ToolCalls: []ToolCall{prior}},
+			NewToolResultMessage(prior, "sunny"),
+		},
+		Tools: []ToolDefinition{weatherTool},
+	})
+	if err != nil {
+		t.Fatalf("GenerateChat failed: %v", err)
+	}
+
+	if len(resp.Message.ToolCalls) != 1 {
+		t.Fatalf("expected 1 tool call, got %d", len(resp.Message.ToolCalls))
+	}
+	call := resp.Message.ToolCalls[0]
+	if call.ID == "" || call.Name != "get_weather" || call.Arguments != `{"city":"Lima"}` {
+		t.Errorf("unexpected tool call: %+v", call)
+	}
+}
@@ -54,6 +54,32 @@ type tupleLeapCompletionRequest struct {
 	Stream      bool               `json:"stream"`
 }
 
+type tupleLeapStreamChunk struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *tupleLeapUsage `json:"usage,omitempty"`
+}
+
+type tupleLeapUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// usage converts the token usage
+func (u tupleLeapUsage) usage() Usage {
+	usage := newUsage(u.PromptTokens, u.CompletionTokens, 0)
+	if u.TotalTokens > 0 {
+		usage.TotalTokens = u.TotalTokens
+	}
+	return usage
+}
+
 type tupleLeapCompletionResponse struct {
 	ID      string `json:"id"`
 	Object  string `json:"object"`
@@ -68,11 +94,7 @@ type tupleLeapCompletionResponse struct {
 		Text         string `json:"text,omitempty"`
 		FinishReason string `json:"finish_reason"`
 	} `json:"choices"`
-	Usage struct {
-		PromptTokens     int `json:"prompt_tokens"`
-		CompletionTokens int `json:"completion_tokens"`
-		TotalTokens      int `json:"total_tokens"`
-	} `json:"usage"`
+	Usage tupleLeapUsage `json:"usage"`
 }
 
 // GenerateCompletion generates a text completion using TupleLeap AI
@@ -92,30 +114,12 @@ func (p *TupleLeapProvider) GenerateCompletion(ctx context.Context, req *Complet
 		Stream:      false,
 	}
 
-	data, err := json.Marshal(tupleLeapReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/completions", bytes.NewBuffer(data))
+	httpResp, err := p.doRequest(ctx, "/completions", tupleLeapReq)
 	if err != nil {
 		return nil, err
 	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
-
-	httpResp, err := p.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
 	defer httpResp.Body.Close()
 
-	if httpResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(httpResp.Body)
-		return nil, fmt.Errorf("tupleleap API error (status %d): %s", httpResp.StatusCode, string(body))
-	}
-
 	var resp tupleLeapCompletionResponse
 	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
@@ -134,6 +138,7 @@ func (p *TupleLeapProvider) GenerateCompletion(ctx context.Context, req *Complet
 	return &CompletionResponse{
 		Text:         text,
 		TokensUsed:   resp.Usage.TotalTokens,
+		Usage:        resp.Usage.usage(),
 		FinishReason: resp.Choices[0].FinishReason,
 		Model:        resp.Model,
 	}, nil
@@ -141,29 +146,128 @@ func (p *TupleLeapProvider) GenerateCompletion(ctx context.Context, req *Complet
 
 // GenerateChat generates a chat response using TupleLeap AI
 func (p *TupleLeapProvider) GenerateChat(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
-	// Convert messages
+	httpResp, err := p.doRequest(ctx, "/chat/completions", p.buildChatRequest(req, false))
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	var resp tupleLeapCompletionResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("no chat choices returned")
+	}
+
+	return &ChatResponse{
+		Message: Message{
+			Role:    resp.Choices[0].Message.Role,
+			Content: resp.Choices[0].Message.Content,
+		},
+		TokensUsed:   resp.Usage.TotalTokens,
+		Usage:        resp.Usage.usage(),
+		FinishReason: resp.Choices[0].FinishReason,
+		Model:        resp.Model,
+	}, nil
+}
+
+// StreamChat streams a chat response using TupleLeap AI (server-sent events)
+func (p *TupleLeapProvider) StreamChat(ctx context.Context, req *ChatRequest) (<-chan ChatChunk, error) {
+	httpResp, err := p.doRequest(ctx, "/chat/completions", p.buildChatRequest(req, true))
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan ChatChunk, 16)
+
+	go func() {
+		out := &chunkSender{ctx: ctx, ch: ch}
+		defer out.close()
+		defer httpResp.Body.Close()
+
+		final := ChatChunk{Done: true}
+		var streamErr error
+		finished := false
+
+		err := readSSE(httpResp.Body, func(_, data string) bool {
+			if data == "[DONE]" {
+				finished = true
+				return false
+			}
+
+			var chunk tupleLeapStreamChunk
+			if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+				streamErr = fmt.Errorf("failed to decode stream chunk: %w", err)
+				return false
+			}
+
+			final.Model = chunk.Model
+			if chunk.Usage != nil {
+				final.TokensUsed = chunk.Usage.TotalTokens
+				final.Usage = chunk.Usage.usage()
+			}
+			if len(chunk.Choices) == 0 {
+				return true
+			}
+			if chunk.Choices[0].FinishReason != "" {
+				final.FinishReason = chunk.Choices[0].FinishReason
+			}
+			if content := chunk.Choices[0].Delta.Content; content != "" {
+				return out.send(ChatChunk{Content: content, Model: chunk.Model})
+			}
+			return true
+		})
+		if err != nil {
+			streamErr = fmt.Errorf("tupleleap stream error: %w", err)
+		}
+		if streamErr == nil && !finished && ctx.Err() == nil {
+			streamErr = fmt.Errorf("tupleleap stream ended before [DONE]")
+		}
+		if streamErr != nil {
+			out.send(ChatChunk{Err: streamErr})
+			return
+		}
+		if !finished {
+			return
+		}
+
+		out.send(final)
+	}()
+
+	return ch, nil
+}
+
+// buildChatRequest converts a chat request to the TupleLeap wire format
+func (p *TupleLeapProvider) buildChatRequest(req *ChatRequest, stream bool) tupleLeapCompletionRequest {
 	messages := make([]tupleLeapMessage, len(req.Messages))
 	for i, msg := range req.Messages {
+		// TupleLeap accepts text only, so media parts are dropped
 		messages[i] = tupleLeapMessage{
 			Role:    msg.Role,
-			Content: msg.Content,
+			Content: msg.Text(),
 		}
 	}
 
-	tupleLeapReq := tupleLeapCompletionRequest{
+	return tupleLeapCompletionRequest{
 		Model:       req.Model,
 		Messages:    messages,
 		Temperature: req.Temperature,
 		MaxTokens:   req.MaxTokens,
-		Stream:      false,
+		Stream:      stream,
 	}
+}
 
-	data, err := json.Marshal(tupleLeapReq)
+// doRequest posts a request to the TupleLeap API and returns the response once
+// the status has been checked. The caller is responsible for closing the body.
+func (p *TupleLeapProvider) doRequest(ctx context.Context, path string, body tupleLeapCompletionRequest) (*http.Response, error) {
+	data, err := json.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/chat/completions", bytes.NewBuffer(data))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+path, bytes.NewBuffer(data))
 	if err != nil {
 		return nil, err
 	}
@@ -175,31 +279,14 @@ func (p *TupleLeapProvider) GenerateChat(ctx context.Context, req *ChatRequest)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
-	defer httpResp.Body.Close()
 
 	if httpResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(httpResp.Body)
-		return nil, fmt.Errorf("tupleleap API error (status %d): %s", httpResp.StatusCode, string(body))
-	}
-
-	var resp tupleLeapCompletionResponse
-	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	if len(resp.Choices) == 0 {
-		return nil, fmt.Errorf("no chat choices returned")
+		defer httpResp.Body.Close()
+		respBody, _ := io.ReadAll(httpResp.Body)
+		return nil, fmt.Errorf("tupleleap API error (status %d): %s", httpResp.StatusCode, string(respBody))
 	}
 
-	return &ChatResponse{
-		Message: Message{
-			Role:    resp.Choices[0].Message.Role,
-			Content: resp.Choices[0].Message.Content,
-		},
-		TokensUsed:   resp.Usage.TotalTokens,
-		FinishReason: resp.Choices[0].FinishReason,
-		Model:        resp.Model,
-	}, nil
+	return httpResp, nil
 }
 
 // SetHTTPClient allows setting a custom HTTP client
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Ranganaths/minion/mcp/client"
 	"github.com/Ranganaths/minion/models"
+	"github.com/Ranganaths/minion/tools"
 )
 
 // Mock tool registrar for testing
@@ -446,3 +447,27 @@ type mockError struct{}
 func (e *mockError) Error() string {
 	return "mock error"
 }
+
+func TestRegistryRegistrar(t *testing.T) {
+	registry := tools.NewRegistry()
+	registrar := NewRegistryRegistrar(registry)
+
+	wrapper := NewMCPToolWrapper("github", client.MCPTool{Name: "create_issue"}, client.NewMCPClientManager(nil))
+	if err := registrar.RegisterTool(wrapper); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := registry.Get("mcp_github_create_issue"); err != nil {
+		t.Errorf("expected the wrapper in the registry: %v", err)
+	}
+
+	if err := registrar.RegisterTool("not a tool"); err == nil {
+		t.Error("expected an error for a value that is not a tool")
+	}
+
+	if err := registrar.UnregisterTool("mcp_github_create_issue"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if registry.Count() != 0 {
+		t.Errorf("expected an empty registry, got %d tools", registry.Count())
+	}
+}
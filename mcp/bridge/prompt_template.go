@@ -0,0 +1,224 @@
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/Ranganaths/minion/llm"
+	"github.com/Ranganaths/minion/mcp/client"
+	"github.com/Ranganaths/minion/prompt"
+)
+
+// PromptClient is the subset of MCPClient used to load prompts
+type PromptClient interface {
+	ListPrompts(ctx context.Context) ([]client.MCPPrompt, error)
+	GetPrompt(ctx context.Context, name string, args map[string]string) (*client.MCPGetPromptResult, error)
+}
+
+// PromptTemplate is an MCP prompt that formats locally into chat messages.
+// Each text message of the prompt keeps its role.
+type PromptTemplate struct {
+	name        string
+	description string
+	messages    []promptMessage
+	inputVars   []string
+	partialVars map[string]any
+}
+
+// placeholderPattern matches the argument placeholders sent to the server.
+// Private use characters do not occur in ordinary prompt text.
+var placeholderPattern = regexp.MustCompile("\ue000[0-9]+\ue001")
+
+// promptMessage is the template of a single prompt message
+type promptMessage struct {
+	role     string
+	template *template.Template
+}
+
+// Name returns the name of the MCP prompt
+func (t *PromptTemplate) Name() string {
+	return t.name
+}
+
+// Description returns the description of the MCP prompt
+func (t *PromptTemplate) Description() string {
+	return t.description
+}
+
+// InputVariables returns the required arguments of the prompt
+func (t *PromptTemplate) InputVariables() []string {
+	return t.inputVars
+}
+
+// FormatMessages formats the prompt into chat messages
+func (t *PromptTemplate) FormatMessages(vars map[string]any) ([]prompt.ChatMessage, error) {
+	mergedVars := make(map[string]any, len(t.partialVars)+len(vars))
+	for k, v := range t.partialVars {
+		mergedVars[k] = v
+	}
+	for k, v := range vars {
+		mergedVars[k] = v
+	}
+
+	for _, key := range t.inputVars {
+		if _, ok := mergedVars[key]; !ok {
+			return nil, fmt.Errorf("missing required variable: %s", key)
+		}
+	}
+
+	messages := make([]prompt.ChatMessage, len(t.messages))
+	for i, msg := range t.messages {
+		var content strings.Builder
+		if err := msg.template.Execute(&content, mergedVars); err != nil {
+			return nil, fmt.Errorf("failed to format prompt %s: %w", t.name, err)
+		}
+		messages[i] = prompt.ChatMessage{Role: msg.role, Content: content.String()}
+	}
+
+	return messages, nil
+}
+
+// FormatLLMMessages formats the prompt into messages for an llm.ChatRequest
+func (t *PromptTemplate) FormatLLMMessages(vars map[string]any) ([]llm.Message, error) {
+	messages, err := t.FormatMessages(vars)
+	if err != nil {
+		return nil, err
+	}
+	return prompt.ToLLMMessages(messages), nil
+}
+
+// Format formats the prompt as plain text, joining the messages with blank
+// lines. Roles are dropped; use FormatMessages to keep them.
+func (t *PromptTemplate) Format(vars map[string]any) (string, error) {
+	messages, err := t.FormatMessages(vars)
+	if err != nil {
+		return "", err
+	}
+
+	texts := make([]string, len(messages))
+	for i, msg := range messages {
+		texts[i] = msg.Content
+	}
+	return strings.Join(texts, "\n\n"), nil
+}
+
+// LoadPromptTemplate converts an MCP prompt into a PromptTemplate.
+//
+// The prompt is rendered once on the server with each argument set to a
+// placeholder, so the returned template formats locally without further
+// server calls. Any argument name is supported, and text from the server
+// is kept literally. Required arguments become input variables; optional
+// arguments default to an empty string. Non-text messages are skipped.
+// Servers that branch on argument values rather than substituting them
+// should be called with GetPrompt instead.
+func LoadPromptTemplate(ctx context.Context, promptClient PromptClient, name string) (*PromptTemplate, error) {
+	prompts, err := promptClient.ListPrompts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompts: %w", err)
+	}
+
+	for _, p := range prompts {
+		if p.Name == name {
+			return promptTemplate(ctx, promptClient, p)
+		}
+	}
+
+	return nil, fmt.Errorf("prompt not found: %s", name)
+}
+
+// LoadPromptTemplates converts every prompt on the server, keyed by name
+func LoadPromptTemplates(ctx context.Context, promptClient PromptClient) (map[string]*PromptTemplate, error) {
+	prompts, err := promptClient.ListPrompts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompts: %w", err)
+	}
+
+	templates := make(map[string]*PromptTemplate, len(prompts))
+	for _, p := range prompts {
+		template, err := promptTemplate(ctx, promptClient, p)
+		if err != nil {
+			return nil, err
+		}
+		templates[p.Name] = template
+	}
+
+	return templates, nil
+}
+
+// promptTemplate renders a prompt with placeholder arguments and turns each
+// text message into a template
+func promptTemplate(ctx context.Context, promptClient PromptClient, p client.MCPPrompt) (*PromptTemplate, error) {
+	args := make(map[string]string, len(p.Arguments))
+	placeholders := make(map[string]string, len(p.Arguments))
+	var inputVars []string
+	partialVars := make(map[string]any)
+
+	for i, arg := range p.Arguments {
+		placeholder := fmt.Sprintf("\ue000%d\ue001", i)
+		args[arg.Name] = placeholder
+		placeholders[placeholder] = arg.Name
+		if arg.Required {
+			inputVars = append(inputVars, arg.Name)
+		} else {
+			partialVars[arg.Name] = ""
+		}
+	}
+
+	rendered, err := promptClient.GetPrompt(ctx, p.Name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []promptMessage
+	for _, msg := range rendered.Messages {
+		text := msg.Text()
+		if text == "" {
+			continue
+		}
+
+		tmpl, err := template.New(p.Name).Parse(templateText(text, placeholders))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse prompt %s: %w", p.Name, err)
+		}
+		messages = append(messages, promptMessage{role: msg.Role, template: tmpl})
+	}
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("prompt %s has no text messages", p.Name)
+	}
+
+	return &PromptTemplate{
+		name:        p.Name,
+		description: p.Description,
+		messages:    messages,
+		inputVars:   inputVars,
+		partialVars: partialVars,
+	}, nil
+}
+
+// templateText converts rendered prompt text into template source. The
+// placeholders become {{index . "name"}} actions and the remaining text is
+// escaped, so braces sent by the server are never executed.
+func templateText(text string, placeholders map[string]string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range placeholderPattern.FindAllStringIndex(text, -1) {
+		name, ok := placeholders[text[loc[0]:loc[1]]]
+		if !ok {
+			continue
+		}
+		b.WriteString(escapeTemplateText(text[last:loc[0]]))
+		fmt.Fprintf(&b, "{{index . %s}}", strconv.Quote(name))
+		last = loc[1]
+	}
+	b.WriteString(escapeTemplateText(text[last:]))
+	return b.String()
+}
+
+// escapeTemplateText makes text print literally in a Go template
+func escapeTemplateText(text string) string {
+	return strings.ReplaceAll(text, "{{", `{{"{{"}}`)
+}
@@ -0,0 +1,109 @@
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ranganaths/minion/mcp/client"
+)
+
+// unregisteringRegistrar records registered tools by name and supports
+// removal, like the framework's tool registry
+type unregisteringRegistrar struct {
+	tools map[string]bool
+	mu    sync.Mutex
+}
+
+func (r *unregisteringRegistrar) RegisterTool(tool interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tools[tool.(*MCPToolWrapper).Name()] = true
+	return nil
+}
+
+func (r *unregisteringRegistrar) UnregisterTool(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tools, name)
+	return nil
+}
+
+func (r *unregisteringRegistrar) names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var names []string
+	for name := range r.tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestBridgeRegistry_RefreshServerTools(t *testing.T) {
+	var mu sync.Mutex
+	toolNames := []string{"search", "fetch"}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID int64 `json:"id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		mu.Lock()
+		tools := make([]interface{}, len(toolNames))
+		for i, name := range toolNames {
+			tools[i] = map[string]interface{}{"name": name}
+		}
+		mu.Unlock()
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  map[string]interface{}{"tools": tools},
+		})
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	manager := client.NewMCPClientManager(nil)
+	defer manager.Close()
+	config := client.DefaultClientConfig("docs")
+	config.Transport = client.TransportHTTP
+	config.URL = server.URL
+	if err := manager.ConnectServer(ctx, config); err != nil {
+		t.Fatalf("ConnectServer failed: %v", err)
+	}
+
+	registrar := &unregisteringRegistrar{tools: make(map[string]bool)}
+	registry := NewBridgeRegistry(manager, registrar)
+	if err := registry.RegisterServerTools(ctx, "docs"); err != nil {
+		t.Fatalf("RegisterServerTools failed: %v", err)
+	}
+
+	mu.Lock()
+	toolNames = []string{"search", "summarize"}
+	mu.Unlock()
+
+	if err := registry.RefreshServerTools(ctx, "docs"); err != nil {
+		t.Fatalf("RefreshServerTools failed: %v", err)
+	}
+
+	want := []string{"mcp_docs_search", "mcp_docs_summarize"}
+	if got := registrar.names(); len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("registrar tools = %v, want %v", got, want)
+	}
+	if registry.Count() != 2 {
+		t.Errorf("wrapped tools = %d, want 2", registry.Count())
+	}
+	if _, err := registry.GetWrappedTool("mcp_docs_fetch"); err == nil {
+		t.Error("expected removed tool to be unwrapped")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/Ranganaths/minion/mcp/client"
+	"github.com/Ranganaths/minion/tools"
 )
 
 // ToolRegistrar is the minimal interface needed for tool registration
@@ -15,6 +16,45 @@ type ToolRegistrar interface {
 	RegisterTool(tool interface{}) error
 }
 
+// ToolUnregistrar is implemented by registrars that can also remove tools.
+// When the registrar supports it, tools dropped by a server are removed
+// and changed tools are replaced rather than left stale.
+type ToolUnregistrar interface {
+	UnregisterTool(name string) error
+}
+
+// RegistryRegistrar registers MCP tools directly in a tools.Registry, for
+// use without the framework. Agents can then reach MCP tools alongside other
+// tools through the registry; an agents.RegistryToolSource also sees tools
+// re-registered after the server's tool list changes.
+type RegistryRegistrar struct {
+	registry tools.Registry
+}
+
+var (
+	_ ToolRegistrar   = (*RegistryRegistrar)(nil)
+	_ ToolUnregistrar = (*RegistryRegistrar)(nil)
+)
+
+// NewRegistryRegistrar creates a registrar for registry
+func NewRegistryRegistrar(registry tools.Registry) *RegistryRegistrar {
+	return &RegistryRegistrar{registry: registry}
+}
+
+// RegisterTool registers a tool, which must implement tools.Tool
+func (r *RegistryRegistrar) RegisterTool(tool interface{}) error {
+	t, ok := tool.(tools.Tool)
+	if !ok {
+		return fmt.Errorf("tool must implement tools.Tool interface")
+	}
+	return r.registry.Register(t)
+}
+
+// UnregisterTool removes a tool by name
+func (r *RegistryRegistrar) UnregisterTool(name string) error {
+	return r.registry.Unregister(name)
+}
+
 // BridgeRegistry manages MCP tool wrappers
 type BridgeRegistry struct {
 	clientManager *client.MCPClientManager
@@ -23,6 +63,9 @@ type BridgeRegistry struct {
 	// Track wrapped tools
 	wrappedTools map[string]*MCPToolWrapper // tool name → wrapper
 	mu           sync.RWMutex
+
+	// Clients watched for tools/list_changed, by server name
+	watched map[string]*client.MCPClient
 }
 
 // NewBridgeRegistry creates a new bridge registry
@@ -34,10 +77,13 @@ func NewBridgeRegistry(
 		clientManager: clientManager,
 		registrar:     registrar,
 		wrappedTools:  make(map[string]*MCPToolWrapper),
+		watched:       make(map[string]*client.MCPClient),
 	}
 }
 
-// RegisterServerTools wraps and registers all tools from an MCP server
+// RegisterServerTools wraps and registers all tools from an MCP server.
+// The server's tools are re-registered automatically whenever it sends
+// notifications/tools/list_changed.
 func (r *BridgeRegistry) RegisterServerTools(
 	ctx context.Context,
 	serverName string,
@@ -54,6 +100,70 @@ func (r *BridgeRegistry) RegisterServerTools(
 		return fmt.Errorf("no tools found on server: %s", serverName)
 	}
 
+	if r.registerTools(serverName, tools) == 0 {
+		return fmt.Errorf("failed to register any tools from server: %s", serverName)
+	}
+
+	r.watchServer(serverName, mcpClient)
+
+	return nil
+}
+
+// RefreshServerTools re-fetches a server's tool list and re-registers its
+// tools, removing tools the server no longer offers
+func (r *BridgeRegistry) RefreshServerTools(ctx context.Context, serverName string) error {
+	mcpClient, err := r.clientManager.GetClient(serverName)
+	if err != nil {
+		return fmt.Errorf("server not found: %w", err)
+	}
+
+	if err := mcpClient.RefreshTools(ctx); err != nil {
+		return fmt.Errorf("failed to refresh tools: %w", err)
+	}
+
+	return r.reregisterServerTools(serverName, mcpClient.GetTools())
+}
+
+// reregisterServerTools replaces a server's registered tools with tools
+func (r *BridgeRegistry) reregisterServerTools(serverName string, tools []client.MCPTool) error {
+	// No tools registered yet is fine
+	_ = r.UnregisterServerTools(serverName)
+
+	if len(tools) == 0 {
+		return nil
+	}
+	if r.registerTools(serverName, tools) == 0 {
+		return fmt.Errorf("failed to register any tools from server: %s", serverName)
+	}
+
+	return nil
+}
+
+// watchServer re-registers a server's tools when its client reports a tool
+// list change. Each client is subscribed once; after a reconnect the new
+// client replaces the old one.
+func (r *BridgeRegistry) watchServer(serverName string, mcpClient *client.MCPClient) {
+	r.mu.Lock()
+	if r.watched[serverName] == mcpClient {
+		r.mu.Unlock()
+		return
+	}
+	r.watched[serverName] = mcpClient
+	r.mu.Unlock()
+
+	mcpClient.OnToolsChanged(func(tools []client.MCPTool) {
+		r.mu.RLock()
+		current := r.watched[serverName]
+		r.mu.RUnlock()
+
+		if current == mcpClient {
+			_ = r.reregisterServerTools(serverName, tools)
+		}
+	})
+}
+
+// registerTools wraps and registers tools, returning how many succeeded
+func (r *BridgeRegistry) registerTools(serverName string, tools []client.MCPTool) int {
 	// Wrap and register each tool
 	registered := 0
 	for _, mcpTool := range tools {
@@ -73,11 +183,7 @@ func (r *BridgeRegistry) RegisterServerTools(
 		registered++
 	}
 
-	if registered == 0 {
-		return fmt.Errorf("failed to register any tools from server: %s", serverName)
-	}
-
-	return nil
+	return registered
 }
 
 // UnregisterServerTools removes all tools from a server
@@ -89,12 +195,17 @@ func (r *BridgeRegistry) UnregisterServerTools(serverName string) error {
 	prefix := fmt.Sprintf("mcp_%s_", serverName)
 	removed := 0
 
+	unregistrar, canUnregister := r.registrar.(ToolUnregistrar)
+
 	for toolName := range r.wrappedTools {
 		if strings.HasPrefix(toolName, prefix) {
 			delete(r.wrappedTools, toolName)
 			removed++
-			// Note: Can't unregister from framework as it doesn't have Unregister method
-			// Tools remain registered but become unavailable when disconnected
+			// Registrars without Unregister keep the tool, which becomes
+			// unavailable once the server disconnects
+			if canUnregister {
+				_ = unregistrar.UnregisterTool(toolName)
+			}
 		}
 	}
 
@@ -0,0 +1,173 @@
+package bridge
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/Ranganaths/minion/documentloader"
+	"github.com/Ranganaths/minion/mcp/client"
+	"github.com/Ranganaths/minion/vectorstore"
+)
+
+// ResourceClient is the subset of MCPClient used to load resources
+type ResourceClient interface {
+	ListResources(ctx context.Context) ([]client.MCPResource, error)
+	ReadResource(ctx context.Context, uri string) (*client.MCPReadResourceResult, error)
+}
+
+// ResourceLoaderConfig configures an MCP resource loader
+type ResourceLoaderConfig struct {
+	// ServerName is recorded in document metadata as "mcp_server"
+	ServerName string
+
+	// URIs are the resources to load. If empty, every resource the server
+	// lists is loaded.
+	URIs []string
+
+	// MimeTypes optionally restricts listed resources to these MIME types.
+	// It does not apply to explicit URIs.
+	MimeTypes []string
+}
+
+// ResourceLoader loads MCP server resources as documents so they can be
+// split, embedded and stored like any other RAG source
+type ResourceLoader struct {
+	documentloader.BaseLoader
+	client ResourceClient
+	config ResourceLoaderConfig
+}
+
+// NewResourceLoader creates a document loader for an MCP server's resources
+func NewResourceLoader(resourceClient ResourceClient, cfg ResourceLoaderConfig) *ResourceLoader {
+	return &ResourceLoader{
+		BaseLoader: documentloader.NewBaseLoader(documentloader.DefaultLoaderConfig()),
+		client:     resourceClient,
+		config:     cfg,
+	}
+}
+
+// Load reads each resource and returns one document per text content.
+// Binary contents are decoded only when their MIME type is textual and are
+// skipped otherwise.
+func (l *ResourceLoader) Load(ctx context.Context) ([]vectorstore.Document, error) {
+	resources, err := l.resources(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []vectorstore.Document
+	for _, resource := range resources {
+		result, err := l.client.ReadResource(ctx, resource.URI)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, contents := range result.Contents {
+			text, ok := contentsText(contents)
+			if !ok {
+				continue
+			}
+
+			metadata := map[string]any{
+				"source": contents.URI,
+			}
+			if contents.URI == "" {
+				metadata["source"] = resource.URI
+			}
+			if resource.Name != "" {
+				metadata["title"] = resource.Name
+			}
+			if mimeType := firstNonEmpty(contents.MimeType, resource.MimeType); mimeType != "" {
+				metadata["mime_type"] = mimeType
+			}
+			if l.config.ServerName != "" {
+				metadata["mcp_server"] = l.config.ServerName
+			}
+
+			docs = append(docs, vectorstore.NewDocumentWithMetadata(text, metadata))
+		}
+	}
+
+	return docs, nil
+}
+
+// LoadAndSplit loads resources and splits them into chunks
+func (l *ResourceLoader) LoadAndSplit(ctx context.Context, splitter documentloader.TextSplitter) ([]vectorstore.Document, error) {
+	return l.BaseLoader.LoadAndSplit(ctx, l, splitter)
+}
+
+// resources returns the configured resources, listing the server when no
+// URIs were given
+func (l *ResourceLoader) resources(ctx context.Context) ([]client.MCPResource, error) {
+	if len(l.config.URIs) > 0 {
+		resources := make([]client.MCPResource, len(l.config.URIs))
+		for i, uri := range l.config.URIs {
+			resources[i] = client.MCPResource{URI: uri}
+		}
+		return resources, nil
+	}
+
+	listed, err := l.client.ListResources(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources: %w", err)
+	}
+	if len(l.config.MimeTypes) == 0 {
+		return listed, nil
+	}
+
+	var filtered []client.MCPResource
+	for _, resource := range listed {
+		for _, mimeType := range l.config.MimeTypes {
+			if resource.MimeType == mimeType {
+				filtered = append(filtered, resource)
+				break
+			}
+		}
+	}
+	return filtered, nil
+}
+
+// contentsText returns the text of resource contents, decoding blobs with a
+// textual MIME type
+func contentsText(contents client.MCPResourceContents) (string, bool) {
+	if contents.Blob == "" {
+		return contents.Text, contents.Text != ""
+	}
+	if !isTextMimeType(contents.MimeType) {
+		return "", false
+	}
+
+	data, err := base64.StdEncoding.DecodeString(contents.Blob)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// isTextMimeType reports whether a MIME type holds text
+func isTextMimeType(mimeType string) bool {
+	return strings.HasPrefix(mimeType, "text/") ||
+		strings.HasSuffix(mimeType, "json") ||
+		strings.HasSuffix(mimeType, "xml") ||
+		mimeType == "application/yaml"
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Ensure ResourceLoader implements documentloader.Loader
+var _ documentloader.Loader = (*ResourceLoader)(nil)
+
+// Ensure MCPClient satisfies the bridge client interfaces
+var (
+	_ ResourceClient = (*client.MCPClient)(nil)
+	_ PromptClient   = (*client.MCPClient)(nil)
+)
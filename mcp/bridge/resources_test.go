@@ -0,0 +1,186 @@
+package bridge
+
+import (
+	"context"
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Ranganaths/minion/mcp/client"
+	"github.com/Ranganaths/minion/prompt"
+)
+
+// fakeMCPServer serves resources and prompts from memory
+type fakeMCPServer struct {
+	resources []client.MCPResource
+	contents  map[string][]client.MCPResourceContents
+	prompts   []client.MCPPrompt
+}
+
+func (f *fakeMCPServer) ListResources(ctx context.Context) ([]client.MCPResource, error) {
+	return f.resources, nil
+}
+
+func (f *fakeMCPServer) ReadResource(ctx context.Context, uri string) (*client.MCPReadResourceResult, error) {
+	return &client.MCPReadResourceResult{Contents: f.contents[uri]}, nil
+}
+
+func (f *fakeMCPServer) ListPrompts(ctx context.Context) ([]client.MCPPrompt, error) {
+	return f.prompts, nil
+}
+
+// GetPrompt renders "Write about <topic> for <audience>" as two messages,
+// or a review conversation for the "review" prompt
+func (f *fakeMCPServer) GetPrompt(ctx context.Context, name string, args map[string]string) (*client.MCPGetPromptResult, error) {
+	if name == "review" {
+		return &client.MCPGetPromptResult{Messages: []client.MCPPromptMessage{
+			{Role: "user", Content: map[string]interface{}{"type": "text", "text": "Review " + args["file-path"] + " by " + args["user.name"] + ". Keep {{.Secret}} and {{template \"x\"}} as written."}},
+			{Role: "assistant", Content: map[string]interface{}{"type": "text", "text": "Which checks for " + args["file-path"] + "?"}},
+		}}, nil
+	}
+	return &client.MCPGetPromptResult{Messages: []client.MCPPromptMessage{
+		{Role: "user", Content: map[string]interface{}{"type": "text", "text": "Write about " + args["topic"]}},
+		{Role: "user", Content: map[string]interface{}{"type": "image", "data": "..."}},
+		{Role: "user", Content: map[string]interface{}{"type": "text", "text": "Audience: " + args["audience"]}},
+	}}, nil
+}
+
+func newFakeMCPServer() *fakeMCPServer {
+	return &fakeMCPServer{
+		resources: []client.MCPResource{
+			{URI: "file:///readme.md", Name: "README", MimeType: "text/markdown"},
+			{URI: "file:///config.json", Name: "Config", MimeType: "application/json"},
+			{URI: "file:///logo.png", Name: "Logo", MimeType: "image/png"},
+		},
+		contents: map[string][]client.MCPResourceContents{
+			"file:///readme.md":   {{URI: "file:///readme.md", Text: "# Readme"}},
+			"file:///config.json": {{URI: "file:///config.json", MimeType: "application/json", Blob: base64.StdEncoding.EncodeToString([]byte(`{"a":1}`))}},
+			"file:///logo.png":    {{URI: "file:///logo.png", MimeType: "image/png", Blob: "iVBORw0KGgo="}},
+		},
+		prompts: []client.MCPPrompt{
+			{Name: "article", Arguments: []client.MCPPromptArgument{
+				{Name: "topic", Required: true},
+				{Name: "audience"},
+			}},
+			{Name: "review", Arguments: []client.MCPPromptArgument{
+				{Name: "file-path", Required: true},
+				{Name: "user.name", Required: true},
+			}},
+		},
+	}
+}
+
+func TestResourceLoader_Load(t *testing.T) {
+	server := newFakeMCPServer()
+
+	tests := []struct {
+		name    string
+		config  ResourceLoaderConfig
+		sources []string
+	}{
+		{
+			name:    "all resources skip binary",
+			config:  ResourceLoaderConfig{ServerName: "files"},
+			sources: []string{"file:///readme.md", "file:///config.json"},
+		},
+		{
+			name:    "explicit URIs",
+			config:  ResourceLoaderConfig{URIs: []string{"file:///config.json"}},
+			sources: []string{"file:///config.json"},
+		},
+		{
+			name:    "MIME type filter",
+			config:  ResourceLoaderConfig{MimeTypes: []string{"text/markdown"}},
+			sources: []string{"file:///readme.md"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docs, err := NewResourceLoader(server, tt.config).Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if len(docs) != len(tt.sources) {
+				t.Fatalf("expected %d documents, got %d", len(tt.sources), len(docs))
+			}
+			for i, doc := range docs {
+				if doc.Metadata["source"] != tt.sources[i] {
+					t.Errorf("doc %d source = %v, want %s", i, doc.Metadata["source"], tt.sources[i])
+				}
+			}
+		})
+	}
+
+	docs, _ := NewResourceLoader(server, ResourceLoaderConfig{ServerName: "files"}).Load(context.Background())
+	if docs[0].PageContent != "# Readme" || docs[0].Metadata["title"] != "README" || docs[0].Metadata["mcp_server"] != "files" {
+		t.Errorf("unexpected document %+v", docs[0])
+	}
+	if docs[1].PageContent != `{"a":1}` {
+		t.Errorf("expected decoded JSON blob, got %q", docs[1].PageContent)
+	}
+}
+
+func TestLoadPromptTemplate(t *testing.T) {
+	server := newFakeMCPServer()
+	ctx := context.Background()
+
+	template, err := LoadPromptTemplate(ctx, server, "article")
+	if err != nil {
+		t.Fatalf("LoadPromptTemplate failed: %v", err)
+	}
+	if vars := template.InputVariables(); len(vars) != 1 || vars[0] != "topic" {
+		t.Errorf("InputVariables = %v, want [topic]", vars)
+	}
+
+	text, err := template.Format(map[string]any{"topic": "Go", "audience": "beginners"})
+	if err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	if text != "Write about Go\n\nAudience: beginners" {
+		t.Errorf("Format = %q", text)
+	}
+
+	text, _ = template.Format(map[string]any{"topic": "Go"})
+	if !strings.HasSuffix(text, "Audience: ") {
+		t.Errorf("expected optional argument to default to empty, got %q", text)
+	}
+
+	if _, err := template.Format(map[string]any{}); err == nil {
+		t.Error("expected missing required argument to fail")
+	}
+
+	if _, err := LoadPromptTemplate(ctx, server, "missing"); err == nil {
+		t.Error("expected unknown prompt to fail")
+	}
+
+	templates, err := LoadPromptTemplates(ctx, server)
+	if err != nil || len(templates) != 2 || templates["article"] == nil || templates["review"] == nil {
+		t.Errorf("LoadPromptTemplates = %v, %v", templates, err)
+	}
+}
+
+func TestLoadPromptTemplateMessages(t *testing.T) {
+	template, err := LoadPromptTemplate(context.Background(), newFakeMCPServer(), "review")
+	if err != nil {
+		t.Fatalf("LoadPromptTemplate failed: %v", err)
+	}
+
+	messages, err := template.FormatMessages(map[string]any{"file-path": "main.go", "user.name": "{{.Secret}}"})
+	if err != nil {
+		t.Fatalf("FormatMessages failed: %v", err)
+	}
+
+	want := []prompt.ChatMessage{
+		{Role: "user", Content: `Review main.go by {{.Secret}}. Keep {{.Secret}} and {{template "x"}} as written.`},
+		{Role: "assistant", Content: "Which checks for main.go?"},
+	}
+	if !reflect.DeepEqual(messages, want) {
+		t.Errorf("FormatMessages = %+v, want %+v", messages, want)
+	}
+
+	if _, err := template.FormatMessages(map[string]any{"file-path": "main.go"}); err == nil {
+		t.Error("expected missing required argument to fail")
+	}
+}
@@ -29,8 +29,11 @@ type MCPToolWrapper struct {
 	validateSchema   bool
 }
 
-// Ensure MCPToolWrapper implements tools.Tool interface
-var _ tools.Tool = (*MCPToolWrapper)(nil)
+// Ensure MCPToolWrapper implements tools.Tool and tools.SchemaProvider interfaces
+var (
+	_ tools.Tool           = (*MCPToolWrapper)(nil)
+	_ tools.SchemaProvider = (*MCPToolWrapper)(nil)
+)
 
 // NewMCPToolWrapper creates a new MCP tool wrapper
 func NewMCPToolWrapper(
@@ -48,7 +51,8 @@ func NewMCPToolWrapper(
 	}
 }
 
-// WithSchemaValidation enables or disables schema validation
+// WithSchemaValidation enables or disables schema validation in Execute.
+// Tool registries still validate against InputSchema before calling Execute.
 func (w *MCPToolWrapper) WithSchemaValidation(enabled bool) *MCPToolWrapper {
 	w.validateSchema = enabled
 	return w
@@ -72,6 +76,11 @@ func (w *MCPToolWrapper) Description() string {
 	return fmt.Sprintf("[MCP:%s] %s", w.serverName, w.mcpTool.Description)
 }
 
+// InputSchema returns the input schema advertised by the MCP server
+func (w *MCPToolWrapper) InputSchema() map[string]interface{} {
+	return w.mcpTool.InputSchema
+}
+
 // Execute executes the MCP tool via the client
 func (w *MCPToolWrapper) Execute(
 	ctx context.Context,
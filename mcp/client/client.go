@@ -146,6 +146,7 @@ func newMCPClient(config *ClientConfig) (*MCPClient, error) {
 		metrics: &clientMetrics{
 			mu: sync.RWMutex{},
 		},
+		notifications: newNotificationRouter(),
 	}, nil
 }
 
@@ -187,6 +188,7 @@ func (c *MCPClient) connectOnce(ctx context.Context) error {
 		return fmt.Errorf("failed to create transport: %w", err)
 	}
 	c.transport = transport
+	c.transport.SetNotificationHandler(c.handleNotification)
 
 	// Connect transport
 	if err := c.transport.Connect(ctx); err != nil {
@@ -263,13 +265,21 @@ func (c *MCPClient) GetTool(name string) (*MCPTool, error) {
 
 // CallTool executes a tool on the remote server
 func (c *MCPClient) CallTool(ctx context.Context, toolName string, args map[string]interface{}) (*MCPCallToolResult, error) {
+	return c.callTool(ctx, &MCPCallToolRequest{
+		Name:      toolName,
+		Arguments: args,
+	})
+}
+
+// callTool sends a tools/call request
+func (c *MCPClient) callTool(ctx context.Context, request *MCPCallToolRequest) (*MCPCallToolResult, error) {
 	// Check connection
 	if !c.IsConnected() {
 		return nil, fmt.Errorf("not connected to server")
 	}
 
 	// Verify tool exists
-	if _, err := c.GetTool(toolName); err != nil {
+	if _, err := c.GetTool(request.Name); err != nil {
 		return nil, err
 	}
 
@@ -280,12 +290,6 @@ func (c *MCPClient) CallTool(ctx context.Context, toolName string, args map[stri
 		c.recordMetrics(time.Since(startTime), callErr == nil)
 	}()
 
-	// Create request
-	request := &MCPCallToolRequest{
-		Name:      toolName,
-		Arguments: args,
-	}
-
 	// Send request
 	result, err := c.transport.SendRequest(ctx, "tools/call", request)
 	if err != nil {
@@ -354,6 +358,10 @@ func (c *MCPClient) createTransport() (Transport, error) {
 		return newStdioTransport(c.config)
 	case TransportHTTP:
 		return newHTTPTransport(c.config)
+	case TransportStreamableHTTP:
+		return newStreamableHTTPTransport(c.config)
+	case TransportSSE:
+		return newSSETransport(c.config)
 	default:
 		return nil, fmt.Errorf("unsupported transport: %s", c.config.Transport)
 	}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// notificationRouter dispatches server notifications to registered handlers
+type notificationRouter struct {
+	handlers     map[string][]NotificationHandler
+	toolsChanged []func(tools []MCPTool)
+	logHandlers  []func(message MCPLogMessage)
+	progress     map[string]ProgressHandler // progress token → handler
+	nextToken    atomic.Int64
+	mu           sync.RWMutex
+}
+
+func newNotificationRouter() *notificationRouter {
+	return &notificationRouter{
+		handlers: make(map[string][]NotificationHandler),
+		progress: make(map[string]ProgressHandler),
+	}
+}
+
+// OnNotification registers a handler for a notification method, e.g.
+// NotificationResourceUpdated. Handlers run on the transport's read
+// goroutine and must not block or call back into the client synchronously.
+func (c *MCPClient) OnNotification(method string, handler NotificationHandler) {
+	c.notifications.mu.Lock()
+	defer c.notifications.mu.Unlock()
+	c.notifications.handlers[method] = append(c.notifications.handlers[method], handler)
+}
+
+// OnToolsChanged registers a handler called with the new tool list after the
+// server reports notifications/tools/list_changed and the client has
+// refreshed its cached tools
+func (c *MCPClient) OnToolsChanged(handler func(tools []MCPTool)) {
+	c.notifications.mu.Lock()
+	defer c.notifications.mu.Unlock()
+	c.notifications.toolsChanged = append(c.notifications.toolsChanged, handler)
+}
+
+// OnLogMessage registers a handler for log messages sent by the server
+func (c *MCPClient) OnLogMessage(handler func(message MCPLogMessage)) {
+	c.notifications.mu.Lock()
+	defer c.notifications.mu.Unlock()
+	c.notifications.logHandlers = append(c.notifications.logHandlers, handler)
+}
+
+// SetLogLevel sets the minimum level of log messages the server sends,
+// e.g. "debug", "info", "warning" or "error"
+func (c *MCPClient) SetLogLevel(ctx context.Context, level string) error {
+	if _, err := c.request(ctx, "logging/setLevel", map[string]interface{}{"level": level}); err != nil {
+		return fmt.Errorf("failed to set log level: %w", err)
+	}
+	return nil
+}
+
+// RefreshTools re-fetches the server's tool list and updates the cache
+func (c *MCPClient) RefreshTools(ctx context.Context) error {
+	c.stateMu.RLock()
+	connected := c.connected && c.transport != nil
+	c.stateMu.RUnlock()
+
+	if !connected {
+		return fmt.Errorf("not connected to server")
+	}
+
+	return c.discoverTools(ctx)
+}
+
+// CallToolWithProgress executes a tool like CallTool and passes progress
+// notifications for the call to onProgress until it returns
+func (c *MCPClient) CallToolWithProgress(ctx context.Context, toolName string, args map[string]interface{}, onProgress ProgressHandler) (*MCPCallToolResult, error) {
+	if onProgress == nil {
+		return c.CallTool(ctx, toolName, args)
+	}
+
+	token := fmt.Sprintf("%s-%d", c.serverName, c.notifications.nextToken.Add(1))
+
+	c.notifications.mu.Lock()
+	c.notifications.progress[token] = onProgress
+	c.notifications.mu.Unlock()
+
+	defer func() {
+		c.notifications.mu.Lock()
+		delete(c.notifications.progress, token)
+		c.notifications.mu.Unlock()
+	}()
+
+	return c.callTool(ctx, &MCPCallToolRequest{
+		Name:      toolName,
+		Arguments: args,
+		Meta:      map[string]interface{}{"progressToken": token},
+	})
+}
+
+// handleNotification dispatches a notification from the transport
+func (c *MCPClient) handleNotification(notification *MCPNotification) {
+	router := c.notifications
+
+	switch notification.Method {
+	case NotificationProgress:
+		var progress MCPProgress
+		if err := json.Unmarshal(notification.Params, &progress); err == nil {
+			router.mu.RLock()
+			handler, ok := router.progress[fmt.Sprint(progress.ProgressToken)]
+			router.mu.RUnlock()
+			if ok {
+				handler(progress)
+			}
+		}
+
+	case NotificationMessage:
+		var message MCPLogMessage
+		if err := json.Unmarshal(notification.Params, &message); err == nil {
+			router.mu.RLock()
+			handlers := append([]func(MCPLogMessage){}, router.logHandlers...)
+			router.mu.RUnlock()
+			for _, handler := range handlers {
+				handler(message)
+			}
+		}
+
+	case NotificationToolsListChanged:
+		// Refreshing sends a request whose response arrives on the goroutine
+		// running this handler, so it must happen asynchronously
+		go c.refreshChangedTools()
+	}
+
+	router.mu.RLock()
+	handlers := append([]NotificationHandler{}, router.handlers[notification.Method]...)
+	router.mu.RUnlock()
+	for _, handler := range handlers {
+		handler(notification)
+	}
+}
+
+// refreshChangedTools refreshes the cached tools after a list_changed
+// notification and notifies OnToolsChanged handlers
+func (c *MCPClient) refreshChangedTools() {
+	timeout := c.config.RequestTimeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := c.RefreshTools(ctx); err != nil {
+		c.metrics.mu.Lock()
+		c.metrics.lastError = fmt.Sprintf("failed to refresh tools: %v", err)
+		c.metrics.lastErrorTime = time.Now()
+		c.metrics.mu.Unlock()
+		return
+	}
+
+	c.notifications.mu.RLock()
+	handlers := append([]func([]MCPTool){}, c.notifications.toolsChanged...)
+	c.notifications.mu.RUnlock()
+
+	tools := c.GetTools()
+	for _, handler := range handlers {
+		handler(tools)
+	}
+}
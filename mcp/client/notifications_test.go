@@ -0,0 +1,254 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// pipeServer is a scripted MCP server on the other end of a stdio transport
+type pipeServer struct {
+	reader *bufio.Scanner
+	writer io.Writer
+	mu     sync.Mutex
+}
+
+func (s *pipeServer) send(t *testing.T, msg interface{}) {
+	t.Helper()
+	data, _ := json.Marshal(msg)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.writer.Write(append(data, '\n')); err != nil {
+		t.Errorf("server write failed: %v", err)
+	}
+}
+
+func (s *pipeServer) receive(t *testing.T) map[string]interface{} {
+	t.Helper()
+	if !s.reader.Scan() {
+		t.Fatalf("server read failed: %v", s.reader.Err())
+	}
+	var msg map[string]interface{}
+	if err := json.Unmarshal(s.reader.Bytes(), &msg); err != nil {
+		t.Fatalf("invalid message %s: %v", s.reader.Bytes(), err)
+	}
+	return msg
+}
+
+func (s *pipeServer) respond(t *testing.T, request map[string]interface{}, result interface{}) {
+	t.Helper()
+	s.send(t, map[string]interface{}{"jsonrpc": "2.0", "id": request["id"], "result": result})
+}
+
+func toolsResult(names ...string) map[string]interface{} {
+	tools := make([]interface{}, len(names))
+	for i, name := range names {
+		tools[i] = map[string]interface{}{"name": name, "inputSchema": map[string]interface{}{"type": "object"}}
+	}
+	return map[string]interface{}{"tools": tools}
+}
+
+// newPipeClient connects a client to a pipeServer over an in-memory stdio
+// transport, with the tool list already discovered
+func newPipeClient(t *testing.T) (*MCPClient, *pipeServer) {
+	t.Helper()
+
+	clientIn, serverOut := io.Pipe()
+	serverIn, clientOut := io.Pipe()
+	t.Cleanup(func() {
+		clientIn.Close()
+		serverIn.Close()
+	})
+
+	transport := &stdioTransport{
+		config:     DefaultClientConfig("pipe"),
+		stdin:      clientOut,
+		stdout:     clientIn,
+		pending:    make(map[int64]chan *jsonrpcResponse),
+		readerDone: make(chan struct{}),
+		connected:  true,
+	}
+
+	c, err := newMCPClient(DefaultClientConfig("pipe"))
+	if err != nil {
+		t.Fatalf("newMCPClient failed: %v", err)
+	}
+	transport.SetNotificationHandler(c.handleNotification)
+	c.transport = transport
+	c.connected = true
+	go transport.readLoop()
+
+	server := &pipeServer{reader: bufio.NewScanner(serverIn), writer: serverOut}
+
+	done := make(chan error, 1)
+	go func() { done <- c.RefreshTools(context.Background()) }()
+	server.respond(t, server.receive(t), toolsResult("search"))
+	if err := <-done; err != nil {
+		t.Fatalf("RefreshTools failed: %v", err)
+	}
+
+	return c, server
+}
+
+func TestMCPClient_CallToolWithProgress(t *testing.T) {
+	c, server := newPipeClient(t)
+
+	var mu sync.Mutex
+	var updates []MCPProgress
+
+	type callResult struct {
+		result *MCPCallToolResult
+		err    error
+	}
+	done := make(chan callResult, 1)
+	go func() {
+		result, err := c.CallToolWithProgress(context.Background(), "search", nil, func(p MCPProgress) {
+			mu.Lock()
+			updates = append(updates, p)
+			mu.Unlock()
+		})
+		done <- callResult{result, err}
+	}()
+
+	request := server.receive(t)
+	meta := request["params"].(map[string]interface{})["_meta"].(map[string]interface{})
+	token := meta["progressToken"]
+
+	for _, progress := range []float64{1, 2} {
+		server.send(t, map[string]interface{}{
+			"jsonrpc": "2.0",
+			"method":  NotificationProgress,
+			"params":  map[string]interface{}{"progressToken": token, "progress": progress, "total": 2},
+		})
+	}
+	// Progress for another request is not delivered
+	server.send(t, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  NotificationProgress,
+		"params":  map[string]interface{}{"progressToken": "other", "progress": 9},
+	})
+	server.respond(t, request, map[string]interface{}{"content": []interface{}{}})
+
+	res := <-done
+	if res.err != nil {
+		t.Fatalf("CallToolWithProgress failed: %v", res.err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(updates) != 2 || updates[1].Progress != 2 || updates[1].Total != 2 {
+		t.Errorf("unexpected progress updates %+v", updates)
+	}
+}
+
+func TestMCPClient_ToolsListChanged(t *testing.T) {
+	c, server := newPipeClient(t)
+
+	changed := make(chan []MCPTool, 1)
+	c.OnToolsChanged(func(tools []MCPTool) { changed <- tools })
+
+	server.send(t, map[string]interface{}{"jsonrpc": "2.0", "method": NotificationToolsListChanged})
+	request := server.receive(t)
+	if request["method"] != "tools/list" {
+		t.Fatalf("expected tools/list after list_changed, got %v", request["method"])
+	}
+	server.respond(t, request, toolsResult("search", "fetch"))
+
+	select {
+	case tools := <-changed:
+		if len(tools) != 2 || tools[1].Name != "fetch" {
+			t.Errorf("unexpected tools %+v", tools)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("OnToolsChanged was not called")
+	}
+
+	if _, err := c.GetTool("fetch"); err != nil {
+		t.Errorf("expected cached tools to be refreshed: %v", err)
+	}
+}
+
+func TestMCPClient_LogAndGenericNotifications(t *testing.T) {
+	c, server := newPipeClient(t)
+
+	logs := make(chan MCPLogMessage, 1)
+	c.OnLogMessage(func(m MCPLogMessage) { logs <- m })
+
+	updated := make(chan string, 1)
+	c.OnNotification(NotificationResourceUpdated, func(n *MCPNotification) {
+		var params struct {
+			URI string `json:"uri"`
+		}
+		_ = json.Unmarshal(n.Params, &params)
+		updated <- params.URI
+	})
+
+	server.send(t, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  NotificationMessage,
+		"params":  map[string]interface{}{"level": "warning", "logger": "db", "data": "slow query"},
+	})
+	server.send(t, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  NotificationResourceUpdated,
+		"params":  map[string]interface{}{"uri": "file:///a.txt"},
+	})
+
+	select {
+	case m := <-logs:
+		if m.Level != "warning" || m.Logger != "db" || m.Data != "slow query" {
+			t.Errorf("unexpected log message %+v", m)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("log message not delivered")
+	}
+
+	select {
+	case uri := <-updated:
+		if uri != "file:///a.txt" {
+			t.Errorf("uri = %s", uri)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("resource update not delivered")
+	}
+}
+
+func TestStdioTransport_ServerRequestsAndCancellation(t *testing.T) {
+	c, server := newPipeClient(t)
+
+	// Server-initiated ping is answered; unsupported requests are declined
+	server.send(t, map[string]interface{}{"jsonrpc": "2.0", "id": "p1", "method": "ping"})
+	if reply := server.receive(t); reply["id"] != "p1" || reply["result"] == nil {
+		t.Errorf("unexpected ping reply %v", reply)
+	}
+	server.send(t, map[string]interface{}{"jsonrpc": "2.0", "id": 7, "method": "sampling/createMessage"})
+	if reply := server.receive(t); reply["error"] == nil {
+		t.Errorf("expected sampling request to be declined, got %v", reply)
+	}
+
+	// A cancelled call sends notifications/cancelled with its request ID
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.CallTool(ctx, "search", nil)
+		done <- err
+	}()
+
+	request := server.receive(t)
+	cancel()
+
+	cancelled := server.receive(t)
+	if cancelled["method"] != NotificationCancelled {
+		t.Fatalf("expected cancellation, got %v", cancelled)
+	}
+	if params := cancelled["params"].(map[string]interface{}); params["requestId"] != request["id"] {
+		t.Errorf("requestId = %v, want %v", params["requestId"], request["id"])
+	}
+	if err := <-done; err == nil {
+		t.Error("expected cancelled call to fail")
+	}
+}
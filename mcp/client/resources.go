@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// maxListPages bounds cursor pagination so a misbehaving server cannot
+// keep a list call running forever
+const maxListPages = 100
+
+// ListResources returns all resources exposed by the server, following
+// pagination cursors
+func (c *MCPClient) ListResources(ctx context.Context) ([]MCPResource, error) {
+	var resources []MCPResource
+
+	err := c.paginate(ctx, "resources/list", func(result interface{}) (string, error) {
+		page, ok := result.(*MCPListResourcesResult)
+		if !ok {
+			return "", fmt.Errorf("invalid resources response")
+		}
+		resources = append(resources, page.Resources...)
+		return page.NextCursor, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// ListResourceTemplates returns all resource templates exposed by the
+// server, following pagination cursors
+func (c *MCPClient) ListResourceTemplates(ctx context.Context) ([]MCPResourceTemplate, error) {
+	var templates []MCPResourceTemplate
+
+	err := c.paginate(ctx, "resources/templates/list", func(result interface{}) (string, error) {
+		page, ok := result.(*MCPListResourceTemplatesResult)
+		if !ok {
+			return "", fmt.Errorf("invalid resource templates response")
+		}
+		templates = append(templates, page.ResourceTemplates...)
+		return page.NextCursor, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// ReadResource reads the contents of a resource by URI
+func (c *MCPClient) ReadResource(ctx context.Context, uri string) (*MCPReadResourceResult, error) {
+	result, err := c.request(ctx, "resources/read", map[string]interface{}{"uri": uri})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource %s: %w", uri, err)
+	}
+
+	contents, ok := result.(*MCPReadResourceResult)
+	if !ok {
+		return nil, fmt.Errorf("invalid resource contents response")
+	}
+
+	return contents, nil
+}
+
+// SubscribeResource asks the server to send notifications/resources/updated
+// when the resource changes
+func (c *MCPClient) SubscribeResource(ctx context.Context, uri string) error {
+	if _, err := c.request(ctx, "resources/subscribe", map[string]interface{}{"uri": uri}); err != nil {
+		return fmt.Errorf("failed to subscribe to resource %s: %w", uri, err)
+	}
+	return nil
+}
+
+// UnsubscribeResource cancels a subscription made with SubscribeResource
+func (c *MCPClient) UnsubscribeResource(ctx context.Context, uri string) error {
+	if _, err := c.request(ctx, "resources/unsubscribe", map[string]interface{}{"uri": uri}); err != nil {
+		return fmt.Errorf("failed to unsubscribe from resource %s: %w", uri, err)
+	}
+	return nil
+}
+
+// ListPrompts returns all prompts exposed by the server, following
+// pagination cursors
+func (c *MCPClient) ListPrompts(ctx context.Context) ([]MCPPrompt, error) {
+	var prompts []MCPPrompt
+
+	err := c.paginate(ctx, "prompts/list", func(result interface{}) (string, error) {
+		page, ok := result.(*MCPListPromptsResult)
+		if !ok {
+			return "", fmt.Errorf("invalid prompts response")
+		}
+		prompts = append(prompts, page.Prompts...)
+		return page.NextCursor, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return prompts, nil
+}
+
+// GetPrompt renders a prompt on the server with the given arguments
+func (c *MCPClient) GetPrompt(ctx context.Context, name string, args map[string]string) (*MCPGetPromptResult, error) {
+	params := map[string]interface{}{"name": name}
+	if len(args) > 0 {
+		params["arguments"] = args
+	}
+
+	result, err := c.request(ctx, "prompts/get", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prompt %s: %w", name, err)
+	}
+
+	rendered, ok := result.(*MCPGetPromptResult)
+	if !ok {
+		return nil, fmt.Errorf("invalid prompt response")
+	}
+
+	return rendered, nil
+}
+
+// request sends a request on the connected transport
+func (c *MCPClient) request(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	c.stateMu.RLock()
+	transport := c.transport
+	connected := c.connected
+	c.stateMu.RUnlock()
+
+	if !connected || transport == nil {
+		return nil, fmt.Errorf("not connected to server")
+	}
+
+	return transport.SendRequest(ctx, method, params)
+}
+
+// paginate calls a list method until the server stops returning a cursor.
+// handle consumes one page and returns its next cursor.
+func (c *MCPClient) paginate(ctx context.Context, method string, handle func(result interface{}) (string, error)) error {
+	cursor := ""
+	for page := 0; page < maxListPages; page++ {
+		var params interface{}
+		if cursor != "" {
+			params = map[string]interface{}{"cursor": cursor}
+		}
+
+		result, err := c.request(ctx, method, params)
+		if err != nil {
+			return fmt.Errorf("failed to call %s: %w", method, err)
+		}
+
+		cursor, err = handle(result)
+		if err != nil {
+			return err
+		}
+		if cursor == "" {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s returned more than %d pages", method, maxListPages)
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newResourceServer serves tools/list plus paginated resources and prompts
+func newResourceServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int64                  `json:"id"`
+			Method string                 `json:"method"`
+			Params map[string]interface{} `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var result interface{}
+		switch req.Method {
+		case "tools/list":
+			result = map[string]interface{}{"tools": []interface{}{}}
+		case "resources/list":
+			if req.Params["cursor"] == "page2" {
+				result = map[string]interface{}{"resources": []interface{}{
+					map[string]interface{}{"uri": "file:///b.txt", "name": "b"},
+				}}
+			} else {
+				result = map[string]interface{}{
+					"resources": []interface{}{
+						map[string]interface{}{"uri": "file:///a.md", "name": "a", "mimeType": "text/markdown"},
+					},
+					"nextCursor": "page2",
+				}
+			}
+		case "resources/templates/list":
+			result = map[string]interface{}{"resourceTemplates": []interface{}{
+				map[string]interface{}{"uriTemplate": "file:///{path}", "name": "files"},
+			}}
+		case "resources/read":
+			result = map[string]interface{}{"contents": []interface{}{
+				map[string]interface{}{"uri": req.Params["uri"], "mimeType": "text/plain", "text": "hello"},
+			}}
+		case "resources/subscribe", "resources/unsubscribe":
+			result = map[string]interface{}{}
+		case "prompts/list":
+			result = map[string]interface{}{"prompts": []interface{}{
+				map[string]interface{}{
+					"name":      "summarize",
+					"arguments": []interface{}{map[string]interface{}{"name": "topic", "required": true}},
+				},
+			}}
+		case "prompts/get":
+			args, _ := req.Params["arguments"].(map[string]interface{})
+			result = map[string]interface{}{"messages": []interface{}{
+				map[string]interface{}{
+					"role":    "user",
+					"content": map[string]interface{}{"type": "text", "text": "Summarize " + args["topic"].(string)},
+				},
+			}}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+}
+
+func connectTestClient(t *testing.T, url string) *MCPClient {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	manager := NewMCPClientManager(nil)
+	t.Cleanup(func() { manager.Close() })
+
+	config := DefaultClientConfig("resources")
+	config.Transport = TransportHTTP
+	config.URL = url
+	if err := manager.ConnectServer(ctx, config); err != nil {
+		t.Fatalf("ConnectServer failed: %v", err)
+	}
+
+	c, err := manager.GetClient("resources")
+	if err != nil {
+		t.Fatalf("GetClient failed: %v", err)
+	}
+	return c
+}
+
+func TestMCPClient_Resources(t *testing.T) {
+	server := newResourceServer(t)
+	defer server.Close()
+	c := connectTestClient(t, server.URL)
+	ctx := context.Background()
+
+	resources, err := c.ListResources(ctx)
+	if err != nil {
+		t.Fatalf("ListResources failed: %v", err)
+	}
+	if len(resources) != 2 || resources[0].MimeType != "text/markdown" || resources[1].URI != "file:///b.txt" {
+		t.Errorf("expected both pages of resources, got %+v", resources)
+	}
+
+	templates, err := c.ListResourceTemplates(ctx)
+	if err != nil {
+		t.Fatalf("ListResourceTemplates failed: %v", err)
+	}
+	if len(templates) != 1 || templates[0].URITemplate != "file:///{path}" {
+		t.Errorf("unexpected templates %+v", templates)
+	}
+
+	contents, err := c.ReadResource(ctx, "file:///a.md")
+	if err != nil {
+		t.Fatalf("ReadResource failed: %v", err)
+	}
+	if len(contents.Contents) != 1 || contents.Contents[0].Text != "hello" || contents.Contents[0].URI != "file:///a.md" {
+		t.Errorf("unexpected contents %+v", contents)
+	}
+
+	if err := c.SubscribeResource(ctx, "file:///a.md"); err != nil {
+		t.Errorf("SubscribeResource failed: %v", err)
+	}
+	if err := c.UnsubscribeResource(ctx, "file:///a.md"); err != nil {
+		t.Errorf("UnsubscribeResource failed: %v", err)
+	}
+}
+
+func TestMCPClient_Prompts(t *testing.T) {
+	server := newResourceServer(t)
+	defer server.Close()
+	c := connectTestClient(t, server.URL)
+	ctx := context.Background()
+
+	prompts, err := c.ListPrompts(ctx)
+	if err != nil {
+		t.Fatalf("ListPrompts failed: %v", err)
+	}
+	if len(prompts) != 1 || len(prompts[0].Arguments) != 1 || !prompts[0].Arguments[0].Required {
+		t.Fatalf("unexpected prompts %+v", prompts)
+	}
+
+	rendered, err := c.GetPrompt(ctx, "summarize", map[string]string{"topic": "Q3"})
+	if err != nil {
+		t.Fatalf("GetPrompt failed: %v", err)
+	}
+	if len(rendered.Messages) != 1 || rendered.Messages[0].Text() != "Summarize Q3" {
+		t.Errorf("unexpected prompt %+v", rendered)
+	}
+}
+
+func TestMCPClient_ResourcesNotConnected(t *testing.T) {
+	c, err := newMCPClient(DefaultClientConfig("offline"))
+	if err != nil {
+		t.Fatalf("newMCPClient failed: %v", err)
+	}
+
+	if _, err := c.ListResources(context.Background()); err == nil {
+		t.Error("expected error when not connected")
+	}
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// parseResult decodes a JSON-RPC result into the type for its method.
+// Methods without a dedicated type are decoded generically.
+func parseResult(method string, result json.RawMessage) (interface{}, error) {
+	var target interface{}
+
+	switch method {
+	case "tools/list":
+		var toolsList struct {
+			Tools []map[string]interface{} `json:"tools"`
+		}
+		if err := json.Unmarshal(result, &toolsList); err != nil {
+			return nil, fmt.Errorf("failed to parse tools list: %w", err)
+		}
+		return map[string]interface{}{
+			"tools": convertToInterfaceSlice(toolsList.Tools),
+		}, nil
+
+	case "tools/call":
+		target = &MCPCallToolResult{}
+	case "resources/list":
+		target = &MCPListResourcesResult{}
+	case "resources/templates/list":
+		target = &MCPListResourceTemplatesResult{}
+	case "resources/read":
+		target = &MCPReadResourceResult{}
+	case "prompts/list":
+		target = &MCPListPromptsResult{}
+	case "prompts/get":
+		target = &MCPGetPromptResult{}
+
+	default:
+		// Generic response
+		var generic interface{}
+		if err := json.Unmarshal(result, &generic); err != nil {
+			return nil, fmt.Errorf("failed to parse result: %w", err)
+		}
+		return generic, nil
+	}
+
+	if err := json.Unmarshal(result, target); err != nil {
+		return nil, fmt.Errorf("failed to parse %s result: %w", method, err)
+	}
+	return target, nil
+}
+
+// Helper to convert []map[string]interface{} to []interface{}
+func convertToInterfaceSlice(maps []map[string]interface{}) []interface{} {
+	result := make([]interface{}, len(maps))
+	for i, m := range maps {
+		result[i] = m
+	}
+	return result
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// sseEvent is a single server-sent event
+type sseEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// sseReader reads server-sent events from a text/event-stream body
+type sseReader struct {
+	scanner *bufio.Scanner
+}
+
+// newSSEReader creates a reader for an event stream
+func newSSEReader(r io.Reader) *sseReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024) // 10MB max event
+	return &sseReader{scanner: scanner}
+}
+
+// Next returns the next complete event, or io.EOF when the stream ends.
+// Comments are skipped and an event cut off by the end of the stream is
+// discarded, as the SSE specification requires.
+func (r *sseReader) Next() (*sseEvent, error) {
+	var event sseEvent
+	var data []string
+	pending := false
+
+	for r.scanner.Scan() {
+		line := r.scanner.Text()
+
+		if line == "" {
+			if pending {
+				event.Data = strings.Join(data, "\n")
+				return &event, nil
+			}
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "id":
+			event.ID = value
+		case "event":
+			event.Event = value
+		case "data":
+			data = append(data, value)
+		default:
+			continue
+		}
+		pending = true
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// handleIncoming processes a JSON-RPC message received from the server.
+// Responses to client requests are returned; notifications are passed to
+// handler and server requests are answered through reply, returning nil.
+func handleIncoming(data []byte, handler NotificationHandler, reply func(*jsonrpcReply) error) (*jsonrpcResponse, error) {
+	var msg jsonrpcMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, fmt.Errorf("invalid JSON-RPC message: %w", err)
+	}
+
+	if msg.Method != "" {
+		if len(msg.ID) == 0 {
+			if handler != nil {
+				handler(&MCPNotification{Method: msg.Method, Params: msg.Params})
+			}
+			return nil, nil
+		}
+		return nil, reply(replyToServerRequest(&msg))
+	}
+
+	var resp jsonrpcResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("invalid JSON-RPC response: %w", err)
+	}
+	return &resp, nil
+}
+
+// replyToServerRequest answers a request initiated by the server. Only ping
+// is supported; other requests such as sampling are declined so the server
+// does not wait for a response.
+func replyToServerRequest(msg *jsonrpcMessage) *jsonrpcReply {
+	reply := &jsonrpcReply{JSONRPC: "2.0", ID: msg.ID}
+	if msg.Method == "ping" {
+		reply.Result = map[string]interface{}{}
+	} else {
+		reply.Error = &jsonrpcError{
+			Code:    -32601,
+			Message: fmt.Sprintf("Method not found: %s", msg.Method),
+		}
+	}
+	return reply
+}
+
+// resultOrError converts a JSON-RPC response to the parsed result for
+// method, or an error if the server reported one
+func resultOrError(method string, resp *jsonrpcResponse) (interface{}, error) {
+	if resp.Error != nil {
+		return nil, fmt.Errorf("JSON-RPC error %d: %s", resp.Error.Code, resp.Error.Message)
+	}
+	return parseResult(method, resp.Result)
+}
+
+// initializeParams are the params of the initialize request sent by
+// transports that perform the MCP handshake
+func initializeParams() map[string]interface{} {
+	return map[string]interface{}{
+		"protocolVersion": ProtocolVersion,
+		"capabilities":    map[string]interface{}{},
+		"clientInfo": map[string]interface{}{
+			"name":    "minion",
+			"version": "1.0.0",
+		},
+	}
+}
@@ -17,6 +17,9 @@ type httpTransport struct {
 	requestID   atomic.Int64
 	connected   bool
 	authHeaders map[string]string // Pre-computed auth headers
+
+	// Server notifications
+	notificationHandler NotificationHandler
 }
 
 // BearerAuthConfig contains bearer token authentication configuration
@@ -213,7 +216,55 @@ func (t *httpTransport) SendRequest(ctx context.Context, method string, params i
 	}
 
 	// Parse result based on method
-	return t.parseResult(method, jsonResp.Result)
+	return parseResult(method, jsonResp.Result)
+}
+
+// SendNotification posts a JSON-RPC notification. Servers acknowledge
+// notifications with 202 Accepted or an empty 200 or 204 response.
+func (t *httpTransport) SendNotification(ctx context.Context, method string, params interface{}) error {
+	if !t.connected {
+		return fmt.Errorf("not connected")
+	}
+
+	reqData, err := json.Marshal(&jsonrpcNotification{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", t.config.URL, bytes.NewReader(reqData))
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	for key, value := range t.authHeaders {
+		httpReq.Header.Set(key, value)
+	}
+
+	resp, err := t.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent:
+		return nil
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(body))
+	}
+}
+
+// SetNotificationHandler sets the handler for server notifications. Plain
+// request/response HTTP has no channel for server-initiated messages, so
+// the handler is only stored.
+func (t *httpTransport) SetNotificationHandler(handler NotificationHandler) {
+	t.notificationHandler = handler
 }
 
 // Close closes the connection
@@ -226,34 +277,3 @@ func (t *httpTransport) Close() error {
 func (t *httpTransport) IsConnected() bool {
 	return t.connected
 }
-
-// parseResult parses the result based on the method
-func (t *httpTransport) parseResult(method string, result json.RawMessage) (interface{}, error) {
-	switch method {
-	case "tools/list":
-		var toolsList struct {
-			Tools []map[string]interface{} `json:"tools"`
-		}
-		if err := json.Unmarshal(result, &toolsList); err != nil {
-			return nil, fmt.Errorf("failed to parse tools list: %w", err)
-		}
-		return map[string]interface{}{
-			"tools": convertToInterfaceSlice(toolsList.Tools),
-		}, nil
-
-	case "tools/call":
-		var toolResult MCPCallToolResult
-		if err := json.Unmarshal(result, &toolResult); err != nil {
-			return nil, fmt.Errorf("failed to parse tool result: %w", err)
-		}
-		return &toolResult, nil
-
-	default:
-		// Generic response
-		var generic interface{}
-		if err := json.Unmarshal(result, &generic); err != nil {
-			return nil, fmt.Errorf("failed to parse result: %w", err)
-		}
-		return generic, nil
-	}
-}
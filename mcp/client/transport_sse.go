@@ -0,0 +1,332 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// sseTransport implements the legacy MCP HTTP+SSE transport. The client
+// holds a GET event stream open; the server first sends an "endpoint" event
+// naming the URL to POST messages to, then delivers responses and
+// notifications as "message" events on the stream.
+type sseTransport struct {
+	config      *ClientConfig
+	client      *http.Client
+	authHeaders map[string]string
+	requestID   atomic.Int64
+	endpoint    string
+
+	// Request tracking
+	pending   map[int64]chan *jsonrpcResponse
+	pendingMu sync.Mutex
+
+	// Connection state
+	connected bool
+	mu        sync.RWMutex
+
+	// Event stream
+	notificationHandler NotificationHandler
+	streamCancel        context.CancelFunc
+	streamDone          chan struct{}
+}
+
+// newSSETransport creates a legacy HTTP+SSE transport
+func newSSETransport(config *ClientConfig) (Transport, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("URL is required for SSE transport")
+	}
+
+	authHeaders, err := buildAuthHeaders(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure authentication: %w", err)
+	}
+
+	return &sseTransport{
+		config: config,
+		// The event stream is long-lived; requests are bounded by
+		// RequestTimeout through their context instead
+		client:      &http.Client{},
+		authHeaders: authHeaders,
+		pending:     make(map[int64]chan *jsonrpcResponse),
+	}, nil
+}
+
+// Connect opens the event stream, waits for the endpoint event and performs
+// the initialize handshake
+func (t *sseTransport) Connect(ctx context.Context) error {
+	t.mu.Lock()
+	if t.connected {
+		t.mu.Unlock()
+		return fmt.Errorf("already connected")
+	}
+	t.mu.Unlock()
+
+	// The stream outlives ctx, which may only bound the connect attempt
+	streamCtx, cancel := context.WithCancel(context.Background())
+
+	req, err := http.NewRequestWithContext(streamCtx, http.MethodGet, t.config.URL, nil)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	for key, value := range t.authHeaders {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to open event stream: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || !isEventStream(resp) {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		cancel()
+		return fmt.Errorf("failed to open event stream: HTTP %d: %s", resp.StatusCode, string(body))
+	}
+
+	endpoint := make(chan string, 1)
+	t.streamCancel = cancel
+	t.streamDone = make(chan struct{})
+	go t.readLoop(resp.Body, endpoint)
+
+	select {
+	case <-ctx.Done():
+		t.stopStream()
+		return ctx.Err()
+	case <-t.streamDone:
+		return fmt.Errorf("event stream closed before endpoint event")
+	case ep := <-endpoint:
+		resolved, err := resolveEndpoint(t.config.URL, ep)
+		if err != nil {
+			t.stopStream()
+			return err
+		}
+		t.endpoint = resolved
+	}
+
+	t.mu.Lock()
+	t.connected = true
+	t.mu.Unlock()
+
+	if _, err := t.SendRequest(ctx, "initialize", initializeParams()); err != nil {
+		t.Close()
+		return fmt.Errorf("initialize failed: %w", err)
+	}
+	if err := t.SendNotification(ctx, "notifications/initialized", nil); err != nil {
+		t.Close()
+		return fmt.Errorf("initialized notification failed: %w", err)
+	}
+
+	return nil
+}
+
+// SendRequest POSTs a request to the endpoint and waits for its response
+// on the event stream
+func (t *sseTransport) SendRequest(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	if !t.IsConnected() {
+		return nil, fmt.Errorf("not connected")
+	}
+
+	if t.config.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.config.RequestTimeout)
+		defer cancel()
+	}
+
+	id := t.requestID.Add(1)
+
+	respChan := make(chan *jsonrpcResponse, 1)
+	t.pendingMu.Lock()
+	t.pending[id] = respChan
+	t.pendingMu.Unlock()
+
+	defer func() {
+		t.pendingMu.Lock()
+		delete(t.pending, id)
+		t.pendingMu.Unlock()
+	}()
+
+	err := t.post(ctx, &jsonrpcRequest{
+		JSONRPC: "2.0",
+		ID:      id,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		// Tell the server to stop working on the abandoned request
+		_ = t.SendNotification(context.Background(), NotificationCancelled, map[string]interface{}{
+			"requestId": id,
+			"reason":    ctx.Err().Error(),
+		})
+		return nil, ctx.Err()
+	case <-t.streamDone:
+		return nil, fmt.Errorf("event stream closed")
+	case resp := <-respChan:
+		return resultOrError(method, resp)
+	}
+}
+
+// SendNotification POSTs a notification to the endpoint
+func (t *sseTransport) SendNotification(ctx context.Context, method string, params interface{}) error {
+	if !t.IsConnected() {
+		return fmt.Errorf("not connected")
+	}
+
+	return t.post(ctx, &jsonrpcNotification{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	})
+}
+
+// SetNotificationHandler sets the handler for server notifications.
+// It must be called before Connect.
+func (t *sseTransport) SetNotificationHandler(handler NotificationHandler) {
+	t.notificationHandler = handler
+}
+
+// Close closes the event stream
+func (t *sseTransport) Close() error {
+	t.mu.Lock()
+	if !t.connected {
+		t.mu.Unlock()
+		return nil
+	}
+	t.connected = false
+	t.mu.Unlock()
+
+	t.stopStream()
+	return nil
+}
+
+// IsConnected returns connection status
+func (t *sseTransport) IsConnected() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.connected
+}
+
+// stopStream cancels the event stream and waits for the reader to exit
+func (t *sseTransport) stopStream() {
+	if t.streamCancel != nil {
+		t.streamCancel()
+		<-t.streamDone
+	}
+}
+
+// post sends a JSON-RPC message to the endpoint
+func (t *sseTransport) post(ctx context.Context, msg interface{}) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range t.authHeaders {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// readLoop reads the event stream, publishing the endpoint event and
+// delivering responses to waiting requests
+func (t *sseTransport) readLoop(body io.ReadCloser, endpoint chan<- string) {
+	defer close(t.streamDone)
+	defer body.Close()
+
+	reader := newSSEReader(body)
+	for {
+		event, err := reader.Next()
+		if err != nil {
+			t.mu.Lock()
+			t.connected = false
+			t.mu.Unlock()
+			return
+		}
+
+		switch event.Event {
+		case "endpoint":
+			select {
+			case endpoint <- event.Data:
+			default:
+			}
+
+		case "", "message":
+			response, err := handleIncoming([]byte(event.Data), t.notificationHandler, t.reply)
+			if err != nil || response == nil {
+				continue
+			}
+
+			t.pendingMu.Lock()
+			respChan, exists := t.pending[response.ID]
+			t.pendingMu.Unlock()
+
+			if exists {
+				select {
+				case respChan <- response:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// reply answers a server request on the endpoint. It runs asynchronously
+// because it is called from the read loop.
+func (t *sseTransport) reply(reply *jsonrpcReply) error {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = t.post(ctx, reply)
+	}()
+	return nil
+}
+
+// resolveEndpoint resolves the endpoint event data against the stream URL
+func resolveEndpoint(streamURL, endpoint string) (string, error) {
+	base, err := url.Parse(streamURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+	ref, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+
+	resolved := base.ResolveReference(ref)
+	if resolved.Host != base.Host {
+		return "", fmt.Errorf("endpoint %q is not on the server's origin", endpoint)
+	}
+	return resolved.String(), nil
+}
@@ -0,0 +1,422 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// streamableServer is a minimal Streamable HTTP MCP server. tools/call
+// answers "report" over an SSE stream with a progress event first, "flaky"
+// over a stream that drops before the response and must be resumed, and
+// anything else with plain JSON.
+type streamableServer struct {
+	mu        sync.Mutex
+	sessionID string
+	deleted   bool
+	replay    map[string]string // Last-Event-ID → event data
+	push      chan string       // notifications for the GET stream
+}
+
+func newStreamableServer() *streamableServer {
+	return &streamableServer{
+		sessionID: "session-1",
+		replay:    make(map[string]string),
+		push:      make(chan string, 1),
+	}
+}
+
+func writeEvent(w http.ResponseWriter, id, data string) {
+	if id != "" {
+		fmt.Fprintf(w, "id: %s\n", id)
+	}
+	fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+	w.(http.Flusher).Flush()
+}
+
+func rpcResult(id interface{}, result interface{}) string {
+	data, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": id, "result": result})
+	return string(data)
+}
+
+func textContent(text string) map[string]interface{} {
+	return map[string]interface{}{"content": []interface{}{map[string]interface{}{"type": "text", "text": text}}}
+}
+
+func (s *streamableServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer secret" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	sessionID := s.sessionID
+	s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodDelete:
+		s.mu.Lock()
+		s.deleted = r.Header.Get(headerSessionID) == sessionID
+		s.mu.Unlock()
+		return
+
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "text/event-stream")
+		if lastEventID := r.Header.Get(headerLastEventID); lastEventID != "" {
+			s.mu.Lock()
+			data := s.replay[lastEventID]
+			s.mu.Unlock()
+			writeEvent(w, "", data)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		select {
+		case data := <-s.push:
+			writeEvent(w, "n1", data)
+			<-r.Context().Done()
+		case <-r.Context().Done():
+		}
+		return
+	}
+
+	var msg struct {
+		ID     interface{}            `json:"id"`
+		Method string                 `json:"method"`
+		Params map[string]interface{} `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if msg.Method == "initialize" {
+		w.Header().Set(headerSessionID, sessionID)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, rpcResult(msg.ID, map[string]interface{}{"protocolVersion": ProtocolVersion}))
+		return
+	}
+	if r.Header.Get(headerSessionID) != sessionID {
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return
+	}
+	if msg.ID == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	switch msg.Method {
+	case "tools/list":
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, rpcResult(msg.ID, map[string]interface{}{"tools": []interface{}{
+			map[string]interface{}{"name": "report"},
+			map[string]interface{}{"name": "flaky"},
+			map[string]interface{}{"name": "echo"},
+		}}))
+
+	case "tools/call":
+		switch msg.Params["name"] {
+		case "report":
+			meta, _ := msg.Params["_meta"].(map[string]interface{})
+			progress, _ := json.Marshal(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"method":  NotificationProgress,
+				"params":  map[string]interface{}{"progressToken": meta["progressToken"], "progress": 1, "total": 1},
+			})
+			w.Header().Set("Content-Type", "text/event-stream")
+			writeEvent(w, "1", string(progress))
+			writeEvent(w, "2", rpcResult(msg.ID, textContent("report ready")))
+
+		case "flaky":
+			s.mu.Lock()
+			s.replay["10"] = rpcResult(msg.ID, textContent("resumed"))
+			s.mu.Unlock()
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "id: 10\n: response follows on resume\n\n")
+
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, rpcResult(msg.ID, textContent("echo")))
+		}
+
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, rpcResult(msg.ID, map[string]interface{}{}))
+	}
+}
+
+func streamableConfig(url string) *ClientConfig {
+	config := DefaultClientConfig("stream")
+	config.Transport = TransportStreamableHTTP
+	config.URL = url
+	config.AuthType = AuthBearer
+	config.AuthConfig = &BearerAuthConfig{Token: "secret"}
+	return config
+}
+
+func contentText(t *testing.T, result *MCPCallToolResult) string {
+	t.Helper()
+	if result == nil || len(result.Content) == 0 {
+		t.Fatalf("empty result %+v", result)
+	}
+	return result.Content[0].(map[string]interface{})["text"].(string)
+}
+
+func TestStreamableHTTPTransport(t *testing.T) {
+	mcpServer := newStreamableServer()
+	server := httptest.NewServer(mcpServer)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	manager := NewMCPClientManager(nil)
+	if err := manager.ConnectServer(ctx, streamableConfig(server.URL)); err != nil {
+		t.Fatalf("ConnectServer failed: %v", err)
+	}
+	c, _ := manager.GetClient("stream")
+
+	if got := len(c.GetTools()); got != 3 {
+		t.Fatalf("expected 3 tools, got %d", got)
+	}
+
+	// JSON response
+	result, err := c.CallTool(ctx, "echo", nil)
+	if err != nil {
+		t.Fatalf("CallTool(echo) failed: %v", err)
+	}
+	if text := contentText(t, result); text != "echo" {
+		t.Errorf("echo text = %q", text)
+	}
+
+	// SSE response with progress
+	var progress []MCPProgress
+	result, err = c.CallToolWithProgress(ctx, "report", nil, func(p MCPProgress) {
+		progress = append(progress, p)
+	})
+	if err != nil {
+		t.Fatalf("CallTool(report) failed: %v", err)
+	}
+	if text := contentText(t, result); text != "report ready" || len(progress) != 1 {
+		t.Errorf("report text = %q, progress = %+v", text, progress)
+	}
+
+	// Dropped stream resumed with Last-Event-ID
+	result, err = c.CallTool(ctx, "flaky", nil)
+	if err != nil {
+		t.Fatalf("CallTool(flaky) failed: %v", err)
+	}
+	if text := contentText(t, result); text != "resumed" {
+		t.Errorf("flaky text = %q", text)
+	}
+
+	// Notification on the standalone GET stream
+	logs := make(chan MCPLogMessage, 1)
+	c.OnLogMessage(func(m MCPLogMessage) { logs <- m })
+	mcpServer.push <- `{"jsonrpc":"2.0","method":"notifications/message","params":{"level":"info","data":"hello"}}`
+	select {
+	case m := <-logs:
+		if m.Data != "hello" {
+			t.Errorf("log data = %v", m.Data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification from GET stream not delivered")
+	}
+
+	// Closing ends the session
+	if err := manager.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	mcpServer.mu.Lock()
+	deleted := mcpServer.deleted
+	mcpServer.mu.Unlock()
+	if !deleted {
+		t.Error("expected session to be deleted on close")
+	}
+}
+
+func TestStreamableHTTPTransport_Errors(t *testing.T) {
+	mcpServer := newStreamableServer()
+	server := httptest.NewServer(mcpServer)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Wrong credentials fail the initialize handshake
+	config := streamableConfig(server.URL)
+	config.AuthConfig = &BearerAuthConfig{Token: "wrong"}
+	transport, err := newStreamableHTTPTransport(config)
+	if err != nil {
+		t.Fatalf("newStreamableHTTPTransport failed: %v", err)
+	}
+	if err := transport.Connect(ctx); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected 401 on connect, got %v", err)
+	}
+
+	// An expired session is reported as such
+	transport, _ = newStreamableHTTPTransport(streamableConfig(server.URL))
+	if err := transport.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer transport.Close()
+
+	mcpServer.mu.Lock()
+	mcpServer.sessionID = "session-2"
+	mcpServer.mu.Unlock()
+
+	if _, err := transport.SendRequest(ctx, "tools/list", nil); err == nil || !strings.Contains(err.Error(), "session expired") {
+		t.Errorf("expected session expired error, got %v", err)
+	}
+}
+
+func TestStreamableHTTPTransport_PoolAndCircuitBreaker(t *testing.T) {
+	server := httptest.NewServer(newStreamableServer())
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pool := NewConnectionPool(nil)
+	defer pool.Close()
+
+	pooled, err := pool.Acquire(ctx, "stream", streamableConfig(server.URL))
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	defer pool.Release(pooled, "stream")
+
+	breaker := NewCircuitBreaker(nil)
+	err = breaker.Execute(ctx, func(ctx context.Context) error {
+		_, err := pooled.GetClient().CallTool(ctx, "echo", nil)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("CallTool through circuit breaker failed: %v", err)
+	}
+	if !breaker.IsClosed() {
+		t.Errorf("expected closed circuit, got %s", breaker.GetState())
+	}
+}
+
+// legacySSEServer is a minimal HTTP+SSE MCP server: GET /sse streams
+// events, POST /messages accepts requests and answers on the stream
+type legacySSEServer struct {
+	events chan string
+}
+
+func (s *legacySSEServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/sse":
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: endpoint\ndata: /messages?sessionId=abc\n\n")
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case data := <-s.events:
+				writeEvent(w, "", data)
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+	case r.Method == http.MethodPost && r.URL.Path == "/messages":
+		if r.URL.Query().Get("sessionId") != "abc" {
+			http.Error(w, "unknown session", http.StatusNotFound)
+			return
+		}
+		var msg struct {
+			ID     interface{} `json:"id"`
+			Method string      `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+
+		switch msg.Method {
+		case "tools/list":
+			s.events <- rpcResult(msg.ID, map[string]interface{}{"tools": []interface{}{map[string]interface{}{"name": "echo"}}})
+		case "tools/call":
+			s.events <- rpcResult(msg.ID, textContent("from sse"))
+		default:
+			if msg.ID != nil {
+				s.events <- rpcResult(msg.ID, map[string]interface{}{})
+			}
+		}
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestSSETransport(t *testing.T) {
+	server := httptest.NewServer(&legacySSEServer{events: make(chan string, 8)})
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	manager := NewMCPClientManager(nil)
+	defer manager.Close()
+
+	config := DefaultClientConfig("legacy")
+	config.Transport = TransportSSE
+	config.URL = server.URL + "/sse"
+	if err := manager.ConnectServer(ctx, config); err != nil {
+		t.Fatalf("ConnectServer failed: %v", err)
+	}
+	c, _ := manager.GetClient("legacy")
+
+	result, err := c.CallTool(ctx, "echo", nil)
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if text := contentText(t, result); text != "from sse" {
+		t.Errorf("text = %q", text)
+	}
+}
+
+func TestSSEReader(t *testing.T) {
+	stream := ": comment\nid: 1\nevent: message\ndata: {\"a\":\ndata: 1}\n\nretry: 100\n\ndata: cut off"
+	reader := newSSEReader(strings.NewReader(stream))
+
+	event, err := reader.Next()
+	if err != nil {
+		t.Fatalf("Next failed: %v", err)
+	}
+	if event.ID != "1" || event.Event != "message" || event.Data != "{\"a\":\n1}" {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	if event, err := reader.Next(); err == nil {
+		t.Errorf("expected incomplete trailing event to be discarded, got %+v", event)
+	}
+}
+
+func TestResolveEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+		wantErr  bool
+	}{
+		{endpoint: "/messages?sessionId=1", want: "http://host:8080/messages?sessionId=1"},
+		{endpoint: "messages", want: "http://host:8080/mcp/messages"},
+		{endpoint: "http://host:8080/other", want: "http://host:8080/other"},
+		{endpoint: "http://evil.example/messages", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := resolveEndpoint("http://host:8080/mcp/sse", tt.endpoint)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("resolveEndpoint(%q) = %q, %v; want %q", tt.endpoint, got, err, tt.want)
+		}
+	}
+}
@@ -28,6 +28,10 @@ type stdioTransport struct {
 	// Connection state
 	connected bool
 	mu        sync.RWMutex
+	writeMu   sync.Mutex
+
+	// Server notifications
+	notificationHandler NotificationHandler
 
 	// Reader goroutines
 	readerDone chan struct{}
@@ -50,6 +54,30 @@ type jsonrpcResponse struct {
 	Error   *jsonrpcError   `json:"error,omitempty"`
 }
 
+// jsonrpcNotification represents a JSON-RPC 2.0 notification
+type jsonrpcNotification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+// jsonrpcMessage is the envelope used to tell server requests and
+// notifications apart from responses
+type jsonrpcMessage struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// jsonrpcReply is a response to a request initiated by the server, whose
+// ID may be a number or a string
+type jsonrpcReply struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *jsonrpcError   `json:"error,omitempty"`
+}
+
 // jsonrpcError represents a JSON-RPC error
 type jsonrpcError struct {
 	Code    int    `json:"code"`
@@ -171,6 +199,11 @@ func (t *stdioTransport) SendRequest(ctx context.Context, method string, params
 	// Wait for response
 	select {
 	case <-ctx.Done():
+		// Tell the server to stop working on the abandoned request
+		_ = t.SendNotification(context.Background(), NotificationCancelled, map[string]interface{}{
+			"requestId": id,
+			"reason":    ctx.Err().Error(),
+		})
 		return nil, ctx.Err()
 	case resp := <-respChan:
 		if resp.Error != nil {
@@ -178,7 +211,7 @@ func (t *stdioTransport) SendRequest(ctx context.Context, method string, params
 		}
 
 		// Parse result based on method
-		return t.parseResult(method, resp.Result)
+		return parseResult(method, resp.Result)
 	}
 }
 
@@ -218,7 +251,27 @@ func (t *stdioTransport) IsConnected() bool {
 	return t.connected
 }
 
-// sendMessage sends a message over stdio
+// SendNotification sends a JSON-RPC notification
+func (t *stdioTransport) SendNotification(ctx context.Context, method string, params interface{}) error {
+	if !t.IsConnected() {
+		return fmt.Errorf("not connected")
+	}
+
+	return t.sendMessage(&jsonrpcNotification{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	})
+}
+
+// SetNotificationHandler sets the handler for server notifications.
+// It must be called before Connect.
+func (t *stdioTransport) SetNotificationHandler(handler NotificationHandler) {
+	t.notificationHandler = handler
+}
+
+// sendMessage sends a message over stdio. Writes are serialized so
+// concurrent requests cannot interleave on the pipe.
 func (t *stdioTransport) sendMessage(msg interface{}) error {
 	data, err := json.Marshal(msg)
 	if err != nil {
@@ -228,6 +281,9 @@ func (t *stdioTransport) sendMessage(msg interface{}) error {
 	// Add newline delimiter
 	data = append(data, '\n')
 
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+
 	if _, err := t.stdin.Write(data); err != nil {
 		return fmt.Errorf("failed to write message: %w", err)
 	}
@@ -250,6 +306,16 @@ func (t *stdioTransport) readLoop() {
 			continue
 		}
 
+		// Requests and notifications from the server carry a method
+		var msg jsonrpcMessage
+		if err := json.Unmarshal(line, &msg); err != nil {
+			continue
+		}
+		if msg.Method != "" {
+			t.handleServerMessage(&msg)
+			continue
+		}
+
 		// Parse JSON-RPC response
 		var resp jsonrpcResponse
 		if err := json.Unmarshal(line, &resp); err != nil {
@@ -276,6 +342,19 @@ func (t *stdioTransport) readLoop() {
 	}
 }
 
+// handleServerMessage dispatches a notification to the handler, or answers a
+// server request
+func (t *stdioTransport) handleServerMessage(msg *jsonrpcMessage) {
+	if len(msg.ID) == 0 {
+		if t.notificationHandler != nil {
+			t.notificationHandler(&MCPNotification{Method: msg.Method, Params: msg.Params})
+		}
+		return
+	}
+
+	_ = t.sendMessage(replyToServerRequest(msg))
+}
+
 // readStderr reads and logs stderr output
 func (t *stdioTransport) readStderr() {
 	defer close(t.stderrDone)
@@ -287,43 +366,3 @@ func (t *stdioTransport) readStderr() {
 		_ = scanner.Text()
 	}
 }
-
-// parseResult parses the result based on the method
-func (t *stdioTransport) parseResult(method string, result json.RawMessage) (interface{}, error) {
-	switch method {
-	case "tools/list":
-		var toolsList struct {
-			Tools []map[string]interface{} `json:"tools"`
-		}
-		if err := json.Unmarshal(result, &toolsList); err != nil {
-			return nil, fmt.Errorf("failed to parse tools list: %w", err)
-		}
-		return map[string]interface{}{
-			"tools": convertToInterfaceSlice(toolsList.Tools),
-		}, nil
-
-	case "tools/call":
-		var toolResult MCPCallToolResult
-		if err := json.Unmarshal(result, &toolResult); err != nil {
-			return nil, fmt.Errorf("failed to parse tool result: %w", err)
-		}
-		return &toolResult, nil
-
-	default:
-		// Generic response
-		var generic interface{}
-		if err := json.Unmarshal(result, &generic); err != nil {
-			return nil, fmt.Errorf("failed to parse result: %w", err)
-		}
-		return generic, nil
-	}
-}
-
-// Helper to convert []map[string]interface{} to []interface{}
-func convertToInterfaceSlice(maps []map[string]interface{}) []interface{} {
-	result := make([]interface{}, len(maps))
-	for i, m := range maps {
-		result[i] = m
-	}
-	return result
-}
@@ -0,0 +1,456 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Streamable HTTP headers
+const (
+	headerSessionID       = "Mcp-Session-Id"
+	headerProtocolVersion = "MCP-Protocol-Version"
+	headerLastEventID     = "Last-Event-ID"
+)
+
+// maxStreamResumes bounds how often a broken response stream is resumed
+const maxStreamResumes = 3
+
+// streamableHTTPTransport implements the MCP Streamable HTTP transport.
+// Each request is POSTed to the endpoint and answered either with a JSON
+// body or with an SSE stream that may carry notifications before the
+// response. Streams cut off before the response are resumed with a GET
+// carrying Last-Event-ID. If the server offers one, a standalone GET stream
+// delivers server notifications between requests.
+type streamableHTTPTransport struct {
+	config      *ClientConfig
+	client      *http.Client
+	authHeaders map[string]string
+	requestID   atomic.Int64
+
+	// Session state, set during initialize
+	sessionID       string
+	protocolVersion string
+	connected       bool
+	mu              sync.RWMutex
+
+	// Server notifications
+	notificationHandler NotificationHandler
+	listenCancel        context.CancelFunc
+	listenDone          chan struct{}
+}
+
+// newStreamableHTTPTransport creates a Streamable HTTP transport
+func newStreamableHTTPTransport(config *ClientConfig) (Transport, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("URL is required for streamable HTTP transport")
+	}
+
+	authHeaders, err := buildAuthHeaders(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure authentication: %w", err)
+	}
+
+	return &streamableHTTPTransport{
+		config: config,
+		// Streams outlive a fixed client timeout; requests are bounded by
+		// RequestTimeout through their context instead
+		client:      &http.Client{},
+		authHeaders: authHeaders,
+	}, nil
+}
+
+// Connect performs the initialize handshake and opens the notification
+// stream if the server supports one
+func (t *streamableHTTPTransport) Connect(ctx context.Context) error {
+	t.mu.Lock()
+	if t.connected {
+		t.mu.Unlock()
+		return fmt.Errorf("already connected")
+	}
+	t.connected = true
+	t.mu.Unlock()
+
+	result, err := t.SendRequest(ctx, "initialize", initializeParams())
+	if err != nil {
+		t.setDisconnected()
+		return fmt.Errorf("initialize failed: %w", err)
+	}
+
+	if info, ok := result.(map[string]interface{}); ok {
+		if version, ok := info["protocolVersion"].(string); ok {
+			t.mu.Lock()
+			t.protocolVersion = version
+			t.mu.Unlock()
+		}
+	}
+
+	if err := t.SendNotification(ctx, "notifications/initialized", nil); err != nil {
+		t.setDisconnected()
+		return fmt.Errorf("initialized notification failed: %w", err)
+	}
+
+	listenCtx, cancel := context.WithCancel(context.Background())
+	t.listenCancel = cancel
+	t.listenDone = make(chan struct{})
+	go t.listen(listenCtx)
+
+	return nil
+}
+
+// SendRequest POSTs a request and waits for its response
+func (t *streamableHTTPTransport) SendRequest(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	if !t.IsConnected() {
+		return nil, fmt.Errorf("not connected")
+	}
+
+	if t.config.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.config.RequestTimeout)
+		defer cancel()
+	}
+
+	id := t.requestID.Add(1)
+	resp, err := t.post(ctx, &jsonrpcRequest{
+		JSONRPC: "2.0",
+		ID:      id,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			t.cancelRequest(id, ctx.Err())
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if sessionID := resp.Header.Get(headerSessionID); sessionID != "" {
+		t.mu.Lock()
+		t.sessionID = sessionID
+		t.mu.Unlock()
+	}
+
+	var response *jsonrpcResponse
+	if isEventStream(resp) {
+		response, err = t.readResponseStream(ctx, resp.Body, id)
+	} else {
+		response, err = readJSONResponse(resp.Body)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			t.cancelRequest(id, ctx.Err())
+		}
+		return nil, err
+	}
+
+	return resultOrError(method, response)
+}
+
+// SendNotification POSTs a notification, which the server acknowledges
+// with 202 Accepted
+func (t *streamableHTTPTransport) SendNotification(ctx context.Context, method string, params interface{}) error {
+	if !t.IsConnected() {
+		return fmt.Errorf("not connected")
+	}
+
+	resp, err := t.post(ctx, &jsonrpcNotification{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// SetNotificationHandler sets the handler for server notifications.
+// It must be called before Connect.
+func (t *streamableHTTPTransport) SetNotificationHandler(handler NotificationHandler) {
+	t.notificationHandler = handler
+}
+
+// Close stops the notification stream and ends the session on the server
+func (t *streamableHTTPTransport) Close() error {
+	t.mu.Lock()
+	if !t.connected {
+		t.mu.Unlock()
+		return nil
+	}
+	t.connected = false
+	sessionID := t.sessionID
+	t.mu.Unlock()
+
+	if t.listenCancel != nil {
+		t.listenCancel()
+		<-t.listenDone
+	}
+
+	// Best effort - the server also expires idle sessions
+	if sessionID != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		req, err := t.newRequest(ctx, http.MethodDelete, nil)
+		if err == nil {
+			if resp, err := t.client.Do(req); err == nil {
+				resp.Body.Close()
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsConnected returns connection status
+func (t *streamableHTTPTransport) IsConnected() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.connected
+}
+
+func (t *streamableHTTPTransport) setDisconnected() {
+	t.mu.Lock()
+	t.connected = false
+	t.mu.Unlock()
+}
+
+// newRequest creates a request to the endpoint with session, protocol and
+// authentication headers
+func (t *streamableHTTPTransport) newRequest(ctx context.Context, method string, body []byte) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, t.config.URL, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json, text/event-stream")
+	} else {
+		req.Header.Set("Accept", "text/event-stream")
+	}
+
+	t.mu.RLock()
+	if t.sessionID != "" {
+		req.Header.Set(headerSessionID, t.sessionID)
+	}
+	if t.protocolVersion != "" {
+		req.Header.Set(headerProtocolVersion, t.protocolVersion)
+	}
+	t.mu.RUnlock()
+
+	for key, value := range t.authHeaders {
+		req.Header.Set(key, value)
+	}
+
+	return req, nil
+}
+
+// post sends a JSON-RPC message and checks the response status
+func (t *streamableHTTPTransport) post(ctx context.Context, msg interface{}) (*http.Response, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	req, err := t.newRequest(ctx, http.MethodPost, data)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusNotFound && req.Header.Get(headerSessionID) != "" {
+			return nil, fmt.Errorf("MCP session expired: %s", string(body))
+		}
+		return nil, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(body))
+	}
+
+	return resp, nil
+}
+
+// readResponseStream reads an SSE response stream until the response with
+// the given ID arrives, dispatching notifications on the way. A stream that
+// ends early is resumed from the last event ID.
+func (t *streamableHTTPTransport) readResponseStream(ctx context.Context, body io.Reader, id int64) (*jsonrpcResponse, error) {
+	lastEventID := ""
+
+	for resumes := 0; ; resumes++ {
+		response, eventID, err := t.readStream(body, id)
+		if eventID != "" {
+			lastEventID = eventID
+		}
+		if response != nil {
+			return response, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if lastEventID == "" || resumes >= maxStreamResumes {
+			if err == nil {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, fmt.Errorf("response stream ended before response: %w", err)
+		}
+
+		resumed, err := t.resume(ctx, lastEventID)
+		if err != nil {
+			return nil, err
+		}
+		defer resumed.Body.Close()
+		body = resumed.Body
+	}
+}
+
+// resume reopens a stream after lastEventID
+func (t *streamableHTTPTransport) resume(ctx context.Context, lastEventID string) (*http.Response, error) {
+	req, err := t.newRequest(ctx, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(headerLastEventID, lastEventID)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resume stream: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || !isEventStream(resp) {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to resume stream: HTTP %d", resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+// readStream reads events until the response with the given ID (0 for
+// none) or the end of the stream. It returns the last event ID seen.
+func (t *streamableHTTPTransport) readStream(body io.Reader, id int64) (*jsonrpcResponse, string, error) {
+	reader := newSSEReader(body)
+	lastEventID := ""
+
+	for {
+		event, err := reader.Next()
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return nil, lastEventID, err
+		}
+		if event.ID != "" {
+			lastEventID = event.ID
+		}
+		if event.Data == "" || (event.Event != "" && event.Event != "message") {
+			continue
+		}
+
+		response, err := handleIncoming([]byte(event.Data), t.notificationHandler, t.reply)
+		if err != nil {
+			continue
+		}
+		if response != nil && id != 0 && response.ID == id {
+			return response, lastEventID, nil
+		}
+	}
+}
+
+// listen holds the standalone GET stream open for server notifications,
+// reconnecting with Last-Event-ID if it drops. Servers that do not offer a
+// stream answer 405, which ends listening.
+func (t *streamableHTTPTransport) listen(ctx context.Context) {
+	defer close(t.listenDone)
+
+	lastEventID := ""
+	for ctx.Err() == nil {
+		req, err := t.newRequest(ctx, http.MethodGet, nil)
+		if err != nil {
+			return
+		}
+		if lastEventID != "" {
+			req.Header.Set(headerLastEventID, lastEventID)
+		}
+
+		resp, err := t.client.Do(req)
+		if err == nil {
+			if resp.StatusCode != http.StatusOK || !isEventStream(resp) {
+				resp.Body.Close()
+				return
+			}
+			_, eventID, _ := t.readStream(resp.Body, 0)
+			resp.Body.Close()
+			if eventID != "" {
+				lastEventID = eventID
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// reply answers a server request with a POST
+func (t *streamableHTTPTransport) reply(reply *jsonrpcReply) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := t.post(ctx, reply)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// cancelRequest tells the server to stop working on an abandoned request
+func (t *streamableHTTPTransport) cancelRequest(id int64, reason error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_ = t.SendNotification(ctx, NotificationCancelled, map[string]interface{}{
+		"requestId": id,
+		"reason":    reason.Error(),
+	})
+}
+
+// isEventStream reports whether a response is an SSE stream
+func isEventStream(resp *http.Response) bool {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return mediaType == "text/event-stream"
+}
+
+// readJSONResponse decodes a single JSON-RPC response body
+func readJSONResponse(body io.Reader) (*jsonrpcResponse, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var response jsonrpcResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &response, nil
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"sync"
 	"time"
 )
@@ -12,8 +13,22 @@ type TransportType string
 const (
 	TransportStdio TransportType = "stdio"
 	TransportHTTP  TransportType = "http"
+
+	// TransportStreamableHTTP is the MCP Streamable HTTP transport: POST
+	// requests answered with JSON or SSE streams, session IDs and resumable
+	// event streams
+	TransportStreamableHTTP TransportType = "streamable-http"
+
+	// TransportSSE is the legacy HTTP+SSE transport: a long-lived SSE stream
+	// carrying responses and a separate endpoint for POSTed requests
+	TransportSSE TransportType = "sse"
 )
 
+// ProtocolVersion is the MCP protocol revision sent by transports that
+// perform the initialize handshake
+const ProtocolVersion = "2025-03-26"
+
+
 // AuthType defines the authentication method
 type AuthType string
 
@@ -63,6 +78,7 @@ type MCPTool struct {
 type MCPCallToolRequest struct {
 	Name      string                 `json:"name"`
 	Arguments map[string]interface{} `json:"arguments"`
+	Meta      map[string]interface{} `json:"_meta,omitempty"`
 }
 
 // MCPCallToolResult represents the result of a tool call
@@ -71,6 +87,130 @@ type MCPCallToolResult struct {
 	IsError bool          `json:"isError"`
 }
 
+// MCPResource describes a resource exposed by an MCP server
+type MCPResource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// MCPResourceTemplate describes a family of resources addressed by an
+// RFC 6570 URI template, e.g. "file:///{path}"
+type MCPResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// MCPResourceContents is the content of a resource. Exactly one of Text
+// or Blob (base64-encoded binary data) is set.
+type MCPResourceContents struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Blob     string `json:"blob,omitempty"`
+}
+
+// MCPListResourcesResult is the result of resources/list
+type MCPListResourcesResult struct {
+	Resources  []MCPResource `json:"resources"`
+	NextCursor string        `json:"nextCursor,omitempty"`
+}
+
+// MCPListResourceTemplatesResult is the result of resources/templates/list
+type MCPListResourceTemplatesResult struct {
+	ResourceTemplates []MCPResourceTemplate `json:"resourceTemplates"`
+	NextCursor        string                `json:"nextCursor,omitempty"`
+}
+
+// MCPReadResourceResult is the result of resources/read
+type MCPReadResourceResult struct {
+	Contents []MCPResourceContents `json:"contents"`
+}
+
+// MCPPrompt describes a prompt template exposed by an MCP server
+type MCPPrompt struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Arguments   []MCPPromptArgument `json:"arguments,omitempty"`
+}
+
+// MCPPromptArgument describes an argument accepted by a prompt
+type MCPPromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// MCPPromptMessage is a single message of a rendered prompt. Content is a
+// content item such as {"type": "text", "text": "..."}.
+type MCPPromptMessage struct {
+	Role    string                 `json:"role"`
+	Content map[string]interface{} `json:"content"`
+}
+
+// Text returns the message text, or an empty string for non-text content
+func (m MCPPromptMessage) Text() string {
+	if m.Content["type"] != "text" {
+		return ""
+	}
+	text, _ := m.Content["text"].(string)
+	return text
+}
+
+// MCPListPromptsResult is the result of prompts/list
+type MCPListPromptsResult struct {
+	Prompts    []MCPPrompt `json:"prompts"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+}
+
+// MCPGetPromptResult is the result of prompts/get
+type MCPGetPromptResult struct {
+	Description string             `json:"description,omitempty"`
+	Messages    []MCPPromptMessage `json:"messages"`
+}
+
+// MCP notification methods
+const (
+	NotificationToolsListChanged     = "notifications/tools/list_changed"
+	NotificationResourcesListChanged = "notifications/resources/list_changed"
+	NotificationResourceUpdated      = "notifications/resources/updated"
+	NotificationPromptsListChanged   = "notifications/prompts/list_changed"
+	NotificationProgress             = "notifications/progress"
+	NotificationMessage              = "notifications/message"
+	NotificationCancelled            = "notifications/cancelled"
+)
+
+// MCPNotification is a notification sent by an MCP server
+type MCPNotification struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// NotificationHandler handles server notifications. Handlers run on the
+// transport's read goroutine and must not block.
+type NotificationHandler func(notification *MCPNotification)
+
+// MCPProgress reports progress of a long-running request
+type MCPProgress struct {
+	ProgressToken interface{} `json:"progressToken"`
+	Progress      float64     `json:"progress"`
+	Total         float64     `json:"total,omitempty"`
+	Message       string      `json:"message,omitempty"`
+}
+
+// ProgressHandler receives progress updates for a request
+type ProgressHandler func(progress MCPProgress)
+
+// MCPLogMessage is a log message sent by an MCP server
+type MCPLogMessage struct {
+	Level  string      `json:"level"`
+	Logger string      `json:"logger,omitempty"`
+	Data   interface{} `json:"data"`
+}
+
 // ManagerConfig configures the MCPClientManager
 type ManagerConfig struct {
 	MaxServers          int
@@ -161,6 +301,9 @@ type MCPClient struct {
 
 	// Metrics
 	metrics *clientMetrics
+
+	// Notification handling
+	notifications *notificationRouter
 }
 
 // clientMetrics tracks client performance
@@ -187,6 +330,13 @@ type Transport interface {
 
 	// IsConnected returns connection status
 	IsConnected() bool
+
+	// SendNotification sends a JSON-RPC notification, which has no response
+	SendNotification(ctx context.Context, method string, params interface{}) error
+
+	// SetNotificationHandler sets the handler for notifications sent by the
+	// server. Transports that cannot receive server messages ignore it.
+	SetNotificationHandler(handler NotificationHandler)
 }
 
 // MCPClientStatus represents the status of a client connection
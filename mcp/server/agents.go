@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Ranganaths/minion/models"
+)
+
+// AgentExecutor runs agents by ID. core.Framework satisfies it.
+type AgentExecutor interface {
+	Execute(ctx context.Context, agentID string, input *models.Input) (*models.Output, error)
+}
+
+// agentTool publishes a single agent as an MCP tool
+type agentTool struct {
+	name        string
+	description string
+	agentID     string
+	executor    AgentExecutor
+}
+
+// AgentToolName returns the tool name under which an agent is published:
+// "agent_" followed by the agent name in lower snake case
+func AgentToolName(agent *models.Agent) string {
+	var b strings.Builder
+	lastUnderscore := false
+	for _, r := range strings.ToLower(agent.Name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			lastUnderscore = false
+		} else if !lastUnderscore && b.Len() > 0 {
+			b.WriteByte('_')
+			lastUnderscore = true
+		}
+	}
+
+	name := strings.TrimSuffix(b.String(), "_")
+	if name == "" {
+		name = agent.ID
+	}
+	return "agent_" + name
+}
+
+// RegisterAgent publishes an agent as a tool named AgentToolName(agent).
+// Calling the tool runs executor.Execute with the "input" argument as the
+// raw input and the optional "context" argument as the input context.
+func (s *Server) RegisterAgent(executor AgentExecutor, agent *models.Agent) error {
+	if executor == nil {
+		return fmt.Errorf("agent executor is required")
+	}
+	if agent == nil || agent.ID == "" {
+		return fmt.Errorf("agent with an ID is required")
+	}
+
+	name := AgentToolName(agent)
+	if _, err := s.registry.Get(name); err == nil {
+		return fmt.Errorf("tool %s already registered", name)
+	}
+
+	description := agent.Description
+	if description == "" {
+		description = fmt.Sprintf("Run the %s agent", agent.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.agents[name]; exists {
+		return fmt.Errorf("tool %s already registered", name)
+	}
+	s.agents[name] = &agentTool{
+		name:        name,
+		description: description,
+		agentID:     agent.ID,
+		executor:    executor,
+	}
+
+	return nil
+}
+
+// agentInputSchema is the input schema shared by all agent tools
+func agentInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"input": map[string]interface{}{
+				"type":        "string",
+				"description": "The request for the agent",
+			},
+			"context": map[string]interface{}{
+				"type":        "object",
+				"description": "Optional context passed to the agent",
+			},
+		},
+		"required": []string{"input"},
+	}
+}
+
+// call executes the agent and converts its output to a tool result
+func (a *agentTool) call(ctx context.Context, arguments map[string]interface{}) interface{} {
+	raw, ok := arguments["input"].(string)
+	if !ok || raw == "" {
+		return errorResult("input is required")
+	}
+	inputContext, _ := arguments["context"].(map[string]interface{})
+
+	output, err := a.executor.Execute(ctx, a.agentID, &models.Input{
+		Raw:     raw,
+		Type:    "text",
+		Context: inputContext,
+	})
+	if err != nil {
+		return errorResult(err.Error())
+	}
+	if output.Error != "" {
+		return errorResult(output.Error)
+	}
+
+	return textResult(output.Result)
+}
@@ -0,0 +1,316 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Ranganaths/minion/mcp/client"
+	"github.com/Ranganaths/minion/models"
+	"github.com/Ranganaths/minion/tools"
+)
+
+// ProtocolVersion is the MCP protocol revision implemented by the server
+const ProtocolVersion = "2024-11-05"
+
+// JSON-RPC 2.0 error codes
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// Config configures an MCP server
+type Config struct {
+	// Name and Version are reported to clients as serverInfo on initialize
+	Name    string
+	Version string
+
+	// Instructions optionally tells clients how to use the server's tools
+	Instructions string
+
+	// Agent, if set, restricts the published tools to those the agent
+	// may execute (Tool.CanExecute). Nil publishes every registered tool.
+	Agent *models.Agent
+}
+
+// DefaultConfig returns the default server configuration
+func DefaultConfig() *Config {
+	return &Config{
+		Name:    "minion",
+		Version: "1.0.0",
+	}
+}
+
+// Server publishes a tool registry, and optionally framework agents, to MCP
+// clients. It handles initialize, ping, tools/list and tools/call; the
+// transports (ServeStdio, ServeHTTP) only move JSON-RPC messages to and from
+// HandleMessage.
+type Server struct {
+	config   *Config
+	registry tools.Registry
+	agents   map[string]*agentTool
+	mu       sync.RWMutex
+}
+
+// NewServer creates an MCP server for the registry. A nil config uses
+// DefaultConfig.
+func NewServer(registry tools.Registry, config *Config) *Server {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	return &Server{
+		config:   config,
+		registry: registry,
+		agents:   make(map[string]*agentTool),
+	}
+}
+
+// jsonrpcRequest is an incoming JSON-RPC request or notification.
+// ID is kept raw because clients may use numbers or strings.
+type jsonrpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type jsonrpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *jsonrpcError   `json:"error,omitempty"`
+}
+
+type jsonrpcError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// nullID is used when the request ID could not be determined
+var nullID = json.RawMessage("null")
+
+// HandleMessage processes a single JSON-RPC message and returns the encoded
+// response, or nil for notifications, which get no response
+func (s *Server) HandleMessage(ctx context.Context, data []byte) []byte {
+	var request jsonrpcRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		return encodeResponse(&jsonrpcResponse{
+			ID:    nullID,
+			Error: &jsonrpcError{Code: CodeParseError, Message: "Parse error"},
+		})
+	}
+
+	if request.JSONRPC != "2.0" || request.Method == "" {
+		id := request.ID
+		if id == nil {
+			id = nullID
+		}
+		return encodeResponse(&jsonrpcResponse{
+			ID:    id,
+			Error: &jsonrpcError{Code: CodeInvalidRequest, Message: "Invalid request"},
+		})
+	}
+
+	result, rpcErr := s.dispatch(ctx, request.Method, request.Params)
+
+	// Notifications carry no ID and are never answered
+	if request.ID == nil {
+		return nil
+	}
+	if result == nil && rpcErr == nil {
+		result = map[string]interface{}{}
+	}
+
+	return encodeResponse(&jsonrpcResponse{
+		ID:     request.ID,
+		Result: result,
+		Error:  rpcErr,
+	})
+}
+
+// dispatch routes a request to its method handler
+func (s *Server) dispatch(ctx context.Context, method string, params json.RawMessage) (interface{}, *jsonrpcError) {
+	switch method {
+	case "initialize":
+		return s.handleInitialize(), nil
+	case "notifications/initialized", "notifications/cancelled":
+		return nil, nil
+	case "ping":
+		return map[string]interface{}{}, nil
+	case "tools/list":
+		return map[string]interface{}{"tools": s.ListTools()}, nil
+	case "tools/call":
+		return s.handleToolsCall(ctx, params)
+	default:
+		return nil, &jsonrpcError{
+			Code:    CodeMethodNotFound,
+			Message: fmt.Sprintf("Method not found: %s", method),
+		}
+	}
+}
+
+// handleInitialize describes the server and its capabilities
+func (s *Server) handleInitialize() interface{} {
+	result := map[string]interface{}{
+		"protocolVersion": ProtocolVersion,
+		"capabilities": map[string]interface{}{
+			"tools": map[string]interface{}{},
+		},
+		"serverInfo": map[string]interface{}{
+			"name":    s.config.Name,
+			"version": s.config.Version,
+		},
+	}
+	if s.config.Instructions != "" {
+		result["instructions"] = s.config.Instructions
+	}
+	return result
+}
+
+// ListTools returns the published tools, registry tools first and then
+// agents, each sorted by name
+func (s *Server) ListTools() []client.MCPTool {
+	names := s.registry.List()
+	sort.Strings(names)
+
+	result := make([]client.MCPTool, 0, len(names))
+	for _, name := range names {
+		tool, err := s.registry.Get(name)
+		if err != nil || !s.published(tool) {
+			continue
+		}
+		result = append(result, client.MCPTool{
+			Name:        tool.Name(),
+			Description: tool.Description(),
+			InputSchema: inputSchema(tool),
+		})
+	}
+
+	s.mu.RLock()
+	agentNames := make([]string, 0, len(s.agents))
+	for name := range s.agents {
+		agentNames = append(agentNames, name)
+	}
+	sort.Strings(agentNames)
+	for _, name := range agentNames {
+		agent := s.agents[name]
+		result = append(result, client.MCPTool{
+			Name:        agent.name,
+			Description: agent.description,
+			InputSchema: agentInputSchema(),
+		})
+	}
+	s.mu.RUnlock()
+
+	return result
+}
+
+// handleToolsCall executes a tool or agent. Unknown tools are protocol
+// errors; failures inside a tool are reported as an isError result so the
+// calling model can see and react to them.
+func (s *Server) handleToolsCall(ctx context.Context, params json.RawMessage) (interface{}, *jsonrpcError) {
+	var request client.MCPCallToolRequest
+	if len(params) == 0 || json.Unmarshal(params, &request) != nil || request.Name == "" {
+		return nil, &jsonrpcError{Code: CodeInvalidParams, Message: "Invalid params"}
+	}
+	if request.Arguments == nil {
+		request.Arguments = map[string]interface{}{}
+	}
+
+	s.mu.RLock()
+	agent, isAgent := s.agents[request.Name]
+	s.mu.RUnlock()
+	if isAgent {
+		return agent.call(ctx, request.Arguments), nil
+	}
+
+	tool, err := s.registry.Get(request.Name)
+	if err != nil || !s.published(tool) {
+		return nil, &jsonrpcError{
+			Code:    CodeInvalidParams,
+			Message: fmt.Sprintf("Tool not found: %s", request.Name),
+		}
+	}
+
+	output, err := s.registry.Execute(ctx, request.Name, &models.ToolInput{Params: request.Arguments})
+	if err != nil {
+		return errorResult(err.Error()), nil
+	}
+	if !output.Success {
+		return errorResult(output.Error), nil
+	}
+
+	return textResult(output.Result), nil
+}
+
+// published reports whether a registry tool is visible to clients
+func (s *Server) published(tool tools.Tool) bool {
+	return s.config.Agent == nil || tool.CanExecute(s.config.Agent)
+}
+
+// inputSchema returns the tool's schema, or an open object schema for tools
+// that do not declare one
+func inputSchema(tool tools.Tool) map[string]interface{} {
+	if schema := tools.InputSchema(tool); schema != nil {
+		return schema
+	}
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           map[string]interface{}{},
+		"additionalProperties": true,
+	}
+}
+
+// textResult wraps a tool result as a single text content item. Strings are
+// sent as-is and other values as JSON.
+func textResult(result interface{}) *client.MCPCallToolResult {
+	var text string
+	switch v := result.(type) {
+	case nil:
+		text = ""
+	case string:
+		text = v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			text = fmt.Sprintf("%v", v)
+		} else {
+			text = string(data)
+		}
+	}
+
+	return &client.MCPCallToolResult{
+		Content: []interface{}{
+			map[string]interface{}{"type": "text", "text": text},
+		},
+	}
+}
+
+// errorResult reports a tool failure to the client
+func errorResult(message string) *client.MCPCallToolResult {
+	result := textResult(message)
+	result.IsError = true
+	return result
+}
+
+// encodeResponse marshals a response, which cannot fail for the value types
+// the server produces
+func encodeResponse(response *jsonrpcResponse) []byte {
+	response.JSONRPC = "2.0"
+	data, err := json.Marshal(response)
+	if err != nil {
+		data, _ = json.Marshal(&jsonrpcResponse{
+			JSONRPC: "2.0",
+			ID:      response.ID,
+			Error:   &jsonrpcError{Code: CodeInternalError, Message: err.Error()},
+		})
+	}
+	return data
+}
@@ -0,0 +1,344 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ranganaths/minion/mcp/client"
+	"github.com/Ranganaths/minion/models"
+	"github.com/Ranganaths/minion/tools"
+)
+
+// addTool adds two numbers and declares a schema for its params
+type addTool struct{}
+
+func (t *addTool) Name() string        { return "add" }
+func (t *addTool) Description() string { return "Adds two numbers" }
+
+func (t *addTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"a": tools.NumberProperty("First number"),
+		"b": tools.NumberProperty("Second number"),
+	}, "a", "b")
+}
+
+func (t *addTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
+	a, _ := input.Params["a"].(float64)
+	b, _ := input.Params["b"].(float64)
+	return &models.ToolOutput{ToolName: t.Name(), Success: true, Result: map[string]interface{}{"sum": a + b}}, nil
+}
+
+func (t *addTool) CanExecute(agent *models.Agent) bool {
+	for _, c := range agent.Capabilities {
+		if c == "math" {
+			return true
+		}
+	}
+	return false
+}
+
+// failTool always fails and declares no schema
+type failTool struct{}
+
+func (t *failTool) Name() string                        { return "fail" }
+func (t *failTool) Description() string                 { return "Always fails" }
+func (t *failTool) CanExecute(agent *models.Agent) bool { return true }
+
+func (t *failTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
+	return &models.ToolOutput{ToolName: t.Name(), Success: false, Error: "boom"}, nil
+}
+
+// echoExecutor answers agent executions with the agent ID and raw input
+type echoExecutor struct{}
+
+func (e *echoExecutor) Execute(ctx context.Context, agentID string, input *models.Input) (*models.Output, error) {
+	return &models.Output{Result: fmt.Sprintf("%s: %s", agentID, input.Raw), Type: "text"}, nil
+}
+
+func newTestServer(t *testing.T, config *Config) *Server {
+	t.Helper()
+
+	registry := tools.NewRegistry()
+	for _, tool := range []tools.Tool{&addTool{}, &failTool{}} {
+		if err := registry.Register(tool); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	return NewServer(registry, config)
+}
+
+func call(t *testing.T, s *Server, message string) map[string]interface{} {
+	t.Helper()
+
+	data := s.HandleMessage(context.Background(), []byte(message))
+	if data == nil {
+		t.Fatalf("expected a response to %s", message)
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(data, &response); err != nil {
+		t.Fatalf("invalid response %s: %v", data, err)
+	}
+	return response
+}
+
+func errorCode(response map[string]interface{}) int {
+	rpcErr, ok := response["error"].(map[string]interface{})
+	if !ok {
+		return 0
+	}
+	return int(rpcErr["code"].(float64))
+}
+
+func TestHandleMessage(t *testing.T) {
+	s := newTestServer(t, &Config{Name: "test", Version: "0.1.0"})
+
+	tests := []struct {
+		name     string
+		message  string
+		wantCode int
+		check    func(t *testing.T, result map[string]interface{})
+	}{
+		{
+			name:    "initialize",
+			message: `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`,
+			check: func(t *testing.T, result map[string]interface{}) {
+				if result["protocolVersion"] != ProtocolVersion {
+					t.Errorf("protocolVersion = %v", result["protocolVersion"])
+				}
+				info := result["serverInfo"].(map[string]interface{})
+				if info["name"] != "test" || info["version"] != "0.1.0" {
+					t.Errorf("serverInfo = %v", info)
+				}
+			},
+		},
+		{
+			name:    "ping with string id",
+			message: `{"jsonrpc":"2.0","id":"abc","method":"ping"}`,
+		},
+		{
+			name:    "list tools with schemas",
+			message: `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+			check: func(t *testing.T, result map[string]interface{}) {
+				list := result["tools"].([]interface{})
+				if len(list) != 2 {
+					t.Fatalf("expected 2 tools, got %d", len(list))
+				}
+				add := list[0].(map[string]interface{})
+				schema := add["inputSchema"].(map[string]interface{})
+				if add["name"] != "add" || schema["type"] != "object" || len(schema["required"].([]interface{})) != 2 {
+					t.Errorf("unexpected add tool %v", add)
+				}
+				fail := list[1].(map[string]interface{})
+				if fail["inputSchema"].(map[string]interface{})["additionalProperties"] != true {
+					t.Errorf("expected open schema for tool without one, got %v", fail["inputSchema"])
+				}
+			},
+		},
+		{
+			name:    "call tool",
+			message: `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"add","arguments":{"a":2,"b":3}}}`,
+			check: func(t *testing.T, result map[string]interface{}) {
+				if result["isError"] == true {
+					t.Fatalf("unexpected error result %v", result)
+				}
+				text := result["content"].([]interface{})[0].(map[string]interface{})["text"]
+				if text != `{"sum":5}` {
+					t.Errorf("text = %v", text)
+				}
+			},
+		},
+		{
+			name:    "invalid arguments are a tool error",
+			message: `{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"add","arguments":{"a":"two"}}}`,
+			check: func(t *testing.T, result map[string]interface{}) {
+				if result["isError"] != true {
+					t.Errorf("expected isError, got %v", result)
+				}
+			},
+		},
+		{
+			name:    "failing tool",
+			message: `{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"fail"}}`,
+			check: func(t *testing.T, result map[string]interface{}) {
+				text := result["content"].([]interface{})[0].(map[string]interface{})["text"]
+				if result["isError"] != true || text != "boom" {
+					t.Errorf("unexpected result %v", result)
+				}
+			},
+		},
+		{name: "unknown tool", message: `{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"nope"}}`, wantCode: CodeInvalidParams},
+		{name: "missing params", message: `{"jsonrpc":"2.0","id":7,"method":"tools/call"}`, wantCode: CodeInvalidParams},
+		{name: "unknown method", message: `{"jsonrpc":"2.0","id":8,"method":"resources/list"}`, wantCode: CodeMethodNotFound},
+		{name: "parse error", message: `{"jsonrpc":`, wantCode: CodeParseError},
+		{name: "invalid request", message: `{"id":9,"method":"ping"}`, wantCode: CodeInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := call(t, s, tt.message)
+
+			if code := errorCode(response); code != tt.wantCode {
+				t.Fatalf("error code = %d, want %d (%v)", code, tt.wantCode, response)
+			}
+			if tt.check != nil {
+				tt.check(t, response["result"].(map[string]interface{}))
+			}
+		})
+	}
+}
+
+func TestHandleMessage_Notification(t *testing.T) {
+	s := newTestServer(t, nil)
+
+	if data := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)); data != nil {
+		t.Errorf("expected no response to a notification, got %s", data)
+	}
+}
+
+func TestServer_AgentFilter(t *testing.T) {
+	s := newTestServer(t, &Config{Name: "test", Agent: &models.Agent{ID: "a1", Capabilities: []string{}}})
+
+	list := s.ListTools()
+	if len(list) != 1 || list[0].Name != "fail" {
+		t.Fatalf("expected only the fail tool, got %v", list)
+	}
+
+	response := call(t, s, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"add","arguments":{"a":1,"b":2}}}`)
+	if code := errorCode(response); code != CodeInvalidParams {
+		t.Errorf("expected hidden tool to be not found, got %v", response)
+	}
+}
+
+func TestServer_RegisterAgent(t *testing.T) {
+	s := newTestServer(t, nil)
+	agent := &models.Agent{ID: "agent-1", Name: "Sales Analyst", Description: "Analyzes sales"}
+
+	if err := s.RegisterAgent(&echoExecutor{}, agent); err != nil {
+		t.Fatalf("RegisterAgent failed: %v", err)
+	}
+	if err := s.RegisterAgent(&echoExecutor{}, agent); err == nil {
+		t.Error("expected duplicate registration to fail")
+	}
+
+	name := AgentToolName(agent)
+	if name != "agent_sales_analyst" {
+		t.Errorf("AgentToolName = %q", name)
+	}
+
+	list := s.ListTools()
+	if last := list[len(list)-1]; last.Name != name || last.Description != "Analyzes sales" {
+		t.Errorf("unexpected agent tool %v", last)
+	}
+
+	response := call(t, s, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"agent_sales_analyst","arguments":{"input":"Q3 totals"}}}`)
+	result := response["result"].(map[string]interface{})
+	text := result["content"].([]interface{})[0].(map[string]interface{})["text"]
+	if text != "agent-1: Q3 totals" {
+		t.Errorf("text = %v", text)
+	}
+
+	response = call(t, s, `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"agent_sales_analyst","arguments":{}}}`)
+	if response["result"].(map[string]interface{})["isError"] != true {
+		t.Errorf("expected missing input to be a tool error, got %v", response)
+	}
+}
+
+func TestServeStdio(t *testing.T) {
+	s := newTestServer(t, nil)
+
+	input := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize"}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		``,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"add","arguments":{"a":1,"b":1}}}`,
+	}, "\n")
+	var output bytes.Buffer
+
+	if err := s.ServeStdio(context.Background(), strings.NewReader(input), &output); err != nil {
+		t.Fatalf("ServeStdio failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 responses, got %d: %q", len(lines), output.String())
+	}
+	for _, line := range lines {
+		var response map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &response); err != nil || response["error"] != nil {
+			t.Errorf("unexpected response %s", line)
+		}
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	s := newTestServer(t, nil)
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	resp, err := ts.Client().Get(ts.URL)
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 405 {
+		t.Errorf("GET status = %d, want 405", resp.StatusCode)
+	}
+
+	resp, err = ts.Client().Post(ts.URL, "application/json", strings.NewReader(`{"jsonrpc":"2.0","method":"notifications/initialized"}`))
+	if err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != 202 {
+		t.Errorf("notification status = %d, want 202", resp.StatusCode)
+	}
+}
+
+func TestServer_ClientRoundTrip(t *testing.T) {
+	s := newTestServer(t, nil)
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	manager := client.NewMCPClientManager(nil)
+	defer manager.Close()
+
+	config := client.DefaultClientConfig("minion")
+	config.Transport = client.TransportHTTP
+	config.URL = ts.URL
+	if err := manager.ConnectServer(ctx, config); err != nil {
+		t.Fatalf("ConnectServer failed: %v", err)
+	}
+
+	mcpClient, err := manager.GetClient("minion")
+	if err != nil {
+		t.Fatalf("GetClient failed: %v", err)
+	}
+	if got := len(mcpClient.GetTools()); got != 2 {
+		t.Fatalf("expected 2 discovered tools, got %d", got)
+	}
+
+	result, err := mcpClient.CallTool(ctx, "add", map[string]interface{}{"a": 40, "b": 2})
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result %v", result)
+	}
+	text := result.Content[0].(map[string]interface{})["text"]
+	if text != `{"sum":42}` {
+		t.Errorf("text = %v", text)
+	}
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// maxMessageSize bounds a single JSON-RPC message on either transport
+const maxMessageSize = 10 * 1024 * 1024
+
+// ServeStdio serves newline-delimited JSON-RPC messages read from r and
+// writes responses to w, one per line. Requests are handled concurrently so
+// a slow tool does not block others. It returns when r reaches EOF or ctx is
+// cancelled, after in-flight requests finish.
+//
+// A server launched by an MCP host as a subprocess typically calls
+// ServeStdio(ctx, os.Stdin, os.Stdout).
+func (s *Server) ServeStdio(ctx context.Context, r io.Reader, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var writeMu sync.Mutex
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		message := append([]byte(nil), line...)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			response := s.HandleMessage(ctx, message)
+			if response == nil {
+				return
+			}
+
+			writeMu.Lock()
+			defer writeMu.Unlock()
+			_, _ = w.Write(append(response, '\n'))
+		}()
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read stdin: %w", err)
+	}
+	return nil
+}
+
+// ServeHTTP serves JSON-RPC messages POSTed as the request body. Responses
+// are returned as application/json; notifications get 202 Accepted.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxMessageSize))
+	if err != nil {
+		http.Error(w, "Failed to read body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	response := s.HandleMessage(r.Context(), body)
+	if response == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(response)
+}
+
+// ListenAndServe serves the MCP HTTP transport on addr until the server
+// fails. Use the Server as an http.Handler directly for custom routing,
+// TLS or authentication middleware.
+func (s *Server) ListenAndServe(addr string) error {
+	return http.ListenAndServe(addr, s)
+}
+
+// Ensure Server implements http.Handler
+var _ http.Handler = (*Server)(nil)
@@ -11,19 +11,28 @@ import (
 // It's the simplest memory type that remembers all previous interactions.
 type ConversationBufferMemory struct {
 	mu      sync.RWMutex
-	history *InMemoryChatMessageHistory
+	history ChatMessageHistory
 	config  MemoryConfig
 }
 
 // ConversationBufferMemoryConfig configures the conversation buffer memory
 type ConversationBufferMemoryConfig struct {
 	MemoryConfig
+
+	// ChatHistory stores the messages (default: in memory). Use a durable
+	// history, such as one from a ChatMessageHistoryFactory, to resume a
+	// conversation across processes.
+	ChatHistory ChatMessageHistory
 }
 
 // NewConversationBufferMemory creates a new conversation buffer memory
 func NewConversationBufferMemory(cfg ...ConversationBufferMemoryConfig) *ConversationBufferMemory {
 	config := DefaultMemoryConfig()
+	var history ChatMessageHistory = NewInMemoryChatMessageHistory()
 	if len(cfg) > 0 {
+		if cfg[0].ChatHistory != nil {
+			history = cfg[0].ChatHistory
+		}
 		if cfg[0].MemoryKey != "" {
 			config.MemoryKey = cfg[0].MemoryKey
 		}
@@ -43,7 +52,7 @@ func NewConversationBufferMemory(cfg ...ConversationBufferMemoryConfig) *Convers
 	}
 
 	return &ConversationBufferMemory{
-		history: NewInMemoryChatMessageHistory(),
+		history: history,
 		config:  config,
 	}
 }
@@ -77,13 +86,9 @@ func (m *ConversationBufferMemory) SaveContext(ctx context.Context, inputs map[s
 	defer m.mu.Unlock()
 
 	// Get input message
-	inputVal, ok := inputs[m.config.InputKey]
-	if ok {
-		inputStr, _ := inputVal.(string)
-		if inputStr != "" {
-			if err := m.history.AddUserMessage(ctx, inputStr); err != nil {
-				return err
-			}
+	if msg, ok := inputMessage(inputs[m.config.InputKey]); ok {
+		if err := m.history.AddMessage(ctx, msg); err != nil {
+			return err
 		}
 	}
 
@@ -123,7 +128,11 @@ func (m *ConversationBufferMemory) formatMessages(messages []ChatMessage) string
 	var parts []string
 	for _, msg := range messages {
 		prefix := m.getPrefixForRole(msg.Role)
-		parts = append(parts, fmt.Sprintf("%s: %s", prefix, msg.Content))
+		content := msg.Content
+		if len(msg.Parts) > 0 {
+			content = strings.TrimSpace(content + " " + describeParts(msg.Parts))
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", prefix, content))
 	}
 	return strings.Join(parts, "\n")
 }
@@ -246,12 +255,9 @@ func (m *ConversationSummaryMemory) SaveContext(ctx context.Context, inputs map[
 	var newMessages []ChatMessage
 
 	// Get input message
-	if inputVal, ok := inputs[m.config.InputKey]; ok {
-		if inputStr, ok := inputVal.(string); ok && inputStr != "" {
-			msg := NewHumanMessage(inputStr)
-			m.history.AddMessage(ctx, msg)
-			newMessages = append(newMessages, msg)
-		}
+	if msg, ok := inputMessage(inputs[m.config.InputKey]); ok {
+		m.history.AddMessage(ctx, msg)
+		newMessages = append(newMessages, msg)
 	}
 
 	// Get output message
@@ -71,17 +71,3 @@ func (h *InMemoryChatMessageHistory) LastMessage() (ChatMessage, bool) {
 	}
 	return h.messages[len(h.messages)-1], true
 }
-
-// FileChatMessageHistory stores chat messages in a file (placeholder for future implementation)
-type FileChatMessageHistory struct {
-	*InMemoryChatMessageHistory
-	filePath string
-}
-
-// NewFileChatMessageHistory creates a new file-based chat history
-func NewFileChatMessageHistory(filePath string) *FileChatMessageHistory {
-	return &FileChatMessageHistory{
-		InMemoryChatMessageHistory: NewInMemoryChatMessageHistory(),
-		filePath:                   filePath,
-	}
-}
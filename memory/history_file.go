@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileChatMessageHistory stores chat messages in an append-only JSON Lines
+// file, one message per line. Messages already in the file are loaded when
+// the history is opened; each new message is appended as it is added.
+//
+// A history file should be written by one process at a time. Messages
+// appended by other processes after opening are not seen.
+type FileChatMessageHistory struct {
+	mu       sync.RWMutex
+	filePath string
+	messages []ChatMessage
+}
+
+var _ ChatMessageHistory = (*FileChatMessageHistory)(nil)
+
+// NewFileChatMessageHistory opens the history stored at filePath, creating
+// the file and its directory if they do not exist
+func NewFileChatMessageHistory(filePath string) (*FileChatMessageHistory, error) {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	h := &FileChatMessageHistory{
+		filePath: filePath,
+		messages: make([]ChatMessage, 0),
+	}
+	if err := h.load(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// NewFileChatMessageHistoryFactory returns a factory that stores each
+// session's history in dir/<sessionID>.jsonl
+func NewFileChatMessageHistoryFactory(dir string) ChatMessageHistoryFactory {
+	return func(ctx context.Context, sessionID string) (ChatMessageHistory, error) {
+		if sessionID == "" || sessionID == "." || sessionID == ".." || filepath.Base(sessionID) != sessionID {
+			return nil, fmt.Errorf("invalid session ID for a history file: %q", sessionID)
+		}
+		return NewFileChatMessageHistory(filepath.Join(dir, sessionID+".jsonl"))
+	}
+}
+
+// load reads the messages stored in the file. A final line without a
+// newline is a write interrupted by a crash; it is ignored and truncated
+// from the file so the next message starts on a line of its own.
+func (h *FileChatMessageHistory) load() error {
+	data, err := os.ReadFile(h.filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read history file: %w", err)
+	}
+	interrupted := false
+	if i := bytes.LastIndexByte(data, '\n'); i < len(data)-1 {
+		data = data[:i+1]
+		interrupted = true
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var msg ChatMessage
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			return fmt.Errorf("invalid message on line %d of %s: %w", line, h.filePath, err)
+		}
+		h.messages = append(h.messages, msg)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if interrupted {
+		if err := os.Truncate(h.filePath, int64(len(data))); err != nil {
+			return fmt.Errorf("failed to truncate interrupted write: %w", err)
+		}
+	}
+	return nil
+}
+
+// AddUserMessage adds a user/human message
+func (h *FileChatMessageHistory) AddUserMessage(ctx context.Context, message string) error {
+	return h.AddMessage(ctx, NewHumanMessage(message))
+}
+
+// AddAIMessage adds an AI/assistant message
+func (h *FileChatMessageHistory) AddAIMessage(ctx context.Context, message string) error {
+	return h.AddMessage(ctx, NewAIMessage(message))
+}
+
+// AddMessage appends a message to the file
+func (h *FileChatMessageHistory) AddMessage(ctx context.Context, message ChatMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	f, err := os.OpenFile(h.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to append message: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close history file: %w", err)
+	}
+
+	h.messages = append(h.messages, message)
+	return nil
+}
+
+// Messages returns all messages
+func (h *FileChatMessageHistory) Messages(ctx context.Context) ([]ChatMessage, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	result := make([]ChatMessage, len(h.messages))
+	copy(result, h.messages)
+	return result, nil
+}
+
+// Clear removes all messages and truncates the file
+func (h *FileChatMessageHistory) Clear(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := os.Truncate(h.filePath, 0); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to clear history file: %w", err)
+	}
+	h.messages = make([]ChatMessage, 0)
+	return nil
+}
+
+// FilePath returns the path of the history file
+func (h *FileChatMessageHistory) FilePath() string {
+	return h.filePath
+}
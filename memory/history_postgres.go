@@ -0,0 +1,186 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultPostgresHistoryTable is the table Postgres chat histories use
+const DefaultPostgresHistoryTable = "chat_message_history"
+
+// tableNamePattern matches table names that are safe to interpolate into SQL
+var tableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// PostgresChatMessageHistoryConfig configures a Postgres chat history
+type PostgresChatMessageHistoryConfig struct {
+	// DB is an open connection using a Postgres driver such as
+	// github.com/lib/pq (required)
+	DB *sql.DB
+
+	// TableName is the table messages are stored in
+	// (default: DefaultPostgresHistoryTable)
+	TableName string
+
+	// CreateSchema creates the table if it does not exist. Production
+	// deployments should apply migrations/003_chat_history.up.sql instead.
+	CreateSchema bool
+}
+
+// PostgresChatMessageHistory stores a session's chat messages as rows keyed
+// by session ID, ordered by insertion. Messages are read on every call, so
+// several processes can share a session.
+type PostgresChatMessageHistory struct {
+	db        *sql.DB
+	table     string
+	sessionID string
+}
+
+var _ ChatMessageHistory = (*PostgresChatMessageHistory)(nil)
+
+// NewPostgresChatMessageHistory creates the history of a session
+func NewPostgresChatMessageHistory(ctx context.Context, cfg PostgresChatMessageHistoryConfig, sessionID string) (*PostgresChatMessageHistory, error) {
+	table, err := postgresHistoryTable(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if sessionID == "" {
+		return nil, fmt.Errorf("session ID is required")
+	}
+
+	return &PostgresChatMessageHistory{db: cfg.DB, table: table, sessionID: sessionID}, nil
+}
+
+// NewPostgresChatMessageHistoryFactory returns a factory for the Postgres
+// histories of sessions. The schema is created once, if requested.
+func NewPostgresChatMessageHistoryFactory(ctx context.Context, cfg PostgresChatMessageHistoryConfig) (ChatMessageHistoryFactory, error) {
+	table, err := postgresHistoryTable(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, sessionID string) (ChatMessageHistory, error) {
+		if sessionID == "" {
+			return nil, fmt.Errorf("session ID is required")
+		}
+		return &PostgresChatMessageHistory{db: cfg.DB, table: table, sessionID: sessionID}, nil
+	}, nil
+}
+
+// postgresHistoryTable validates the configuration, creates the schema if
+// requested and returns the table name
+func postgresHistoryTable(ctx context.Context, cfg PostgresChatMessageHistoryConfig) (string, error) {
+	if cfg.DB == nil {
+		return "", fmt.Errorf("database connection is required")
+	}
+
+	table := cfg.TableName
+	if table == "" {
+		table = DefaultPostgresHistoryTable
+	}
+	if !tableNamePattern.MatchString(table) {
+		return "", fmt.Errorf("invalid table name %q", table)
+	}
+
+	if cfg.CreateSchema {
+		if err := EnsurePostgresHistorySchema(ctx, cfg.DB, table); err != nil {
+			return "", err
+		}
+	}
+	return table, nil
+}
+
+// EnsurePostgresHistorySchema creates a chat history table and its session
+// index if they do not exist
+func EnsurePostgresHistorySchema(ctx context.Context, db *sql.DB, table string) error {
+	if !tableNamePattern.MatchString(table) {
+		return fmt.Errorf("invalid table name %q", table)
+	}
+
+	index := strings.ReplaceAll(table, ".", "_") + "_session_idx"
+	_, err := db.ExecContext(ctx, fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			id BIGSERIAL PRIMARY KEY,
+			session_id VARCHAR(255) NOT NULL,
+			message JSONB NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS %s ON %s (session_id, id);`, table, index, table))
+	if err != nil {
+		return fmt.Errorf("failed to create chat history schema: %w", err)
+	}
+	return nil
+}
+
+// AddUserMessage adds a user/human message
+func (h *PostgresChatMessageHistory) AddUserMessage(ctx context.Context, message string) error {
+	return h.AddMessage(ctx, NewHumanMessage(message))
+}
+
+// AddAIMessage adds an AI/assistant message
+func (h *PostgresChatMessageHistory) AddAIMessage(ctx context.Context, message string) error {
+	return h.AddMessage(ctx, NewAIMessage(message))
+}
+
+// AddMessage inserts a message
+func (h *PostgresChatMessageHistory) AddMessage(ctx context.Context, message ChatMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	_, err = h.db.ExecContext(ctx,
+		fmt.Sprintf(`INSERT INTO %s (session_id, message) VALUES ($1, $2)`, h.table),
+		h.sessionID, string(data))
+	if err != nil {
+		return fmt.Errorf("failed to insert message: %w", err)
+	}
+	return nil
+}
+
+// Messages returns all messages of the session in insertion order
+func (h *PostgresChatMessageHistory) Messages(ctx context.Context) ([]ChatMessage, error) {
+	rows, err := h.db.QueryContext(ctx,
+		fmt.Sprintf(`SELECT message FROM %s WHERE session_id = $1 ORDER BY id`, h.table),
+		h.sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load messages: %w", err)
+	}
+	defer rows.Close()
+
+	messages := make([]ChatMessage, 0)
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan message: %w", err)
+		}
+		var msg ChatMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return nil, fmt.Errorf("invalid message in session %s: %w", h.sessionID, err)
+		}
+		messages = append(messages, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load messages: %w", err)
+	}
+	return messages, nil
+}
+
+// Clear deletes the session's messages
+func (h *PostgresChatMessageHistory) Clear(ctx context.Context) error {
+	_, err := h.db.ExecContext(ctx,
+		fmt.Sprintf(`DELETE FROM %s WHERE session_id = $1`, h.table),
+		h.sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to clear messages: %w", err)
+	}
+	return nil
+}
+
+// SessionID returns the session the history belongs to
+func (h *PostgresChatMessageHistory) SessionID() string {
+	return h.sessionID
+}
@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultRedisHistoryKeyPrefix prefixes the keys of Redis chat histories
+const DefaultRedisHistoryKeyPrefix = "minion:chat_history:"
+
+// RedisChatMessageHistoryConfig configures a Redis chat history
+type RedisChatMessageHistoryConfig struct {
+	// Client is the Redis connection (required)
+	Client redis.UniversalClient
+
+	// KeyPrefix is prepended to session IDs to form list keys
+	// (default: DefaultRedisHistoryKeyPrefix)
+	KeyPrefix string
+
+	// TTL expires a session's history after this long without new messages
+	// (0 = never)
+	TTL time.Duration
+}
+
+// RedisChatMessageHistory stores a session's chat messages in a Redis list,
+// one JSON-encoded message per element. Each new message resets the list's
+// TTL, so idle conversations expire. The list is read on every call, so
+// several processes can share a session.
+type RedisChatMessageHistory struct {
+	client redis.UniversalClient
+	key    string
+	ttl    time.Duration
+}
+
+var _ ChatMessageHistory = (*RedisChatMessageHistory)(nil)
+
+// NewRedisChatMessageHistory creates the history of a session
+func NewRedisChatMessageHistory(cfg RedisChatMessageHistoryConfig, sessionID string) (*RedisChatMessageHistory, error) {
+	if cfg.Client == nil {
+		return nil, fmt.Errorf("redis client is required")
+	}
+	if sessionID == "" {
+		return nil, fmt.Errorf("session ID is required")
+	}
+
+	prefix := cfg.KeyPrefix
+	if prefix == "" {
+		prefix = DefaultRedisHistoryKeyPrefix
+	}
+
+	return &RedisChatMessageHistory{
+		client: cfg.Client,
+		key:    prefix + sessionID,
+		ttl:    cfg.TTL,
+	}, nil
+}
+
+// NewRedisChatMessageHistoryFactory returns a factory for the Redis
+// histories of sessions
+func NewRedisChatMessageHistoryFactory(cfg RedisChatMessageHistoryConfig) ChatMessageHistoryFactory {
+	return func(ctx context.Context, sessionID string) (ChatMessageHistory, error) {
+		return NewRedisChatMessageHistory(cfg, sessionID)
+	}
+}
+
+// AddUserMessage adds a user/human message
+func (h *RedisChatMessageHistory) AddUserMessage(ctx context.Context, message string) error {
+	return h.AddMessage(ctx, NewHumanMessage(message))
+}
+
+// AddAIMessage adds an AI/assistant message
+func (h *RedisChatMessageHistory) AddAIMessage(ctx context.Context, message string) error {
+	return h.AddMessage(ctx, NewAIMessage(message))
+}
+
+// AddMessage appends a message to the list and refreshes its TTL
+func (h *RedisChatMessageHistory) AddMessage(ctx context.Context, message ChatMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	_, err = h.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.RPush(ctx, h.key, data)
+		if h.ttl > 0 {
+			pipe.Expire(ctx, h.key, h.ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to append message: %w", err)
+	}
+	return nil
+}
+
+// Messages returns all messages
+func (h *RedisChatMessageHistory) Messages(ctx context.Context) ([]ChatMessage, error) {
+	values, err := h.client.LRange(ctx, h.key, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load messages: %w", err)
+	}
+
+	messages := make([]ChatMessage, len(values))
+	for i, value := range values {
+		if err := json.Unmarshal([]byte(value), &messages[i]); err != nil {
+			return nil, fmt.Errorf("invalid message %d in %s: %w", i, h.key, err)
+		}
+	}
+	return messages, nil
+}
+
+// Clear deletes the list
+func (h *RedisChatMessageHistory) Clear(ctx context.Context) error {
+	if err := h.client.Del(ctx, h.key).Err(); err != nil {
+		return fmt.Errorf("failed to clear messages: %w", err)
+	}
+	return nil
+}
+
+// Key returns the Redis key of the list
+func (h *RedisChatMessageHistory) Key() string {
+	return h.key
+}
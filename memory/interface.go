@@ -4,6 +4,10 @@ package memory
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Ranganaths/minion/llm"
 )
 
 // Memory is the interface for conversation memory management.
@@ -40,19 +44,27 @@ type ChatMessageHistory interface {
 	Clear(ctx context.Context) error
 }
 
+// ChatMessageHistoryFactory returns the history of a conversation session,
+// loading any messages already stored for it
+type ChatMessageHistoryFactory func(ctx context.Context, sessionID string) (ChatMessageHistory, error)
+
 // ChatMessage represents a single message in a conversation
 type ChatMessage struct {
 	// Role is the message sender role (human, ai, system, function)
-	Role MessageRole
+	Role MessageRole `json:"role"`
 
 	// Content is the message text content
-	Content string
+	Content string `json:"content"`
+
+	// Parts are multi-modal content (images and documents) that follow
+	// Content
+	Parts []llm.ContentPart `json:"parts,omitempty"`
 
 	// Name is an optional name for the sender
-	Name string
+	Name string `json:"name,omitempty"`
 
 	// Metadata contains additional message data
-	Metadata map[string]any
+	Metadata map[string]any `json:"metadata,omitempty"`
 }
 
 // MessageRole represents the role of a message sender
@@ -77,6 +89,11 @@ func NewHumanMessage(content string) ChatMessage {
 	return ChatMessage{Role: RoleHuman, Content: content}
 }
 
+// NewHumanMessageWithParts creates a human message with images or documents
+func NewHumanMessageWithParts(content string, parts ...llm.ContentPart) ChatMessage {
+	return ChatMessage{Role: RoleHuman, Content: content, Parts: parts}
+}
+
 // NewAIMessage creates a new AI message
 func NewAIMessage(content string) ChatMessage {
 	return ChatMessage{Role: RoleAI, Content: content}
@@ -92,6 +109,94 @@ func NewFunctionMessage(name, content string) ChatMessage {
 	return ChatMessage{Role: RoleFunction, Content: content, Name: name}
 }
 
+// llmRoles maps memory roles to llm.Message roles
+var llmRoles = map[MessageRole]string{
+	RoleHuman:    "user",
+	RoleAI:       "assistant",
+	RoleSystem:   "system",
+	RoleFunction: "tool",
+}
+
+// ToLLMMessage converts the message to an llm.Message, keeping its parts.
+// Function messages take their tool call ID from Metadata["tool_call_id"].
+func (m ChatMessage) ToLLMMessage() llm.Message {
+	role, ok := llmRoles[m.Role]
+	if !ok {
+		role = string(m.Role)
+	}
+
+	msg := llm.Message{Role: role, Content: m.Content, Parts: m.Parts, Name: m.Name}
+	if id, ok := m.Metadata["tool_call_id"].(string); ok {
+		msg.ToolCallID = id
+	}
+	return msg
+}
+
+// FromLLMMessage converts an llm.Message to a ChatMessage, keeping its parts
+func FromLLMMessage(msg llm.Message) ChatMessage {
+	role := MessageRole(msg.Role)
+	for memoryRole, llmRole := range llmRoles {
+		if llmRole == msg.Role {
+			role = memoryRole
+		}
+	}
+
+	result := ChatMessage{Role: role, Content: msg.Content, Parts: msg.Parts, Name: msg.Name}
+	if msg.ToolCallID != "" {
+		result.Metadata = map[string]any{"tool_call_id": msg.ToolCallID}
+	}
+	return result
+}
+
+// ToLLMMessages converts messages to llm.Messages
+func ToLLMMessages(messages []ChatMessage) []llm.Message {
+	result := make([]llm.Message, len(messages))
+	for i, msg := range messages {
+		result[i] = msg.ToLLMMessage()
+	}
+	return result
+}
+
+// describeParts returns text placeholders for a message's images and
+// documents, used when history is formatted as a string
+func describeParts(parts []llm.ContentPart) string {
+	var placeholders []string
+	for _, part := range parts {
+		switch part.Type {
+		case llm.ContentTypeText:
+			placeholders = append(placeholders, part.Text)
+		case llm.ContentTypeDocument:
+			if part.Name != "" {
+				placeholders = append(placeholders, fmt.Sprintf("[document: %s]", part.Name))
+			} else {
+				placeholders = append(placeholders, "[document]")
+			}
+		default:
+			placeholders = append(placeholders, "[image]")
+		}
+	}
+	return strings.Join(placeholders, " ")
+}
+
+// inputMessage converts a chain input to a human message. Inputs may be a
+// string, an llm.Message, or content parts.
+func inputMessage(value any) (ChatMessage, bool) {
+	switch v := value.(type) {
+	case string:
+		return NewHumanMessage(v), v != ""
+	case llm.Message:
+		msg := FromLLMMessage(v)
+		msg.Role = RoleHuman
+		return msg, v.Content != "" || len(v.Parts) > 0
+	case []llm.ContentPart:
+		return NewHumanMessageWithParts("", v...), len(v) > 0
+	case llm.ContentPart:
+		return NewHumanMessageWithParts("", v), true
+	default:
+		return ChatMessage{}, false
+	}
+}
+
 // MemoryConfig holds common configuration for memory implementations
 type MemoryConfig struct {
 	// MemoryKey is the key used to store memory in chain inputs (default: "history")
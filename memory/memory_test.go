@@ -3,6 +3,8 @@ package memory
 import (
 	"context"
 	"testing"
+
+	"github.com/Ranganaths/minion/llm"
 )
 
 func TestInMemoryChatMessageHistory(t *testing.T) {
@@ -254,6 +256,64 @@ func TestChatMessageHelpers(t *testing.T) {
 	})
 }
 
+func TestMultiModalMessages(t *testing.T) {
+	ctx := context.Background()
+	image := llm.ImagePart("image/png", []byte("png"))
+
+	t.Run("parts survive history", func(t *testing.T) {
+		memory := NewConversationBufferMemory(ConversationBufferMemoryConfig{MemoryConfig: MemoryConfig{ReturnMessages: true}})
+
+		err := memory.SaveContext(ctx,
+			map[string]any{"input": llm.Message{Role: "user", Content: "What is this?", Parts: []llm.ContentPart{image}}},
+			map[string]any{"output": "A cat"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		vars, _ := memory.LoadMemoryVariables(ctx)
+		messages := vars["history"].([]ChatMessage)
+		if len(messages) != 2 {
+			t.Fatalf("expected 2 messages, got %d", len(messages))
+		}
+		if messages[0].Role != RoleHuman || messages[0].Content != "What is this?" || len(messages[0].Parts) != 1 {
+			t.Errorf("unexpected human message: %+v", messages[0])
+		}
+
+		llmMessages := ToLLMMessages(messages)
+		if llmMessages[0].Role != "user" || llmMessages[0].Parts[0].Data != image.Data {
+			t.Errorf("unexpected llm message: %+v", llmMessages[0])
+		}
+		if llmMessages[1].Role != "assistant" || llmMessages[1].Content != "A cat" {
+			t.Errorf("unexpected llm message: %+v", llmMessages[1])
+		}
+	})
+
+	t.Run("formatted history uses placeholders", func(t *testing.T) {
+		memory := NewConversationBufferMemory()
+
+		memory.SaveContext(ctx,
+			map[string]any{"input": []llm.ContentPart{llm.TextPart("Summarize"), llm.DocumentPart("q3.pdf", "application/pdf", []byte("%PDF")), image}},
+			map[string]any{"output": "Revenue grew"})
+
+		vars, _ := memory.LoadMemoryVariables(ctx)
+		history := vars["history"].(string)
+		if !contains(history, "Human: Summarize [document: q3.pdf] [image]") {
+			t.Errorf("unexpected history: %s", history)
+		}
+	})
+
+	t.Run("llm message roundtrip", func(t *testing.T) {
+		tool := llm.Message{Role: "tool", Content: "42", Name: "calc", ToolCallID: "call_1"}
+		msg := FromLLMMessage(tool)
+		if msg.Role != RoleFunction || msg.Name != "calc" {
+			t.Errorf("unexpected message: %+v", msg)
+		}
+		if back := msg.ToLLMMessage(); back.Role != "tool" || back.ToolCallID != "call_1" || back.Content != "42" {
+			t.Errorf("unexpected roundtrip: %+v", back)
+		}
+	})
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
 }
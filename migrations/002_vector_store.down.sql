@@ -0,0 +1,8 @@
+-- Rollback vector store schema
+
+-- Per-collection ANN indexes are dropped with the table
+DROP TABLE IF EXISTS vector_documents;
+DROP TABLE IF EXISTS vector_collections;
+
+-- Drop extensions (commented out to avoid affecting other databases)
+-- DROP EXTENSION IF EXISTS vector;
@@ -0,0 +1,55 @@
+-- Minion Agent Framework - Vector Store Schema
+-- Backs vectorstore/pgvector: named collections of documents with embeddings
+-- and JSONB metadata. Embedding dimensions are fixed per collection, so ANN
+-- indexes (HNSW or IVFFlat) are created per collection as partial expression
+-- indexes by the store itself (see PGVectorStore.CreateIndex).
+
+CREATE EXTENSION IF NOT EXISTS vector;
+
+-- ============================================================================
+-- VECTOR COLLECTIONS TABLE
+-- ============================================================================
+CREATE TABLE IF NOT EXISTS vector_collections (
+    name VARCHAR(255) PRIMARY KEY,
+
+    -- Embedding configuration, fixed when the collection is created
+    dimensions INTEGER NOT NULL,
+    distance_metric VARCHAR(20) NOT NULL DEFAULT 'cosine',
+
+    -- Metadata
+    metadata JSONB DEFAULT '{}'::jsonb,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT vector_collections_dimensions_check CHECK (dimensions > 0),
+    CONSTRAINT vector_collections_metric_check CHECK (distance_metric IN ('cosine', 'euclidean', 'dot_product'))
+);
+
+-- ============================================================================
+-- VECTOR DOCUMENTS TABLE
+-- ============================================================================
+CREATE TABLE IF NOT EXISTS vector_documents (
+    collection VARCHAR(255) NOT NULL REFERENCES vector_collections(name) ON DELETE CASCADE,
+    id VARCHAR(255) NOT NULL,
+
+    -- Document content
+    content TEXT NOT NULL,
+    metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+
+    -- Untyped so collections can use different dimensions
+    embedding vector NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (collection, id)
+);
+
+-- Metadata filters (containment and key existence)
+CREATE INDEX IF NOT EXISTS idx_vector_documents_metadata ON vector_documents USING GIN (metadata);
+
+COMMENT ON TABLE vector_collections IS 'Named vector store collections with their embedding dimensions and distance metric';
+COMMENT ON TABLE vector_documents IS 'Documents with embeddings and JSONB metadata for vectorstore/pgvector';
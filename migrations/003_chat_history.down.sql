@@ -0,0 +1,3 @@
+-- Rollback chat history schema
+
+DROP TABLE IF EXISTS chat_message_history;
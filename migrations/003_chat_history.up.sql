@@ -0,0 +1,24 @@
+-- Minion Agent Framework - Chat History Schema
+-- Backs memory.PostgresChatMessageHistory: conversation messages stored as
+-- JSONB rows keyed by session ID, read back in insertion order.
+
+-- ============================================================================
+-- CHAT MESSAGE HISTORY TABLE
+-- ============================================================================
+CREATE TABLE IF NOT EXISTS chat_message_history (
+    id BIGSERIAL PRIMARY KEY,
+
+    -- Conversation the message belongs to
+    session_id VARCHAR(255) NOT NULL,
+
+    -- memory.ChatMessage as JSON (role, content, parts, name, metadata)
+    message JSONB NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Loading a session reads its messages in id order
+CREATE INDEX IF NOT EXISTS chat_message_history_session_idx ON chat_message_history (session_id, id);
+
+COMMENT ON TABLE chat_message_history IS 'Conversation messages for memory.PostgresChatMessageHistory';
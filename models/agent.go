@@ -34,6 +34,10 @@ type AgentConfig struct {
 	Temperature float64 `json:"temperature"`
 	MaxTokens   int     `json:"max_tokens"`
 
+	// Tool calling configuration
+	EnableTools       bool `json:"enable_tools"`        // Let the LLM call the agent's permitted tools
+	MaxToolIterations int  `json:"max_tool_iterations"` // Maximum LLM rounds when tools are enabled (default: 10)
+
 	// Behavior configuration
 	Personality string `json:"personality"` // "professional", "friendly", "concise"
 	Language    string `json:"language"`    // "en", "es", etc.
@@ -190,12 +190,28 @@ func (t *CostTracker) CalculateCost(provider, model string, promptTokens, comple
 	return promptCost + completionCost
 }
 
-// RecordCost records a cost entry
+// RecordCost records a cost entry and the request in the global metrics.
+// Callers that record request metrics themselves, with their latency and
+// error, should use RecordCostEntry so the request is counted once.
 func (t *CostTracker) RecordCost(ctx context.Context, agentID, sessionID, provider, model string, promptTokens, completionTokens int) float64 {
 	if !t.config.Enabled {
 		return 0
 	}
 
+	cost := t.RecordCostEntry(ctx, agentID, sessionID, provider, model, promptTokens, completionTokens)
+
+	// Record to metrics
+	RecordLLMRequest(provider, model, 0, promptTokens, completionTokens, cost, nil)
+
+	return cost
+}
+
+// RecordCostEntry records a cost entry without recording request metrics
+func (t *CostTracker) RecordCostEntry(ctx context.Context, agentID, sessionID, provider, model string, promptTokens, completionTokens int) float64 {
+	if !t.config.Enabled {
+		return 0
+	}
+
 	cost := t.CalculateCost(provider, model, promptTokens, completionTokens)
 
 	record := CostRecord{
@@ -219,9 +235,6 @@ func (t *CostTracker) RecordCost(ctx context.Context, agentID, sessionID, provid
 		t.checkBudgetAlert()
 	}
 
-	// Record to metrics
-	RecordLLMRequest(provider, model, 0, promptTokens, completionTokens, cost, nil)
-
 	return cost
 }
 
@@ -238,7 +238,7 @@ func (o *Observability) ObserveLLMCall(
 	duration := time.Since(start)
 
 	// Calculate cost
-	cost := o.CostTracker.RecordCost(ctx, agentID, sessionID, provider, model, promptTokens, completionTokens)
+	cost := o.CostTracker.RecordCostEntry(ctx, agentID, sessionID, provider, model, promptTokens, completionTokens)
 
 	// Record token usage in span
 	o.Tracer.RecordLLMTokens(span, promptTokens, completionTokens, cost)
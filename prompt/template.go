@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	"github.com/Ranganaths/minion/llm"
 )
 
 // Template represents a prompt template
@@ -177,6 +179,7 @@ type ChatTemplate struct {
 	systemTemplate  *Template
 	humanTemplate   *Template
 	aiTemplate      *Template
+	partVars        []string
 	inputVars       []string
 }
 
@@ -191,6 +194,11 @@ type ChatTemplateConfig struct {
 	// AITemplate is the AI message template (optional)
 	AITemplate string
 
+	// PartVariables name variables holding images or documents to attach
+	// to the human message. Values may be an llm.ContentPart, a
+	// []llm.ContentPart or an llm.Message, whose parts are used.
+	PartVariables []string
+
 	// InputVariables are the required input variables
 	InputVariables []string
 }
@@ -239,6 +247,10 @@ func NewChatTemplate(cfg ChatTemplateConfig) (*ChatTemplate, error) {
 		}
 	}
 
+	for _, v := range cfg.PartVariables {
+		varsMap[v] = true
+	}
+
 	// Use provided input variables or extracted ones
 	inputVars := cfg.InputVariables
 	if len(inputVars) == 0 {
@@ -251,6 +263,7 @@ func NewChatTemplate(cfg ChatTemplateConfig) (*ChatTemplate, error) {
 		systemTemplate: systemTemplate,
 		humanTemplate:  humanTemplate,
 		aiTemplate:     aiTemplate,
+		partVars:       cfg.PartVariables,
 		inputVars:      inputVars,
 	}, nil
 }
@@ -259,6 +272,23 @@ func NewChatTemplate(cfg ChatTemplateConfig) (*ChatTemplate, error) {
 type ChatMessage struct {
 	Role    string
 	Content string
+
+	// Parts are images or documents attached from part variables
+	Parts []llm.ContentPart
+}
+
+// ToLLMMessage converts the message to an llm.Message
+func (m ChatMessage) ToLLMMessage() llm.Message {
+	return llm.Message{Role: m.Role, Content: m.Content, Parts: m.Parts}
+}
+
+// ToLLMMessages converts formatted messages to llm.Messages
+func ToLLMMessages(messages []ChatMessage) []llm.Message {
+	result := make([]llm.Message, len(messages))
+	for i, msg := range messages {
+		result[i] = msg.ToLLMMessage()
+	}
+	return result
 }
 
 // FormatMessages formats the chat template into messages
@@ -273,12 +303,20 @@ func (t *ChatTemplate) FormatMessages(vars map[string]any) ([]ChatMessage, error
 		messages = append(messages, ChatMessage{Role: "system", Content: content})
 	}
 
-	if t.humanTemplate != nil {
-		content, err := t.humanTemplate.Format(vars)
-		if err != nil {
-			return nil, fmt.Errorf("human template error: %w", err)
+	parts, err := t.formatParts(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.humanTemplate != nil || len(parts) > 0 {
+		var content string
+		if t.humanTemplate != nil {
+			content, err = t.humanTemplate.Format(vars)
+			if err != nil {
+				return nil, fmt.Errorf("human template error: %w", err)
+			}
 		}
-		messages = append(messages, ChatMessage{Role: "user", Content: content})
+		messages = append(messages, ChatMessage{Role: "user", Content: content, Parts: parts})
 	}
 
 	if t.aiTemplate != nil {
@@ -292,6 +330,26 @@ func (t *ChatTemplate) FormatMessages(vars map[string]any) ([]ChatMessage, error
 	return messages, nil
 }
 
+// formatParts collects the content parts held by the part variables
+func (t *ChatTemplate) formatParts(vars map[string]any) ([]llm.ContentPart, error) {
+	var parts []llm.ContentPart
+	for _, name := range t.partVars {
+		switch v := vars[name].(type) {
+		case nil:
+			return nil, fmt.Errorf("missing required variable: %s", name)
+		case llm.ContentPart:
+			parts = append(parts, v)
+		case []llm.ContentPart:
+			parts = append(parts, v...)
+		case llm.Message:
+			parts = append(parts, v.Parts...)
+		default:
+			return nil, fmt.Errorf("variable %s must hold content parts, got %T", name, v)
+		}
+	}
+	return parts, nil
+}
+
 // InputVariables returns the required input variables
 func (t *ChatTemplate) InputVariables() []string {
 	return t.inputVars
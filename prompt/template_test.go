@@ -2,6 +2,8 @@ package prompt
 
 import (
 	"testing"
+
+	"github.com/Ranganaths/minion/llm"
 )
 
 // TestTemplate tests the basic template
@@ -198,6 +200,45 @@ func TestChatTemplate(t *testing.T) {
 			t.Errorf("expected 2 input variables, got %d", len(vars))
 		}
 	})
+
+	t.Run("PartVariables", func(t *testing.T) {
+		tmpl, err := NewChatTemplate(ChatTemplateConfig{
+			SystemTemplate: "You describe images.",
+			HumanTemplate:  "{{.question}}",
+			PartVariables:  []string{"image"},
+		})
+		if err != nil {
+			t.Fatalf("failed to create chat template: %v", err)
+		}
+
+		image := llm.ImagePart("image/png", []byte("png"))
+		messages, err := tmpl.FormatMessages(map[string]any{
+			"question": "What is this?",
+			"image":    image,
+		})
+		if err != nil {
+			t.Fatalf("format failed: %v", err)
+		}
+
+		if len(messages) != 2 || messages[1].Content != "What is this?" {
+			t.Fatalf("unexpected messages: %+v", messages)
+		}
+		if len(messages[1].Parts) != 1 || messages[1].Parts[0].Data != image.Data {
+			t.Errorf("expected the image on the human message, got %+v", messages[1].Parts)
+		}
+
+		llmMessages := ToLLMMessages(messages)
+		if llmMessages[1].Role != "user" || !llmMessages[1].HasMedia() {
+			t.Errorf("unexpected llm message: %+v", llmMessages[1])
+		}
+
+		if _, err := tmpl.FormatMessages(map[string]any{"question": "What is this?"}); err == nil {
+			t.Error("expected error for missing part variable")
+		}
+		if _, err := tmpl.FormatMessages(map[string]any{"question": "What is this?", "image": "cat.png"}); err == nil {
+			t.Error("expected error for non-part value")
+		}
+	})
 }
 
 // TestFewShotTemplate tests few-shot templates
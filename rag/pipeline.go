@@ -42,6 +42,12 @@ type PipelineConfig struct {
 	// RetrieverK is the number of documents to retrieve (default: 4)
 	RetrieverK int
 
+	// Retriever replaces the default similarity retriever over VectorStore
+	// (optional). Retrievers that implement retriever.DocumentIndexer, such
+	// as BM25Retriever and EnsembleRetriever, also index every document
+	// added to the pipeline.
+	Retriever retriever.Retriever
+
 	// ReturnSources includes source documents in response
 	ReturnSources bool
 
@@ -82,18 +88,22 @@ func NewPipeline(cfg PipelineConfig) (*Pipeline, error) {
 		})
 	}
 
-	// Create retriever
-	retrieverK := cfg.RetrieverK
-	if retrieverK <= 0 {
-		retrieverK = 4
-	}
+	// Create retriever if not provided
+	ret := cfg.Retriever
+	if ret == nil {
+		retrieverK := cfg.RetrieverK
+		if retrieverK <= 0 {
+			retrieverK = 4
+		}
 
-	ret, err := retriever.NewVectorStoreRetriever(retriever.VectorStoreRetrieverConfig{
-		VectorStore: vs,
-		K:           retrieverK,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create retriever: %w", err)
+		var err error
+		ret, err = retriever.NewVectorStoreRetriever(retriever.VectorStoreRetrieverConfig{
+			VectorStore: vs,
+			K:           retrieverK,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create retriever: %w", err)
+		}
 	}
 
 	// Create RAG chain
@@ -123,9 +133,34 @@ func (p *Pipeline) AddDocuments(ctx context.Context, docs []vectorstore.Document
 	// Split documents
 	splitDocs := p.splitter.SplitDocuments(docs)
 
-	// Add to vector store
-	_, err := p.vectorStore.AddDocuments(ctx, splitDocs)
-	return err
+	return p.store(ctx, splitDocs)
+}
+
+// store adds split documents to the vector store, and to the retriever if
+// it keeps its own index. The retriever indexes them under the IDs assigned
+// by the vector store.
+func (p *Pipeline) store(ctx context.Context, docs []vectorstore.Document) error {
+	ids, err := p.vectorStore.AddDocuments(ctx, docs)
+	if err != nil {
+		return err
+	}
+
+	indexer, ok := p.retriever.(retriever.DocumentIndexer)
+	if !ok {
+		return nil
+	}
+
+	indexed := make([]vectorstore.Document, len(docs))
+	for i, doc := range docs {
+		if i < len(ids) {
+			doc = doc.WithID(ids[i])
+		}
+		indexed[i] = doc
+	}
+	if _, err := indexer.AddDocuments(ctx, indexed); err != nil {
+		return fmt.Errorf("failed to index documents: %w", err)
+	}
+	return nil
 }
 
 // AddTexts adds text content to the pipeline
@@ -149,8 +184,7 @@ func (p *Pipeline) LoadDocuments(ctx context.Context, loader documentloader.Load
 		return fmt.Errorf("failed to load documents: %w", err)
 	}
 
-	_, err = p.vectorStore.AddDocuments(ctx, docs)
-	return err
+	return p.store(ctx, docs)
 }
 
 // Query runs a RAG query
@@ -212,6 +246,11 @@ func (p *Pipeline) Clear() {
 	if memStore, ok := p.vectorStore.(*vectorstore.MemoryVectorStore); ok {
 		memStore.Clear()
 	}
+	if indexer, ok := p.retriever.(retriever.DocumentIndexer); ok {
+		// Clear has no error result; a persisted index that could not be
+		// rewritten still holds the documents until its next change
+		_ = indexer.Clear(context.Background())
+	}
 }
 
 // PipelineBuilder helps construct a pipeline with a fluent API
@@ -259,6 +298,13 @@ func (b *PipelineBuilder) WithRetrieverK(k int) *PipelineBuilder {
 	return b
 }
 
+// WithRetriever sets the retriever, replacing the default similarity
+// retriever over the vector store
+func (b *PipelineBuilder) WithRetriever(r retriever.Retriever) *PipelineBuilder {
+	b.config.Retriever = r
+	return b
+}
+
 // WithReturnSources sets whether to return source documents
 func (b *PipelineBuilder) WithReturnSources(returnSources bool) *PipelineBuilder {
 	b.config.ReturnSources = returnSources
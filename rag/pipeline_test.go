@@ -6,6 +6,7 @@ import (
 
 	"github.com/Ranganaths/minion/embeddings"
 	"github.com/Ranganaths/minion/llm"
+	"github.com/Ranganaths/minion/retriever"
 	"github.com/Ranganaths/minion/vectorstore"
 )
 
@@ -282,6 +283,64 @@ func TestPipelineBuilder(t *testing.T) {
 			t.Error("expected provided vector store to be used")
 		}
 	})
+
+	t.Run("WithHybridRetriever", func(t *testing.T) {
+		embedder := NewMockEmbedder(128)
+		llmProvider := NewMockLLMProvider("Answer")
+
+		vs, _ := vectorstore.NewMemoryVectorStore(vectorstore.MemoryVectorStoreConfig{
+			Embedder: embedder,
+		})
+		bm25, _ := retriever.NewBM25Retriever(retriever.BM25RetrieverConfig{K: 2})
+		vector, _ := retriever.NewVectorStoreRetriever(retriever.VectorStoreRetrieverConfig{VectorStore: vs, K: 2})
+		hybrid, _ := retriever.NewEnsembleRetriever(retriever.EnsembleRetrieverConfig{
+			Retrievers: []retriever.Retriever{bm25, vector},
+			Weights:    []float64{0.6, 0.4},
+		})
+
+		pipeline, err := NewPipelineBuilder().
+			WithEmbedder(embedder).
+			WithLLM(llmProvider).
+			WithVectorStore(vs).
+			WithRetriever(hybrid).
+			Build()
+		if err != nil {
+			t.Fatalf("failed to build pipeline: %v", err)
+		}
+		if pipeline.Retriever() != hybrid {
+			t.Error("expected provided retriever to be used")
+		}
+
+		ctx := context.Background()
+		err = pipeline.AddTexts(ctx, []string{
+			"Error E_DISK_FULL: free space on the data volume.",
+			"Error E_NET_DOWN: check the network cable.",
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to add texts: %v", err)
+		}
+
+		// The keyword index shares the vector store's document IDs
+		if bm25.Len() != 2 {
+			t.Errorf("expected 2 indexed documents, got %d", bm25.Len())
+		}
+
+		_, sources, err := pipeline.QueryWithSources(ctx, "What does E_NET_DOWN mean?")
+		if err != nil {
+			t.Fatalf("query failed: %v", err)
+		}
+		if len(sources) != 2 || sources[0].PageContent != "Error E_NET_DOWN: check the network cable." {
+			t.Errorf("expected the exact match first, got %+v", sources)
+		}
+		if sources[0].ID == "" {
+			t.Error("expected the fused documents to carry the vector store IDs")
+		}
+
+		pipeline.Clear()
+		if bm25.Len() != 0 {
+			t.Errorf("expected clear to empty the keyword index, got %d documents", bm25.Len())
+		}
+	})
 }
 
 // TestQueryWithSources tests query with source documents
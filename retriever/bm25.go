@@ -0,0 +1,497 @@
+package retriever
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/Ranganaths/minion/vectorstore"
+)
+
+// Tokenizer splits text into the terms that are indexed and searched
+type Tokenizer func(text string) []string
+
+// EnglishStopwords are common English words that are not indexed by default
+var EnglishStopwords = []string{
+	"a", "an", "and", "are", "as", "at", "be", "but", "by", "for", "if", "in",
+	"into", "is", "it", "no", "not", "of", "on", "or", "such", "that", "the",
+	"their", "then", "there", "these", "they", "this", "to", "was", "will", "with",
+	"what", "which", "who", "how", "do", "does",
+}
+
+// DefaultTokenizer lowercases text and splits it on whitespace and
+// punctuation. Hyphens, underscores and dots inside a term are kept, so
+// product codes such as "AB-1234", error codes such as "E_CONN_RESET" and
+// versions such as "v1.2.3" remain single terms.
+func DefaultTokenizer(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '.'
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if token := strings.Trim(field, "-_."); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// DocumentIndexer is implemented by retrievers that keep their own index of
+// documents, such as BM25Retriever. Documents stored in a vector store must
+// also be added to these retrievers to be found.
+type DocumentIndexer interface {
+	// AddDocuments indexes documents, replacing documents with the same ID
+	AddDocuments(ctx context.Context, docs []vectorstore.Document) ([]string, error)
+
+	// Delete removes documents by their IDs
+	Delete(ctx context.Context, ids []string) error
+
+	// Clear removes all documents
+	Clear(ctx context.Context) error
+}
+
+// BM25Retriever is an in-memory keyword index that ranks documents with
+// Okapi BM25. It finds exact terms such as product names, SKUs and error
+// codes that embeddings tend to miss.
+//
+// When FilePath is set, the documents are loaded from the file on creation
+// and the file is rewritten after every change. Metadata is stored as JSON,
+// so numbers are float64 after loading.
+//
+// BM25Retriever is safe for concurrent use by multiple goroutines.
+type BM25Retriever struct {
+	mu        sync.RWMutex
+	k         int
+	k1        float64
+	b         float64
+	tokenizer Tokenizer
+	stopwords map[string]bool
+	filePath  string
+
+	documents map[string]*bm25Document
+	docFreq   map[string]int
+	totalLen  int
+	idCounter int
+}
+
+// bm25Document is an indexed document with its term frequencies
+type bm25Document struct {
+	doc    vectorstore.Document
+	terms  map[string]int
+	length int
+}
+
+var (
+	_ Retriever       = (*BM25Retriever)(nil)
+	_ DocumentIndexer = (*BM25Retriever)(nil)
+)
+
+// BM25RetrieverConfig configures the BM25 retriever
+type BM25RetrieverConfig struct {
+	// K is the number of documents to retrieve (default: 4)
+	K int
+
+	// K1 controls term frequency saturation (default: 1.2)
+	K1 float64
+
+	// B controls document length normalization, from 0 to 1 (default: 0.75)
+	B float64
+
+	// DisableLengthNormalization scores documents without regard to their
+	// length, as with B set to 0
+	DisableLengthNormalization bool
+
+	// Tokenizer splits text into terms (default: DefaultTokenizer)
+	Tokenizer Tokenizer
+
+	// Stopwords are terms that are not indexed (default: EnglishStopwords)
+	Stopwords []string
+
+	// DisableStopwords indexes every term
+	DisableStopwords bool
+
+	// FilePath persists the index to a JSON file (optional)
+	FilePath string
+}
+
+// NewBM25Retriever creates a new BM25 retriever
+func NewBM25Retriever(cfg BM25RetrieverConfig) (*BM25Retriever, error) {
+	if cfg.B < 0 || cfg.B > 1 {
+		return nil, fmt.Errorf("b must be between 0 and 1, got %v", cfg.B)
+	}
+
+	r := &BM25Retriever{
+		k:         cfg.K,
+		k1:        cfg.K1,
+		b:         cfg.B,
+		tokenizer: cfg.Tokenizer,
+		stopwords: make(map[string]bool),
+		filePath:  cfg.FilePath,
+		documents: make(map[string]*bm25Document),
+		docFreq:   make(map[string]int),
+	}
+	if r.k <= 0 {
+		r.k = DefaultRetrieverConfig().K
+	}
+	if r.k1 <= 0 {
+		r.k1 = 1.2
+	}
+	if cfg.DisableLengthNormalization {
+		r.b = 0
+	} else if r.b == 0 {
+		r.b = 0.75
+	}
+	if r.tokenizer == nil {
+		r.tokenizer = DefaultTokenizer
+	}
+	if !cfg.DisableStopwords {
+		stopwords := cfg.Stopwords
+		if stopwords == nil {
+			stopwords = EnglishStopwords
+		}
+		for _, word := range stopwords {
+			r.stopwords[strings.ToLower(word)] = true
+		}
+	}
+
+	if r.filePath != "" {
+		if err := os.MkdirAll(filepath.Dir(r.filePath), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create index directory: %w", err)
+		}
+		if err := r.load(); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// terms tokenizes text and drops stopwords
+func (r *BM25Retriever) terms(text string) []string {
+	tokens := r.tokenizer(text)
+	terms := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if !r.stopwords[strings.ToLower(token)] {
+			terms = append(terms, token)
+		}
+	}
+	return terms
+}
+
+// AddDocuments indexes documents. Documents without an ID are assigned
+// one that no other document uses; documents with the ID of an indexed
+// document replace it. The index is unchanged if saving it fails.
+func (r *BM25Retriever) AddDocuments(ctx context.Context, docs []vectorstore.Document) ([]string, error) {
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	taken := make(map[string]bool, len(docs))
+	for _, doc := range docs {
+		if doc.ID != "" {
+			taken[doc.ID] = true
+		}
+	}
+
+	idCounter := r.idCounter
+	added := make([]vectorstore.Document, len(docs))
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		doc = doc.Clone()
+		doc.Embedding = nil
+		if doc.ID == "" {
+			for {
+				idCounter++
+				doc.ID = fmt.Sprintf("doc_%d", idCounter)
+				if _, ok := r.documents[doc.ID]; !ok && !taken[doc.ID] {
+					break
+				}
+			}
+		}
+		added[i] = doc
+		ids[i] = doc.ID
+	}
+
+	if r.filePath != "" {
+		// Later documents replace earlier ones with the same ID
+		replaced := make(map[string]bool, len(added))
+		latest := make(map[string]vectorstore.Document, len(added))
+		for _, doc := range added {
+			replaced[doc.ID] = true
+			latest[doc.ID] = doc
+		}
+		stored := r.storedDocuments(replaced)
+		for _, doc := range latest {
+			stored = append(stored, doc)
+		}
+		if err := r.save(idCounter, stored); err != nil {
+			return nil, err
+		}
+	}
+
+	r.idCounter = idCounter
+	for _, doc := range added {
+		r.add(doc)
+	}
+	return ids, nil
+}
+
+// add indexes doc, replacing any document with the same ID
+func (r *BM25Retriever) add(doc vectorstore.Document) {
+	r.remove(doc.ID)
+
+	terms := r.terms(doc.PageContent)
+	indexed := &bm25Document{doc: doc, terms: make(map[string]int), length: len(terms)}
+	for _, term := range terms {
+		if indexed.terms[term] == 0 {
+			r.docFreq[term]++
+		}
+		indexed.terms[term]++
+	}
+
+	r.documents[doc.ID] = indexed
+	r.totalLen += indexed.length
+}
+
+// remove drops the document with id from the index
+func (r *BM25Retriever) remove(id string) {
+	indexed, ok := r.documents[id]
+	if !ok {
+		return
+	}
+
+	for term := range indexed.terms {
+		if r.docFreq[term]--; r.docFreq[term] == 0 {
+			delete(r.docFreq, term)
+		}
+	}
+	r.totalLen -= indexed.length
+	delete(r.documents, id)
+}
+
+// Delete removes documents by their IDs. The index is unchanged if saving
+// it fails.
+func (r *BM25Retriever) Delete(ctx context.Context, ids []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.filePath != "" {
+		deleted := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			deleted[id] = true
+		}
+		if err := r.save(r.idCounter, r.storedDocuments(deleted)); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range ids {
+		r.remove(id)
+	}
+	return nil
+}
+
+// Clear removes all documents. The index is unchanged if saving it fails.
+func (r *BM25Retriever) Clear(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.filePath != "" {
+		if err := r.save(0, nil); err != nil {
+			return err
+		}
+	}
+
+	r.documents = make(map[string]*bm25Document)
+	r.docFreq = make(map[string]int)
+	r.totalLen = 0
+	r.idCounter = 0
+	return nil
+}
+
+// Len returns the number of indexed documents
+func (r *BM25Retriever) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.documents)
+}
+
+// GetRelevantDocuments retrieves the K documents with the highest BM25
+// scores for the query
+func (r *BM25Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]vectorstore.Document, error) {
+	results, err := r.SearchWithScore(ctx, query, r.K())
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]vectorstore.Document, len(results))
+	for i, result := range results {
+		docs[i] = result.Document
+	}
+	return docs, nil
+}
+
+// SearchWithScore returns up to k documents that contain a query term,
+// ordered by BM25 score
+func (r *BM25Retriever) SearchWithScore(ctx context.Context, query string, k int) ([]vectorstore.SearchResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	queryTerms := r.terms(query)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(queryTerms) == 0 || len(r.documents) == 0 {
+		return nil, nil
+	}
+
+	n := float64(len(r.documents))
+	avgLen := float64(r.totalLen) / n
+	idf := make(map[string]float64, len(queryTerms))
+	for _, term := range queryTerms {
+		if df := r.docFreq[term]; df > 0 {
+			idf[term] = math.Log(1 + (n-float64(df)+0.5)/(float64(df)+0.5))
+		}
+	}
+	if len(idf) == 0 {
+		return nil, nil
+	}
+
+	var results []vectorstore.SearchResult
+	for _, indexed := range r.documents {
+		var score float64
+		for _, term := range queryTerms {
+			tf := float64(indexed.terms[term])
+			if tf == 0 {
+				continue
+			}
+			norm := 1 - r.b
+			if avgLen > 0 {
+				norm += r.b * float64(indexed.length) / avgLen
+			}
+			score += idf[term] * tf * (r.k1 + 1) / (tf + r.k1*norm)
+		}
+		if score > 0 {
+			results = append(results, vectorstore.SearchResult{Document: indexed.doc.Clone(), Score: float32(score)})
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Document.ID < results[j].Document.ID
+	})
+	if k > 0 && len(results) > k {
+		results = results[:k]
+	}
+	return results, nil
+}
+
+// K returns the number of documents to retrieve
+func (r *BM25Retriever) K() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.k
+}
+
+// SetK updates the number of documents to retrieve
+func (r *BM25Retriever) SetK(k int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.k = k
+}
+
+// bm25File is the persisted form of the index. Term statistics are rebuilt
+// on load, so the tokenizer and stopwords may change between runs.
+type bm25File struct {
+	IDCounter int             `json:"id_counter"`
+	Documents []bm25StoredDoc `json:"documents"`
+}
+
+// bm25StoredDoc is a persisted document
+type bm25StoredDoc struct {
+	ID          string         `json:"id"`
+	PageContent string         `json:"page_content"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+}
+
+// load reads the documents stored in the index file
+func (r *BM25Retriever) load() error {
+	data, err := os.ReadFile(r.filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read index file: %w", err)
+	}
+
+	var file bm25File
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("invalid index file %s: %w", r.filePath, err)
+	}
+
+	r.idCounter = file.IDCounter
+	for _, stored := range file.Documents {
+		doc := vectorstore.NewDocumentWithMetadata(stored.PageContent, stored.Metadata).WithID(stored.ID)
+		if doc.Metadata == nil {
+			doc.Metadata = make(map[string]any)
+		}
+		r.add(doc)
+	}
+	return nil
+}
+
+// storedDocuments returns the indexed documents, except those in skip
+func (r *BM25Retriever) storedDocuments(skip map[string]bool) []vectorstore.Document {
+	docs := make([]vectorstore.Document, 0, len(r.documents))
+	for id, indexed := range r.documents {
+		if !skip[id] {
+			docs = append(docs, indexed.doc)
+		}
+	}
+	return docs
+}
+
+// save writes docs to the index file. Changes are saved before they are
+// applied to the index, so a failed save leaves the index as it was. The
+// file is replaced atomically so a crash leaves either the old or the new
+// index.
+func (r *BM25Retriever) save(idCounter int, docs []vectorstore.Document) error {
+	file := bm25File{IDCounter: idCounter, Documents: make([]bm25StoredDoc, 0, len(docs))}
+	for _, doc := range docs {
+		file.Documents = append(file.Documents, bm25StoredDoc{
+			ID:          doc.ID,
+			PageContent: doc.PageContent,
+			Metadata:    doc.Metadata,
+		})
+	}
+	sort.Slice(file.Documents, func(i, j int) bool { return file.Documents[i].ID < file.Documents[j].ID })
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("failed to marshal index: %w", err)
+	}
+
+	tmp := r.filePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write index file: %w", err)
+	}
+	if err := os.Rename(tmp, r.filePath); err != nil {
+		return fmt.Errorf("failed to replace index file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,396 @@
+package retriever
+
+import (
+	"context"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Ranganaths/minion/vectorstore"
+)
+
+// productDocs are catalog entries whose SKUs and error codes only match
+// exactly
+var productDocs = []vectorstore.Document{
+	vectorstore.NewDocumentWithMetadata("The AB-1234 router supports WiFi 6 and mesh networking.", map[string]any{"sku": "AB-1234"}).WithID("router"),
+	vectorstore.NewDocumentWithMetadata("The CD-5678 switch has 24 gigabit ports.", map[string]any{"sku": "CD-5678"}).WithID("switch"),
+	vectorstore.NewDocumentWithMetadata("Error E_CONN_RESET means the router dropped the connection. Restart the router.", map[string]any{"sku": "AB-1234"}).WithID("troubleshooting"),
+	vectorstore.NewDocumentWithMetadata("Mesh networking extends WiFi coverage across large homes.", nil).WithID("mesh"),
+}
+
+// staticRetriever returns fixed documents, or fails with err
+type staticRetriever struct {
+	docs []vectorstore.Document
+	err  error
+}
+
+func (r *staticRetriever) GetRelevantDocuments(ctx context.Context, query string) ([]vectorstore.Document, error) {
+	return r.docs, r.err
+}
+
+func newTestBM25Retriever(t *testing.T, cfg BM25RetrieverConfig) *BM25Retriever {
+	t.Helper()
+
+	r, err := NewBM25Retriever(cfg)
+	if err != nil {
+		t.Fatalf("failed to create retriever: %v", err)
+	}
+	if _, err := r.AddDocuments(context.Background(), productDocs); err != nil {
+		t.Fatalf("failed to add documents: %v", err)
+	}
+	return r
+}
+
+// documentIDs returns the IDs of docs in order
+func documentIDs(docs []vectorstore.Document) []string {
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.ID
+	}
+	return ids
+}
+
+func TestDefaultTokenizer(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{text: "Hello, World!", want: []string{"hello", "world"}},
+		{text: "Order AB-1234 failed with E_CONN_RESET.", want: []string{"order", "ab-1234", "failed", "with", "e_conn_reset"}},
+		{text: "Upgrade to v1.2.3 (or later)", want: []string{"upgrade", "to", "v1.2.3", "or", "later"}},
+		{text: " -- ", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		if got := DefaultTokenizer(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("DefaultTokenizer(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestBM25Retriever(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ExactMatches", func(t *testing.T) {
+		r := newTestBM25Retriever(t, BM25RetrieverConfig{})
+
+		docs, err := r.GetRelevantDocuments(ctx, "What is CD-5678?")
+		if err != nil {
+			t.Fatalf("retrieval failed: %v", err)
+		}
+		if got := documentIDs(docs); !reflect.DeepEqual(got, []string{"switch"}) {
+			t.Errorf("expected only the switch, got %v", got)
+		}
+
+		docs, _ = r.GetRelevantDocuments(ctx, "e_conn_reset")
+		if got := documentIDs(docs); !reflect.DeepEqual(got, []string{"troubleshooting"}) {
+			t.Errorf("expected only the troubleshooting entry, got %v", got)
+		}
+	})
+
+	t.Run("Ranking", func(t *testing.T) {
+		r := newTestBM25Retriever(t, BM25RetrieverConfig{K: 2})
+
+		// "router" appears twice in the troubleshooting entry
+		results, err := r.SearchWithScore(ctx, "router", 10)
+		if err != nil {
+			t.Fatalf("search failed: %v", err)
+		}
+		if got := documentIDs(searchDocuments(results)); !reflect.DeepEqual(got, []string{"troubleshooting", "router"}) {
+			t.Errorf("unexpected ranking: %v", got)
+		}
+		if results[0].Score <= results[1].Score {
+			t.Errorf("expected descending scores, got %v and %v", results[0].Score, results[1].Score)
+		}
+
+		docs, _ := r.GetRelevantDocuments(ctx, "mesh wifi router")
+		if len(docs) != 2 {
+			t.Errorf("expected K=2 documents, got %d", len(docs))
+		}
+	})
+
+	t.Run("Stopwords", func(t *testing.T) {
+		r := newTestBM25Retriever(t, BM25RetrieverConfig{})
+		if docs, _ := r.GetRelevantDocuments(ctx, "the and of"); len(docs) != 0 {
+			t.Errorf("expected stopwords to match nothing, got %v", documentIDs(docs))
+		}
+
+		r = newTestBM25Retriever(t, BM25RetrieverConfig{DisableStopwords: true})
+		if docs, _ := r.GetRelevantDocuments(ctx, "the"); len(docs) == 0 {
+			t.Error("expected stopwords to be indexed when disabled")
+		}
+
+		r = newTestBM25Retriever(t, BM25RetrieverConfig{Stopwords: []string{"Router"}})
+		if docs, _ := r.GetRelevantDocuments(ctx, "router"); len(docs) != 0 {
+			t.Errorf("expected custom stopwords to be dropped, got %v", documentIDs(docs))
+		}
+	})
+
+	t.Run("ReplaceAndDelete", func(t *testing.T) {
+		r := newTestBM25Retriever(t, BM25RetrieverConfig{})
+
+		if _, err := r.AddDocuments(ctx, []vectorstore.Document{vectorstore.NewDocument("The CD-9999 switch has 48 ports.").WithID("switch")}); err != nil {
+			t.Fatalf("failed to replace document: %v", err)
+		}
+		if r.Len() != len(productDocs) {
+			t.Errorf("expected the document to be replaced, got %d documents", r.Len())
+		}
+		if docs, _ := r.GetRelevantDocuments(ctx, "CD-5678"); len(docs) != 0 {
+			t.Error("expected the replaced content to be gone")
+		}
+
+		if err := r.Delete(ctx, []string{"switch"}); err != nil {
+			t.Fatalf("delete failed: %v", err)
+		}
+		if docs, _ := r.GetRelevantDocuments(ctx, "CD-9999"); len(docs) != 0 {
+			t.Error("expected the deleted document to be gone")
+		}
+
+		if err := r.Clear(ctx); err != nil || r.Len() != 0 {
+			t.Errorf("expected an empty index after clear, got %d documents (%v)", r.Len(), err)
+		}
+	})
+
+	t.Run("Persistence", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "index", "bm25.json")
+		r := newTestBM25Retriever(t, BM25RetrieverConfig{FilePath: path})
+		ids, err := r.AddDocuments(ctx, []vectorstore.Document{vectorstore.NewDocument("Firmware 2.1 fixes E_CONN_RESET.")})
+		if err != nil {
+			t.Fatalf("failed to add document: %v", err)
+		}
+		if err := r.Delete(ctx, []string{"mesh"}); err != nil {
+			t.Fatalf("delete failed: %v", err)
+		}
+
+		reopened, err := NewBM25Retriever(BM25RetrieverConfig{FilePath: path})
+		if err != nil {
+			t.Fatalf("failed to reopen index: %v", err)
+		}
+		if reopened.Len() != len(productDocs) {
+			t.Errorf("expected %d documents after reopening, got %d", len(productDocs), reopened.Len())
+		}
+
+		original, _ := r.SearchWithScore(ctx, "E_CONN_RESET router", 10)
+		loaded, _ := reopened.SearchWithScore(ctx, "E_CONN_RESET router", 10)
+		if !reflect.DeepEqual(original, loaded) {
+			t.Errorf("expected identical results after reopening:\n got %+v\nwant %+v", loaded, original)
+		}
+
+		// New IDs do not collide with persisted ones
+		added, _ := reopened.AddDocuments(ctx, []vectorstore.Document{vectorstore.NewDocument("Another note")})
+		if added[0] == ids[0] {
+			t.Errorf("expected a new ID, got %s again", added[0])
+		}
+	})
+
+	t.Run("GeneratedIDs", func(t *testing.T) {
+		r := newTestBM25Retriever(t, BM25RetrieverConfig{})
+		if _, err := r.AddDocuments(ctx, []vectorstore.Document{vectorstore.NewDocument("Caller chosen ID").WithID("doc_1")}); err != nil {
+			t.Fatalf("failed to add document: %v", err)
+		}
+
+		ids, err := r.AddDocuments(ctx, []vectorstore.Document{
+			vectorstore.NewDocument("Generated ID"),
+			vectorstore.NewDocument("Caller chosen ID in the same batch").WithID("doc_2"),
+		})
+		if err != nil {
+			t.Fatalf("failed to add documents: %v", err)
+		}
+		if want := []string{"doc_3", "doc_2"}; !reflect.DeepEqual(ids, want) {
+			t.Errorf("expected IDs %v, got %v", want, ids)
+		}
+		if docs, _ := r.GetRelevantDocuments(ctx, "caller chosen"); len(docs) != 2 {
+			t.Errorf("expected both caller chosen documents to be kept, got %v", documentIDs(docs))
+		}
+	})
+
+	t.Run("SaveFailure", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "index")
+		r := newTestBM25Retriever(t, BM25RetrieverConfig{FilePath: filepath.Join(dir, "bm25.json")})
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatalf("failed to remove index directory: %v", err)
+		}
+
+		if _, err := r.AddDocuments(ctx, []vectorstore.Document{vectorstore.NewDocument("Unsaved note")}); err == nil {
+			t.Error("expected add to fail")
+		}
+		if err := r.Delete(ctx, []string{"mesh"}); err == nil {
+			t.Error("expected delete to fail")
+		}
+		if err := r.Clear(ctx); err == nil {
+			t.Error("expected clear to fail")
+		}
+		if r.Len() != len(productDocs) {
+			t.Errorf("expected the index to be unchanged, got %d documents", r.Len())
+		}
+		if docs, _ := r.GetRelevantDocuments(ctx, "unsaved"); len(docs) != 0 {
+			t.Errorf("expected the unsaved document not to be indexed, got %v", documentIDs(docs))
+		}
+	})
+
+	t.Run("LengthNormalization", func(t *testing.T) {
+		docs := []vectorstore.Document{
+			vectorstore.NewDocument("Router").WithID("short"),
+			vectorstore.NewDocument("Router manual with setup steps and safety notes").WithID("long"),
+		}
+		scores := func(cfg BM25RetrieverConfig) map[string]float32 {
+			r, _ := NewBM25Retriever(cfg)
+			if _, err := r.AddDocuments(ctx, docs); err != nil {
+				t.Fatalf("failed to add documents: %v", err)
+			}
+			results, _ := r.SearchWithScore(ctx, "router", 2)
+			scores := make(map[string]float32, len(results))
+			for _, result := range results {
+				scores[result.Document.ID] = result.Score
+			}
+			return scores
+		}
+
+		if s := scores(BM25RetrieverConfig{}); s["short"] <= s["long"] {
+			t.Errorf("expected the shorter document to score higher by default, got %v", s)
+		}
+		if s := scores(BM25RetrieverConfig{DisableLengthNormalization: true}); s["short"] != s["long"] {
+			t.Errorf("expected equal scores without length normalization, got %v", s)
+		}
+	})
+
+	t.Run("InvalidB", func(t *testing.T) {
+		if _, err := NewBM25Retriever(BM25RetrieverConfig{B: 1.5}); err == nil {
+			t.Error("expected error for b > 1")
+		}
+	})
+}
+
+// searchDocuments returns the documents of results
+func searchDocuments(results []vectorstore.SearchResult) []vectorstore.Document {
+	docs := make([]vectorstore.Document, len(results))
+	for i, result := range results {
+		docs[i] = result.Document
+	}
+	return docs
+}
+
+func TestEnsembleRetriever(t *testing.T) {
+	ctx := context.Background()
+	a := vectorstore.NewDocument("a").WithID("a")
+	b := vectorstore.NewDocument("b").WithID("b")
+	c := vectorstore.NewDocument("c").WithID("c")
+	d := vectorstore.NewDocument("d").WithID("d")
+
+	t.Run("ReciprocalRankFusion", func(t *testing.T) {
+		r, err := NewEnsembleRetriever(EnsembleRetrieverConfig{
+			Retrievers: []Retriever{
+				&staticRetriever{docs: []vectorstore.Document{a, b, c}},
+				&staticRetriever{docs: []vectorstore.Document{c, d, a}},
+			},
+			Weights: []float64{0.5, 0.5},
+			K:       10,
+		})
+		if err != nil {
+			t.Fatalf("failed to create retriever: %v", err)
+		}
+
+		results, err := r.GetRelevantDocumentsWithScore(ctx, "query")
+		if err != nil {
+			t.Fatalf("retrieval failed: %v", err)
+		}
+
+		// a: 0.5/61 + 0.5/63, c: 0.5/63 + 0.5/61, b: 0.5/62, d: 0.5/62
+		if got := documentIDs(searchDocuments(results)); !reflect.DeepEqual(got, []string{"a", "c", "b", "d"}) {
+			t.Errorf("unexpected fused ranking: %v", got)
+		}
+		if want := 0.5/61 + 0.5/63; math.Abs(float64(results[0].Score)-want) > 1e-6 {
+			t.Errorf("expected score %v, got %v", want, results[0].Score)
+		}
+	})
+
+	t.Run("Weights", func(t *testing.T) {
+		r, _ := NewEnsembleRetriever(EnsembleRetrieverConfig{
+			Retrievers: []Retriever{
+				&staticRetriever{docs: []vectorstore.Document{a, b}},
+				&staticRetriever{docs: []vectorstore.Document{b, a}},
+			},
+			Weights: []float64{0.2, 0.8},
+			K:       1,
+		})
+
+		docs, _ := r.GetRelevantDocuments(ctx, "query")
+		if got := documentIDs(docs); !reflect.DeepEqual(got, []string{"b"}) {
+			t.Errorf("expected the heavier retriever to win, got %v", got)
+		}
+	})
+
+	t.Run("IDKey", func(t *testing.T) {
+		first := vectorstore.NewDocumentWithMetadata("chunk one", map[string]any{"chunk": 1})
+		second := vectorstore.NewDocumentWithMetadata("chunk one, reformatted", map[string]any{"chunk": 1})
+
+		r, _ := NewEnsembleRetriever(EnsembleRetrieverConfig{
+			Retrievers: []Retriever{
+				&staticRetriever{docs: []vectorstore.Document{first}},
+				&staticRetriever{docs: []vectorstore.Document{second}},
+			},
+			IDKey: "chunk",
+		})
+
+		docs, _ := r.GetRelevantDocuments(ctx, "query")
+		if len(docs) != 1 || docs[0].PageContent != "chunk one" {
+			t.Errorf("expected the documents to be merged, got %+v", docs)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		boom := errors.New("boom")
+		r, _ := NewEnsembleRetriever(EnsembleRetrieverConfig{
+			Retrievers: []Retriever{&staticRetriever{docs: []vectorstore.Document{a}}, &staticRetriever{err: boom}},
+		})
+		if _, err := r.GetRelevantDocuments(ctx, "query"); !errors.Is(err, boom) {
+			t.Errorf("expected the retriever error, got %v", err)
+		}
+	})
+
+	t.Run("InvalidConfig", func(t *testing.T) {
+		if _, err := NewEnsembleRetriever(EnsembleRetrieverConfig{}); err == nil {
+			t.Error("expected error for no retrievers")
+		}
+		if _, err := NewEnsembleRetriever(EnsembleRetrieverConfig{
+			Retrievers: []Retriever{&staticRetriever{}},
+			Weights:    []float64{0.5, 0.5},
+		}); err == nil {
+			t.Error("expected error for mismatched weights")
+		}
+	})
+
+	t.Run("HybridSearch", func(t *testing.T) {
+		vs := setupTestVectorStore(t)
+		bm25, _ := NewBM25Retriever(BM25RetrieverConfig{})
+		vector, _ := NewVectorStoreRetriever(VectorStoreRetrieverConfig{VectorStore: vs, K: 3})
+
+		r, err := NewEnsembleRetriever(EnsembleRetrieverConfig{Retrievers: []Retriever{bm25, vector}})
+		if err != nil {
+			t.Fatalf("failed to create retriever: %v", err)
+		}
+
+		// Documents are indexed by the keyword retriever only
+		sku := vectorstore.NewDocumentWithMetadata("Replacement part XK-42 fits every model.", map[string]any{"source": "parts.txt"})
+		if _, err := vs.AddDocuments(ctx, []vectorstore.Document{sku}); err != nil {
+			t.Fatalf("failed to add document: %v", err)
+		}
+		if _, err := r.AddDocuments(ctx, []vectorstore.Document{sku}); err != nil {
+			t.Fatalf("failed to index document: %v", err)
+		}
+		if bm25.Len() != 1 {
+			t.Errorf("expected the keyword index to hold 1 document, got %d", bm25.Len())
+		}
+
+		docs, err := r.GetRelevantDocuments(ctx, "xk-42")
+		if err != nil {
+			t.Fatalf("retrieval failed: %v", err)
+		}
+		if len(docs) == 0 || docs[0].PageContent != sku.PageContent {
+			t.Errorf("expected the exact match first, got %+v", docs)
+		}
+	})
+}
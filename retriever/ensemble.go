@@ -0,0 +1,245 @@
+package retriever
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Ranganaths/minion/vectorstore"
+)
+
+// DefaultRRFConstant is the rank constant of reciprocal rank fusion. It
+// damps the advantage of the top ranks over the ones just below them.
+const DefaultRRFConstant = 60
+
+// EnsembleRetriever combines the results of several retrievers, such as a
+// BM25Retriever for exact keyword matches and a VectorStoreRetriever for
+// semantic matches, with weighted reciprocal rank fusion. A document scores
+// the sum of weight / (c + rank) over the result lists it appears in, so
+// documents ranked high by several retrievers come first.
+type EnsembleRetriever struct {
+	retrievers []Retriever
+	weights    []float64
+	k          int
+	c          int
+	idKey      string
+}
+
+var (
+	_ Retriever       = (*EnsembleRetriever)(nil)
+	_ DocumentIndexer = (*EnsembleRetriever)(nil)
+)
+
+// EnsembleRetrieverConfig configures the ensemble retriever
+type EnsembleRetrieverConfig struct {
+	// Retrievers are the retrievers whose results are fused
+	Retrievers []Retriever
+
+	// Weights are the weights of the retrievers, in the same order
+	// (default: equal weights)
+	Weights []float64
+
+	// K is the number of documents to retrieve (default: 4)
+	K int
+
+	// RankConstant is the constant c of reciprocal rank fusion (default: 60)
+	RankConstant int
+
+	// IDKey is the metadata field that identifies a document across result
+	// lists (default: documents with the same PageContent are the same)
+	IDKey string
+}
+
+// NewEnsembleRetriever creates a new ensemble retriever
+func NewEnsembleRetriever(cfg EnsembleRetrieverConfig) (*EnsembleRetriever, error) {
+	if len(cfg.Retrievers) == 0 {
+		return nil, fmt.Errorf("at least one retriever is required")
+	}
+	for i, r := range cfg.Retrievers {
+		if r == nil {
+			return nil, fmt.Errorf("retriever %d is nil", i)
+		}
+	}
+
+	weights := cfg.Weights
+	if weights == nil {
+		weights = make([]float64, len(cfg.Retrievers))
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+	if len(weights) != len(cfg.Retrievers) {
+		return nil, fmt.Errorf("expected %d weights, got %d", len(cfg.Retrievers), len(weights))
+	}
+	for i, w := range weights {
+		if w < 0 {
+			return nil, fmt.Errorf("weight %d must not be negative, got %v", i, w)
+		}
+	}
+
+	k := cfg.K
+	if k <= 0 {
+		k = DefaultRetrieverConfig().K
+	}
+	c := cfg.RankConstant
+	if c <= 0 {
+		c = DefaultRRFConstant
+	}
+
+	return &EnsembleRetriever{
+		retrievers: cfg.Retrievers,
+		weights:    weights,
+		k:          k,
+		c:          c,
+		idKey:      cfg.IDKey,
+	}, nil
+}
+
+// GetRelevantDocuments retrieves the K documents with the highest fused
+// scores
+func (r *EnsembleRetriever) GetRelevantDocuments(ctx context.Context, query string) ([]vectorstore.Document, error) {
+	results, err := r.GetRelevantDocumentsWithScore(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]vectorstore.Document, len(results))
+	for i, result := range results {
+		docs[i] = result.Document
+	}
+	return docs, nil
+}
+
+// GetRelevantDocumentsWithScore queries every retriever concurrently and
+// returns the K documents with the highest fused scores
+func (r *EnsembleRetriever) GetRelevantDocumentsWithScore(ctx context.Context, query string) ([]vectorstore.SearchResult, error) {
+	rankings := make([][]vectorstore.Document, len(r.retrievers))
+	errs := make([]error, len(r.retrievers))
+
+	var wg sync.WaitGroup
+	for i, ret := range r.retrievers {
+		wg.Add(1)
+		go func(i int, ret Retriever) {
+			defer wg.Done()
+			docs, err := ret.GetRelevantDocuments(ctx, query)
+			if err != nil {
+				errs[i] = fmt.Errorf("retriever %d failed: %w", i, err)
+				return
+			}
+			rankings[i] = docs
+		}(i, ret)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	results := r.fuse(rankings)
+	if len(results) > r.k {
+		results = results[:r.k]
+	}
+	return results, nil
+}
+
+// fuse merges rankings with weighted reciprocal rank fusion. Ties keep the
+// order in which documents were first seen.
+func (r *EnsembleRetriever) fuse(rankings [][]vectorstore.Document) []vectorstore.SearchResult {
+	index := make(map[string]int)
+	var results []vectorstore.SearchResult
+	var scores []float64
+
+	for i, docs := range rankings {
+		seen := make(map[string]bool, len(docs))
+		for rank, doc := range docs {
+			key := r.key(doc)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			j, ok := index[key]
+			if !ok {
+				j = len(results)
+				index[key] = j
+				results = append(results, vectorstore.SearchResult{Document: doc})
+				scores = append(scores, 0)
+			}
+			scores[j] += r.weights[i] / float64(r.c+rank+1)
+		}
+	}
+
+	for i := range results {
+		results[i].Score = float32(scores[i])
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+// key identifies doc across result lists
+func (r *EnsembleRetriever) key(doc vectorstore.Document) string {
+	if r.idKey != "" {
+		if id, ok := doc.GetMetadata(r.idKey); ok {
+			return fmt.Sprint(id)
+		}
+	}
+	return doc.PageContent
+}
+
+// AddDocuments adds documents to the retrievers that keep their own index.
+// The IDs are those assigned by the first such retriever.
+func (r *EnsembleRetriever) AddDocuments(ctx context.Context, docs []vectorstore.Document) ([]string, error) {
+	var ids []string
+	for _, ret := range r.retrievers {
+		indexer, ok := ret.(DocumentIndexer)
+		if !ok {
+			continue
+		}
+		added, err := indexer.AddDocuments(ctx, docs)
+		if err != nil {
+			return nil, err
+		}
+		if ids == nil {
+			ids = added
+		}
+	}
+	return ids, nil
+}
+
+// Delete removes documents from the retrievers that keep their own index
+func (r *EnsembleRetriever) Delete(ctx context.Context, ids []string) error {
+	for _, ret := range r.retrievers {
+		if indexer, ok := ret.(DocumentIndexer); ok {
+			if err := indexer.Delete(ctx, ids); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Clear removes all documents from the retrievers that keep their own index
+func (r *EnsembleRetriever) Clear(ctx context.Context) error {
+	for _, ret := range r.retrievers {
+		if indexer, ok := ret.(DocumentIndexer); ok {
+			if err := indexer.Clear(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Retrievers returns the fused retrievers
+func (r *EnsembleRetriever) Retrievers() []Retriever {
+	return r.retrievers
+}
+
+// Weights returns the weights of the retrievers
+func (r *EnsembleRetriever) Weights() []float64 {
+	return r.weights
+}
@@ -0,0 +1,480 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Ranganaths/minion/core"
+	"github.com/Ranganaths/minion/vectorstore/pgvector"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// PostgresMemoryConfig configures a PostgresMemoryManager
+type PostgresMemoryConfig struct {
+	// Consolidate prunes memories accessed fewer than MinAccessCount times
+	// that have not been accessed (or, if never accessed, created) within
+	// PruneAfter
+	MinAccessCount int
+	PruneAfter     time.Duration
+
+	// MergeThreshold is the cosine similarity at or above which Consolidate
+	// merges two memories into the more recently updated one. Zero disables
+	// merging.
+	MergeThreshold float64
+}
+
+// DefaultPostgresMemoryConfig returns the consolidation settings used by
+// core.InMemoryMemoryManager, with merging of near-identical memories
+func DefaultPostgresMemoryConfig() *PostgresMemoryConfig {
+	return &PostgresMemoryConfig{
+		MinAccessCount: 3,
+		PruneAfter:     90 * 24 * time.Hour,
+		MergeThreshold: 0.95,
+	}
+}
+
+// PostgresMemoryManager implements core.MemoryManager using the memories
+// table from migrations/001_initial_schema.up.sql. Embeddings are stored in
+// a pgvector column and searched by cosine distance, so they must match the
+// column's dimension (1536). Memory and agent IDs must be UUIDs.
+type PostgresMemoryManager struct {
+	db     *sql.DB
+	config *PostgresMemoryConfig
+}
+
+var _ core.MemoryManager = (*PostgresMemoryManager)(nil)
+
+// NewPostgresMemoryManager creates a memory manager from an existing
+// database connection. A nil config uses DefaultPostgresMemoryConfig.
+func NewPostgresMemoryManager(db *sql.DB, config *PostgresMemoryConfig) *PostgresMemoryManager {
+	if config == nil {
+		config = DefaultPostgresMemoryConfig()
+	}
+	return &PostgresMemoryManager{db: db, config: config}
+}
+
+const memoryColumns = `id, agent_id, COALESCE(user_id, ''), key, value, type, COALESCE(source, ''),
+	embedding::text, metadata, COALESCE(access_count, 0), last_accessed, created_at, updated_at`
+
+// Store creates or updates a memory. A memory with the same agent, user and
+// key as an existing one updates it in place, and memory.ID is set to the
+// stored row's ID.
+func (m *PostgresMemoryManager) Store(ctx context.Context, memory *core.Memory) error {
+	if memory.Type == "" {
+		memory.Type = core.MemoryTypeFact
+	}
+
+	metadataJSON, err := json.Marshal(memory.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal memory metadata: %w", err)
+	}
+	if memory.Metadata == nil {
+		metadataJSON = []byte("{}")
+	}
+
+	var embedding sql.NullString
+	if len(memory.Embedding) > 0 {
+		embedding = sql.NullString{String: pgvector.EncodeVector(memory.Embedding), Valid: true}
+	}
+
+	now := time.Now()
+
+	// An existing memory is updated by ID first, so its key can change
+	if memory.ID != "" {
+		if _, err := uuid.Parse(memory.ID); err != nil {
+			return fmt.Errorf("invalid memory ID: %s", memory.ID)
+		}
+
+		query := `
+			UPDATE memories
+			SET agent_id = $2, user_id = $3, key = $4, value = $5, type = $6, source = NULLIF($7, ''),
+				embedding = $8::vector, metadata = $9, updated_at = $10
+			WHERE id = $1
+			RETURNING created_at
+		`
+		err := m.db.QueryRowContext(ctx, query,
+			memory.ID, memory.AgentID, memory.UserID, memory.Key, memory.Value, memory.Type, memory.Source,
+			embedding, metadataJSON, now,
+		).Scan(&memory.CreatedAt)
+		if err == nil {
+			memory.UpdatedAt = now
+			return nil
+		}
+		if err != sql.ErrNoRows {
+			return fmt.Errorf("failed to update memory: %w", err)
+		}
+	} else {
+		memory.ID = uuid.New().String()
+	}
+
+	// user_id is stored as '' rather than NULL for memories without a user,
+	// so the (agent_id, user_id, key) constraint also applies to them
+	query := `
+		INSERT INTO memories (id, agent_id, user_id, key, value, type, source, embedding, metadata, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8::vector, $9, $10, $10)
+		ON CONFLICT (agent_id, user_id, key) DO UPDATE
+		SET value = EXCLUDED.value, embedding = EXCLUDED.embedding, metadata = EXCLUDED.metadata,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at, updated_at
+	`
+	err = m.db.QueryRowContext(ctx, query,
+		memory.ID, memory.AgentID, memory.UserID, memory.Key, memory.Value, memory.Type, memory.Source,
+		embedding, metadataJSON, now,
+	).Scan(&memory.ID, &memory.CreatedAt, &memory.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to store memory: %w", err)
+	}
+
+	return nil
+}
+
+// Get retrieves a memory by ID and records the access
+func (m *PostgresMemoryManager) Get(ctx context.Context, memoryID string) (*core.Memory, error) {
+	if _, err := uuid.Parse(memoryID); err != nil {
+		return nil, fmt.Errorf("memory not found: %s", memoryID)
+	}
+
+	query := `
+		UPDATE memories
+		SET access_count = COALESCE(access_count, 0) + 1, last_accessed = $2
+		WHERE id = $1
+		RETURNING ` + memoryColumns
+	memory, err := scanMemory(m.db.QueryRowContext(ctx, query, memoryID, time.Now()))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("memory not found: %s", memoryID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return memory, nil
+}
+
+// GetByKey retrieves a memory by key
+func (m *PostgresMemoryManager) GetByKey(ctx context.Context, agentID, userID, key string) (*core.Memory, error) {
+	if _, err := uuid.Parse(agentID); err != nil {
+		return nil, fmt.Errorf("memory not found for key: %s", key)
+	}
+
+	query := `SELECT ` + memoryColumns + ` FROM memories
+		WHERE agent_id = $1 AND COALESCE(user_id, '') = $2 AND key = $3`
+	memory, err := scanMemory(m.db.QueryRowContext(ctx, query, agentID, userID, key))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("memory not found for key: %s", key)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return memory, nil
+}
+
+// Search finds relevant memories. With an embedding, memories are ranked by
+// cosine similarity and those scoring below MinScore or without an embedding
+// are excluded. Otherwise Query is matched case-insensitively against keys
+// and values, and results are ranked by access count.
+func (m *PostgresMemoryManager) Search(ctx context.Context, filters core.MemorySearchFilters) ([]*core.Memory, error) {
+	conditions, args, ok := memoryConditions(filters.AgentID, filters.UserID, filters.Type)
+	if !ok {
+		return []*core.Memory{}, nil
+	}
+
+	orderBy := "access_count DESC, updated_at DESC"
+	if len(filters.Embedding) > 0 {
+		args = append(args, pgvector.EncodeVector(filters.Embedding))
+		distance := fmt.Sprintf("(embedding <=> $%d::vector)", len(args))
+		conditions = append(conditions, "embedding IS NOT NULL")
+		if filters.MinScore > 0 {
+			args = append(args, filters.MinScore)
+			conditions = append(conditions, fmt.Sprintf("1 - %s >= $%d", distance, len(args)))
+		}
+		orderBy = distance
+	} else if filters.Query != "" {
+		args = append(args, likePattern(filters.Query))
+		conditions = append(conditions, fmt.Sprintf("(key ILIKE $%d OR value ILIKE $%d)", len(args), len(args)))
+	}
+
+	query := `SELECT ` + memoryColumns + ` FROM memories`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY " + orderBy
+	if filters.Limit > 0 {
+		args = append(args, filters.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	return m.query(ctx, query, args...)
+}
+
+// Delete removes a memory
+func (m *PostgresMemoryManager) Delete(ctx context.Context, memoryID string) error {
+	if _, err := uuid.Parse(memoryID); err != nil {
+		return nil
+	}
+
+	if _, err := m.db.ExecContext(ctx, `DELETE FROM memories WHERE id = $1`, memoryID); err != nil {
+		return fmt.Errorf("failed to delete memory: %w", err)
+	}
+	return nil
+}
+
+// ExtractFromSession extracts important information from a session and stores as memory
+func (m *PostgresMemoryManager) ExtractFromSession(ctx context.Context, session *core.Session, extractor core.MemoryExtractor) error {
+	memories, err := extractor.Extract(ctx, session)
+	if err != nil {
+		return fmt.Errorf("failed to extract memories: %w", err)
+	}
+
+	for _, memory := range memories {
+		memory.Source = session.ID
+		if err := m.Store(ctx, memory); err != nil {
+			return fmt.Errorf("failed to store memory: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Consolidate merges near-identical memories and prunes low-value ones for
+// an agent and user. Keys are already unique per agent and user, so merging
+// is by embedding similarity: the more recently updated memory is kept and
+// inherits the other's access count.
+func (m *PostgresMemoryManager) Consolidate(ctx context.Context, agentID, userID string) error {
+	if _, err := uuid.Parse(agentID); err != nil {
+		return nil
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if m.config.MergeThreshold > 0 {
+		if err := m.mergeSimilar(ctx, tx, agentID, userID); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		DELETE FROM memories
+		WHERE agent_id = $1 AND COALESCE(user_id, '') = $2
+			AND COALESCE(access_count, 0) < $3
+			AND COALESCE(last_accessed, created_at) < $4
+	`
+	pruneThreshold := time.Now().Add(-m.config.PruneAfter)
+	if _, err := tx.ExecContext(ctx, query, agentID, userID, m.config.MinAccessCount, pruneThreshold); err != nil {
+		return fmt.Errorf("failed to prune memories: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit consolidation: %w", err)
+	}
+
+	return nil
+}
+
+// mergeSimilar folds each memory into the most recently updated memory it is
+// similar to
+func (m *PostgresMemoryManager) mergeSimilar(ctx context.Context, tx *sql.Tx, agentID, userID string) error {
+	query := `
+		SELECT newer.id, older.id, COALESCE(older.access_count, 0)
+		FROM memories newer
+		JOIN memories older
+			ON older.agent_id = newer.agent_id
+			AND COALESCE(older.user_id, '') = COALESCE(newer.user_id, '')
+			AND (older.updated_at, older.id) < (newer.updated_at, newer.id)
+		WHERE newer.agent_id = $1 AND COALESCE(newer.user_id, '') = $2
+			AND newer.embedding IS NOT NULL AND older.embedding IS NOT NULL
+			AND 1 - (newer.embedding <=> older.embedding) >= $3
+		ORDER BY newer.updated_at DESC, newer.id DESC
+	`
+	rows, err := tx.QueryContext(ctx, query, agentID, userID, m.config.MergeThreshold)
+	if err != nil {
+		return fmt.Errorf("failed to find similar memories: %w", err)
+	}
+	defer rows.Close()
+
+	merged := make(map[string]bool)
+	inherited := make(map[string]int)
+	var order []string
+	for rows.Next() {
+		var keeperID, duplicateID string
+		var accessCount int
+		if err := rows.Scan(&keeperID, &duplicateID, &accessCount); err != nil {
+			return fmt.Errorf("failed to scan similar memories: %w", err)
+		}
+		if merged[keeperID] || merged[duplicateID] {
+			continue
+		}
+		merged[duplicateID] = true
+		if _, exists := inherited[keeperID]; !exists {
+			order = append(order, keeperID)
+		}
+		inherited[keeperID] += accessCount
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating similar memories: %w", err)
+	}
+	rows.Close()
+
+	if len(merged) == 0 {
+		return nil
+	}
+
+	for _, keeperID := range order {
+		_, err := tx.ExecContext(ctx,
+			`UPDATE memories SET access_count = COALESCE(access_count, 0) + $2 WHERE id = $1`,
+			keeperID, inherited[keeperID],
+		)
+		if err != nil {
+			return fmt.Errorf("failed to merge memory: %w", err)
+		}
+	}
+
+	duplicates := make([]string, 0, len(merged))
+	for id := range merged {
+		duplicates = append(duplicates, id)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM memories WHERE id = ANY($1::uuid[])`, pq.Array(duplicates)); err != nil {
+		return fmt.Errorf("failed to delete merged memories: %w", err)
+	}
+
+	return nil
+}
+
+// List lists memories with filters
+func (m *PostgresMemoryManager) List(ctx context.Context, filters core.MemoryListFilters) ([]*core.Memory, error) {
+	conditions, args, ok := memoryConditions(filters.AgentID, filters.UserID, filters.Type)
+	if !ok {
+		return []*core.Memory{}, nil
+	}
+
+	sortBy := "updated_at"
+	switch filters.SortBy {
+	case "created_at", "updated_at", "access_count":
+		sortBy = filters.SortBy
+	}
+	order := "DESC"
+	if filters.Order == "asc" {
+		order = "ASC"
+	}
+
+	query := `SELECT ` + memoryColumns + ` FROM memories`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, id", sortBy, order)
+	if filters.Limit > 0 {
+		args = append(args, filters.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filters.Offset > 0 {
+		args = append(args, filters.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	return m.query(ctx, query, args...)
+}
+
+// query runs a query selecting memoryColumns
+func (m *PostgresMemoryManager) query(ctx context.Context, query string, args ...interface{}) ([]*core.Memory, error) {
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query memories: %w", err)
+	}
+	defer rows.Close()
+
+	memories := []*core.Memory{}
+	for rows.Next() {
+		memory, err := scanMemory(rows)
+		if err != nil {
+			return nil, err
+		}
+		memories = append(memories, memory)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating memories: %w", err)
+	}
+
+	return memories, nil
+}
+
+// memoryConditions builds the WHERE conditions shared by Search and List.
+// It reports false when agentID is not a UUID and so cannot match.
+func memoryConditions(agentID, userID string, memoryType core.MemoryType) ([]string, []interface{}, bool) {
+	var conditions []string
+	var args []interface{}
+
+	if agentID != "" {
+		if _, err := uuid.Parse(agentID); err != nil {
+			return nil, nil, false
+		}
+		args = append(args, agentID)
+		conditions = append(conditions, fmt.Sprintf("agent_id = $%d", len(args)))
+	}
+	if userID != "" {
+		args = append(args, userID)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+	if memoryType != "" {
+		args = append(args, memoryType)
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+	}
+
+	return conditions, args, true
+}
+
+// scanMemory scans a row selected with memoryColumns
+func scanMemory(row rowScanner) (*core.Memory, error) {
+	var memory core.Memory
+	var embedding sql.NullString
+	var metadataJSON []byte
+	var lastAccessed sql.NullTime
+
+	err := row.Scan(
+		&memory.ID, &memory.AgentID, &memory.UserID, &memory.Key, &memory.Value, &memory.Type, &memory.Source,
+		&embedding, &metadataJSON, &memory.AccessCount, &lastAccessed, &memory.CreatedAt, &memory.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan memory: %w", err)
+	}
+
+	if embedding.Valid {
+		memory.Embedding, err = pgvector.ParseVector(embedding.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse memory embedding: %w", err)
+		}
+	}
+
+	if len(metadataJSON) > 0 {
+		if err := json.Unmarshal(metadataJSON, &memory.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal memory metadata: %w", err)
+		}
+	}
+	if memory.Metadata == nil {
+		memory.Metadata = make(map[string]interface{})
+	}
+
+	if lastAccessed.Valid {
+		memory.LastAccessed = lastAccessed.Time
+	}
+
+	return &memory, nil
+}
+
+// likePattern escapes query for use in an ILIKE substring match
+func likePattern(query string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + replacer.Replace(query) + "%"
+}
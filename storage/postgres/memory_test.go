@@ -0,0 +1,264 @@
+package postgres
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ranganaths/minion/core"
+)
+
+// embedding returns a 1536-dimensional embedding, matching the memories
+// table, with the given leading values
+func embedding(values ...float32) []float32 {
+	v := make([]float32, 1536)
+	copy(v, values)
+	return v
+}
+
+func TestLikePattern(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"coffee", "%coffee%"},
+		{"100%", `%100\%%`},
+		{"snake_case", `%snake\_case%`},
+		{`C:\temp`, `%C:\\temp%`},
+	}
+	for _, tt := range tests {
+		if got := likePattern(tt.query); got != tt.want {
+			t.Errorf("likePattern(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestPostgresMemoryManager_Integration(t *testing.T) {
+	db := newTestDB(t)
+	agentID := newTestAgent(t, db)
+	manager := NewPostgresMemoryManager(db, nil)
+	ctx := context.Background()
+
+	memories := []*core.Memory{
+		{AgentID: agentID, UserID: "user-1", Key: "drink", Value: "Prefers coffee", Type: core.MemoryTypePreference, Embedding: embedding(1, 0)},
+		{AgentID: agentID, UserID: "user-1", Key: "city", Value: "Lives in Lisbon", Embedding: embedding(0, 1)},
+		{AgentID: agentID, UserID: "user-1", Key: "pet", Value: "Has a cat named 100%"},
+	}
+	for _, memory := range memories {
+		if err := manager.Store(ctx, memory); err != nil {
+			t.Fatalf("Store failed: %v", err)
+		}
+	}
+
+	// Storing the same key updates the existing memory
+	update := &core.Memory{AgentID: agentID, UserID: "user-1", Key: "drink", Value: "Prefers tea", Embedding: embedding(1, 0.1)}
+	if err := manager.Store(ctx, update); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if update.ID != memories[0].ID {
+		t.Errorf("expected upsert to keep ID %s, got %s", memories[0].ID, update.ID)
+	}
+
+	got, err := manager.GetByKey(ctx, agentID, "user-1", "drink")
+	if err != nil {
+		t.Fatalf("GetByKey failed: %v", err)
+	}
+	if got.Value != "Prefers tea" || got.Type != core.MemoryTypePreference || len(got.Embedding) != 1536 {
+		t.Errorf("unexpected memory %+v", got)
+	}
+
+	got, err = manager.Get(ctx, memories[1].ID)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.AccessCount != 1 || got.LastAccessed.IsZero() {
+		t.Errorf("expected Get to record access, got count %d", got.AccessCount)
+	}
+
+	tests := []struct {
+		name    string
+		filters core.MemorySearchFilters
+		want    []string
+	}{
+		{
+			name:    "embedding",
+			filters: core.MemorySearchFilters{AgentID: agentID, Embedding: embedding(1, 0)},
+			want:    []string{"drink", "city"},
+		},
+		{
+			name:    "embedding with min score",
+			filters: core.MemorySearchFilters{AgentID: agentID, Embedding: embedding(0, 1), MinScore: 0.9},
+			want:    []string{"city"},
+		},
+		{
+			name:    "keyword",
+			filters: core.MemorySearchFilters{AgentID: agentID, Query: "LISBON"},
+			want:    []string{"city"},
+		},
+		{
+			name:    "keyword with wildcard characters",
+			filters: core.MemorySearchFilters{AgentID: agentID, Query: "100%"},
+			want:    []string{"pet"},
+		},
+		{
+			name:    "type",
+			filters: core.MemorySearchFilters{AgentID: agentID, Type: core.MemoryTypePreference},
+			want:    []string{"drink"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := manager.Search(ctx, tt.filters)
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			var keys []string
+			for _, memory := range results {
+				keys = append(keys, memory.Key)
+			}
+			if !reflect.DeepEqual(keys, tt.want) {
+				t.Errorf("Search() = %v, want %v", keys, tt.want)
+			}
+		})
+	}
+
+	listed, err := manager.List(ctx, core.MemoryListFilters{AgentID: agentID, SortBy: "access_count", Limit: 1})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(listed) != 1 || listed[0].Key != "city" {
+		t.Errorf("expected most accessed memory first, got %v", listed)
+	}
+
+	if err := manager.Delete(ctx, memories[2].ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := manager.Get(ctx, memories[2].ID); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestPostgresMemoryManager_ExtractFromSession(t *testing.T) {
+	db := newTestDB(t)
+	agentID := newTestAgent(t, db)
+	sessions := NewPostgresSessionManager(db)
+	manager := NewPostgresMemoryManager(db, nil)
+	ctx := context.Background()
+
+	session, err := sessions.Create(ctx, agentID, "user-1", time.Hour)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := sessions.Append(ctx, session.ID, core.Message{Role: core.MessageRoleUser, Content: "I prefer short answers"}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	session, err = sessions.Get(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	if err := manager.ExtractFromSession(ctx, session, &core.SimpleMemoryExtractor{AgentID: agentID}); err != nil {
+		t.Fatalf("ExtractFromSession failed: %v", err)
+	}
+
+	memories, err := manager.List(ctx, core.MemoryListFilters{AgentID: agentID})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(memories) == 0 {
+		t.Fatal("expected memories to be extracted")
+	}
+	for _, memory := range memories {
+		if memory.Source != session.ID {
+			t.Errorf("expected source %s, got %s", session.ID, memory.Source)
+		}
+	}
+}
+
+func TestPostgresMemoryManager_Consolidate(t *testing.T) {
+	db := newTestDB(t)
+	agentID := newTestAgent(t, db)
+	manager := NewPostgresMemoryManager(db, nil)
+	ctx := context.Background()
+
+	store := func(key string, embedding []float32) *core.Memory {
+		t.Helper()
+		memory := &core.Memory{AgentID: agentID, UserID: "user-1", Key: key, Value: key, Embedding: embedding}
+		if err := manager.Store(ctx, memory); err != nil {
+			t.Fatalf("Store failed: %v", err)
+		}
+		return memory
+	}
+	age := func(memory *core.Memory, accessCount int, lastAccessed time.Time) {
+		t.Helper()
+		_, err := db.Exec(`UPDATE memories SET access_count = $2, last_accessed = $3, created_at = $3 WHERE id = $1`,
+			memory.ID, accessCount, lastAccessed)
+		if err != nil {
+			t.Fatalf("failed to age memory: %v", err)
+		}
+	}
+
+	old := time.Now().Add(-100 * 24 * time.Hour)
+
+	original := store("favorite color", embedding(1, 0))
+	age(original, 2, time.Now())
+	duplicate := store("favourite colour", embedding(1, 0.01))
+	stale := store("old project", embedding(0, 1))
+	age(stale, 1, old)
+	popular := store("home town", embedding(0, 0, 1))
+	age(popular, 5, old)
+	fresh := store("new hobby", nil)
+
+	if err := manager.Consolidate(ctx, agentID, "user-1"); err != nil {
+		t.Fatalf("Consolidate failed: %v", err)
+	}
+
+	remaining, err := manager.List(ctx, core.MemoryListFilters{AgentID: agentID, SortBy: "created_at", Order: "asc"})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	byID := make(map[string]*core.Memory)
+	for _, memory := range remaining {
+		byID[memory.ID] = memory
+	}
+
+	// The near-identical memories merge into the more recently updated one
+	if _, exists := byID[original.ID]; exists {
+		t.Error("expected the older similar memory to be merged")
+	}
+	if merged, exists := byID[duplicate.ID]; !exists || merged.AccessCount != 2 {
+		t.Errorf("expected the newer similar memory to inherit access count, got %+v", merged)
+	}
+	// Old memories are pruned unless they have been accessed often
+	if _, exists := byID[stale.ID]; exists {
+		t.Error("expected stale memory to be pruned")
+	}
+	if _, exists := byID[popular.ID]; !exists {
+		t.Error("expected frequently accessed memory to be kept")
+	}
+	// New memories are kept even though they have never been accessed
+	if _, exists := byID[fresh.ID]; !exists {
+		t.Error("expected new memory to be kept")
+	}
+}
+
+func TestPostgresMemoryManager_InvalidID(t *testing.T) {
+	// IDs that are not UUIDs are rejected before reaching the database
+	manager := NewPostgresMemoryManager(nil, nil)
+	ctx := context.Background()
+
+	if _, err := manager.Get(ctx, "not-a-uuid"); err == nil || !strings.Contains(err.Error(), "memory not found") {
+		t.Errorf("expected memory not found, got %v", err)
+	}
+	if _, err := manager.GetByKey(ctx, "not-a-uuid", "", "key"); err == nil || !strings.Contains(err.Error(), "memory not found") {
+		t.Errorf("expected memory not found, got %v", err)
+	}
+	if err := manager.Store(ctx, &core.Memory{ID: "not-a-uuid"}); err == nil {
+		t.Error("expected error for invalid memory ID")
+	}
+	if results, err := manager.Search(ctx, core.MemorySearchFilters{AgentID: "not-a-uuid"}); err != nil || len(results) != 0 {
+		t.Errorf("expected no results, got %d (%v)", len(results), err)
+	}
+}
@@ -0,0 +1,356 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Ranganaths/minion/core"
+	"github.com/google/uuid"
+)
+
+// PostgresSessionManager implements core.SessionManager using the sessions
+// table from migrations/001_initial_schema.up.sql. Agent IDs must refer to
+// rows in the agents table.
+type PostgresSessionManager struct {
+	db *sql.DB
+}
+
+var _ core.SessionManager = (*PostgresSessionManager)(nil)
+
+// NewPostgresSessionManager creates a session manager from an existing
+// database connection
+func NewPostgresSessionManager(db *sql.DB) *PostgresSessionManager {
+	return &PostgresSessionManager{db: db}
+}
+
+const sessionColumns = `id, agent_id, COALESCE(user_id, ''), status, history, workspace, metadata,
+	created_at, updated_at, expires_at`
+
+// Create creates a new session
+func (m *PostgresSessionManager) Create(ctx context.Context, agentID, userID string, timeout time.Duration) (*core.Session, error) {
+	now := time.Now()
+	session := &core.Session{
+		ID:        uuid.New().String(),
+		AgentID:   agentID,
+		UserID:    userID,
+		Status:    core.SessionStatusActive,
+		History:   []core.Message{},
+		Workspace: make(map[string]interface{}),
+		Metadata:  make(map[string]interface{}),
+		CreatedAt: now,
+		UpdatedAt: now,
+		ExpiresAt: now.Add(timeout),
+	}
+
+	query := `
+		INSERT INTO sessions (id, agent_id, user_id, status, history, workspace, metadata, created_at, updated_at, expires_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, '[]'::jsonb, '{}'::jsonb, '{}'::jsonb, $5, $6, $7)
+	`
+	_, err := m.db.ExecContext(ctx, query,
+		session.ID, session.AgentID, session.UserID, session.Status,
+		session.CreatedAt, session.UpdatedAt, session.ExpiresAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return session, nil
+}
+
+// Get retrieves a session by ID. Expired sessions are reported as errors
+// until CleanupExpired removes them.
+func (m *PostgresSessionManager) Get(ctx context.Context, sessionID string) (*core.Session, error) {
+	session, err := m.get(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		return nil, fmt.Errorf("session expired: %s", sessionID)
+	}
+
+	return session, nil
+}
+
+// Append adds a message to the session history. The message is appended in
+// a single UPDATE so concurrent writers cannot lose each other's messages.
+func (m *PostgresSessionManager) Append(ctx context.Context, sessionID string, message core.Message) error {
+	if message.ID == "" {
+		message.ID = uuid.New().String()
+	}
+	if message.Timestamp.IsZero() {
+		message.Timestamp = time.Now()
+	}
+
+	messageJSON, err := json.Marshal([]core.Message{message})
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	query := `
+		UPDATE sessions
+		SET history = COALESCE(history, '[]'::jsonb) || $2::jsonb, updated_at = $3
+		WHERE id = $1 AND status = $4
+	`
+	result, err := m.exec(ctx, sessionID, query, sessionID, string(messageJSON), time.Now(), core.SessionStatusActive)
+	if err != nil {
+		return fmt.Errorf("failed to append message: %w", err)
+	}
+
+	if result == 0 {
+		// Distinguish a missing session from one that is no longer active
+		if _, err := m.get(ctx, sessionID); err != nil {
+			return err
+		}
+		return fmt.Errorf("cannot append to non-active session: %s", sessionID)
+	}
+
+	return nil
+}
+
+// SetWorkspace updates the workspace (working memory)
+func (m *PostgresSessionManager) SetWorkspace(ctx context.Context, sessionID string, key string, value interface{}) error {
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal workspace value: %w", err)
+	}
+
+	query := `
+		UPDATE sessions
+		SET workspace = COALESCE(workspace, '{}'::jsonb) || jsonb_build_object($2::text, $3::jsonb), updated_at = $4
+		WHERE id = $1
+	`
+	result, err := m.exec(ctx, sessionID, query, sessionID, key, string(valueJSON), time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to set workspace: %w", err)
+	}
+	if result == 0 {
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	return nil
+}
+
+// GetWorkspace retrieves a value from the workspace. Values come back as
+// decoded JSON, so numbers are float64 and structs are maps.
+func (m *PostgresSessionManager) GetWorkspace(ctx context.Context, sessionID string, key string) (interface{}, error) {
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	var valueJSON []byte
+	query := `SELECT workspace -> $2 FROM sessions WHERE id = $1`
+	err := m.db.QueryRowContext(ctx, query, sessionID, key).Scan(&valueJSON)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	if valueJSON == nil {
+		return nil, fmt.Errorf("workspace key not found: %s", key)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(valueJSON, &value); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workspace value: %w", err)
+	}
+
+	return value, nil
+}
+
+// GetHistory retrieves the conversation history, returning the last limit
+// messages when limit is positive
+func (m *PostgresSessionManager) GetHistory(ctx context.Context, sessionID string, limit int) ([]core.Message, error) {
+	session, err := m.get(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := session.History
+	if limit > 0 && len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+
+	return history, nil
+}
+
+// Close closes a session (marks as completed)
+func (m *PostgresSessionManager) Close(ctx context.Context, sessionID string) error {
+	query := `UPDATE sessions SET status = $2, updated_at = $3 WHERE id = $1`
+	result, err := m.exec(ctx, sessionID, query, sessionID, core.SessionStatusClosed, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to close session: %w", err)
+	}
+	if result == 0 {
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	return nil
+}
+
+// Delete permanently deletes a session
+func (m *PostgresSessionManager) Delete(ctx context.Context, sessionID string) error {
+	if _, err := m.exec(ctx, sessionID, `DELETE FROM sessions WHERE id = $1`, sessionID); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// CleanupExpired deletes expired sessions using the schema's
+// cleanup_expired_sessions function, which keeps archived sessions
+func (m *PostgresSessionManager) CleanupExpired(ctx context.Context) (int, error) {
+	var count int
+	if err := m.db.QueryRowContext(ctx, `SELECT cleanup_expired_sessions()`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to cleanup expired sessions: %w", err)
+	}
+
+	return count, nil
+}
+
+// List lists sessions for an agent or user, most recently created first
+func (m *PostgresSessionManager) List(ctx context.Context, filters core.SessionFilters) ([]*core.Session, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filters.AgentID != "" {
+		if _, err := uuid.Parse(filters.AgentID); err != nil {
+			return []*core.Session{}, nil
+		}
+		args = append(args, filters.AgentID)
+		conditions = append(conditions, fmt.Sprintf("agent_id = $%d", len(args)))
+	}
+	if filters.UserID != "" {
+		args = append(args, filters.UserID)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+	if filters.Status != "" {
+		args = append(args, filters.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	query := `SELECT ` + sessionColumns + ` FROM sessions`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC"
+	if filters.Limit > 0 {
+		args = append(args, filters.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filters.Offset > 0 {
+		args = append(args, filters.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []*core.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// get loads a session without checking its expiry
+func (m *PostgresSessionManager) get(ctx context.Context, sessionID string) (*core.Session, error) {
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE id = $1`
+	session, err := scanSession(m.db.QueryRowContext(ctx, query, sessionID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// exec runs a statement keyed by sessionID and returns the number of rows
+// affected. IDs that are not UUIDs cannot match any row, so they affect
+// nothing rather than failing in Postgres.
+func (m *PostgresSessionManager) exec(ctx context.Context, sessionID, query string, args ...interface{}) (int64, error) {
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return 0, nil
+	}
+
+	result, err := m.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanSession scans a row selected with sessionColumns
+func scanSession(row rowScanner) (*core.Session, error) {
+	var session core.Session
+	var historyJSON, workspaceJSON, metadataJSON []byte
+
+	err := row.Scan(
+		&session.ID, &session.AgentID, &session.UserID, &session.Status,
+		&historyJSON, &workspaceJSON, &metadataJSON,
+		&session.CreatedAt, &session.UpdatedAt, &session.ExpiresAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan session: %w", err)
+	}
+
+	if len(historyJSON) > 0 {
+		if err := json.Unmarshal(historyJSON, &session.History); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal session history: %w", err)
+		}
+	}
+	if session.History == nil {
+		session.History = []core.Message{}
+	}
+
+	if len(workspaceJSON) > 0 {
+		if err := json.Unmarshal(workspaceJSON, &session.Workspace); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal session workspace: %w", err)
+		}
+	}
+	if session.Workspace == nil {
+		session.Workspace = make(map[string]interface{})
+	}
+
+	if len(metadataJSON) > 0 {
+		if err := json.Unmarshal(metadataJSON, &session.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal session metadata: %w", err)
+		}
+	}
+	if session.Metadata == nil {
+		session.Metadata = make(map[string]interface{})
+	}
+
+	return &session, nil
+}
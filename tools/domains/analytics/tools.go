@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/Ranganaths/minion/models"
+	"github.com/Ranganaths/minion/tools"
 )
 
 // SQLGeneratorTool converts natural language to SQL queries
@@ -23,6 +24,14 @@ func (t *SQLGeneratorTool) Description() string {
 	return "Converts natural language queries into SQL statements for data analysis"
 }
 
+func (t *SQLGeneratorTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"query":   tools.StringProperty("Natural language description of the query"),
+		"schema":  tools.ObjectProperty("Database schema: table names mapped to their columns", nil),
+		"dialect": tools.StringProperty("SQL dialect (default: postgres)"),
+	}, "query")
+}
+
 func (t *SQLGeneratorTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	query, ok := input.Params["query"].(string)
 	if !ok {
@@ -72,8 +81,15 @@ func (t *AnomalyDetectorTool) Description() string {
 	return "Identifies anomalies and outliers in time-series data using statistical methods"
 }
 
+func (t *AnomalyDetectorTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"data":        tools.ArrayProperty("Numeric series to scan for anomalies", tools.Items("number")),
+		"sensitivity": tools.NumberProperty("Threshold in standard deviations (default: 2.0)"),
+	}, "data")
+}
+
 func (t *AnomalyDetectorTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	data, ok := input.Params["data"].([]float64)
+	data, ok := tools.Float64Slice(input.Params["data"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -123,6 +139,12 @@ func (t *CorrelationAnalyzerTool) Description() string {
 	return "Analyzes correlations between multiple metrics and identifies relationships"
 }
 
+func (t *CorrelationAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"datasets": tools.ObjectProperty("Named numeric series to correlate, keyed by series name", nil),
+	}, "datasets")
+}
+
 func (t *CorrelationAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	datasets, ok := input.Params["datasets"].(map[string][]float64)
 	if !ok {
@@ -162,8 +184,16 @@ func (t *TrendPredictorTool) Description() string {
 	return "Advanced trend prediction with multiple forecasting models and confidence intervals"
 }
 
+func (t *TrendPredictorTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"data":    tools.ArrayProperty("Numeric series in chronological order", tools.Items("number")),
+		"periods": tools.IntegerProperty("Number of periods to predict (default: 5)"),
+		"method":  tools.StringProperty("Forecasting method (default: auto)"),
+	}, "data")
+}
+
 func (t *TrendPredictorTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	data, ok := input.Params["data"].([]float64)
+	data, ok := tools.Float64Slice(input.Params["data"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -173,7 +203,7 @@ func (t *TrendPredictorTool) Execute(ctx context.Context, input *models.ToolInpu
 	}
 
 	periods := 5
-	if p, ok := input.Params["periods"].(int); ok {
+	if p, ok := tools.Int(input.Params["periods"]); ok {
 		periods = p
 	}
 
@@ -206,8 +236,15 @@ func (t *DataValidatorTool) Description() string {
 	return "Validates data quality, completeness, and consistency with configurable rules"
 }
 
+func (t *DataValidatorTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"data":  tools.ArrayProperty("Records to validate", tools.Items("object")),
+		"rules": tools.ObjectProperty("Validation rules, e.g. required_fields", nil),
+	}, "data")
+}
+
 func (t *DataValidatorTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	data, ok := input.Params["data"].([]map[string]interface{})
+	data, ok := tools.MapSlice(input.Params["data"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -242,6 +279,13 @@ func (t *ReportGeneratorTool) Description() string {
 	return "Generates comprehensive business reports from data with insights and visualizations"
 }
 
+func (t *ReportGeneratorTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"data":        tools.ObjectProperty("Data to report on", nil),
+		"report_type": tools.StringProperty("Report type: summary or detailed (default: summary)"),
+	}, "data")
+}
+
 func (t *ReportGeneratorTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	data, ok := input.Params["data"].(map[string]interface{})
 	if !ok {
@@ -281,8 +325,15 @@ func (t *DataTransformerTool) Description() string {
 	return "Performs data transformation operations including normalization, cleaning, and enrichment"
 }
 
+func (t *DataTransformerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"data":       tools.ArrayProperty("Records to transform", tools.Items("object")),
+		"operations": tools.ArrayProperty("Operations to apply in order: clean, normalize, deduplicate, enrich", tools.Items("string")),
+	}, "data", "operations")
+}
+
 func (t *DataTransformerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	data, ok := input.Params["data"].([]map[string]interface{})
+	data, ok := tools.MapSlice(input.Params["data"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -291,7 +342,7 @@ func (t *DataTransformerTool) Execute(ctx context.Context, input *models.ToolInp
 		}, nil
 	}
 
-	operations, ok := input.Params["operations"].([]string)
+	operations, ok := tools.StringSlice(input.Params["operations"])
 	if !ok {
 		operations = []string{"clean"}
 	}
@@ -325,8 +376,14 @@ func (t *StatisticalAnalyzerTool) Description() string {
 	return "Performs comprehensive statistical analysis including distributions, tests, and measures"
 }
 
+func (t *StatisticalAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"data": tools.ArrayProperty("Numeric values to analyze", tools.Items("number")),
+	}, "data")
+}
+
 func (t *StatisticalAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	data, ok := input.Params["data"].([]float64)
+	data, ok := tools.Float64Slice(input.Params["data"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -359,8 +416,14 @@ func (t *DataProfilingTool) Description() string {
 	return "Profiles datasets to understand structure, quality, patterns, and characteristics"
 }
 
+func (t *DataProfilingTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"data": tools.ArrayProperty("Records to profile", tools.Items("object")),
+	}, "data")
+}
+
 func (t *DataProfilingTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	data, ok := input.Params["data"].([]map[string]interface{})
+	data, ok := tools.MapSlice(input.Params["data"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -393,8 +456,15 @@ func (t *TimeSeriesAnalyzerTool) Description() string {
 	return "Analyzes time-series data for seasonality, trends, and cyclical patterns"
 }
 
+func (t *TimeSeriesAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"data":       tools.ArrayProperty("Numeric series in chronological order", tools.Items("number")),
+		"timestamps": tools.ArrayProperty("Timestamps matching each data point", tools.Items("string")),
+	}, "data")
+}
+
 func (t *TimeSeriesAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	data, ok := input.Params["data"].([]float64)
+	data, ok := tools.Float64Slice(input.Params["data"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -403,7 +473,7 @@ func (t *TimeSeriesAnalyzerTool) Execute(ctx context.Context, input *models.Tool
 		}, nil
 	}
 
-	timestamps, _ := input.Params["timestamps"].([]string)
+	timestamps, _ := tools.StringSlice(input.Params["timestamps"])
 
 	analysis := analyzeTimeSeries(data, timestamps)
 
@@ -842,7 +912,7 @@ func validateData(data []map[string]interface{}, rules map[string]interface{}) m
 		recordValid := true
 
 		// Check for missing required fields
-		if requiredFields, ok := rules["required_fields"].([]string); ok {
+		if requiredFields, ok := tools.StringSlice(rules["required_fields"]); ok {
 			for _, field := range requiredFields {
 				if _, exists := record[field]; !exists {
 					errors = append(errors, map[string]interface{}{
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/Ranganaths/minion/models"
+	"github.com/Ranganaths/minion/tools"
 )
 
 // SlackMessageTool sends messages to Slack channels
@@ -20,6 +21,16 @@ func (t *SlackMessageTool) Description() string {
 	return "Sends messages to Slack channels with rich formatting, attachments, and mentions"
 }
 
+func (t *SlackMessageTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"channel":     tools.StringProperty("Channel name or ID"),
+		"message":     tools.StringProperty("Message text"),
+		"thread_ts":   tools.StringProperty("Timestamp of the parent message to reply in a thread"),
+		"mentions":    tools.ArrayProperty("User IDs to mention", tools.Items("string")),
+		"attachments": tools.ArrayProperty("Slack message attachments", tools.Items("object")),
+	}, "channel", "message")
+}
+
 func (t *SlackMessageTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	channel, ok := input.Params["channel"].(string)
 	if !ok {
@@ -63,6 +74,16 @@ func (t *SlackChannelTool) Description() string {
 	return "Creates, archives, and manages Slack channels"
 }
 
+func (t *SlackChannelTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"action":       tools.EnumProperty("Channel action", "create", "archive", "invite", "list"),
+		"channel_name": tools.StringProperty("Channel name (create)"),
+		"is_private":   tools.BooleanProperty("Create a private channel (create)"),
+		"channel_id":   tools.StringProperty("Channel ID (archive, invite)"),
+		"user_id":      tools.StringProperty("User to invite (invite)"),
+	}, "action")
+}
+
 func (t *SlackChannelTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	action, ok := input.Params["action"].(string)
 	if !ok {
@@ -97,6 +118,16 @@ func (t *TeamsMessageTool) Description() string {
 	return "Sends messages to Microsoft Teams channels with adaptive cards"
 }
 
+func (t *TeamsMessageTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"team_id":       tools.StringProperty("Team ID"),
+		"channel_id":    tools.StringProperty("Channel ID"),
+		"message":       tools.StringProperty("Message text"),
+		"message_type":  tools.StringProperty("Message content type, e.g. text or html"),
+		"adaptive_card": tools.ObjectProperty("Adaptive card to attach", nil),
+	}, "team_id", "channel_id", "message")
+}
+
 func (t *TeamsMessageTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	teamID, ok := input.Params["team_id"].(string)
 	if !ok {
@@ -149,6 +180,15 @@ func (t *DiscordMessageTool) Description() string {
 	return "Sends messages to Discord channels with embeds and reactions"
 }
 
+func (t *DiscordMessageTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"channel_id": tools.StringProperty("Channel ID"),
+		"message":    tools.StringProperty("Message text"),
+		"embed":      tools.ObjectProperty("Rich embed to attach", nil),
+		"reactions":  tools.ArrayProperty("Emoji reactions to add", tools.Items("string")),
+	}, "channel_id", "message")
+}
+
 func (t *DiscordMessageTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	channelID, ok := input.Params["channel_id"].(string)
 	if !ok {
@@ -192,6 +232,18 @@ func (t *GmailSendTool) Description() string {
 	return "Sends emails via Gmail with attachments and HTML formatting"
 }
 
+func (t *GmailSendTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"to":          tools.StringProperty("Recipient email address"),
+		"subject":     tools.StringProperty("Email subject"),
+		"body":        tools.StringProperty("Email body"),
+		"cc":          tools.StringProperty("CC recipients"),
+		"bcc":         tools.StringProperty("BCC recipients"),
+		"is_html":     tools.BooleanProperty("Send the body as HTML"),
+		"attachments": tools.ArrayProperty("Attachments to include", tools.Items("object")),
+	}, "to", "subject", "body")
+}
+
 func (t *GmailSendTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	to, ok := input.Params["to"].(string)
 	if !ok {
@@ -244,6 +296,13 @@ func (t *GmailSearchTool) Description() string {
 	return "Searches Gmail messages with advanced filters"
 }
 
+func (t *GmailSearchTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"query":       tools.StringProperty("Gmail search query"),
+		"max_results": tools.IntegerProperty("Maximum number of results (default: 10)"),
+	}, "query")
+}
+
 func (t *GmailSearchTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	query, ok := input.Params["query"].(string)
 	if !ok {
@@ -255,7 +314,7 @@ func (t *GmailSearchTool) Execute(ctx context.Context, input *models.ToolInput)
 	}
 
 	maxResults := 10
-	if mr, ok := input.Params["max_results"].(int); ok {
+	if mr, ok := tools.Int(input.Params["max_results"]); ok {
 		maxResults = mr
 	}
 
@@ -283,6 +342,16 @@ func (t *ZoomMeetingTool) Description() string {
 	return "Creates, updates, and manages Zoom meetings"
 }
 
+func (t *ZoomMeetingTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"action":     tools.EnumProperty("Meeting action", "create", "list", "delete"),
+		"topic":      tools.StringProperty("Meeting topic (create)"),
+		"start_time": tools.StringProperty("Start time in ISO 8601 format (create)"),
+		"duration":   tools.IntegerProperty("Duration in minutes (create, default: 60)"),
+		"meeting_id": tools.StringProperty("Meeting ID (delete)"),
+	}, "action")
+}
+
 func (t *ZoomMeetingTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	action, ok := input.Params["action"].(string)
 	if !ok {
@@ -317,6 +386,14 @@ func (t *TwilioSMSTool) Description() string {
 	return "Sends SMS messages via Twilio"
 }
 
+func (t *TwilioSMSTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"to":      tools.StringProperty("Recipient phone number"),
+		"message": tools.StringProperty("SMS text"),
+		"from":    tools.StringProperty("Sender phone number"),
+	}, "to", "message")
+}
+
 func (t *TwilioSMSTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	to, ok := input.Params["to"].(string)
 	if !ok {
@@ -360,6 +437,14 @@ func (t *TwilioCallTool) Description() string {
 	return "Makes phone calls via Twilio with TwiML instructions"
 }
 
+func (t *TwilioCallTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"to":   tools.StringProperty("Phone number to call"),
+		"from": tools.StringProperty("Caller phone number"),
+		"url":  tools.StringProperty("TwiML URL that controls the call"),
+	}, "to")
+}
+
 func (t *TwilioCallTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	to, ok := input.Params["to"].(string)
 	if !ok {
@@ -398,13 +483,13 @@ func sendSlackMessage(channel, message string, params map[string]interface{}) ma
 	timestamp := fmt.Sprintf("%.6f", float64(time.Now().Unix())+0.123456)
 
 	// Check for attachments
-	attachments, _ := params["attachments"].([]map[string]interface{})
+	attachments, _ := tools.MapSlice(params["attachments"])
 
 	// Check for thread
 	threadTS, _ := params["thread_ts"].(string)
 
 	// Check for mentions
-	mentions, _ := params["mentions"].([]string)
+	mentions, _ := tools.StringSlice(params["mentions"])
 	if len(mentions) > 0 {
 		for _, mention := range mentions {
 			message = strings.ReplaceAll(message, "@"+mention, "<@"+mention+">")
@@ -529,7 +614,7 @@ func sendDiscordMessage(channelID, message string, params map[string]interface{}
 	}
 
 	// Add reactions if specified
-	if reactions, ok := params["reactions"].([]string); ok {
+	if reactions, ok := tools.StringSlice(params["reactions"]); ok {
 		result["reactions"] = reactions
 	}
 
@@ -557,7 +642,7 @@ func sendGmailEmail(to, subject, body string, params map[string]interface{}) map
 	}
 
 	// Handle attachments
-	if attachments, ok := params["attachments"].([]map[string]interface{}); ok {
+	if attachments, ok := tools.MapSlice(params["attachments"]); ok {
 		result["attachments"] = attachments
 		result["has_attachments"] = true
 	}
@@ -611,7 +696,7 @@ func manageZoomMeeting(action string, params map[string]interface{}) map[string]
 	case "create":
 		topic, _ := params["topic"].(string)
 		startTime, _ := params["start_time"].(string)
-		duration, _ := params["duration"].(int)
+		duration, _ := tools.Int(params["duration"])
 		if duration == 0 {
 			duration = 60
 		}
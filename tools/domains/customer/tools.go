@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/Ranganaths/minion/models"
+	"github.com/Ranganaths/minion/tools"
 )
 
 // SentimentAnalyzerTool analyzes sentiment from text and feedback
@@ -21,6 +22,12 @@ func (t *SentimentAnalyzerTool) Description() string {
 	return "Analyzes sentiment from customer feedback, reviews, and text content"
 }
 
+func (t *SentimentAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"text": tools.StringProperty("Text to analyze"),
+	}, "text")
+}
+
 func (t *SentimentAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	text, ok := input.Params["text"].(string)
 	if !ok {
@@ -55,6 +62,12 @@ func (t *TicketClassifierTool) Description() string {
 	return "Automatically classifies support tickets by category, priority, and urgency"
 }
 
+func (t *TicketClassifierTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"ticket": tools.ObjectProperty("Ticket with subject and description fields", nil),
+	}, "ticket")
+}
+
 func (t *TicketClassifierTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	ticket, ok := input.Params["ticket"].(map[string]interface{})
 	if !ok {
@@ -89,6 +102,13 @@ func (t *ResponseGeneratorTool) Description() string {
 	return "Generates context-aware templated responses for customer support"
 }
 
+func (t *ResponseGeneratorTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"context":       tools.ObjectProperty("Response context, e.g. customer_name", nil),
+		"response_type": tools.StringProperty("Response template to use (default: general)"),
+	}, "context")
+}
+
 func (t *ResponseGeneratorTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	context, ok := input.Params["context"].(map[string]interface{})
 	if !ok {
@@ -128,6 +148,12 @@ func (t *CustomerHealthScorerTool) Description() string {
 	return "Calculates comprehensive customer health score based on multiple factors"
 }
 
+func (t *CustomerHealthScorerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"customer": tools.ObjectProperty("Customer with usage_percentage, last_activity_days, support_tickets and nps_score fields", nil),
+	}, "customer")
+}
+
 func (t *CustomerHealthScorerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	customer, ok := input.Params["customer"].(map[string]interface{})
 	if !ok {
@@ -162,8 +188,14 @@ func (t *FeedbackAnalyzerTool) Description() string {
 	return "Analyzes customer feedback to identify patterns, trends, and actionable insights"
 }
 
+func (t *FeedbackAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"feedback": tools.ArrayProperty("Feedback items, each with text and rating fields", tools.Items("object")),
+	}, "feedback")
+}
+
 func (t *FeedbackAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	feedback, ok := input.Params["feedback"].([]map[string]interface{})
+	feedback, ok := tools.MapSlice(input.Params["feedback"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -196,8 +228,14 @@ func (t *NPSCalculatorTool) Description() string {
 	return "Calculates Net Promoter Score from customer survey responses"
 }
 
+func (t *NPSCalculatorTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"scores": tools.ArrayProperty("NPS survey scores from 0 to 10", tools.Items("number")),
+	}, "scores")
+}
+
 func (t *NPSCalculatorTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	scores, ok := input.Params["scores"].([]float64)
+	scores, ok := tools.Float64Slice(input.Params["scores"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -230,6 +268,12 @@ func (t *SupportMetricsAnalyzerTool) Description() string {
 	return "Analyzes support team metrics including response time, resolution rate, and CSAT"
 }
 
+func (t *SupportMetricsAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"metrics": tools.ObjectProperty("Support metrics: first_response_time_hours, avg_resolution_time_hours, resolution_rate and csat_score", nil),
+	}, "metrics")
+}
+
 func (t *SupportMetricsAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	metrics, ok := input.Params["metrics"].(map[string]interface{})
 	if !ok {
@@ -264,8 +308,14 @@ func (t *CSATAnalyzerTool) Description() string {
 	return "Analyzes Customer Satisfaction (CSAT) scores and identifies improvement areas"
 }
 
+func (t *CSATAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"responses": tools.ArrayProperty("CSAT responses, each with score and category fields", tools.Items("object")),
+	}, "responses")
+}
+
 func (t *CSATAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	responses, ok := input.Params["responses"].([]map[string]interface{})
+	responses, ok := tools.MapSlice(input.Params["responses"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -298,6 +348,13 @@ func (t *TicketRoutingTool) Description() string {
 	return "Intelligently routes support tickets to the most appropriate agent or team"
 }
 
+func (t *TicketRoutingTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"ticket":           tools.ObjectProperty("Ticket with subject and description fields", nil),
+		"available_agents": tools.ArrayProperty("Agents to route to, each with name and specialties fields", tools.Items("object")),
+	}, "ticket")
+}
+
 func (t *TicketRoutingTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	ticket, ok := input.Params["ticket"].(map[string]interface{})
 	if !ok {
@@ -308,7 +365,7 @@ func (t *TicketRoutingTool) Execute(ctx context.Context, input *models.ToolInput
 		}, nil
 	}
 
-	agents, _ := input.Params["available_agents"].([]map[string]interface{})
+	agents, _ := tools.MapSlice(input.Params["available_agents"])
 
 	routing := routeTicket(ticket, agents)
 
@@ -334,6 +391,13 @@ func (t *KnowledgeBaseSearchTool) Description() string {
 	return "Searches knowledge base for relevant articles and solutions"
 }
 
+func (t *KnowledgeBaseSearchTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"query": tools.StringProperty("Search query"),
+		"limit": tools.IntegerProperty("Maximum number of articles (default: 5)"),
+	}, "query")
+}
+
 func (t *KnowledgeBaseSearchTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	query, ok := input.Params["query"].(string)
 	if !ok {
@@ -345,7 +409,7 @@ func (t *KnowledgeBaseSearchTool) Execute(ctx context.Context, input *models.Too
 	}
 
 	limit := 5
-	if l, ok := input.Params["limit"].(int); ok {
+	if l, ok := tools.Int(input.Params["limit"]); ok {
 		limit = l
 	}
 
@@ -373,8 +437,14 @@ func (t *CustomerJourneyAnalyzerTool) Description() string {
 	return "Analyzes customer journey across touchpoints to identify pain points and opportunities"
 }
 
+func (t *CustomerJourneyAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"journey": tools.ArrayProperty("Journey touchpoints, each with type and satisfaction fields", tools.Items("object")),
+	}, "journey")
+}
+
 func (t *CustomerJourneyAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	journey, ok := input.Params["journey"].([]map[string]interface{})
+	journey, ok := tools.MapSlice(input.Params["journey"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -1161,7 +1231,7 @@ func findBestAgent(agents []map[string]interface{}, category, priority string) m
 
 	// Simplified agent matching
 	for _, agent := range agents {
-		if specialties, ok := agent["specialties"].([]string); ok {
+		if specialties, ok := tools.StringSlice(agent["specialties"]); ok {
 			for _, specialty := range specialties {
 				if specialty == category {
 					return agent
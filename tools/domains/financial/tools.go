@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/Ranganaths/minion/models"
+	"github.com/Ranganaths/minion/tools"
 )
 
 // InvoiceGeneratorTool creates invoices from order data
@@ -20,6 +21,12 @@ func (t *InvoiceGeneratorTool) Description() string {
 	return "Generates professional invoices from order and customer data"
 }
 
+func (t *InvoiceGeneratorTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"order_data": tools.ObjectProperty("Order with customer, items (each with price and quantity) and tax_rate fields", nil),
+	}, "order_data")
+}
+
 func (t *InvoiceGeneratorTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	orderData, ok := input.Params["order_data"].(map[string]interface{})
 	if !ok {
@@ -54,6 +61,12 @@ func (t *FinancialRatioCalculatorTool) Description() string {
 	return "Calculates key financial ratios including liquidity, profitability, and efficiency metrics"
 }
 
+func (t *FinancialRatioCalculatorTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"financials": tools.ObjectProperty("Financial statement values: revenue, cogs, net_income, total_assets, total_liabilities, equity, current_assets, current_liabilities and inventory", nil),
+	}, "financials")
+}
+
 func (t *FinancialRatioCalculatorTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	financials, ok := input.Params["financials"].(map[string]interface{})
 	if !ok {
@@ -88,8 +101,14 @@ func (t *CashFlowAnalyzerTool) Description() string {
 	return "Analyzes and projects cash flows with scenario planning"
 }
 
+func (t *CashFlowAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"cash_flow_data": tools.ArrayProperty("Cash flow periods, each with period, inflow and outflow fields", tools.Items("object")),
+	}, "cash_flow_data")
+}
+
 func (t *CashFlowAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	cashFlowData, ok := input.Params["cash_flow_data"].([]map[string]interface{})
+	cashFlowData, ok := tools.MapSlice(input.Params["cash_flow_data"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -122,6 +141,13 @@ func (t *TaxCalculatorTool) Description() string {
 	return "Calculates tax implications and estimates for various scenarios"
 }
 
+func (t *TaxCalculatorTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"income":       tools.NumberProperty("Taxable income"),
+		"jurisdiction": tools.StringProperty("Tax jurisdiction (default: US)"),
+	}, "income")
+}
+
 func (t *TaxCalculatorTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	income, ok := input.Params["income"].(float64)
 	if !ok {
@@ -161,6 +187,13 @@ func (t *PricingOptimizerTool) Description() string {
 	return "Optimizes pricing strategies based on costs, competition, and demand"
 }
 
+func (t *PricingOptimizerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"product":     tools.ObjectProperty("Product with cost and current_price fields", nil),
+		"market_data": tools.ObjectProperty("Market data, e.g. average_price", nil),
+	}, "product")
+}
+
 func (t *PricingOptimizerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	product, ok := input.Params["product"].(map[string]interface{})
 	if !ok {
@@ -197,6 +230,12 @@ func (t *BudgetAnalyzerTool) Description() string {
 	return "Analyzes budget vs actual spending with variance analysis and forecasting"
 }
 
+func (t *BudgetAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"budget_data": tools.ObjectProperty("Budget with budget, actual, periods_elapsed, total_periods and categories fields", nil),
+	}, "budget_data")
+}
+
 func (t *BudgetAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	budgetData, ok := input.Params["budget_data"].(map[string]interface{})
 	if !ok {
@@ -231,6 +270,12 @@ func (t *ROICalculatorTool) Description() string {
 	return "Calculates ROI, payback period, and IRR for investments"
 }
 
+func (t *ROICalculatorTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"investment": tools.ObjectProperty("Investment with initial_investment and cash_flows fields", nil),
+	}, "investment")
+}
+
 func (t *ROICalculatorTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	investment, ok := input.Params["investment"].(map[string]interface{})
 	if !ok {
@@ -265,8 +310,14 @@ func (t *ExpenseCategorizerTool) Description() string {
 	return "Automatically categorizes expenses and provides spending insights"
 }
 
+func (t *ExpenseCategorizerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"expenses": tools.ArrayProperty("Expenses, each with description, amount and optional category fields", tools.Items("object")),
+	}, "expenses")
+}
+
 func (t *ExpenseCategorizerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	expenses, ok := input.Params["expenses"].([]map[string]interface{})
+	expenses, ok := tools.MapSlice(input.Params["expenses"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -299,6 +350,12 @@ func (t *BreakEvenAnalyzerTool) Description() string {
 	return "Calculates break-even points and conducts cost-volume-profit analysis"
 }
 
+func (t *BreakEvenAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"cost_data": tools.ObjectProperty("Costs with fixed_costs, variable_cost_per_unit, price_per_unit and current_units fields", nil),
+	}, "cost_data")
+}
+
 func (t *BreakEvenAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	costData, ok := input.Params["cost_data"].(map[string]interface{})
 	if !ok {
@@ -333,8 +390,14 @@ func (t *ProfitabilityAnalyzerTool) Description() string {
 	return "Analyzes profitability by product, customer segment, or business unit"
 }
 
+func (t *ProfitabilityAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"data": tools.ArrayProperty("Segments, each with segment, revenue and cost fields", tools.Items("object")),
+	}, "data")
+}
+
 func (t *ProfitabilityAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	data, ok := input.Params["data"].([]map[string]interface{})
+	data, ok := tools.MapSlice(input.Params["data"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -367,8 +430,15 @@ func (t *FinancialForecastingTool) Description() string {
 	return "Generates financial forecasts with scenario planning and sensitivity analysis"
 }
 
+func (t *FinancialForecastingTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"historical_data": tools.ArrayProperty("Historical periods to forecast from", tools.Items("object")),
+		"periods":         tools.IntegerProperty("Number of periods to forecast (default: 12)"),
+	}, "historical_data")
+}
+
 func (t *FinancialForecastingTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	historicalData, ok := input.Params["historical_data"].([]map[string]interface{})
+	historicalData, ok := tools.MapSlice(input.Params["historical_data"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -378,7 +448,7 @@ func (t *FinancialForecastingTool) Execute(ctx context.Context, input *models.To
 	}
 
 	periods := 12
-	if p, ok := input.Params["periods"].(int); ok {
+	if p, ok := tools.Int(input.Params["periods"]); ok {
 		periods = p
 	}
 
@@ -406,6 +476,12 @@ func (t *PaymentTermsOptimizerTool) Description() string {
 	return "Optimizes payment terms to improve cash flow while maintaining customer relationships"
 }
 
+func (t *PaymentTermsOptimizerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"accounts_data": tools.ObjectProperty("Receivables data with current_dso, avg_invoice_value and annual_revenue fields", nil),
+	}, "accounts_data")
+}
+
 func (t *PaymentTermsOptimizerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	accountsData, ok := input.Params["accounts_data"].(map[string]interface{})
 	if !ok {
@@ -443,7 +519,7 @@ func hasCapability(agent *models.Agent, capability string) bool {
 func generateInvoice(orderData map[string]interface{}) map[string]interface{} {
 	invoiceNumber := fmt.Sprintf("INV-%d", time.Now().Unix())
 
-	items, _ := orderData["items"].([]map[string]interface{})
+	items, _ := tools.MapSlice(orderData["items"])
 
 	subtotal := 0.0
 	for _, item := range items {
@@ -921,7 +997,7 @@ func analyzeBudget(budgetData map[string]interface{}) map[string]interface{} {
 	}
 
 	// Category breakdown
-	categories, _ := budgetData["categories"].([]map[string]interface{})
+	categories, _ := tools.MapSlice(budgetData["categories"])
 	categoryAnalysis := []map[string]interface{}{}
 
 	for _, cat := range categories {
@@ -991,7 +1067,7 @@ func getBudgetRecommendations(status string, categoryAnalysis []map[string]inter
 
 func calculateROI(investment map[string]interface{}) map[string]interface{} {
 	initialInvestment, _ := investment["initial_investment"].(float64)
-	cashFlows, _ := investment["cash_flows"].([]float64)
+	cashFlows, _ := tools.Float64Slice(investment["cash_flows"])
 
 	if len(cashFlows) == 0 {
 		return map[string]interface{}{
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/Ranganaths/minion/models"
+	"github.com/Ranganaths/minion/tools"
 )
 
 // APICallerTool calls external APIs and parses responses
@@ -22,6 +23,15 @@ func (t *APICallerTool) Description() string {
 	return "Calls external APIs with authentication and response parsing capabilities"
 }
 
+func (t *APICallerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"url":     tools.StringProperty("Request URL"),
+		"method":  tools.StringProperty("HTTP method (default: GET)"),
+		"headers": tools.ObjectProperty("Request headers", nil),
+		"body":    tools.StringProperty("Request body"),
+	}, "url")
+}
+
 func (t *APICallerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	url, ok := input.Params["url"].(string)
 	if !ok {
@@ -64,6 +74,13 @@ func (t *FileParserTool) Description() string {
 	return "Parses CSV, JSON, and Excel files into structured data"
 }
 
+func (t *FileParserTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"content":   tools.StringProperty("File content to parse"),
+		"file_type": tools.StringProperty("File type: json, csv or auto to detect (default: auto)"),
+	}, "content")
+}
+
 func (t *FileParserTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	content, ok := input.Params["content"].(string)
 	if !ok {
@@ -103,6 +120,13 @@ func (t *WebScraperTool) Description() string {
 	return "Extracts structured data from websites using selectors and patterns"
 }
 
+func (t *WebScraperTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"url":       tools.StringProperty("Page URL"),
+		"selectors": tools.ObjectProperty("CSS selectors keyed by the field name to extract", nil),
+	}, "url")
+}
+
 func (t *WebScraperTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	url, ok := input.Params["url"].(string)
 	if !ok {
@@ -139,6 +163,14 @@ func (t *DatabaseConnectorTool) Description() string {
 	return "Connects to and queries external databases (PostgreSQL, MySQL, MongoDB)"
 }
 
+func (t *DatabaseConnectorTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"connection_string": tools.StringProperty("Database connection string"),
+		"query":             tools.StringProperty("Query to run"),
+		"db_type":           tools.StringProperty("Database type (default: postgres)"),
+	}, "connection_string", "query")
+}
+
 func (t *DatabaseConnectorTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	connectionString, ok := input.Params["connection_string"].(string)
 	if !ok {
@@ -187,8 +219,16 @@ func (t *DataSyncTool) Description() string {
 	return "Synchronizes data between different systems with conflict resolution"
 }
 
+func (t *DataSyncTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"source_data":   tools.ArrayProperty("Source records, each with an id field", tools.Items("object")),
+		"target_data":   tools.ArrayProperty("Target records, each with an id field", tools.Items("object")),
+		"sync_strategy": tools.StringProperty("Sync strategy (default: merge)"),
+	}, "source_data", "target_data")
+}
+
 func (t *DataSyncTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	sourceData, ok := input.Params["source_data"].([]map[string]interface{})
+	sourceData, ok := tools.MapSlice(input.Params["source_data"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -197,7 +237,7 @@ func (t *DataSyncTool) Execute(ctx context.Context, input *models.ToolInput) (*m
 		}, nil
 	}
 
-	targetData, ok := input.Params["target_data"].([]map[string]interface{})
+	targetData, ok := tools.MapSlice(input.Params["target_data"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -235,6 +275,14 @@ func (t *WebhookHandlerTool) Description() string {
 	return "Processes and validates incoming webhook payloads"
 }
 
+func (t *WebhookHandlerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"payload":   tools.ObjectProperty("Webhook payload, e.g. with an event_type field", nil),
+		"signature": tools.StringProperty("Signature sent with the webhook"),
+		"secret":    tools.StringProperty("Secret used to verify the signature"),
+	}, "payload")
+}
+
 func (t *WebhookHandlerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	payload, ok := input.Params["payload"].(map[string]interface{})
 	if !ok {
@@ -272,6 +320,14 @@ func (t *EmailSenderTool) Description() string {
 	return "Sends emails via SMTP with template support and attachments"
 }
 
+func (t *EmailSenderTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"to":      tools.StringProperty("Recipient email address"),
+		"subject": tools.StringProperty("Email subject"),
+		"body":    tools.StringProperty("Email body"),
+	}, "to", "subject", "body")
+}
+
 func (t *EmailSenderTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	to, ok := input.Params["to"].(string)
 	if !ok {
@@ -324,6 +380,13 @@ func (t *SlackNotifierTool) Description() string {
 	return "Sends formatted messages and notifications to Slack channels"
 }
 
+func (t *SlackNotifierTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"channel": tools.StringProperty("Channel name or ID"),
+		"message": tools.StringProperty("Message text"),
+	}, "channel", "message")
+}
+
 func (t *SlackNotifierTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	channel, ok := input.Params["channel"].(string)
 	if !ok {
@@ -367,6 +430,14 @@ func (t *CloudStorageTool) Description() string {
 	return "Uploads, downloads, and manages files in cloud storage (S3, GCS, Azure Blob)"
 }
 
+func (t *CloudStorageTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"operation": tools.EnumProperty("Storage operation", "upload", "download", "list", "delete"),
+		"provider":  tools.StringProperty("Storage provider (default: s3)"),
+		"file_name": tools.StringProperty("File to upload, download or delete"),
+	}, "operation")
+}
+
 func (t *CloudStorageTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	operation, ok := input.Params["operation"].(string)
 	if !ok {
@@ -406,8 +477,15 @@ func (t *DataExporterTool) Description() string {
 	return "Exports data to CSV, JSON, Excel, PDF, and other formats"
 }
 
+func (t *DataExporterTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"data":   tools.ArrayProperty("Records to export", tools.Items("object")),
+		"format": tools.EnumProperty("Export format (default: csv)", "csv", "json", "xml"),
+	}, "data")
+}
+
 func (t *DataExporterTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	data, ok := input.Params["data"].([]map[string]interface{})
+	data, ok := tools.MapSlice(input.Params["data"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -445,8 +523,15 @@ func (t *EventStreamProcessorTool) Description() string {
 	return "Processes and transforms event streams from Kafka, RabbitMQ, or other message queues"
 }
 
+func (t *EventStreamProcessorTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"events":    tools.ArrayProperty("Events to process, each with an event_type field", tools.Items("object")),
+		"processor": tools.StringProperty("Processor to apply: filter, transform or enrich (default: filter)"),
+	}, "events")
+}
+
 func (t *EventStreamProcessorTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	events, ok := input.Params["events"].([]map[string]interface{})
+	events, ok := tools.MapSlice(input.Params["events"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -484,6 +569,14 @@ func (t *OAuthAuthenticatorTool) Description() string {
 	return "Handles OAuth 2.0 authentication flows for third-party integrations"
 }
 
+func (t *OAuthAuthenticatorTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"provider":      tools.StringProperty("OAuth provider"),
+		"client_id":     tools.StringProperty("OAuth client ID"),
+		"client_secret": tools.StringProperty("OAuth client secret"),
+	}, "provider")
+}
+
 func (t *OAuthAuthenticatorTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	provider, ok := input.Params["provider"].(string)
 	if !ok {
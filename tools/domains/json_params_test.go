@@ -0,0 +1,78 @@
+package domains
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Ranganaths/minion/models"
+	"github.com/Ranganaths/minion/tools"
+	"github.com/Ranganaths/minion/tools/domains/analytics"
+	"github.com/Ranganaths/minion/tools/domains/customer"
+	"github.com/Ranganaths/minion/tools/domains/financial"
+	"github.com/Ranganaths/minion/tools/domains/sales"
+)
+
+// TestDomainToolsAcceptJSONParams executes tools with params decoded from
+// JSON, as LLM tool calls deliver them, so arrays arrive as []interface{}
+// and numbers as float64
+func TestDomainToolsAcceptJSONParams(t *testing.T) {
+	registry := tools.NewRegistry()
+	for _, tool := range []tools.Tool{
+		&sales.RevenueAnalyzerTool{},
+		&sales.CustomerSegmentationTool{},
+		&sales.ForecastingTool{},
+		&sales.ConversionRateAnalyzerTool{},
+		&analytics.AnomalyDetectorTool{},
+		&analytics.DataTransformerTool{},
+		&customer.NPSCalculatorTool{},
+		&financial.CashFlowAnalyzerTool{},
+	} {
+		if err := registry.Register(tool); err != nil {
+			t.Fatalf("Register(%s) failed: %v", tool.Name(), err)
+		}
+	}
+
+	tests := []struct {
+		tool   string
+		params string
+		check  func(t *testing.T, result interface{})
+	}{
+		{tool: "revenue_analyzer", params: `{"revenues": [1, 2, 3]}`, check: func(t *testing.T, result interface{}) {
+			if total := result.(map[string]interface{})["total_revenue"]; total != 6.0 {
+				t.Errorf("expected total revenue 6, got %v", total)
+			}
+		}},
+		{tool: "customer_segmentation", params: `{"customers": [{"id": "a", "revenue": 150000}, {"id": "b", "revenue": 500}]}`},
+		{tool: "sales_forecasting", params: `{"historical_data": [10, 20, 30], "periods": 2}`, check: func(t *testing.T, result interface{}) {
+			if forecast := result.(map[string]interface{})["forecast"].([]float64); len(forecast) != 2 {
+				t.Errorf("expected 2 forecast periods, got %v", forecast)
+			}
+		}},
+		{tool: "conversion_rate_analyzer", params: `{"stage_data": [{"name": "lead", "count": 100}, {"name": "won", "count": 10}]}`},
+		{tool: "anomaly_detector", params: `{"data": [1, 1, 1, 1, 50], "sensitivity": 1.5}`},
+		{tool: "data_transformer", params: `{"data": [{"name": " Ada "}], "operations": ["clean"]}`},
+		{tool: "nps_calculator", params: `{"scores": [10, 9, 3]}`},
+		{tool: "cash_flow_analyzer", params: `{"cash_flow_data": [{"period": "Q1", "inflow": 100, "outflow": 60}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			var params map[string]interface{}
+			if err := json.Unmarshal([]byte(tt.params), &params); err != nil {
+				t.Fatalf("invalid test params: %v", err)
+			}
+
+			output, err := registry.Execute(context.Background(), tt.tool, &models.ToolInput{Params: params})
+			if err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+			if !output.Success {
+				t.Fatalf("expected success, got %+v", output)
+			}
+			if tt.check != nil {
+				tt.check(t, output.Result)
+			}
+		})
+	}
+}
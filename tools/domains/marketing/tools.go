@@ -7,6 +7,7 @@ import (
 	"sort"
 
 	"github.com/Ranganaths/minion/models"
+	"github.com/Ranganaths/minion/tools"
 )
 
 // CampaignROICalculatorTool calculates campaign ROI and ROAS
@@ -20,6 +21,13 @@ func (t *CampaignROICalculatorTool) Description() string {
 	return "Calculates ROI, ROAS, and other performance metrics for marketing campaigns"
 }
 
+func (t *CampaignROICalculatorTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"revenue": tools.NumberProperty("Revenue attributed to the campaign"),
+		"cost":    tools.NumberProperty("Total campaign cost"),
+	}, "revenue", "cost")
+}
+
 func (t *CampaignROICalculatorTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	revenue, okRev := input.Params["revenue"].(float64)
 	cost, okCost := input.Params["cost"].(float64)
@@ -64,8 +72,14 @@ func (t *FunnelAnalyzerTool) Description() string {
 	return "Analyzes marketing funnel stages, conversion rates, and drop-off points"
 }
 
+func (t *FunnelAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"stages": tools.ArrayProperty("Ordered funnel stages, each with name and count fields", tools.Items("object")),
+	}, "stages")
+}
+
 func (t *FunnelAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	stages, ok := input.Params["stages"].([]map[string]interface{})
+	stages, ok := tools.MapSlice(input.Params["stages"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -98,6 +112,15 @@ func (t *CACCalculatorTool) Description() string {
 	return "Calculates Customer Acquisition Cost (CAC) and related metrics like LTV:CAC ratio"
 }
 
+func (t *CACCalculatorTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"total_marketing_cost": tools.NumberProperty("Total sales and marketing spend"),
+		"new_customers":        tools.NumberProperty("Number of customers acquired"),
+		"customer_ltv":         tools.NumberProperty("Customer lifetime value, used for the LTV:CAC ratio"),
+		"arpu":                 tools.NumberProperty("Average revenue per user, used for the payback period"),
+	}, "total_marketing_cost", "new_customers")
+}
+
 func (t *CACCalculatorTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	totalCost, okCost := input.Params["total_marketing_cost"].(float64)
 	newCustomers, okCustomers := input.Params["new_customers"].(float64)
@@ -151,8 +174,15 @@ func (t *AttributionAnalyzerTool) Description() string {
 	return "Analyzes marketing channel attribution and assigns credit for conversions"
 }
 
+func (t *AttributionAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"touchpoints": tools.ArrayProperty("Ordered touchpoints, each with a channel field", tools.Items("object")),
+		"model":       tools.EnumProperty("Attribution model (default: last_touch)", "first_touch", "last_touch", "linear", "time_decay"),
+	}, "touchpoints")
+}
+
 func (t *AttributionAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	touchpoints, ok := input.Params["touchpoints"].([]map[string]interface{})
+	touchpoints, ok := tools.MapSlice(input.Params["touchpoints"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -190,6 +220,13 @@ func (t *ABTestAnalyzerTool) Description() string {
 	return "Analyzes A/B test results and determines statistical significance"
 }
 
+func (t *ABTestAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"variant_a": tools.ObjectProperty("Control variant with visitors and conversions fields", nil),
+		"variant_b": tools.ObjectProperty("Test variant with visitors and conversions fields", nil),
+	}, "variant_a", "variant_b")
+}
+
 func (t *ABTestAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	variantA, okA := input.Params["variant_a"].(map[string]interface{})
 	variantB, okB := input.Params["variant_b"].(map[string]interface{})
@@ -226,6 +263,12 @@ func (t *EngagementScorerTool) Description() string {
 	return "Scores content engagement based on views, clicks, shares, and other metrics"
 }
 
+func (t *EngagementScorerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"metrics": tools.ObjectProperty("Engagement metrics: views, clicks, shares, comments and avg_time_seconds", nil),
+	}, "metrics")
+}
+
 func (t *EngagementScorerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	metrics, ok := input.Params["metrics"].(map[string]interface{})
 	if !ok {
@@ -265,8 +308,14 @@ func (t *ContentPerformanceTool) Description() string {
 	return "Analyzes content performance across channels and formats"
 }
 
+func (t *ContentPerformanceTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"content_data": tools.ArrayProperty("Content items, each with format, channel and engagement fields", tools.Items("object")),
+	}, "content_data")
+}
+
 func (t *ContentPerformanceTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	content, ok := input.Params["content_data"].([]map[string]interface{})
+	content, ok := tools.MapSlice(input.Params["content_data"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -299,6 +348,12 @@ func (t *LeadScoringTool) Description() string {
 	return "Scores leads based on behavior, demographics, and engagement"
 }
 
+func (t *LeadScoringTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"lead": tools.ObjectProperty("Lead with job_title, company_size, page_views, downloads and email_opens fields", nil),
+	}, "lead")
+}
+
 func (t *LeadScoringTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	lead, ok := input.Params["lead"].(map[string]interface{})
 	if !ok {
@@ -338,6 +393,12 @@ func (t *EmailCampaignAnalyzerTool) Description() string {
 	return "Analyzes email campaign metrics including open rates, CTR, and conversions"
 }
 
+func (t *EmailCampaignAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"campaign": tools.ObjectProperty("Campaign with sent, opens, clicks, conversions, bounces and unsubscribes fields", nil),
+	}, "campaign")
+}
+
 func (t *EmailCampaignAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	campaign, ok := input.Params["campaign"].(map[string]interface{})
 	if !ok {
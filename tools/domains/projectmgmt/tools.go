@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Ranganaths/minion/models"
+	"github.com/Ranganaths/minion/tools"
 )
 
 // JiraIssueTool manages Jira issues
@@ -19,6 +20,19 @@ func (t *JiraIssueTool) Description() string {
 	return "Creates, updates, and manages Jira issues with full field support"
 }
 
+func (t *JiraIssueTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"action":        tools.EnumProperty("Issue action", "create", "update", "transition", "search"),
+		"project_key":   tools.StringProperty("Project key (create)"),
+		"issue_type":    tools.StringProperty("Issue type, e.g. Task or Bug (create)"),
+		"summary":       tools.StringProperty("Issue summary (create)"),
+		"description":   tools.StringProperty("Issue description (create)"),
+		"issue_key":     tools.StringProperty("Issue key (update, transition)"),
+		"transition_id": tools.StringProperty("Workflow transition ID (transition)"),
+		"jql":           tools.StringProperty("JQL query (search)"),
+	}, "action")
+}
+
 func (t *JiraIssueTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	action, ok := input.Params["action"].(string)
 	if !ok {
@@ -53,6 +67,18 @@ func (t *JiraSprintTool) Description() string {
 	return "Creates and manages Jira sprints for Scrum boards"
 }
 
+func (t *JiraSprintTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"action":     tools.EnumProperty("Sprint action", "create", "start", "complete"),
+		"name":       tools.StringProperty("Sprint name (create)"),
+		"board_id":   tools.StringProperty("Board ID (create)"),
+		"start_date": tools.StringProperty("Start date (create)"),
+		"end_date":   tools.StringProperty("End date (create)"),
+		"goal":       tools.StringProperty("Sprint goal (create)"),
+		"sprint_id":  tools.StringProperty("Sprint ID (start, complete)"),
+	}, "action")
+}
+
 func (t *JiraSprintTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	action, ok := input.Params["action"].(string)
 	if !ok {
@@ -87,6 +113,16 @@ func (t *AsanaTaskTool) Description() string {
 	return "Creates, updates, and manages Asana tasks with sections and custom fields"
 }
 
+func (t *AsanaTaskTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"action":     tools.EnumProperty("Task action", "create", "update", "complete", "search"),
+		"name":       tools.StringProperty("Task name (create)"),
+		"notes":      tools.StringProperty("Task notes (create)"),
+		"project_id": tools.StringProperty("Project ID (create, search)"),
+		"task_id":    tools.StringProperty("Task ID (update, complete)"),
+	}, "action")
+}
+
 func (t *AsanaTaskTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	action, ok := input.Params["action"].(string)
 	if !ok {
@@ -121,6 +157,14 @@ func (t *AsanaProjectTool) Description() string {
 	return "Creates and manages Asana projects with templates and portfolios"
 }
 
+func (t *AsanaProjectTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"action":       tools.EnumProperty("Project action", "create", "list"),
+		"name":         tools.StringProperty("Project name (create)"),
+		"workspace_id": tools.StringProperty("Workspace ID (create)"),
+	}, "action")
+}
+
 func (t *AsanaProjectTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	action, ok := input.Params["action"].(string)
 	if !ok {
@@ -155,6 +199,17 @@ func (t *TrelloCardTool) Description() string {
 	return "Creates, updates, and manages Trello cards with checklists and labels"
 }
 
+func (t *TrelloCardTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"action":         tools.EnumProperty("Card action", "create", "update", "move", "add_checklist"),
+		"name":           tools.StringProperty("Card name (create)"),
+		"desc":           tools.StringProperty("Card description (create)"),
+		"list_id":        tools.StringProperty("List ID (create, move)"),
+		"card_id":        tools.StringProperty("Card ID (update, move, add_checklist)"),
+		"checklist_name": tools.StringProperty("Checklist name (add_checklist)"),
+	}, "action")
+}
+
 func (t *TrelloCardTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	action, ok := input.Params["action"].(string)
 	if !ok {
@@ -189,6 +244,13 @@ func (t *TrelloBoardTool) Description() string {
 	return "Creates and manages Trello boards with lists and power-ups"
 }
 
+func (t *TrelloBoardTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"action": tools.EnumProperty("Board action", "create", "list"),
+		"name":   tools.StringProperty("Board name (create)"),
+	}, "action")
+}
+
 func (t *TrelloBoardTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	action, ok := input.Params["action"].(string)
 	if !ok {
@@ -223,6 +285,17 @@ func (t *LinearIssueTool) Description() string {
 	return "Creates, updates, and manages Linear issues with cycles and projects"
 }
 
+func (t *LinearIssueTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"action":      tools.EnumProperty("Issue action", "create", "update", "search"),
+		"title":       tools.StringProperty("Issue title (create)"),
+		"description": tools.StringProperty("Issue description (create)"),
+		"team_id":     tools.StringProperty("Team ID (create)"),
+		"issue_id":    tools.StringProperty("Issue ID (update)"),
+		"query":       tools.StringProperty("Search query (search)"),
+	}, "action")
+}
+
 func (t *LinearIssueTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	action, ok := input.Params["action"].(string)
 	if !ok {
@@ -257,6 +330,16 @@ func (t *ClickUpTaskTool) Description() string {
 	return "Creates, updates, and manages ClickUp tasks with custom fields"
 }
 
+func (t *ClickUpTaskTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"action":      tools.EnumProperty("Task action", "create", "update"),
+		"name":        tools.StringProperty("Task name (create)"),
+		"description": tools.StringProperty("Task description (create)"),
+		"list_id":     tools.StringProperty("List ID (create)"),
+		"task_id":     tools.StringProperty("Task ID (update)"),
+	}, "action")
+}
+
 func (t *ClickUpTaskTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	action, ok := input.Params["action"].(string)
 	if !ok {
@@ -291,6 +374,15 @@ func (t *MondayItemTool) Description() string {
 	return "Creates, updates, and manages Monday.com items with column values"
 }
 
+func (t *MondayItemTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"action":    tools.EnumProperty("Item action", "create", "update", "query"),
+		"board_id":  tools.StringProperty("Board ID (create, query)"),
+		"item_name": tools.StringProperty("Item name (create)"),
+		"item_id":   tools.StringProperty("Item ID (update)"),
+	}, "action")
+}
+
 func (t *MondayItemTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	action, ok := input.Params["action"].(string)
 	if !ok {
@@ -0,0 +1,56 @@
+package domains
+
+import (
+	"testing"
+
+	"github.com/Ranganaths/minion/core"
+	"github.com/Ranganaths/minion/tools"
+	"github.com/Ranganaths/minion/tools/visualization"
+)
+
+func TestDomainToolsDeclareSchemas(t *testing.T) {
+	framework := core.NewFramework()
+	defer framework.Close()
+
+	if err := RegisterAllDomainTools(framework); err != nil {
+		t.Fatalf("RegisterAllDomainTools failed: %v", err)
+	}
+
+	all := []tools.Tool{
+		&visualization.BarChartTool{},
+		&visualization.LineChartTool{},
+		&visualization.PieChartTool{},
+		&visualization.TableVisualizerTool{},
+	}
+	for _, name := range framework.ListTools() {
+		tool, err := framework.GetTool(name)
+		if err != nil {
+			t.Fatalf("GetTool(%s) failed: %v", name, err)
+		}
+		all = append(all, tool)
+	}
+
+	for _, tool := range all {
+		schema := tools.InputSchema(tool)
+		if schema == nil {
+			t.Errorf("%s does not declare an input schema", tool.Name())
+			continue
+		}
+
+		if schema["type"] != "object" {
+			t.Errorf("%s: expected object schema, got %v", tool.Name(), schema["type"])
+		}
+		properties, ok := schema["properties"].(map[string]interface{})
+		if !ok || len(properties) == 0 {
+			t.Errorf("%s: schema has no properties", tool.Name())
+			continue
+		}
+		if required, ok := schema["required"].([]string); ok {
+			for _, field := range required {
+				if _, exists := properties[field]; !exists {
+					t.Errorf("%s: required field %s is not a declared property", tool.Name(), field)
+				}
+			}
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sort"
 
 	"github.com/Ranganaths/minion/models"
+	"github.com/Ranganaths/minion/tools"
 )
 
 // RevenueAnalyzerTool analyzes revenue metrics and trends
@@ -20,8 +21,14 @@ func (t *RevenueAnalyzerTool) Description() string {
 	return "Analyzes revenue data including trends, growth rates, and forecasts"
 }
 
+func (t *RevenueAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"revenues": tools.ArrayProperty("Revenue values in chronological order", tools.Items("number")),
+	}, "revenues")
+}
+
 func (t *RevenueAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	revenues, ok := input.Params["revenues"].([]float64)
+	revenues, ok := tools.Float64Slice(input.Params["revenues"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -60,6 +67,12 @@ func (t *PipelineAnalyzerTool) Description() string {
 	return "Analyzes sales pipeline health, conversion rates, and bottlenecks"
 }
 
+func (t *PipelineAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"stages": tools.ObjectProperty("Pipeline stages keyed by stage name", nil),
+	}, "stages")
+}
+
 func (t *PipelineAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	stages, ok := input.Params["stages"].(map[string]interface{})
 	if !ok {
@@ -94,8 +107,15 @@ func (t *CustomerSegmentationTool) Description() string {
 	return "Segments customers by revenue, behavior, or custom criteria"
 }
 
+func (t *CustomerSegmentationTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"customers": tools.ArrayProperty("Customers to segment, each with a revenue field", tools.Items("object")),
+		"criteria":  tools.StringProperty("Segmentation criteria (default: revenue)"),
+	}, "customers")
+}
+
 func (t *CustomerSegmentationTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	customers, ok := input.Params["customers"].([]map[string]interface{})
+	customers, ok := tools.MapSlice(input.Params["customers"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -133,6 +153,12 @@ func (t *DealScoringTool) Description() string {
 	return "Scores deals based on likelihood to close and priority"
 }
 
+func (t *DealScoringTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"deal": tools.ObjectProperty("Deal with value, stage and age_days fields", nil),
+	}, "deal")
+}
+
 func (t *DealScoringTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	deal, ok := input.Params["deal"].(map[string]interface{})
 	if !ok {
@@ -172,8 +198,15 @@ func (t *ForecastingTool) Description() string {
 	return "Generates sales forecasts based on historical data and trends"
 }
 
+func (t *ForecastingTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"historical_data": tools.ArrayProperty("Historical sales values in chronological order", tools.Items("number")),
+		"periods":         tools.IntegerProperty("Number of periods to forecast (default: 3)"),
+	}, "historical_data")
+}
+
 func (t *ForecastingTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	historicalData, ok := input.Params["historical_data"].([]float64)
+	historicalData, ok := tools.Float64Slice(input.Params["historical_data"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -183,7 +216,7 @@ func (t *ForecastingTool) Execute(ctx context.Context, input *models.ToolInput)
 	}
 
 	periods := 3 // default forecast periods
-	if p, ok := input.Params["periods"].(int); ok {
+	if p, ok := tools.Int(input.Params["periods"]); ok {
 		periods = p
 	}
 
@@ -211,8 +244,14 @@ func (t *ConversionRateAnalyzerTool) Description() string {
 	return "Analyzes conversion rates between pipeline stages and identifies bottlenecks"
 }
 
+func (t *ConversionRateAnalyzerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"stage_data": tools.ArrayProperty("Ordered funnel stages, each with name and count fields", tools.Items("object")),
+	}, "stage_data")
+}
+
 func (t *ConversionRateAnalyzerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	stageData, ok := input.Params["stage_data"].([]map[string]interface{})
+	stageData, ok := tools.MapSlice(input.Params["stage_data"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -245,6 +284,12 @@ func (t *ChurnPredictorTool) Description() string {
 	return "Predicts customer churn risk based on engagement and usage patterns"
 }
 
+func (t *ChurnPredictorTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"customer": tools.ObjectProperty("Customer with last_activity_days, support_tickets and contract_days_remaining fields", nil),
+	}, "customer")
+}
+
 func (t *ChurnPredictorTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	customer, ok := input.Params["customer"].(map[string]interface{})
 	if !ok {
@@ -284,8 +329,14 @@ func (t *QuotaAttainmentTool) Description() string {
 	return "Analyzes sales team quota attainment and performance metrics"
 }
 
+func (t *QuotaAttainmentTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"sales_data": tools.ArrayProperty("Sales reps, each with name, quota and actual fields", tools.Items("object")),
+	}, "sales_data")
+}
+
 func (t *QuotaAttainmentTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
-	salesData, ok := input.Params["sales_data"].([]map[string]interface{})
+	salesData, ok := tools.MapSlice(input.Params["sales_data"])
 	if !ok {
 		return &models.ToolOutput{
 			ToolName: t.Name(),
@@ -21,18 +21,39 @@ type Tool interface {
 	CanExecute(agent *models.Agent) bool
 }
 
+// SchemaProvider is implemented by tools that describe their input params
+// with a JSON Schema. The registry validates params against the schema before
+// execution, and the schema is offered to LLMs for native function calling.
+type SchemaProvider interface {
+	// InputSchema returns a JSON Schema of type "object" describing input.Params
+	InputSchema() map[string]interface{}
+}
+
+// InputSchema returns the tool's input schema, or nil if the tool does not
+// implement SchemaProvider
+func InputSchema(tool Tool) map[string]interface{} {
+	if provider, ok := tool.(SchemaProvider); ok {
+		return provider.InputSchema()
+	}
+	return nil
+}
+
 // Registry manages tool registration and execution
 type Registry interface {
 	// Register adds a tool to the registry
 	Register(tool Tool) error
 
+	// Unregister removes a tool from the registry
+	Unregister(name string) error
+
 	// Get retrieves a tool by name
 	Get(name string) (Tool, error)
 
 	// GetToolsForAgent returns tools available for an agent
 	GetToolsForAgent(agent *models.Agent) []Tool
 
-	// Execute runs a tool by name
+	// Execute runs a tool by name, validating params against its schema
+	// if the tool implements SchemaProvider
 	Execute(ctx context.Context, toolName string, input *models.ToolInput) (*models.ToolOutput, error)
 
 	// List returns all registered tool names
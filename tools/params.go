@@ -0,0 +1,116 @@
+package tools
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// Helpers for reading ToolInput params. Params decoded from JSON, such as
+// the arguments of an LLM tool call, hold []interface{} and float64 where Go
+// callers pass typed slices and ints. These helpers accept both forms.
+
+// Float64Slice converts an array of numbers to []float64
+func Float64Slice(v interface{}) ([]float64, bool) {
+	switch values := v.(type) {
+	case []float64:
+		return values, true
+	case []int:
+		result := make([]float64, len(values))
+		for i, value := range values {
+			result[i] = float64(value)
+		}
+		return result, true
+	case []interface{}:
+		result := make([]float64, len(values))
+		for i, value := range values {
+			f, ok := Float64(value)
+			if !ok {
+				return nil, false
+			}
+			result[i] = f
+		}
+		return result, true
+	default:
+		return nil, false
+	}
+}
+
+// MapSlice converts an array of objects to []map[string]interface{}
+func MapSlice(v interface{}) ([]map[string]interface{}, bool) {
+	switch values := v.(type) {
+	case []map[string]interface{}:
+		return values, true
+	case []interface{}:
+		result := make([]map[string]interface{}, len(values))
+		for i, value := range values {
+			m, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			result[i] = m
+		}
+		return result, true
+	default:
+		return nil, false
+	}
+}
+
+// StringSlice converts an array of strings to []string
+func StringSlice(v interface{}) ([]string, bool) {
+	switch values := v.(type) {
+	case []string:
+		return values, true
+	case []interface{}:
+		result := make([]string, len(values))
+		for i, value := range values {
+			s, ok := value.(string)
+			if !ok {
+				return nil, false
+			}
+			result[i] = s
+		}
+		return result, true
+	default:
+		return nil, false
+	}
+}
+
+// Float64 converts a number to float64
+func Float64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// Int converts a whole number to int. Numbers with a fractional part are
+// rejected.
+func Int(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case int32:
+		return int(n), true
+	default:
+		f, ok := Float64(v)
+		if !ok || f != math.Trunc(f) {
+			return 0, false
+		}
+		return int(f), true
+	}
+}
@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParamHelpers(t *testing.T) {
+	var params map[string]interface{}
+	if err := json.Unmarshal([]byte(`{
+		"numbers": [1, 2.5, 3],
+		"records": [{"id": 1}, {"id": 2}],
+		"names": ["a", "b"],
+		"mixed": [1, "two"],
+		"count": 4,
+		"ratio": 0.5
+	}`), &params); err != nil {
+		t.Fatalf("invalid test params: %v", err)
+	}
+
+	if got, ok := Float64Slice(params["numbers"]); !ok || !reflect.DeepEqual(got, []float64{1, 2.5, 3}) {
+		t.Errorf("Float64Slice() = %v, %v", got, ok)
+	}
+	if got, ok := Float64Slice([]int{1, 2}); !ok || !reflect.DeepEqual(got, []float64{1, 2}) {
+		t.Errorf("Float64Slice([]int) = %v, %v", got, ok)
+	}
+	if _, ok := Float64Slice(params["mixed"]); ok {
+		t.Error("Float64Slice() accepted a non-numeric element")
+	}
+
+	if got, ok := MapSlice(params["records"]); !ok || len(got) != 2 || got[1]["id"] != 2.0 {
+		t.Errorf("MapSlice() = %v, %v", got, ok)
+	}
+	if _, ok := MapSlice(params["numbers"]); ok {
+		t.Error("MapSlice() accepted non-object elements")
+	}
+
+	if got, ok := StringSlice(params["names"]); !ok || !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("StringSlice() = %v, %v", got, ok)
+	}
+	if _, ok := StringSlice(params["mixed"]); ok {
+		t.Error("StringSlice() accepted a non-string element")
+	}
+
+	if got, ok := Int(params["count"]); !ok || got != 4 {
+		t.Errorf("Int() = %v, %v", got, ok)
+	}
+	if _, ok := Int(params["ratio"]); ok {
+		t.Error("Int() accepted a fractional number")
+	}
+	if _, ok := Float64Slice(nil); ok {
+		t.Error("Float64Slice() accepted a missing param")
+	}
+}
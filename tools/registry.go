@@ -6,24 +6,28 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/Ranganaths/minion/jsonschema"
 	"github.com/Ranganaths/minion/models"
 )
 
 var (
 	ErrToolNotFound      = errors.New("tool not found")
 	ErrToolAlreadyExists = errors.New("tool already exists")
+	ErrInvalidParams     = errors.New("invalid tool params")
 )
 
 // InMemoryRegistry is a thread-safe in-memory tool registry
 type InMemoryRegistry struct {
-	tools map[string]Tool
-	mu    sync.RWMutex
+	tools     map[string]Tool
+	validator *jsonschema.Validator
+	mu        sync.RWMutex
 }
 
 // NewRegistry creates a new tool registry
 func NewRegistry() *InMemoryRegistry {
 	return &InMemoryRegistry{
-		tools: make(map[string]Tool),
+		tools:     make(map[string]Tool),
+		validator: jsonschema.NewValidator(false),
 	}
 }
 
@@ -41,6 +45,19 @@ func (r *InMemoryRegistry) Register(tool Tool) error {
 	return nil
 }
 
+// Unregister removes a tool from the registry
+func (r *InMemoryRegistry) Unregister(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tools[name]; !exists {
+		return fmt.Errorf("%w: %s", ErrToolNotFound, name)
+	}
+
+	delete(r.tools, name)
+	return nil
+}
+
 // Get retrieves a tool by name
 func (r *InMemoryRegistry) Get(name string) (Tool, error) {
 	r.mu.RLock()
@@ -69,13 +86,24 @@ func (r *InMemoryRegistry) GetToolsForAgent(agent *models.Agent) []Tool {
 	return availableTools
 }
 
-// Execute runs a tool by name
+// Execute runs a tool by name. Tools that implement SchemaProvider have
+// their params validated first; invalid params return ErrInvalidParams.
 func (r *InMemoryRegistry) Execute(ctx context.Context, toolName string, input *models.ToolInput) (*models.ToolOutput, error) {
 	tool, err := r.Get(toolName)
 	if err != nil {
 		return nil, err
 	}
 
+	if schema := InputSchema(tool); schema != nil {
+		var params map[string]interface{}
+		if input != nil {
+			params = input.Params
+		}
+		if err := r.validator.ValidateInput(params, schema); err != nil {
+			return nil, fmt.Errorf("%w for %s: %v", ErrInvalidParams, toolName, err)
+		}
+	}
+
 	return tool.Execute(ctx, input)
 }
 
@@ -0,0 +1,97 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ranganaths/minion/models"
+)
+
+// greetTool greets a person and declares a schema for its params
+type greetTool struct{}
+
+func (t *greetTool) Name() string        { return "greet" }
+func (t *greetTool) Description() string { return "Greets a person" }
+
+func (t *greetTool) InputSchema() map[string]interface{} {
+	return ObjectSchema(map[string]interface{}{
+		"name":  StringProperty("Name of the person"),
+		"times": IntegerProperty("Number of greetings"),
+		"tone":  EnumProperty("Greeting tone", "formal", "casual"),
+		"tags":  ArrayProperty("Tags", Items("string")),
+	}, "name")
+}
+
+func (t *greetTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
+	return &models.ToolOutput{ToolName: t.Name(), Success: true, Result: "Hello, " + input.Params["name"].(string)}, nil
+}
+
+func (t *greetTool) CanExecute(agent *models.Agent) bool { return true }
+
+func TestRegistryExecute_ValidatesSchema(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Register(&greetTool{}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr bool
+	}{
+		{name: "valid", params: map[string]interface{}{"name": "Ada", "times": 2, "tone": "casual"}},
+		{name: "typed slice", params: map[string]interface{}{"name": "Ada", "tags": []string{"a", "b"}}},
+		{name: "missing required", params: map[string]interface{}{"times": 2.0}, wantErr: true},
+		{name: "nil params", params: nil, wantErr: true},
+		{name: "wrong type", params: map[string]interface{}{"name": 42}, wantErr: true},
+		{name: "not in enum", params: map[string]interface{}{"name": "Ada", "tone": "rude"}, wantErr: true},
+		{name: "wrong item type", params: map[string]interface{}{"name": "Ada", "tags": []int{1}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := registry.Execute(context.Background(), "greet", &models.ToolInput{Params: tt.params})
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidParams) {
+					t.Errorf("expected ErrInvalidParams, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+			if output.Result != "Hello, Ada" {
+				t.Errorf("unexpected result: %v", output.Result)
+			}
+		})
+	}
+}
+
+func TestInputSchema(t *testing.T) {
+	if schema := InputSchema(&greetTool{}); schema["type"] != "object" {
+		t.Errorf("expected object schema, got %v", schema)
+	}
+}
+
+func TestRegistryUnregister(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Register(&greetTool{}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	if err := registry.Unregister("greet"); err != nil {
+		t.Fatalf("Unregister failed: %v", err)
+	}
+	if _, err := registry.Get("greet"); !errors.Is(err, ErrToolNotFound) {
+		t.Errorf("expected ErrToolNotFound after Unregister, got %v", err)
+	}
+	if err := registry.Unregister("greet"); !errors.Is(err, ErrToolNotFound) {
+		t.Errorf("expected ErrToolNotFound for unknown tool, got %v", err)
+	}
+
+	// The name can be registered again
+	if err := registry.Register(&greetTool{}); err != nil {
+		t.Errorf("re-Register failed: %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package tools
+
+// Helpers for declaring tool input schemas in Go. They produce plain JSON
+// Schema maps suitable for SchemaProvider.InputSchema and LLM function calling.
+
+// ObjectSchema returns an object schema with the given properties and required fields
+func ObjectSchema(properties map[string]interface{}, required ...string) map[string]interface{} {
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// StringProperty describes a string parameter
+func StringProperty(description string) map[string]interface{} {
+	return map[string]interface{}{"type": "string", "description": description}
+}
+
+// EnumProperty describes a string parameter restricted to the given values
+func EnumProperty(description string, values ...string) map[string]interface{} {
+	return map[string]interface{}{"type": "string", "description": description, "enum": values}
+}
+
+// NumberProperty describes a numeric parameter
+func NumberProperty(description string) map[string]interface{} {
+	return map[string]interface{}{"type": "number", "description": description}
+}
+
+// IntegerProperty describes an integer parameter
+func IntegerProperty(description string) map[string]interface{} {
+	return map[string]interface{}{"type": "integer", "description": description}
+}
+
+// BooleanProperty describes a boolean parameter
+func BooleanProperty(description string) map[string]interface{} {
+	return map[string]interface{}{"type": "boolean", "description": description}
+}
+
+// ArrayProperty describes an array parameter whose elements match items.
+// A nil items schema leaves the element type unconstrained.
+func ArrayProperty(description string, items map[string]interface{}) map[string]interface{} {
+	schema := map[string]interface{}{"type": "array", "description": description}
+	if items != nil {
+		schema["items"] = items
+	}
+	return schema
+}
+
+// ObjectProperty describes an object parameter, optionally with known properties
+func ObjectProperty(description string, properties map[string]interface{}) map[string]interface{} {
+	schema := map[string]interface{}{"type": "object", "description": description}
+	if properties != nil {
+		schema["properties"] = properties
+	}
+	return schema
+}
+
+// Items returns an item schema of the given JSON type for ArrayProperty
+func Items(jsonType string) map[string]interface{} {
+	return map[string]interface{}{"type": jsonType}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/Ranganaths/minion/models"
+	"github.com/Ranganaths/minion/tools"
 )
 
 // ChartType represents different types of charts
@@ -61,6 +62,18 @@ func (t *BarChartTool) Description() string {
 	return "Creates bar charts for comparing values across categories. Ideal for sales by region, product comparisons, monthly revenue, etc."
 }
 
+func (t *BarChartTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"title":        tools.StringProperty("Chart title"),
+		"description":  tools.StringProperty("Chart description"),
+		"categories":   tools.ArrayProperty("Category labels for the x axis", nil),
+		"series":       tools.ArrayProperty("Data series, each with name, data and optional color fields", tools.Items("object")),
+		"x_axis_label": tools.StringProperty("X axis label"),
+		"y_axis_label": tools.StringProperty("Y axis label"),
+		"stacked":      tools.BooleanProperty("Stack the series"),
+	}, "categories")
+}
+
 func (t *BarChartTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	// Extract parameters
 	title, _ := input.Params["title"].(string)
@@ -160,6 +173,18 @@ func (t *LineChartTool) Description() string {
 	return "Creates line charts for showing trends over time. Perfect for revenue trends, sales growth, KPI tracking, etc."
 }
 
+func (t *LineChartTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"title":        tools.StringProperty("Chart title"),
+		"description":  tools.StringProperty("Chart description"),
+		"x_data":       tools.ArrayProperty("X axis values, e.g. dates", nil),
+		"series":       tools.ArrayProperty("Data series, each with name, data and optional color fields", tools.Items("object")),
+		"x_axis_label": tools.StringProperty("X axis label"),
+		"y_axis_label": tools.StringProperty("Y axis label"),
+		"smooth":       tools.BooleanProperty("Draw smoothed lines"),
+	}, "x_data")
+}
+
 func (t *LineChartTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	// Extract parameters
 	title, _ := input.Params["title"].(string)
@@ -255,6 +280,15 @@ func (t *PieChartTool) Description() string {
 	return "Creates pie charts for showing proportions and distributions. Great for market share, revenue by product, customer segments, etc."
 }
 
+func (t *PieChartTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"title":           tools.StringProperty("Chart title"),
+		"description":     tools.StringProperty("Chart description"),
+		"data":            tools.ArrayProperty("Slices, each with name and value fields", tools.Items("object")),
+		"show_percentage": tools.BooleanProperty("Label slices with their percentage"),
+	}, "data")
+}
+
 func (t *PieChartTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	// Extract parameters
 	title, _ := input.Params["title"].(string)
@@ -346,6 +380,17 @@ func (t *TableVisualizerTool) Description() string {
 	return "Creates formatted tables for displaying detailed data. Supports sorting, filtering, and highlighting."
 }
 
+func (t *TableVisualizerTool) InputSchema() map[string]interface{} {
+	return tools.ObjectSchema(map[string]interface{}{
+		"title":       tools.StringProperty("Table title"),
+		"description": tools.StringProperty("Table description"),
+		"columns":     tools.ArrayProperty("Column names or definitions", nil),
+		"rows":        tools.ArrayProperty("Table rows as arrays or objects", nil),
+		"sortable":    tools.BooleanProperty("Allow sorting by column"),
+		"filterable":  tools.BooleanProperty("Allow filtering rows"),
+	}, "columns", "rows")
+}
+
 func (t *TableVisualizerTool) Execute(ctx context.Context, input *models.ToolInput) (*models.ToolOutput, error) {
 	// Extract parameters
 	title, _ := input.Params["title"].(string)
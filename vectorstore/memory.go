@@ -238,51 +238,7 @@ func (vs *MemoryVectorStore) MaxMarginalRelevanceSearch(ctx context.Context, que
 		return nil, err
 	}
 
-	if len(candidates) == 0 {
-		return nil, nil
-	}
-
-	// Apply MMR algorithm
-	selected := make([]Document, 0, k)
-	selectedEmbeddings := make([][]float32, 0, k)
-	remaining := make([]SearchResult, len(candidates))
-	copy(remaining, candidates)
-
-	for len(selected) < k && len(remaining) > 0 {
-		var bestIdx int
-		var bestScore float32 = -1000
-
-		for i, candidate := range remaining {
-			// Calculate relevance to query
-			relevance := embeddings.CosineSimilarity(queryEmbedding, candidate.Document.Embedding)
-
-			// Calculate max similarity to already selected documents
-			var maxSimilarity float32 = 0
-			for _, selEmb := range selectedEmbeddings {
-				sim := embeddings.CosineSimilarity(candidate.Document.Embedding, selEmb)
-				if sim > maxSimilarity {
-					maxSimilarity = sim
-				}
-			}
-
-			// MMR score: lambda * relevance - (1 - lambda) * max_similarity
-			mmrScore := lambda*relevance - (1-lambda)*maxSimilarity
-
-			if mmrScore > bestScore {
-				bestScore = mmrScore
-				bestIdx = i
-			}
-		}
-
-		// Add best candidate to selected
-		selected = append(selected, remaining[bestIdx].Document)
-		selectedEmbeddings = append(selectedEmbeddings, remaining[bestIdx].Document.Embedding)
-
-		// Remove from remaining
-		remaining = append(remaining[:bestIdx], remaining[bestIdx+1:]...)
-	}
-
-	return selected, nil
+	return MaxMarginalRelevance(queryEmbedding, candidates, k, lambda), nil
 }
 
 // Delete removes documents by their IDs
@@ -0,0 +1,232 @@
+package pgvector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/Ranganaths/minion/vectorstore"
+)
+
+// IndexType is the approximate nearest neighbor index type
+type IndexType string
+
+const (
+	// IndexNone performs exact nearest neighbor search
+	IndexNone IndexType = ""
+
+	// IndexHNSW builds a hierarchical navigable small world graph.
+	// Slower to build than IVFFlat but with a better speed/recall tradeoff,
+	// and it can be created before any data is loaded.
+	IndexHNSW IndexType = "hnsw"
+
+	// IndexIVFFlat partitions vectors into lists. The lists are computed
+	// from existing rows, so create or Reindex it after loading data.
+	IndexIVFFlat IndexType = "ivfflat"
+)
+
+// IndexConfig configures a collection's index and its query-time tuning
+type IndexConfig struct {
+	// Type is the index type (default: none)
+	Type IndexType
+
+	// M is the max number of connections per HNSW layer (pgvector default: 16)
+	M int
+
+	// EfConstruction is the HNSW candidate list size during build (pgvector default: 64)
+	EfConstruction int
+
+	// Lists is the number of IVFFlat lists (pgvector recommends rows/1000 up to 1M rows)
+	Lists int
+
+	// EfSearch is the HNSW candidate list size during search (pgvector default: 40)
+	EfSearch int
+
+	// Probes is the number of IVFFlat lists searched (pgvector default: 1)
+	Probes int
+}
+
+// DefaultIndexConfig returns an HNSW index configuration with pgvector's defaults
+func DefaultIndexConfig() IndexConfig {
+	return IndexConfig{
+		Type:           IndexHNSW,
+		M:              16,
+		EfConstruction: 64,
+	}
+}
+
+// CreateIndex creates the configured index for the collection if it does
+// not exist. Each collection gets a partial expression index because the
+// embedding column is shared by collections of different dimensions.
+func (vs *PGVectorStore) CreateIndex(ctx context.Context) error {
+	stmt, err := createIndexStatement(vs.collection, vs.distanceMetric, vs.dimensions, vs.index)
+	if err != nil {
+		return err
+	}
+	if _, err := vs.db.ExecContext(ctx, stmt); err != nil {
+		return fmt.Errorf("failed to create %s index: %w", vs.index.Type, err)
+	}
+	return nil
+}
+
+// DropIndex drops the collection's HNSW and IVFFlat indexes
+func (vs *PGVectorStore) DropIndex(ctx context.Context) error {
+	for _, indexType := range []IndexType{IndexHNSW, IndexIVFFlat} {
+		stmt := "DROP INDEX IF EXISTS " + pq.QuoteIdentifier(indexName(vs.collection, indexType))
+		if _, err := vs.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to drop %s index: %w", indexType, err)
+		}
+	}
+	return nil
+}
+
+// Reindex rebuilds the configured index, which recomputes IVFFlat lists
+// from the current rows
+func (vs *PGVectorStore) Reindex(ctx context.Context) error {
+	if vs.index.Type == IndexNone {
+		return fmt.Errorf("no index configured for collection %s", vs.collection)
+	}
+	stmt := "REINDEX INDEX " + pq.QuoteIdentifier(indexName(vs.collection, vs.index.Type))
+	if _, err := vs.db.ExecContext(ctx, stmt); err != nil {
+		return fmt.Errorf("failed to reindex: %w", err)
+	}
+	return nil
+}
+
+// createIndexStatement builds the CREATE INDEX statement for a collection
+func createIndexStatement(collection string, metric vectorstore.DistanceMetric, dimensions int, cfg IndexConfig) (string, error) {
+	var opClass string
+	switch metric {
+	case vectorstore.DistanceCosine:
+		opClass = "vector_cosine_ops"
+	case vectorstore.DistanceEuclidean:
+		opClass = "vector_l2_ops"
+	case vectorstore.DistanceDotProduct:
+		opClass = "vector_ip_ops"
+	default:
+		return "", fmt.Errorf("unsupported distance metric: %s", metric)
+	}
+
+	var params []string
+	switch cfg.Type {
+	case IndexHNSW:
+		if cfg.M > 0 {
+			params = append(params, fmt.Sprintf("m = %d", cfg.M))
+		}
+		if cfg.EfConstruction > 0 {
+			params = append(params, fmt.Sprintf("ef_construction = %d", cfg.EfConstruction))
+		}
+	case IndexIVFFlat:
+		if cfg.Lists > 0 {
+			params = append(params, fmt.Sprintf("lists = %d", cfg.Lists))
+		}
+	default:
+		return "", fmt.Errorf("unsupported index type: %q", cfg.Type)
+	}
+
+	stmt := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON vector_documents USING %s ((embedding::vector(%d)) %s)",
+		pq.QuoteIdentifier(indexName(collection, cfg.Type)), cfg.Type, dimensions, opClass)
+	if len(params) > 0 {
+		stmt += " WITH (" + strings.Join(params, ", ") + ")"
+	}
+	stmt += " WHERE collection = " + pq.QuoteLiteral(collection)
+	return stmt, nil
+}
+
+// indexName returns a stable index name for a collection. The hash keeps
+// names unique after sanitizing and within PostgreSQL's 63 byte limit.
+func indexName(collection string, indexType IndexType) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(collection) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	name := b.String()
+	if len(name) > 24 {
+		name = name[:24]
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(collection))
+	return fmt.Sprintf("vector_documents_%s_%08x_%s_idx", name, h.Sum32(), indexType)
+}
+
+// queryer is implemented by *sql.DB and *sql.Tx
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// withSearchSettings runs fn with the configured query-time index settings.
+// Settings are applied with SET LOCAL inside a read-only transaction so
+// they do not leak to other users of the connection pool.
+func (vs *PGVectorStore) withSearchSettings(ctx context.Context, fn func(queryer) error) error {
+	var settings []string
+	if vs.index.EfSearch > 0 {
+		settings = append(settings, fmt.Sprintf("SET LOCAL hnsw.ef_search = %d", vs.index.EfSearch))
+	}
+	if vs.index.Probes > 0 {
+		settings = append(settings, fmt.Sprintf("SET LOCAL ivfflat.probes = %d", vs.index.Probes))
+	}
+	if len(settings) == 0 {
+		return fn(vs.db)
+	}
+
+	tx, err := vs.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, setting := range settings {
+		if _, err := tx.ExecContext(ctx, setting); err != nil {
+			return fmt.Errorf("failed to apply search setting: %w", err)
+		}
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// schemaSQL mirrors migrations/002_vector_store.up.sql
+const schemaSQL = `
+CREATE EXTENSION IF NOT EXISTS vector;
+
+CREATE TABLE IF NOT EXISTS vector_collections (
+    name VARCHAR(255) PRIMARY KEY,
+    dimensions INTEGER NOT NULL,
+    distance_metric VARCHAR(20) NOT NULL DEFAULT 'cosine',
+    metadata JSONB DEFAULT '{}'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT vector_collections_dimensions_check CHECK (dimensions > 0),
+    CONSTRAINT vector_collections_metric_check CHECK (distance_metric IN ('cosine', 'euclidean', 'dot_product'))
+);
+
+CREATE TABLE IF NOT EXISTS vector_documents (
+    collection VARCHAR(255) NOT NULL REFERENCES vector_collections(name) ON DELETE CASCADE,
+    id VARCHAR(255) NOT NULL,
+    content TEXT NOT NULL,
+    metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+    embedding vector NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (collection, id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_vector_documents_metadata ON vector_documents USING GIN (metadata);
+`
+
+// EnsureSchema creates the vector store tables if they do not exist
+func EnsureSchema(ctx context.Context, db *sql.DB) error {
+	if _, err := db.ExecContext(ctx, schemaSQL); err != nil {
+		return fmt.Errorf("failed to create vector store schema: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package pgvector
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Ranganaths/minion/vectorstore"
+)
+
+// queryBuilder accumulates positional query arguments
+type queryBuilder struct {
+	args []any
+}
+
+// arg adds an argument and returns its placeholder
+func (b *queryBuilder) arg(v any) string {
+	b.args = append(b.args, v)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+// buildSearchQuery builds the nearest neighbor query for a collection.
+// The embedding is cast to the collection's dimension so the query matches
+// the collection's partial expression index.
+func buildSearchQuery(collection string, metric vectorstore.DistanceMetric, dimensions int, embedding []float32, k int, filters []vectorstore.Filter) (string, []any, error) {
+	op, err := distanceOperator(metric)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var b queryBuilder
+	distance := fmt.Sprintf("(embedding::vector(%d)) %s %s::vector(%d)", dimensions, op, b.arg(EncodeVector(embedding)), dimensions)
+
+	conditions := []string{"collection = " + b.arg(collection)}
+	for _, filter := range filters {
+		condition, err := b.filter(filter)
+		if err != nil {
+			return "", nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT id, content, metadata, embedding::text, %s AS distance FROM vector_documents WHERE %s ORDER BY distance LIMIT %s",
+		distance, strings.Join(conditions, " AND "), b.arg(k))
+	return query, b.args, nil
+}
+
+// filter translates a metadata filter to a JSONB predicate. As with
+// MemoryVectorStore, documents without the field never match.
+func (b *queryBuilder) filter(f vectorstore.Filter) (string, error) {
+	if f.Field == "" {
+		return "", fmt.Errorf("filter field is required")
+	}
+
+	switch f.Operator {
+	case vectorstore.FilterEquals:
+		contains, err := json.Marshal(map[string]any{f.Field: f.Value})
+		if err != nil {
+			return "", fmt.Errorf("invalid value for filter on %s: %w", f.Field, err)
+		}
+		return fmt.Sprintf("metadata @> %s::jsonb", b.arg(string(contains))), nil
+
+	case vectorstore.FilterNotEquals:
+		contains, err := json.Marshal(map[string]any{f.Field: f.Value})
+		if err != nil {
+			return "", fmt.Errorf("invalid value for filter on %s: %w", f.Field, err)
+		}
+		return fmt.Sprintf("(metadata ? %s AND NOT metadata @> %s::jsonb)", b.arg(f.Field), b.arg(string(contains))), nil
+
+	case vectorstore.FilterGreaterThan, vectorstore.FilterLessThan:
+		cmp := ">"
+		if f.Operator == vectorstore.FilterLessThan {
+			cmp = "<"
+		}
+		field := b.arg(f.Field)
+		switch {
+		case isNumber(f.Value):
+			// CASE guards the cast from non-numeric values
+			return fmt.Sprintf("(CASE WHEN jsonb_typeof(metadata->%s) = 'number' THEN (metadata->>%s)::numeric %s %s::numeric ELSE false END)",
+				field, field, cmp, b.arg(f.Value)), nil
+		case isString(f.Value):
+			return fmt.Sprintf("(jsonb_typeof(metadata->%s) = 'string' AND metadata->>%s %s %s)",
+				field, field, cmp, b.arg(f.Value)), nil
+		default:
+			return "", fmt.Errorf("filter %s on %s requires a number or string, got %T", f.Operator, f.Field, f.Value)
+		}
+
+	case vectorstore.FilterIn:
+		if f.Value == nil || reflect.TypeOf(f.Value).Kind() != reflect.Slice {
+			return "", fmt.Errorf("filter in on %s requires a slice, got %T", f.Field, f.Value)
+		}
+		list, err := json.Marshal(f.Value)
+		if err != nil {
+			return "", fmt.Errorf("invalid value for filter on %s: %w", f.Field, err)
+		}
+		return fmt.Sprintf("metadata->%s IN (SELECT jsonb_array_elements(%s::jsonb))", b.arg(f.Field), b.arg(string(list))), nil
+
+	case vectorstore.FilterContains:
+		substr, ok := f.Value.(string)
+		if !ok {
+			return "", fmt.Errorf("filter contains on %s requires a string, got %T", f.Field, f.Value)
+		}
+		field := b.arg(f.Field)
+		return fmt.Sprintf("(jsonb_typeof(metadata->%s) = 'string' AND strpos(metadata->>%s, %s) > 0)", field, field, b.arg(substr)), nil
+
+	default:
+		return "", fmt.Errorf("unsupported filter operator: %s", f.Operator)
+	}
+}
+
+// isNumber reports whether v is a Go numeric value
+func isNumber(v any) bool {
+	if v == nil {
+		return false
+	}
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// isString reports whether v is a string
+func isString(v any) bool {
+	_, ok := v.(string)
+	return ok
+}
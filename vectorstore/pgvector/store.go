@@ -0,0 +1,513 @@
+// Package pgvector provides a persistent vectorstore.VectorStoreRetriever
+// backed by PostgreSQL and the pgvector extension.
+//
+// Documents are stored in named collections (see migrations/002_vector_store.up.sql).
+// Each collection has a fixed embedding dimension and distance metric, and
+// may have its own HNSW or IVFFlat index.
+package pgvector
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/Ranganaths/minion/embeddings"
+	"github.com/Ranganaths/minion/metrics"
+	"github.com/Ranganaths/minion/vectorstore"
+)
+
+// ErrCollectionMismatch is returned when an existing collection was created
+// with a different dimension or distance metric than configured
+var ErrCollectionMismatch = errors.New("collection configuration mismatch")
+
+// DefaultCollectionName is used when no collection name is configured
+const DefaultCollectionName = "default"
+
+// PGVectorStoreConfig configures the pgvector store
+type PGVectorStoreConfig struct {
+	// DB is an existing database connection. If nil, DSN is used to open one.
+	DB *sql.DB
+
+	// DSN is the PostgreSQL connection string, used when DB is nil
+	DSN string
+
+	// Embedder is the embedding provider
+	Embedder embeddings.Embedder
+
+	// CollectionName is the collection documents are stored in (default: "default")
+	CollectionName string
+
+	// DistanceMetric is the distance metric to use (default: cosine)
+	DistanceMetric vectorstore.DistanceMetric
+
+	// Dimensions is the embedding dimension (default: Embedder.Dimension())
+	Dimensions int
+
+	// Index configures the approximate nearest neighbor index for the collection
+	Index IndexConfig
+
+	// CreateSchema creates the vector store tables if they do not exist.
+	// Production deployments should apply migrations/002_vector_store.up.sql instead.
+	CreateSchema bool
+}
+
+// PGVectorStore is a vector store backed by PostgreSQL with pgvector.
+// PGVectorStore is safe for concurrent use by multiple goroutines.
+type PGVectorStore struct {
+	db             *sql.DB
+	ownsDB         bool
+	embedder       embeddings.Embedder
+	collection     string
+	distanceMetric vectorstore.DistanceMetric
+	dimensions     int
+	index          IndexConfig
+	// Metrics
+	searchesTotal  metrics.Counter
+	searchDuration metrics.Histogram
+}
+
+// Ensure PGVectorStore implements the vector store interfaces
+var _ vectorstore.VectorStoreRetriever = (*PGVectorStore)(nil)
+
+// NewPGVectorStore creates a pgvector store, registering its collection and
+// creating the configured index if they do not exist
+func NewPGVectorStore(ctx context.Context, cfg PGVectorStoreConfig) (*PGVectorStore, error) {
+	if cfg.Embedder == nil {
+		return nil, fmt.Errorf("embedder is required")
+	}
+
+	metric := cfg.DistanceMetric
+	if metric == "" {
+		metric = vectorstore.DistanceCosine
+	}
+	if _, err := distanceOperator(metric); err != nil {
+		return nil, err
+	}
+
+	dimensions := cfg.Dimensions
+	if dimensions == 0 {
+		dimensions = cfg.Embedder.Dimension()
+	}
+	if dimensions <= 0 {
+		return nil, fmt.Errorf("dimensions must be positive, got %d", dimensions)
+	}
+
+	collection := cfg.CollectionName
+	if collection == "" {
+		collection = DefaultCollectionName
+	}
+
+	db, ownsDB := cfg.DB, false
+	if db == nil {
+		if cfg.DSN == "" {
+			return nil, fmt.Errorf("either DB or DSN is required")
+		}
+		var err error
+		db, err = sql.Open("postgres", cfg.DSN)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database: %w", err)
+		}
+		if err := db.PingContext(ctx); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to ping database: %w", err)
+		}
+		ownsDB = true
+	}
+
+	m := metrics.GetMetrics()
+	vs := &PGVectorStore{
+		db:             db,
+		ownsDB:         ownsDB,
+		embedder:       cfg.Embedder,
+		collection:     collection,
+		distanceMetric: metric,
+		dimensions:     dimensions,
+		index:          cfg.Index,
+		searchesTotal:  m.Counter(metrics.MetricVectorStoreSearches, nil),
+		searchDuration: m.Histogram(metrics.MetricVectorStoreSearchDuration, nil),
+	}
+
+	if err := vs.init(ctx, cfg.CreateSchema); err != nil {
+		if ownsDB {
+			db.Close()
+		}
+		return nil, err
+	}
+	return vs, nil
+}
+
+// init creates the schema if requested, registers the collection and
+// creates its index
+func (vs *PGVectorStore) init(ctx context.Context, createSchema bool) error {
+	if createSchema {
+		if err := EnsureSchema(ctx, vs.db); err != nil {
+			return err
+		}
+	}
+
+	_, err := vs.db.ExecContext(ctx,
+		`INSERT INTO vector_collections (name, dimensions, distance_metric) VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO NOTHING`,
+		vs.collection, vs.dimensions, string(vs.distanceMetric))
+	if err != nil {
+		return fmt.Errorf("failed to create collection: %w", err)
+	}
+
+	var dimensions int
+	var metric string
+	err = vs.db.QueryRowContext(ctx,
+		`SELECT dimensions, distance_metric FROM vector_collections WHERE name = $1`,
+		vs.collection).Scan(&dimensions, &metric)
+	if err != nil {
+		return fmt.Errorf("failed to load collection: %w", err)
+	}
+	if dimensions != vs.dimensions || metric != string(vs.distanceMetric) {
+		return fmt.Errorf("%w: %s has %d dimensions and %s distance, configured %d and %s",
+			ErrCollectionMismatch, vs.collection, dimensions, metric, vs.dimensions, vs.distanceMetric)
+	}
+
+	if vs.index.Type != IndexNone {
+		return vs.CreateIndex(ctx)
+	}
+	return nil
+}
+
+// AddDocuments adds documents to the collection, replacing documents with
+// the same ID. Documents without an ID are assigned a UUID.
+// The input slice is not modified.
+func (vs *PGVectorStore) AddDocuments(ctx context.Context, docs []vectorstore.Document) ([]string, error) {
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	// Collect texts that need embedding
+	var textsToEmbed []string
+	var embedIndices []int
+	for i, doc := range docs {
+		if len(doc.Embedding) == 0 {
+			textsToEmbed = append(textsToEmbed, doc.PageContent)
+			embedIndices = append(embedIndices, i)
+		}
+	}
+
+	vectors := make([][]float32, len(docs))
+	for i, doc := range docs {
+		vectors[i] = doc.Embedding
+	}
+	if len(textsToEmbed) > 0 {
+		newEmbeddings, err := vs.embedder.EmbedDocuments(ctx, textsToEmbed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed documents: %w", err)
+		}
+		for i, embedding := range newEmbeddings {
+			vectors[embedIndices[i]] = embedding
+		}
+	}
+
+	tx, err := vs.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx,
+		`INSERT INTO vector_documents (collection, id, content, metadata, embedding)
+		VALUES ($1, $2, $3, $4, $5::vector)
+		ON CONFLICT (collection, id) DO UPDATE SET
+			content = EXCLUDED.content,
+			metadata = EXCLUDED.metadata,
+			embedding = EXCLUDED.embedding,
+			updated_at = CURRENT_TIMESTAMP`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare insert: %w", err)
+	}
+	defer stmt.Close()
+
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		if len(vectors[i]) != vs.dimensions {
+			return nil, fmt.Errorf("document %d has %d dimensions, collection %s requires %d",
+				i, len(vectors[i]), vs.collection, vs.dimensions)
+		}
+
+		id := doc.ID
+		if id == "" {
+			id = uuid.NewString()
+		}
+
+		metadata := doc.Metadata
+		if metadata == nil {
+			metadata = map[string]any{}
+		}
+		metadataJSON, err := json.Marshal(metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal metadata for document %s: %w", id, err)
+		}
+
+		if _, err := stmt.ExecContext(ctx, vs.collection, id, doc.PageContent, metadataJSON, EncodeVector(vectors[i])); err != nil {
+			return nil, fmt.Errorf("failed to insert document %s: %w", id, err)
+		}
+		ids[i] = id
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit documents: %w", err)
+	}
+	return ids, nil
+}
+
+// SimilaritySearch finds the most similar documents to a query
+func (vs *PGVectorStore) SimilaritySearch(ctx context.Context, query string, k int) ([]vectorstore.Document, error) {
+	results, err := vs.SimilaritySearchWithScore(ctx, query, k)
+	if err != nil {
+		return nil, err
+	}
+	return documents(results), nil
+}
+
+// SimilaritySearchWithScore returns documents with their similarity scores
+func (vs *PGVectorStore) SimilaritySearchWithScore(ctx context.Context, query string, k int) ([]vectorstore.SearchResult, error) {
+	return vs.SearchWithFilter(ctx, query, k, nil)
+}
+
+// SimilaritySearchByVector searches using a pre-computed embedding
+func (vs *PGVectorStore) SimilaritySearchByVector(ctx context.Context, embedding []float32, k int) ([]vectorstore.Document, error) {
+	results, err := vs.search(ctx, embedding, k, nil)
+	if err != nil {
+		return nil, err
+	}
+	return documents(results), nil
+}
+
+// SearchWithFilter performs similarity search with metadata filters.
+// Filters are evaluated in the database against the JSONB metadata column.
+func (vs *PGVectorStore) SearchWithFilter(ctx context.Context, query string, k int, filters []vectorstore.Filter) ([]vectorstore.SearchResult, error) {
+	queryEmbedding, err := vs.embedder.EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	return vs.search(ctx, queryEmbedding, k, filters)
+}
+
+// MaxMarginalRelevanceSearch performs MMR search for diversity. The fetchK
+// nearest documents are retrieved from the database and reranked locally.
+func (vs *PGVectorStore) MaxMarginalRelevanceSearch(ctx context.Context, query string, k int, fetchK int, lambda float32) ([]vectorstore.Document, error) {
+	if k <= 0 {
+		return nil, fmt.Errorf("k must be positive, got %d", k)
+	}
+	if lambda < 0 || lambda > 1 {
+		return nil, fmt.Errorf("lambda must be between 0 and 1, got %f", lambda)
+	}
+
+	queryEmbedding, err := vs.embedder.EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+
+	if fetchK < k {
+		fetchK = k * 2
+	}
+
+	candidates, err := vs.search(ctx, queryEmbedding, fetchK, nil)
+	if err != nil {
+		return nil, err
+	}
+	return vectorstore.MaxMarginalRelevance(queryEmbedding, candidates, k, lambda), nil
+}
+
+// Delete removes documents by their IDs
+func (vs *PGVectorStore) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := vs.db.ExecContext(ctx,
+		`DELETE FROM vector_documents WHERE collection = $1 AND id = ANY($2)`,
+		vs.collection, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to delete documents: %w", err)
+	}
+	return nil
+}
+
+// Count returns the number of documents in the collection
+func (vs *PGVectorStore) Count(ctx context.Context) (int, error) {
+	var count int
+	err := vs.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM vector_documents WHERE collection = $1`,
+		vs.collection).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count documents: %w", err)
+	}
+	return count, nil
+}
+
+// Clear removes all documents from the collection
+func (vs *PGVectorStore) Clear(ctx context.Context) error {
+	if _, err := vs.db.ExecContext(ctx, `DELETE FROM vector_documents WHERE collection = $1`, vs.collection); err != nil {
+		return fmt.Errorf("failed to clear collection: %w", err)
+	}
+	return nil
+}
+
+// DeleteCollection removes the collection, its documents and its indexes.
+// After calling DeleteCollection, the vector store should not be used.
+func (vs *PGVectorStore) DeleteCollection(ctx context.Context) error {
+	if err := vs.DropIndex(ctx); err != nil {
+		return err
+	}
+	if _, err := vs.db.ExecContext(ctx, `DELETE FROM vector_collections WHERE name = $1`, vs.collection); err != nil {
+		return fmt.Errorf("failed to delete collection: %w", err)
+	}
+	return nil
+}
+
+// Collection returns the name of the collection the store operates on
+func (vs *PGVectorStore) Collection() string {
+	return vs.collection
+}
+
+// Close releases the database connection if the store opened it.
+// After calling Close, the vector store should not be used.
+func (vs *PGVectorStore) Close() error {
+	if vs.ownsDB {
+		return vs.db.Close()
+	}
+	return nil
+}
+
+// search runs a nearest neighbor query and converts distances to scores
+func (vs *PGVectorStore) search(ctx context.Context, embedding []float32, k int, filters []vectorstore.Filter) ([]vectorstore.SearchResult, error) {
+	if k <= 0 {
+		return nil, fmt.Errorf("k must be positive, got %d", k)
+	}
+	if len(embedding) != vs.dimensions {
+		return nil, fmt.Errorf("query has %d dimensions, collection %s requires %d", len(embedding), vs.collection, vs.dimensions)
+	}
+
+	start := time.Now()
+	vs.searchesTotal.Inc()
+	defer func() {
+		vs.searchDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	query, args, err := buildSearchQuery(vs.collection, vs.distanceMetric, vs.dimensions, embedding, k, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []vectorstore.SearchResult
+	err = vs.withSearchSettings(ctx, func(q queryer) error {
+		rows, err := q.QueryContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to search: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var doc vectorstore.Document
+			var metadataJSON []byte
+			var vector string
+			var distance float64
+			if err := rows.Scan(&doc.ID, &doc.PageContent, &metadataJSON, &vector, &distance); err != nil {
+				return fmt.Errorf("failed to scan document: %w", err)
+			}
+			if err := json.Unmarshal(metadataJSON, &doc.Metadata); err != nil {
+				return fmt.Errorf("failed to unmarshal metadata for document %s: %w", doc.ID, err)
+			}
+			if doc.Embedding, err = ParseVector(vector); err != nil {
+				return fmt.Errorf("failed to parse embedding for document %s: %w", doc.ID, err)
+			}
+			results = append(results, vectorstore.SearchResult{
+				Document: doc,
+				Score:    score(vs.distanceMetric, distance),
+			})
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// distanceOperator returns the pgvector operator for a distance metric
+func distanceOperator(metric vectorstore.DistanceMetric) (string, error) {
+	switch metric {
+	case vectorstore.DistanceCosine:
+		return "<=>", nil
+	case vectorstore.DistanceEuclidean:
+		return "<->", nil
+	case vectorstore.DistanceDotProduct:
+		return "<#>", nil
+	default:
+		return "", fmt.Errorf("unsupported distance metric: %s", metric)
+	}
+}
+
+// score converts a pgvector distance to a similarity score on the same
+// scale as MemoryVectorStore
+func score(metric vectorstore.DistanceMetric, distance float64) float32 {
+	switch metric {
+	case vectorstore.DistanceEuclidean:
+		return float32(1 / (1 + distance))
+	case vectorstore.DistanceDotProduct:
+		// <#> returns the negative inner product
+		return float32(-distance)
+	default: // Cosine
+		return float32(1 - distance)
+	}
+}
+
+// documents strips scores from search results
+func documents(results []vectorstore.SearchResult) []vectorstore.Document {
+	docs := make([]vectorstore.Document, len(results))
+	for i, result := range results {
+		docs[i] = result.Document
+	}
+	return docs
+}
+
+// EncodeVector formats an embedding in pgvector's text representation
+func EncodeVector(v []float32) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, f := range v {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(f), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// ParseVector parses pgvector's text representation of an embedding
+func ParseVector(s string) ([]float32, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("invalid vector %q", s)
+	}
+	s = s[1 : len(s)-1]
+	if s == "" {
+		return []float32{}, nil
+	}
+
+	parts := strings.Split(s, ",")
+	v := make([]float32, len(parts))
+	for i, part := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(part), 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vector element %q: %w", part, err)
+		}
+		v[i] = float32(f)
+	}
+	return v, nil
+}